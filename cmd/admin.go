@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/user"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"golang-project/database"
+	"golang-project/internal/audit"
+	"golang-project/internal/event"
+	"golang-project/internal/oidc"
+	adminRepo "golang-project/internal/repository/admin"
+	auditRepo "golang-project/internal/repository/audit"
+	challengeRepo "golang-project/internal/repository/challenge"
+	oidcRepo "golang-project/internal/repository/oidc"
+	outboxRepo "golang-project/internal/repository/outbox"
+	userRepo "golang-project/internal/repository/user"
+	svc "golang-project/internal/service"
+	adminSvc "golang-project/internal/service/admin"
+	authSvc "golang-project/internal/service/authentication"
+	twoFactorSvc "golang-project/internal/service/twofactor"
+	"golang-project/static"
+	"golang-project/util/apperror"
+	"golang-project/util/hashing"
+	"golang-project/util/validator"
+)
+
+// Flag names shared by the admin commands
+const (
+	flagOutput = "output"
+	flagDryRun = "dry-run"
+)
+
+// field represents a named value of an admin command result printed as a table row
+type field struct {
+	name  string
+	value string
+}
+
+// adminAction represents the core logic of an admin command, it returns the result printed as JSON
+// and the fields printed as a table
+type adminAction func(cmd *cobra.Command, s svc.Admin, args []string, dryRun bool) (interface{}, []field, error)
+
+// newAdminGroupCmd creates a command grouping admin subcommands with the output and dry-run flags
+func newAdminGroupCmd(use, short string, subcommands ...*cobra.Command) *cobra.Command {
+	group := &cobra.Command{
+		Use:   use,
+		Short: short,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			// Arguments are parsed at this point, failures past here are operational and need no usage
+			cmd.SilenceUsage = true
+		},
+	}
+
+	group.PersistentFlags().StringP(flagOutput, "o", string(static.OutputTable), "output format (table|json)")
+	group.PersistentFlags().Bool(flagDryRun, false, "report the changes without applying them")
+	group.AddCommand(subcommands...)
+
+	return group
+}
+
+// runAdmin wraps the admin action with the database connection, the admin service and the result printing
+func runAdmin(action adminAction) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString(flagOutput)
+		format := static.OutputFormat(output)
+		if format != static.OutputTable && format != static.OutputJSON {
+			return fmt.Errorf("unsupported output format %q, use table or json", output)
+		}
+
+		dryRun, _ := cmd.Flags().GetBool(flagDryRun)
+
+		databaseConnection, err := connectDatabase()
+		if err != nil {
+			return err
+		}
+		defer databaseConnection.Disconnect()
+
+		// Admin commands are recorded in the audit log with the operating system user running them
+		cmd.SetContext(audit.WithRequest(cmd.Context(), &audit.Request{Source: static.AuditSourceCLI, UserAgent: commandUserAgent()}))
+
+		result, fields, err := action(cmd, newAdminService(databaseConnection), args, dryRun)
+		if err != nil {
+			return describeError(err)
+		}
+
+		return printResult(cmd.OutOrStdout(), format, result, fields)
+	}
+}
+
+// newAdminService wires the admin service with the same services and repositories the server uses,
+// the events are only written to the outbox and the relay of the server dispatches them
+func newAdminService(db database.Connection) svc.Admin {
+	users := userRepo.NewRepository(db)
+	hash := hashing.NewBcrypt()
+	unitOfWork := database.NewUnitOfWork(db)
+	auditor := audit.NewRecorder(auditRepo.NewRepository(db))
+	publisher := event.NewBus(outboxRepo.NewRepository(db))
+	authenticationSvc := authSvc.NewService(users, hash, unitOfWork, publisher, auditor, oidc.NewProviderFromEnv(), oidcRepo.NewRepository(db),
+		challengeRepo.NewRepository(db), twoFactorSvc.NewService(users, hash, unitOfWork, publisher, auditor))
+
+	return adminSvc.NewService(authenticationSvc, users, adminRepo.NewRepository(db), hash, unitOfWork, publisher, auditor)
+}
+
+// commandUserAgent returns the user agent of the admin commands in the audit log
+func commandUserAgent() string {
+	current, err := user.Current()
+	if err != nil {
+		return "admin-cli"
+	}
+
+	return "admin-cli/" + current.Username
+}
+
+// validate checks the admin request against its struct tags like the API binder does
+func validate(request interface{}) error {
+	return validator.New().Validate(request)
+}
+
+// describeError returns the error with the code and the failing fields of the application error
+func describeError(err error) error {
+	appErr := apperror.From(err)
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "%s: %s", appErr.Code, appErr.Error())
+	for _, fieldErr := range appErr.Fields {
+		fmt.Fprintf(&builder, "\n  %s %s", fieldErr.Field, fieldErr.Message)
+	}
+
+	return fmt.Errorf("%s", builder.String())
+}
+
+// printResult writes the result as indented JSON or the fields as a two column table
+func printResult(w io.Writer, format static.OutputFormat, result interface{}, fields []field) error {
+	if format == static.OutputJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "FIELD\tVALUE")
+	for _, f := range fields {
+		fmt.Fprintf(tw, "%s\t%s\n", f.name, f.value)
+	}
+
+	return tw.Flush()
+}
+
+// dryRunField returns the table row reporting whether the changes were applied
+func dryRunField(dryRun bool) field {
+	return field{name: "DRY RUN", value: fmt.Sprint(dryRun)}
+}
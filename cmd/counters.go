@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	ct "golang-project/internal/contract"
+	svc "golang-project/internal/service"
+)
+
+// countersRecomputeCmd represents the counters recompute command in Cobra Command structure
+var countersRecomputeCmd = &cobra.Command{
+	Use:   "recompute",
+	Short: "recount the follower, following, post, favourite and comment counters from the source collections",
+	Args:  cobra.NoArgs,
+	RunE:  runAdmin(runCountersRecomputeCmd),
+}
+
+// init adds the counters command and its subcommands into the root command
+func init() {
+	rootCmd.AddCommand(newAdminGroupCmd("counters", "maintain the denormalised user and post counters", countersRecomputeCmd))
+}
+
+// runCountersRecomputeCmd executes the core logic of the counters recompute command
+func runCountersRecomputeCmd(cmd *cobra.Command, s svc.Admin, args []string, dryRun bool) (interface{}, []field, error) {
+	response, err := s.RecomputeCounters(cmd.Context(), &ct.AdminRecomputeCountersRequest{DryRun: dryRun})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fields := []field{
+		{name: "USERS UPDATED", value: fmt.Sprint(response.UsersUpdated)},
+		{name: "POSTS UPDATED", value: fmt.Sprint(response.PostsUpdated)},
+		dryRunField(response.DryRun),
+	}
+
+	return response, fields, nil
+}
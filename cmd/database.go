@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"fmt"
+
+	"golang-project/database"
+)
+
+// connectDatabase opens and verifies the MongoDB connection configured by the environment
+func connectDatabase(providers ...database.ClientOptionProvider) (database.Connection, error) {
+	databaseConnection, err := database.NewConnectionFromEnv(providers...)
+	if err != nil {
+		return nil, fmt.Errorf("database connection error: %w", err)
+	}
+
+	_, err = databaseConnection.Connect()
+	if err != nil {
+		return nil, fmt.Errorf("database connect error: %w", err)
+	}
+
+	err = databaseConnection.Ping()
+	if err != nil {
+		_ = databaseConnection.Disconnect()
+		return nil, fmt.Errorf("database ping error: %w", err)
+	}
+
+	return databaseConnection, nil
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	ct "golang-project/internal/contract"
+	svc "golang-project/internal/service"
+)
+
+// postUnpublishCmd represents the post unpublish command in Cobra Command structure
+var postUnpublishCmd = &cobra.Command{
+	Use:   "unpublish <slug|id>",
+	Short: "take a published post down without deleting it",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runAdmin(runPostUnpublishCmd),
+}
+
+// init adds the post command and its subcommands into the root command
+func init() {
+	rootCmd.AddCommand(newAdminGroupCmd("post", "moderate blog posts", postUnpublishCmd))
+}
+
+// runPostUnpublishCmd executes the core logic of the post unpublish command
+func runPostUnpublishCmd(cmd *cobra.Command, s svc.Admin, args []string, dryRun bool) (interface{}, []field, error) {
+	request := &ct.AdminPostRequest{Identifier: args[0], DryRun: dryRun}
+	if err := validate(request); err != nil {
+		return nil, nil, err
+	}
+
+	response, err := s.UnpublishPost(cmd.Context(), request)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fields := []field{
+		{name: "ID", value: response.ID.Hex()},
+		{name: "TITLE", value: response.Title},
+		{name: "SLUG", value: response.Slug},
+		{name: "PUBLISHED", value: fmt.Sprint(response.IsPublished)},
+		dryRunField(response.DryRun),
+	}
+
+	return response, fields, nil
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	seedRepo "golang-project/internal/repository/seed"
+	"golang-project/internal/seed"
+	"golang-project/static"
+	"golang-project/util/hashing"
+)
+
+// ErrResetNotAllowed is returned when the database reset is requested outside the local environments
+var ErrResetNotAllowed = errors.New("database reset is only allowed on local environments")
+
+// seedCmd represents the seed command in Cobra Command structure
+var seedCmd = &cobra.Command{
+	Use:   "seed",
+	Short: "populate the database with generated users, posts, tags, comments, follows and favourites",
+	Long: "Populate the database with generated local data. The same --seed always generates the same documents, " +
+		"every generated user signs in with --password and the first one holds the admin role.",
+	Args: cobra.NoArgs,
+	RunE: runSeedCmd,
+}
+
+// init adds the seed command into the root command
+func init() {
+	seedCmd.Flags().Int("users", static.Seed.Users, "number of users")
+	seedCmd.Flags().Int("tags", static.Seed.Tags, "number of tags")
+	seedCmd.Flags().Int("posts-per-user", static.Seed.PostsPerUser, "number of posts written by every user")
+	seedCmd.Flags().Int("comments-per-post", static.Seed.CommentsPerPost, "number of comments on every published post")
+	seedCmd.Flags().Int("follows-per-user", static.Seed.FollowsPerUser, "number of bloggers followed by every user")
+	seedCmd.Flags().Int("favourites-per-user", static.Seed.FavouritesPerUser, "number of posts favourited by every user")
+	seedCmd.Flags().Int64("seed", static.Seed.RandomSeed, "seed of the random generator")
+	seedCmd.Flags().String("password", static.Seed.Password, "password of every generated user")
+	seedCmd.Flags().Bool("reset", false, "drop the seeded collections before seeding")
+
+	rootCmd.AddCommand(seedCmd)
+}
+
+// runSeedCmd executes the core logic of the seed command
+func runSeedCmd(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	flags := cmd.Flags()
+	config := seed.Config{Anchor: static.Seed.Anchor}
+	config.Users, _ = flags.GetInt("users")
+	config.Tags, _ = flags.GetInt("tags")
+	config.PostsPerUser, _ = flags.GetInt("posts-per-user")
+	config.CommentsPerPost, _ = flags.GetInt("comments-per-post")
+	config.FollowsPerUser, _ = flags.GetInt("follows-per-user")
+	config.FavouritesPerUser, _ = flags.GetInt("favourites-per-user")
+	config.RandomSeed, _ = flags.GetInt64("seed")
+	password, _ := flags.GetString("password")
+	reset, _ := flags.GetBool("reset")
+
+	if reset && !slices.Contains(static.Seed.ResetEnvironments, viper.GetString(static.EnvServerEnv)) {
+		return fmt.Errorf("%w, %s is %q", ErrResetNotAllowed, static.EnvServerEnv, viper.GetString(static.EnvServerEnv))
+	}
+
+	hashedPassword, err := hashing.NewBcrypt().Generate([]byte(password))
+	if err != nil {
+		return err
+	}
+
+	data := seed.Generate(config, string(hashedPassword))
+
+	databaseConnection, err := connectDatabase()
+	if err != nil {
+		return err
+	}
+	defer databaseConnection.Disconnect()
+
+	repository := seedRepo.NewRepository(databaseConnection)
+	if reset {
+		if err = repository.Reset(cmd.Context()); err != nil {
+			return fmt.Errorf("database reset error: %w", err)
+		}
+	}
+
+	err = repository.Insert(cmd.Context(), data)
+	if mongo.IsDuplicateKeyError(err) {
+		return fmt.Errorf("database seed error, the documents were already seeded, rerun with --reset: %w", err)
+	}
+	if err != nil {
+		return fmt.Errorf("database seed error: %w", err)
+	}
+
+	fields := []field{
+		{name: static.CollectionUsers, value: fmt.Sprint(len(data.Users))},
+		{name: static.CollectionTags, value: fmt.Sprint(len(data.Tags))},
+		{name: static.CollectionPosts, value: fmt.Sprint(len(data.Posts))},
+		{name: static.CollectionPostTags, value: fmt.Sprint(len(data.PostTags))},
+		{name: static.CollectionComments, value: fmt.Sprint(len(data.Comments))},
+		{name: static.CollectionFollows, value: fmt.Sprint(len(data.Follows))},
+		{name: static.CollectionFavorites, value: fmt.Sprint(len(data.Favourites))},
+	}
+	if len(data.Users) > 0 {
+		fields = append(fields, field{name: "admin", value: data.Users[0].Email})
+	}
+
+	return printResult(cmd.OutOrStdout(), static.OutputTable, nil, fields)
+}
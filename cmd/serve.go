@@ -14,10 +14,31 @@ import (
 	"github.com/spf13/viper"
 
 	"golang-project/database"
+	"golang-project/internal/audit"
+	"golang-project/internal/cache"
+	"golang-project/internal/event"
+	"golang-project/internal/healthcheck"
+	"golang-project/internal/metrics"
 	"golang-project/internal/middleware"
 	"golang-project/internal/registry"
+	accessTokenRepo "golang-project/internal/repository/accesstoken"
+	auditRepo "golang-project/internal/repository/audit"
+	challengeRepo "golang-project/internal/repository/challenge"
+	favouriteRepo "golang-project/internal/repository/favourite"
+	idempotencyRepo "golang-project/internal/repository/idempotency"
+	oidcRepo "golang-project/internal/repository/oidc"
+	outboxRepo "golang-project/internal/repository/outbox"
+	reactionRepo "golang-project/internal/repository/reaction"
+	userRepo "golang-project/internal/repository/user"
+	webhookRepo "golang-project/internal/repository/webhook"
+	accessTokenSvc "golang-project/internal/service/accesstoken"
+	twoFactorSvc "golang-project/internal/service/twofactor"
+	"golang-project/internal/tracing"
+	"golang-project/internal/worker"
 	"golang-project/server"
 	"golang-project/static"
+	"golang-project/util/hashing"
+	"golang-project/util/validator"
 )
 
 // serveCmd represents the serve command in Cobra Command structure
@@ -38,37 +59,121 @@ func runServeCmd(cmd *cobra.Command, args []string) {
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, syscall.SIGINT, syscall.SIGTERM)
 
-	// Initialize MongoDB connection (viper is already loaded in root.go)
-	databaseConnection, err := database.NewConnectionFromEnv()
+	shutdownTracing, err := tracing.NewProviderFromEnv(ctx)
 	if err != nil {
-		log.Fatal("database connection error:", err)
+		log.Fatal("tracing provider error:", err)
 	}
 
-	_, err = databaseConnection.Connect()
+	// Initialize MongoDB connection (viper is already loaded in root.go)
+	databaseConnection, err := connectDatabase(metrics.MongoMonitor, tracing.MongoMonitor)
 	if err != nil {
-		log.Fatal("database connect error:", err)
+		log.Fatal(err)
 	}
 
-	err = databaseConnection.Ping()
+	cacheStore, err := cache.NewStoreFromEnv()
 	if err != nil {
-		log.Fatal("database ping error:", err)
+		log.Fatal("cache store error:", err)
 	}
 
+	// Subsystems register their readiness checks here
+	healthChecks := healthcheck.NewRegistryFromEnv()
+	healthChecks.Register("database", func(ctx context.Context) error { return databaseConnection.Ping() })
+	healthChecks.Register("cache", cacheStore.Ping)
+
+	// Subsystems register their background jobs here, they run until the server shuts down
+	workers := worker.NewGroup()
+
+	// Services publish their domain events to the outbox, the relay dispatches them to the subscribers
+	outbox := outboxRepo.NewRepository(databaseConnection)
+	eventBus := event.NewBus(outbox)
+	workers.Register("outbox", eventBus.Work)
+
 	// Pass MongoDB connection to registry
-	handlerRegistries, err := registry.NewHandlerRegistries(databaseConnection)
+	handlerRegistries, err := registry.NewHandlerRegistries(databaseConnection, cacheStore, eventBus, healthChecks, workers)
 	if err != nil {
 		log.Fatal("registry error:", err)
 	}
 
+	// Stored responses of the idempotent requests expire through the TTL index
+	idempotencyKeys := idempotencyRepo.NewRepository(databaseConnection)
+	if err = idempotencyKeys.EnsureIndexes(ctx); err != nil {
+		log.Println("idempotency index error:", err)
+	}
+
+	// The relay polls the due events and the dispatched ones expire through the TTL index
+	if err = outbox.EnsureIndexes(ctx); err != nil {
+		log.Println("outbox index error:", err)
+	}
+
+	// The delivery worker polls the due deliveries and the delivery log expires through the TTL index
+	if err = webhookRepo.NewRepository(databaseConnection).EnsureIndexes(ctx); err != nil {
+		log.Println("webhook index error:", err)
+	}
+
+	if err = auditRepo.NewRepository(databaseConnection).EnsureIndexes(ctx); err != nil {
+		log.Println("audit log index error:", err)
+	}
+
+	// Abandoned OpenID Connect sign-ins expire through the TTL index
+	if err = oidcRepo.NewRepository(databaseConnection).EnsureIndexes(ctx); err != nil {
+		log.Println("oidc state index error:", err)
+	}
+
+	// One follow per followed user and one favourite per post are kept by the unique indexes
+	if err = favouriteRepo.NewRepository(databaseConnection).EnsureIndexes(ctx); err != nil {
+		log.Println("favourite index error:", err)
+	}
+
+	// One reaction per user per content is kept by the unique index
+	if err = reactionRepo.NewRepository(databaseConnection).EnsureIndexes(ctx); err != nil {
+		log.Println("reaction index error:", err)
+	}
+
+	// Abandoned two-factor sign-ins expire through the TTL index
+	if err = challengeRepo.NewRepository(databaseConnection).EnsureIndexes(ctx); err != nil {
+		log.Println("sign-in challenge index error:", err)
+	}
+
+	// The authentication middleware refuses the JWTs of disabled users and checks the personal access tokens
+	users := userRepo.NewRepository(databaseConnection)
+	if err = users.EnsureIndexes(ctx); err != nil {
+		log.Println("user index error:", err)
+	}
+	accessTokens := accessTokenRepo.NewRepository(databaseConnection)
+	if err = accessTokens.EnsureIndexes(ctx); err != nil {
+		log.Println("access token index error:", err)
+	}
+	accessTokenService := accessTokenSvc.NewService(users, accessTokens, audit.NewRecorder(auditRepo.NewRepository(databaseConnection)))
+
+	// The TOTP secrets stored in plain before the secrets were encrypted are encrypted with the key of the server
+	twoFactorService := twoFactorSvc.NewService(users, hashing.NewBcrypt(), database.NewUnitOfWork(databaseConnection), eventBus,
+		audit.NewRecorder(auditRepo.NewRepository(databaseConnection)))
+	if encrypted, err := twoFactorService.EncryptSecrets(ctx); err != nil {
+		log.Fatal("two-factor secret encryption error:", err)
+	} else if encrypted > 0 {
+		log.Println("two-factor secrets encrypted:", encrypted)
+	}
+
 	serverConfigs := []server.ConfigProvider{
 		func(e *echo.Echo) { e.Debug = true },
 		func(e *echo.Echo) { e.HTTPErrorHandler = middleware.ErrorHandler },
+		func(e *echo.Echo) {
+			e.IPExtractor = middleware.IPExtractor(viper.GetString(static.EnvServerTrustedProxies))
+		},
+		func(e *echo.Echo) {
+			e.Validator = validator.New()
+			e.Binder = validator.NewBinder()
+		},
 		func(e *echo.Echo) {
 			e.Use(
+				middleware.Metrics(handlerRegistries),
+				middleware.Tracing(),
 				middleware.Recover(),
 				middleware.Timeout(),
 				middleware.Correlation(),
-				middleware.Authentication(handlerRegistries),
+				middleware.Audit(),
+				middleware.Authentication(handlerRegistries, users, accessTokenService),
+				middleware.Idempotency(idempotencyKeys),
 			)
 		},
 	}
@@ -84,18 +189,34 @@ func runServeCmd(cmd *cobra.Command, args []string) {
 		}
 	}()
 
+	workerCtx, stopWorkers := context.WithCancel(ctx)
+	workers.Start(workerCtx)
+
 	<-c
 
+	stopWorkers()
+	workers.Wait()
+
 	err = databaseConnection.Disconnect()
 	if err != nil {
 		log.Println(err)
 	}
 
+	err = cacheStore.Close()
+	if err != nil {
+		log.Println(err)
+	}
+
 	err = serverEngine.Shutdown(ctx)
 	if err != nil {
 		log.Println(err)
 	}
 
+	err = shutdownTracing(ctx)
+	if err != nil {
+		log.Println(err)
+	}
+
 	log.Println("golang server gracefully shutdowns")
 
 	ctx, cancel := context.WithCancel(ctx)
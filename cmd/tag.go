@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	ct "golang-project/internal/contract"
+	svc "golang-project/internal/service"
+)
+
+// tagMergeCmd represents the tag merge command in Cobra Command structure
+var tagMergeCmd = &cobra.Command{
+	Use:   "merge <source name|id> <target name|id>",
+	Short: "move the posts of the source tag onto the target tag and remove the source tag",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runAdmin(runTagMergeCmd),
+}
+
+// init adds the tag command and its subcommands into the root command
+func init() {
+	rootCmd.AddCommand(newAdminGroupCmd("tag", "manage post tags", tagMergeCmd))
+}
+
+// runTagMergeCmd executes the core logic of the tag merge command
+func runTagMergeCmd(cmd *cobra.Command, s svc.Admin, args []string, dryRun bool) (interface{}, []field, error) {
+	request := &ct.AdminMergeTagRequest{Source: args[0], Target: args[1], DryRun: dryRun}
+	if err := validate(request); err != nil {
+		return nil, nil, err
+	}
+
+	response, err := s.MergeTags(cmd.Context(), request)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fields := []field{
+		{name: "SOURCE", value: fmt.Sprintf("%s (%s)", response.Source.Name, response.Source.ID.Hex())},
+		{name: "TARGET", value: fmt.Sprintf("%s (%s)", response.Target.Name, response.Target.ID.Hex())},
+		{name: "POSTS UPDATED", value: fmt.Sprint(response.PostsUpdated)},
+		dryRunField(response.DryRun),
+	}
+
+	return response, fields, nil
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	ct "golang-project/internal/contract"
+	svc "golang-project/internal/service"
+	"golang-project/static"
+)
+
+// userCreateCmd represents the user create command in Cobra Command structure
+var userCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "create a user account",
+	Args:  cobra.NoArgs,
+	RunE:  runAdmin(runUserCreateCmd),
+}
+
+// userPromoteCmd represents the user promote command in Cobra Command structure
+var userPromoteCmd = &cobra.Command{
+	Use:   "promote <email|id>",
+	Short: "grant the moderator or admin role to a user",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runAdmin(runUserPromoteCmd),
+}
+
+// userDisableCmd represents the user disable command in Cobra Command structure
+var userDisableCmd = &cobra.Command{
+	Use:   "disable <email|id>",
+	Short: "disable a user account so it can no longer sign in",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runAdmin(runUserDisableCmd),
+}
+
+// userResetPasswordCmd represents the user reset-password command in Cobra Command structure
+var userResetPasswordCmd = &cobra.Command{
+	Use:   "reset-password <email|id>",
+	Short: "reset the password of a user, a random password is generated unless --password is set",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runAdmin(runUserResetPasswordCmd),
+}
+
+// init adds the user command and its subcommands into the root command
+func init() {
+	userCreateCmd.Flags().String("email", "", "email of the user")
+	userCreateCmd.Flags().String("password", "", "password of the user")
+	userCreateCmd.Flags().String("first-name", "", "first name of the user")
+	userCreateCmd.Flags().String("last-name", "", "last name of the user")
+	userCreateCmd.Flags().String("role", string(static.RoleUser), "role of the user (user|moderator|admin)")
+	userPromoteCmd.Flags().String("role", string(static.RoleAdmin), "role granted to the user (moderator|admin)")
+	userResetPasswordCmd.Flags().String("password", "", "new password of the user")
+
+	rootCmd.AddCommand(newAdminGroupCmd("user", "manage user accounts",
+		userCreateCmd,
+		userPromoteCmd,
+		userDisableCmd,
+		userResetPasswordCmd,
+	))
+}
+
+// runUserCreateCmd executes the core logic of the user create command
+func runUserCreateCmd(cmd *cobra.Command, s svc.Admin, args []string, dryRun bool) (interface{}, []field, error) {
+	flags := cmd.Flags()
+	email, _ := flags.GetString("email")
+	password, _ := flags.GetString("password")
+	firstName, _ := flags.GetString("first-name")
+	lastName, _ := flags.GetString("last-name")
+	role, _ := flags.GetString("role")
+
+	request := &ct.AdminCreateUserRequest{
+		Email:     email,
+		Password:  password,
+		FirstName: firstName,
+		LastName:  lastName,
+		Role:      static.UserRole(role),
+		DryRun:    dryRun,
+	}
+	if err := validate(request); err != nil {
+		return nil, nil, err
+	}
+
+	response, err := s.CreateUser(cmd.Context(), request)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return response, userFields(response), nil
+}
+
+// runUserPromoteCmd executes the core logic of the user promote command
+func runUserPromoteCmd(cmd *cobra.Command, s svc.Admin, args []string, dryRun bool) (interface{}, []field, error) {
+	role, _ := cmd.Flags().GetString("role")
+
+	request := &ct.AdminPromoteUserRequest{Identifier: args[0], Role: static.UserRole(role), DryRun: dryRun}
+	if err := validate(request); err != nil {
+		return nil, nil, err
+	}
+
+	response, err := s.PromoteUser(cmd.Context(), request)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return response, userFields(response), nil
+}
+
+// runUserDisableCmd executes the core logic of the user disable command
+func runUserDisableCmd(cmd *cobra.Command, s svc.Admin, args []string, dryRun bool) (interface{}, []field, error) {
+	request := &ct.AdminUserRequest{Identifier: args[0], DryRun: dryRun}
+	if err := validate(request); err != nil {
+		return nil, nil, err
+	}
+
+	response, err := s.DisableUser(cmd.Context(), request)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return response, userFields(response), nil
+}
+
+// runUserResetPasswordCmd executes the core logic of the user reset-password command
+func runUserResetPasswordCmd(cmd *cobra.Command, s svc.Admin, args []string, dryRun bool) (interface{}, []field, error) {
+	password, _ := cmd.Flags().GetString("password")
+
+	request := &ct.AdminResetPasswordRequest{Identifier: args[0], Password: password, DryRun: dryRun}
+	if err := validate(request); err != nil {
+		return nil, nil, err
+	}
+
+	response, err := s.ResetPassword(cmd.Context(), request)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fields := userFields(response.User)
+	if response.Password != "" {
+		fields = append(fields, field{name: "PASSWORD", value: response.Password})
+	}
+
+	return response, fields, nil
+}
+
+// userFields returns the table rows of the admin user result
+func userFields(o *ct.AdminUserResponse) []field {
+	fields := []field{
+		{name: "ID", value: o.ID.Hex()},
+		{name: "EMAIL", value: o.Email},
+		{name: "PSEUDONYM", value: o.Pseudonym},
+		{name: "ROLE", value: string(o.Role)},
+		{name: "DISABLED", value: fmt.Sprint(o.Disabled)},
+	}
+
+	if o.ID.IsZero() {
+		fields[0].value = "-"
+	}
+
+	if o.DisabledAt != "" {
+		fields = append(fields, field{name: "DISABLED AT", value: o.DisabledAt})
+	}
+
+	return append(fields, dryRunField(o.DryRun))
+}
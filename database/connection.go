@@ -13,6 +13,9 @@ var (
 	ErrUninitializedDatabase = errors.New("database instance is not initialized")
 )
 
+// ClientOptionProvider represents the option provider for the MongoDB client
+type ClientOptionProvider func(*options.ClientOptions)
+
 // Connection represents the database connection
 type Connection interface {
 	Connect() (*mongo.Client, error)
@@ -25,6 +28,7 @@ type Connection interface {
 type connection struct {
 	connectionString string
 	databaseName     string
+	providers        []ClientOptionProvider
 	client           *mongo.Client
 	database         *mongo.Database
 	ctx              context.Context
@@ -32,24 +36,25 @@ type connection struct {
 }
 
 // NewConnection creates and returns a database connection instance
-func NewConnection(connectionString, databaseName string) Connection {
+func NewConnection(connectionString, databaseName string, providers ...ClientOptionProvider) Connection {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	return &connection{
 		connectionString: connectionString,
 		databaseName:     databaseName,
+		providers:        providers,
 		ctx:              ctx,
 		cancel:           cancel,
 	}
 }
 
 // NewConnectionFromEnv creates and returns a database connection instance from environment variables
-func NewConnectionFromEnv() (Connection, error) {
+func NewConnectionFromEnv(providers ...ClientOptionProvider) (Connection, error) {
 	connectionString, databaseName, err := BuildConnectionStringFromEnv()
 	if err != nil {
 		return nil, err
 	}
 
-	return NewConnection(connectionString, databaseName), nil
+	return NewConnection(connectionString, databaseName, providers...), nil
 }
 
 // Connect initializes a new MongoDB client
@@ -71,6 +76,10 @@ func (c *connection) Connect() (*mongo.Client, error) {
 	// Note: SetMaxConnLifetime is not available in this version of the MongoDB driver
 	// Connection lifetime is managed by the MongoDB server
 
+	for _, provide := range c.providers {
+		provide(clientOptions)
+	}
+
 	client, err := mongo.Connect(c.ctx, clientOptions)
 	if err != nil {
 		return nil, err
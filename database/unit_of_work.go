@@ -0,0 +1,118 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"golang-project/static"
+)
+
+var (
+	ErrTransactionAborted = errors.New("transaction aborted after exhausting retries")
+)
+
+// UnitOfWork runs multi-collection operations atomically
+type UnitOfWork interface {
+	// Do runs fn inside a MongoDB transaction. Repository calls made with the
+	// context passed to fn join the transaction. The transaction is retried as a
+	// whole on transient errors, so fn must not have side effects outside the database.
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// unitOfWork is an implementation of the unit of work backed by MongoDB sessions
+type unitOfWork struct {
+	db Connection
+}
+
+// NewUnitOfWork returns a new unit of work over the database connection.
+// Transactions require MongoDB to run as a replica set, a single-node one is enough.
+func NewUnitOfWork(db Connection) UnitOfWork {
+	return &unitOfWork{db: db}
+}
+
+// Do runs fn inside a MongoDB transaction with retry on transient errors
+func (u *unitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if u.db.GetDatabase() == nil {
+		return ErrUninitializedDatabase
+	}
+
+	// Nested units of work join the outer transaction
+	if mongo.SessionFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, static.Transaction.Timeout)
+	defer cancel()
+
+	session, err := u.db.GetDatabase().Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(context.Background())
+
+	var lastErr error
+	for attempt := 1; attempt <= static.Transaction.MaxAttempts; attempt++ {
+		lastErr = mongo.WithSession(ctx, session, func(sc mongo.SessionContext) error {
+			return u.run(sc, session, fn)
+		})
+		if lastErr == nil || !hasErrorLabel(lastErr, "TransientTransactionError") {
+			return lastErr
+		}
+
+		if err := wait(ctx, time.Duration(attempt)*static.Transaction.Backoff); err != nil {
+			return err
+		}
+	}
+
+	return errors.Join(ErrTransactionAborted, lastErr)
+}
+
+// run executes a single transaction attempt and retries the commit when its result is unknown
+func (u *unitOfWork) run(sc mongo.SessionContext, session mongo.Session, fn func(ctx context.Context) error) error {
+	if err := session.StartTransaction(); err != nil {
+		return err
+	}
+
+	if err := fn(sc); err != nil {
+		_ = session.AbortTransaction(context.Background())
+		return err
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := session.CommitTransaction(sc)
+		if err == nil {
+			return nil
+		}
+		if attempt >= static.Transaction.MaxAttempts || !hasErrorLabel(err, "UnknownTransactionCommitResult") {
+			return err
+		}
+
+		// The commit result is unknown during an election or a network failure, give the replica set time to recover
+		if err = wait(sc, time.Duration(attempt)*static.Transaction.Backoff); err != nil {
+			return err
+		}
+	}
+}
+
+// wait sleeps for the delay unless ctx is done first
+func wait(ctx context.Context, delay time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(delay):
+		return nil
+	}
+}
+
+// hasErrorLabel checks whether the MongoDB server error carries the label
+func hasErrorLabel(err error, label string) bool {
+	var serverErr mongo.ServerError
+	if errors.As(err, &serverErr) {
+		return serverErr.HasErrorLabel(label)
+	}
+
+	return false
+}
@@ -0,0 +1,97 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"golang-project/database"
+	"golang-project/database/databasetest"
+)
+
+func TestUnitOfWorkCommitsAllWrites(t *testing.T) {
+	db := databasetest.Connect(t)
+	ctx := context.Background()
+	posts, tags := db.GetDatabase().Collection("posts"), db.GetDatabase().Collection("post_tags")
+
+	err := database.NewUnitOfWork(db).Do(ctx, func(ctx context.Context) error {
+		if _, err := posts.InsertOne(ctx, bson.M{"_id": 1}); err != nil {
+			return err
+		}
+
+		_, err := tags.InsertOne(ctx, bson.M{"post_id": 1})
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertCount(t, posts, 1)
+	assertCount(t, tags, 1)
+}
+
+func TestUnitOfWorkAbortsAllWrites(t *testing.T) {
+	db := databasetest.Connect(t)
+	ctx := context.Background()
+	posts, tags := db.GetDatabase().Collection("posts"), db.GetDatabase().Collection("post_tags")
+	failure := errors.New("tag links failed")
+
+	err := database.NewUnitOfWork(db).Do(ctx, func(ctx context.Context) error {
+		if _, err := posts.InsertOne(ctx, bson.M{"_id": 1}); err != nil {
+			return err
+		}
+		if _, err := tags.InsertOne(ctx, bson.M{"post_id": 1}); err != nil {
+			return err
+		}
+
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("err = %v, want %v", err, failure)
+	}
+
+	assertCount(t, posts, 0)
+	assertCount(t, tags, 0)
+}
+
+func TestUnitOfWorkJoinsOuterTransaction(t *testing.T) {
+	db := databasetest.Connect(t)
+	ctx := context.Background()
+	posts := db.GetDatabase().Collection("posts")
+	unitOfWork := database.NewUnitOfWork(db)
+	failure := errors.New("outer failed")
+
+	err := unitOfWork.Do(ctx, func(ctx context.Context) error {
+		err := unitOfWork.Do(ctx, func(ctx context.Context) error {
+			_, err := posts.InsertOne(ctx, bson.M{"_id": 1})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("err = %v, want %v", err, failure)
+	}
+
+	// The nested unit of work is rolled back with the outer one
+	assertCount(t, posts, 0)
+}
+
+// assertCount checks the number of documents committed to the collection
+func assertCount(t *testing.T, collection *mongo.Collection, want int64) {
+	t.Helper()
+
+	count, err := collection.CountDocuments(context.Background(), bson.M{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != want {
+		t.Errorf("documents = %d, want %d", count, want)
+	}
+}
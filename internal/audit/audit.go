@@ -0,0 +1,87 @@
+package audit
+
+import (
+	"context"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	"golang-project/static"
+)
+
+// Request represents the origin of the actions recorded while serving a request or a command
+type Request struct {
+	Source        static.AuditSource
+	IP            string
+	UserAgent     string
+	CorrelationID string
+}
+
+// requestKey is the context key of the Request
+type requestKey struct{}
+
+// WithRequest returns a copy of ctx carrying the origin of the recorded actions
+func WithRequest(ctx context.Context, r *Request) context.Context {
+	return context.WithValue(ctx, requestKey{}, r)
+}
+
+// RequestFrom returns the origin carried by ctx, the actions without one come from the system
+func RequestFrom(ctx context.Context) *Request {
+	if r, ok := ctx.Value(requestKey{}).(*Request); ok {
+		return r
+	}
+
+	return &Request{Source: static.AuditSourceSystem}
+}
+
+// Entry represents an action to record, the zero actor and target IDs are left out
+type Entry struct {
+	ActorID    primitive.ObjectID
+	Action     static.AuditAction
+	TargetType static.AuditTargetType
+	TargetID   primitive.ObjectID
+	Metadata   map[string]string
+}
+
+// Recorder represents the writer of the audit log, the entries are recorded once the actions are committed
+type Recorder interface {
+	Record(context.Context, *Entry)
+}
+
+// recorder is an implementation of Recorder writing the entries to the audit log repository
+type recorder struct {
+	auditRepo repo.Audit
+}
+
+// NewRecorder returns the Recorder writing the entries with the origin of ctx to the audit log
+func NewRecorder(auditRepo repo.Audit) Recorder {
+	return &recorder{auditRepo: auditRepo}
+}
+
+// Record writes the entry, failures are logged as they must not fail the recorded action
+func (r *recorder) Record(ctx context.Context, e *Entry) {
+	origin := RequestFrom(ctx)
+	o := &model.AuditLog{
+		Action:        e.Action,
+		TargetType:    e.TargetType,
+		Source:        origin.Source,
+		IP:            origin.IP,
+		UserAgent:     origin.UserAgent,
+		CorrelationID: origin.CorrelationID,
+		Metadata:      e.Metadata,
+	}
+	if !e.ActorID.IsZero() {
+		o.ActorID = &e.ActorID
+	}
+	if !e.TargetID.IsZero() {
+		o.TargetID = &e.TargetID
+	}
+
+	// The action already happened, a request cancelled in the meantime still records it
+	ctx = context.WithoutCancel(ctx)
+	if err := r.auditRepo.Insert(ctx, o); err != nil {
+		log.Println("audit log error:", e.Action, err)
+	}
+}
@@ -0,0 +1,118 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"golang-project/internal/metrics"
+	"golang-project/static"
+)
+
+var (
+	ErrUnsupportedDriver = errors.New("cache driver is not supported")
+	ErrDriverNotShared   = errors.New("cache driver memory cannot drop the entries of the other replicas, use redis or none")
+)
+
+// Driver names accepted by the CACHE_DRIVER environment variable
+const (
+	DriverNone   = "none"
+	DriverMemory = "memory"
+	DriverRedis  = "redis"
+)
+
+// Store represents the key value storage behind the read-through cache
+type Store interface {
+	// Get returns the value of the key and whether it was found and not expired
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+	Ping(ctx context.Context) error
+	Close() error
+}
+
+// NewStoreFromEnv creates and returns the cache store configured from environment variables,
+// the in-process LRU store is used by default. The LRU store is refused when the server runs more than
+// one replica, an invalidation only reaches the store of the replica handling it.
+func NewStoreFromEnv() (Store, error) {
+	driver := viper.GetString(static.EnvCacheDriver)
+
+	switch driver {
+	case "", DriverMemory:
+		if viper.GetInt(static.EnvServerReplicas) > 1 {
+			return nil, ErrDriverNotShared
+		}
+		size := static.Cache.Size
+		if viper.IsSet(static.EnvCacheSize) {
+			size = viper.GetInt(static.EnvCacheSize)
+		}
+		return NewLRUStore(size), nil
+	case DriverNone:
+		return NewLRUStore(0), nil
+	case DriverRedis:
+		return NewRedisStore(
+			viper.GetString(static.EnvCacheRedisAddress),
+			viper.GetString(static.EnvCacheRedisPassword),
+			viper.GetInt(static.EnvCacheRedisDB),
+		), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedDriver, driver)
+	}
+}
+
+// ttlFromEnv returns the duration the cached responses are kept
+func ttlFromEnv() time.Duration {
+	if ttl := viper.GetDuration(static.EnvCacheTTL); ttl > 0 {
+		return ttl
+	}
+
+	return static.Cache.TTL
+}
+
+// readThrough returns the cached value of the key, or loads it and caches it on a miss.
+// Store failures are counted and fall back to the loader so the cache never fails a request.
+func readThrough[T any](ctx context.Context, store Store, name, key string, ttl time.Duration, load func() (T, error)) (T, error) {
+	value, found, err := store.Get(ctx, key)
+	if err != nil {
+		metrics.CacheErrorsTotal.WithLabelValues(name, "get").Inc()
+	}
+
+	if found {
+		var result T
+		if err = json.Unmarshal(value, &result); err == nil {
+			metrics.CacheRequestsTotal.WithLabelValues(name, metrics.CacheHit).Inc()
+			return result, nil
+		}
+		metrics.CacheErrorsTotal.WithLabelValues(name, "decode").Inc()
+	}
+
+	metrics.CacheRequestsTotal.WithLabelValues(name, metrics.CacheMiss).Inc()
+
+	result, err := load()
+	if err != nil {
+		return result, err
+	}
+
+	if value, err = json.Marshal(result); err == nil {
+		err = store.Set(ctx, key, value, ttl)
+	}
+	if err != nil {
+		metrics.CacheErrorsTotal.WithLabelValues(name, "set").Inc()
+	}
+
+	return result, nil
+}
+
+// invalidate removes the keys from the store after the cached data has changed
+func invalidate(ctx context.Context, store Store, name string, keys ...string) {
+	if err := store.Delete(ctx, keys...); err != nil {
+		metrics.CacheErrorsTotal.WithLabelValues(name, "delete").Inc()
+		return
+	}
+
+	metrics.CacheInvalidationsTotal.WithLabelValues(name).Add(float64(len(keys)))
+}
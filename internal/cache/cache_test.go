@@ -0,0 +1,36 @@
+package cache
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"golang-project/static"
+)
+
+func TestNewStoreFromEnvRefusesTheMemoryDriverOnSeveralReplicas(t *testing.T) {
+	t.Cleanup(func() {
+		viper.Set(static.EnvServerReplicas, 0)
+		viper.Set(static.EnvCacheDriver, "")
+	})
+
+	for _, tc := range []struct {
+		driver   string
+		replicas int
+		err      error
+	}{
+		{driver: "", replicas: 1},
+		{driver: DriverMemory, replicas: 1},
+		{driver: "", replicas: 2, err: ErrDriverNotShared},
+		{driver: DriverMemory, replicas: 3, err: ErrDriverNotShared},
+		{driver: DriverNone, replicas: 3},
+	} {
+		viper.Set(static.EnvCacheDriver, tc.driver)
+		viper.Set(static.EnvServerReplicas, tc.replicas)
+
+		if _, err := NewStoreFromEnv(); !errors.Is(err, tc.err) {
+			t.Errorf("NewStoreFromEnv(%q, %d replicas) error = %v, want %v", tc.driver, tc.replicas, err, tc.err)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// entry is a cached value with its expiry
+type entry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// lruStore is an in-process implementation of the store evicting the least recently used keys
+type lruStore struct {
+	size int
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+// NewLRUStore returns a new in-process store holding at most size keys, a zero size disables caching
+func NewLRUStore(size int) Store {
+	return &lruStore{
+		size:    size,
+		order:   list.New(),
+		entries: map[string]*list.Element{},
+	}
+}
+
+// Get returns the value of the key unless it is missing or expired
+func (s *lruStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	element, ok := s.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	e := element.Value.(*entry)
+	if time.Now().After(e.expiresAt) {
+		s.remove(element)
+		return nil, false, nil
+	}
+
+	s.order.MoveToFront(element)
+
+	return e.value, true, nil
+}
+
+// Set stores the value of the key for the ttl and evicts the least recently used keys above the size
+func (s *lruStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	if s.size <= 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiresAt := time.Now().Add(ttl)
+	if element, ok := s.entries[key]; ok {
+		e := element.Value.(*entry)
+		e.value, e.expiresAt = value, expiresAt
+		s.order.MoveToFront(element)
+		return nil
+	}
+
+	s.entries[key] = s.order.PushFront(&entry{key: key, value: value, expiresAt: expiresAt})
+	for s.order.Len() > s.size {
+		s.remove(s.order.Back())
+	}
+
+	return nil
+}
+
+// Delete removes the keys
+func (s *lruStore) Delete(_ context.Context, keys ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, key := range keys {
+		if element, ok := s.entries[key]; ok {
+			s.remove(element)
+		}
+	}
+
+	return nil
+}
+
+// Ping always succeeds as the store lives in the process
+func (s *lruStore) Ping(context.Context) error {
+	return nil
+}
+
+// Close drops all the keys
+func (s *lruStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.order.Init()
+	s.entries = map[string]*list.Element{}
+
+	return nil
+}
+
+// remove drops the element from the recency list and the index
+func (s *lruStore) remove(element *list.Element) {
+	s.order.Remove(element)
+	delete(s.entries, element.Value.(*entry).key)
+}
@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLRUStoreEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	store := NewLRUStore(2)
+
+	_ = store.Set(ctx, "a", []byte("1"), time.Minute)
+	_ = store.Set(ctx, "b", []byte("2"), time.Minute)
+
+	// Reading a makes b the least recently used key
+	if _, found, _ := store.Get(ctx, "a"); !found {
+		t.Fatal("a is missing")
+	}
+	_ = store.Set(ctx, "c", []byte("3"), time.Minute)
+
+	for key, want := range map[string]bool{"a": true, "b": false, "c": true} {
+		if _, found, _ := store.Get(ctx, key); found != want {
+			t.Errorf("Get(%s) found = %t, want %t", key, found, want)
+		}
+	}
+}
+
+func TestLRUStoreExpiresAndDeletes(t *testing.T) {
+	ctx := context.Background()
+	store := NewLRUStore(10)
+
+	_ = store.Set(ctx, "expired", []byte("1"), -time.Second)
+	_ = store.Set(ctx, "deleted", []byte("2"), time.Minute)
+	_ = store.Set(ctx, "kept", []byte("3"), time.Minute)
+	_ = store.Delete(ctx, "deleted")
+
+	for key, want := range map[string]bool{"expired": false, "deleted": false, "kept": true} {
+		if _, found, _ := store.Get(ctx, key); found != want {
+			t.Errorf("Get(%s) found = %t, want %t", key, found, want)
+		}
+	}
+}
+
+func TestLRUStoreWithoutSizeCachesNothing(t *testing.T) {
+	ctx := context.Background()
+	store := NewLRUStore(0)
+
+	_ = store.Set(ctx, "a", []byte("1"), time.Minute)
+	if _, found, _ := store.Get(ctx, "a"); found {
+		t.Error("a disabled store returned a value")
+	}
+}
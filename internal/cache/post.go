@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	ct "golang-project/internal/contract"
+	"golang-project/internal/event"
+	svc "golang-project/internal/service"
+)
+
+// postCacheName labels the post cache metrics
+const postCacheName = "post"
+
+// postService caches the post details of the wrapped service.Post
+type postService struct {
+	svc.Post
+	reactionSvc svc.Reaction
+	store       Store
+}
+
+// NewPostService returns the service.Post reading post details through the cache
+func NewPostService(next svc.Post, reactionSvc svc.Reaction, store Store) svc.Post {
+	return &postService{Post: next, reactionSvc: reactionSvc, store: store}
+}
+
+// GetByID returns the cached post detail or loads it from the wrapped service. The detail is cached
+// without a viewer and the reactions are attached after reading it, so one viewer's reaction is never served to another
+func (s *postService) GetByID(ctx context.Context, viewerID *primitive.ObjectID, id primitive.ObjectID) (*ct.PostResponse, error) {
+	response, err := readThrough(ctx, s.store, postCacheName, postKey(id), ttlFromEnv(), func() (*ct.PostResponse, error) {
+		return s.Post.GetByID(ctx, nil, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.reactionSvc.AttachToPosts(ctx, viewerID, []*ct.PostResponse{response}); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Update updates the post and drops its cached detail
+func (s *postService) Update(ctx context.Context, userID primitive.ObjectID, r *ct.UpdatePostRequest) (*ct.PostResponse, error) {
+	response, err := s.Post.Update(ctx, userID, r)
+	if err != nil {
+		return nil, err
+	}
+
+	invalidate(ctx, s.store, postCacheName, postKey(r.ID))
+
+	return response, nil
+}
+
+// Delete deletes the post and drops its cached detail
+func (s *postService) Delete(ctx context.Context, id, userID primitive.ObjectID) error {
+	if err := s.Post.Delete(ctx, id, userID); err != nil {
+		return err
+	}
+
+	invalidate(ctx, s.store, postCacheName, postKey(id))
+
+	return nil
+}
+
+// SubscribePostChanges drops the cached detail of the posts changed outside the post service,
+// the relay of the server dispatches the events so the changes made by the admin commands reach its cache too
+func SubscribePostChanges(bus event.Bus, store Store) {
+	event.On(bus, "cache", func(ctx context.Context, e event.PostChanged) error {
+		invalidate(ctx, store, postCacheName, postKey(e.PostID))
+		return nil
+	})
+}
+
+// postKey returns the cache key of the post detail
+func postKey(id primitive.ObjectID) string {
+	return "post:" + id.Hex()
+}
@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	ct "golang-project/internal/contract"
+	svc "golang-project/internal/service"
+	"golang-project/static"
+)
+
+// countingPosts returns the post with the reaction of the viewer and counts the loads
+type countingPosts struct {
+	svc.Post
+	loads int
+}
+
+func (p *countingPosts) GetByID(_ context.Context, viewerID *primitive.ObjectID, id primitive.ObjectID) (*ct.PostResponse, error) {
+	p.loads++
+	response := &ct.PostResponse{ID: id}
+	if viewerID != nil {
+		response.MyReaction = static.ReactionLike
+	}
+
+	return response, nil
+}
+
+// likingReactions lets the given user like every post
+type likingReactions struct {
+	svc.Reaction
+	userID primitive.ObjectID
+}
+
+func (r likingReactions) AttachToPosts(_ context.Context, viewerID *primitive.ObjectID, posts []*ct.PostResponse) error {
+	for _, post := range posts {
+		post.Reactions, post.MyReaction = ct.ReactionCounts{static.ReactionLike: 1}, ""
+		if viewerID != nil && *viewerID == r.userID {
+			post.MyReaction = static.ReactionLike
+		}
+	}
+
+	return nil
+}
+
+func TestPostServiceCachesDetailsWithoutTheViewer(t *testing.T) {
+	ctx := context.Background()
+	userID, otherID, postID := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	posts := &countingPosts{}
+	s := NewPostService(posts, likingReactions{userID: userID}, NewLRUStore(10))
+
+	response, err := s.GetByID(ctx, &userID, postID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if response.MyReaction != static.ReactionLike {
+		t.Errorf("reaction of the liking user = %q, want %q", response.MyReaction, static.ReactionLike)
+	}
+
+	for name, viewerID := range map[string]*primitive.ObjectID{"other user": &otherID, "anonymous": nil} {
+		response, err = s.GetByID(ctx, viewerID, postID)
+		if err != nil {
+			t.Fatalf("GetByID(%s) error = %v", name, err)
+		}
+		if response.MyReaction != "" {
+			t.Errorf("reaction of the %s = %q, want none", name, response.MyReaction)
+		}
+		if response.Reactions[static.ReactionLike] != 1 {
+			t.Errorf("likes seen by the %s = %d, want 1", name, response.Reactions[static.ReactionLike])
+		}
+	}
+
+	if posts.loads != 1 {
+		t.Errorf("loads = %d, want 1", posts.loads)
+	}
+}
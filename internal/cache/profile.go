@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	ct "golang-project/internal/contract"
+	"golang-project/internal/event"
+	svc "golang-project/internal/service"
+)
+
+// profileCacheName labels the profile cache metrics
+const profileCacheName = "profile"
+
+// profileService caches the profiles of the wrapped service.Profile
+type profileService struct {
+	svc.Profile
+	store Store
+}
+
+// NewProfileService returns the service.Profile reading profiles through the cache
+func NewProfileService(next svc.Profile, store Store) svc.Profile {
+	return &profileService{Profile: next, store: store}
+}
+
+// GetByID returns the cached profile or loads it from the wrapped service
+func (s *profileService) GetByID(ctx context.Context, id primitive.ObjectID) (*ct.ProfileResponse, error) {
+	return readThrough(ctx, s.store, profileCacheName, profileKey(id), ttlFromEnv(), func() (*ct.ProfileResponse, error) {
+		return s.Profile.GetByID(ctx, id)
+	})
+}
+
+// Update updates the profile and drops its cached copy
+func (s *profileService) Update(ctx context.Context, id primitive.ObjectID, r *ct.UpdateProfileRequest) (*ct.ProfileResponse, error) {
+	response, err := s.Profile.Update(ctx, id, r)
+	if err != nil {
+		return nil, err
+	}
+
+	invalidate(ctx, s.store, profileCacheName, profileKey(id))
+
+	return response, nil
+}
+
+// ChangePassword changes the password and drops the cached profile, whose version has changed
+func (s *profileService) ChangePassword(ctx context.Context, id primitive.ObjectID, r *ct.ChangePasswordRequest) (*ct.ChangePasswordResponse, error) {
+	response, err := s.Profile.ChangePassword(ctx, id, r)
+	if err != nil {
+		return nil, err
+	}
+
+	invalidate(ctx, s.store, profileCacheName, profileKey(id))
+
+	return response, nil
+}
+
+// SubscribeProfileChanges drops the cached profiles of the users changed outside the profile service,
+// so the version served with a profile is never older than the one the If-Match of its update is checked against
+func SubscribeProfileChanges(bus event.Bus, store Store) {
+	event.On(bus, "cache", func(ctx context.Context, e event.ProfileChanged) error {
+		invalidate(ctx, store, profileCacheName, profileKey(e.UserID))
+		return nil
+	})
+}
+
+// profileKey returns the cache key of the user profile
+func profileKey(id primitive.ObjectID) string {
+	return "profile:" + id.Hex()
+}
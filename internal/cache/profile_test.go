@@ -0,0 +1,99 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	ct "golang-project/internal/contract"
+	"golang-project/internal/event"
+	svc "golang-project/internal/service"
+	"golang-project/static"
+)
+
+// versionedProfiles keeps the version of a single profile and checks the If-Match of its updates
+type versionedProfiles struct {
+	svc.Profile
+	version int64
+}
+
+func (p *versionedProfiles) GetByID(_ context.Context, id primitive.ObjectID) (*ct.ProfileResponse, error) {
+	return &ct.ProfileResponse{ID: id, Version: p.version}, nil
+}
+
+func (p *versionedProfiles) Update(_ context.Context, id primitive.ObjectID, r *ct.UpdateProfileRequest) (*ct.ProfileResponse, error) {
+	if r.IfMatch != nil && *r.IfMatch != p.version {
+		return nil, static.ErrVersionConflict
+	}
+	p.version++
+
+	return &ct.ProfileResponse{ID: id, Version: p.version}, nil
+}
+
+// relayedBus hands the published events to the subscribers right away, as the relay of the outbox does
+type relayedBus struct {
+	event.Bus
+	handlers map[static.EventName][]event.Handler
+}
+
+func (b *relayedBus) Subscribe(name static.EventName, _ string, handler event.Handler) {
+	b.handlers[name] = append(b.handlers[name], handler)
+}
+
+func (b *relayedBus) Publish(ctx context.Context, events ...event.Event) error {
+	for _, e := range events {
+		payload, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		for _, handler := range b.handlers[e.EventName()] {
+			if err = handler(ctx, &event.Envelope{Name: e.EventName(), Payload: payload}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func TestProfileServiceDropsChangedProfiles(t *testing.T) {
+	ctx := context.Background()
+	userID := primitive.NewObjectID()
+	profiles := &versionedProfiles{version: 1}
+	store := NewLRUStore(10)
+	bus := &relayedBus{handlers: map[static.EventName][]event.Handler{}}
+	SubscribeProfileChanges(bus, store)
+	s := NewProfileService(profiles, store)
+
+	response, err := s.GetByID(ctx, userID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	etag := response.Version
+
+	// Another request, such as a two-factor change, changes the version of the user between the GET and the PUT
+	profiles.version++
+	if err = bus.Publish(ctx, event.ProfileChanged{UserID: userID}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	if _, err = s.Update(ctx, userID, &ct.UpdateProfileRequest{IfMatch: &etag}); !errors.Is(err, static.ErrVersionConflict) {
+		t.Fatalf("update with the version read before the change: err = %v, want %v", err, static.ErrVersionConflict)
+	}
+
+	response, err = s.GetByID(ctx, userID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if response.Version != profiles.version {
+		t.Fatalf("version after the change = %d, want %d", response.Version, profiles.version)
+	}
+
+	etag = response.Version
+	if _, err = s.Update(ctx, userID, &ct.UpdateProfileRequest{IfMatch: &etag}); err != nil {
+		t.Errorf("update with the version read after the change: err = %v", err)
+	}
+}
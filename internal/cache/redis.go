@@ -0,0 +1,248 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+
+	"golang-project/static"
+)
+
+var (
+	ErrRedisReply = errors.New("unexpected redis reply")
+)
+
+// redisError represents the error reply of the Redis server
+type redisError string
+
+func (e redisError) Error() string {
+	return "redis: " + string(e)
+}
+
+// redisConn is a Redis connection with its buffered reader
+type redisConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// redisStore is an implementation of the store backed by Redis, it speaks RESP so any
+// compatible server such as a local Redis or an in-memory stand-in can back it
+type redisStore struct {
+	address  string
+	password string
+	db       int
+	idle     chan *redisConn
+}
+
+// NewRedisStore returns a new store connecting lazily to the Redis server at the address
+func NewRedisStore(address, password string, db int) Store {
+	if address == "" {
+		address = "localhost:6379"
+	}
+
+	return &redisStore{
+		address:  address,
+		password: password,
+		db:       db,
+		idle:     make(chan *redisConn, static.Cache.RedisMaxIdle),
+	}
+}
+
+// Get returns the value of the key, Redis drops the expired keys itself
+func (s *redisStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := s.do(ctx, "GET", static.Cache.KeyPrefix+key)
+	if err != nil {
+		return nil, false, err
+	}
+	if reply == nil {
+		return nil, false, nil
+	}
+
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, false, ErrRedisReply
+	}
+
+	return value, true, nil
+}
+
+// Set stores the value of the key for the ttl
+func (s *redisStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	_, err := s.do(ctx, "SET", static.Cache.KeyPrefix+key, string(value), "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	return err
+}
+
+// Delete removes the keys
+func (s *redisStore) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	args := []string{"DEL"}
+	for _, key := range keys {
+		args = append(args, static.Cache.KeyPrefix+key)
+	}
+
+	_, err := s.do(ctx, args...)
+	return err
+}
+
+// Ping verifies that the Redis server answers
+func (s *redisStore) Ping(ctx context.Context) error {
+	_, err := s.do(ctx, "PING")
+	return err
+}
+
+// Close closes the idle connections
+func (s *redisStore) Close() error {
+	for {
+		select {
+		case c := <-s.idle:
+			c.conn.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+// do sends the command on a pooled connection and returns the decoded reply
+func (s *redisStore) do(ctx context.Context, args ...string) (interface{}, error) {
+	c, err := s.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := c.do(ctx, args...)
+	if err != nil {
+		// Server error replies leave the connection usable, anything else may have broken the protocol stream
+		var replyErr redisError
+		if !errors.As(err, &replyErr) {
+			c.conn.Close()
+			return nil, err
+		}
+	}
+
+	s.release(c)
+
+	return reply, err
+}
+
+// acquire returns an idle connection or dials, authenticates and selects the database on a new one
+func (s *redisStore) acquire(ctx context.Context) (*redisConn, error) {
+	select {
+	case c := <-s.idle:
+		return c, nil
+	default:
+	}
+
+	dialer := net.Dialer{Timeout: static.Cache.RedisTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", s.address)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &redisConn{conn: conn, reader: bufio.NewReader(conn)}
+	if s.password != "" {
+		if _, err = c.do(ctx, "AUTH", s.password); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	if s.db != 0 {
+		if _, err = c.do(ctx, "SELECT", strconv.Itoa(s.db)); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// release returns the connection to the idle pool or closes it when the pool is full
+func (s *redisStore) release(c *redisConn) {
+	select {
+	case s.idle <- c:
+	default:
+		c.conn.Close()
+	}
+}
+
+// do writes the command as a RESP array of bulk strings and reads its reply
+func (c *redisConn) do(ctx context.Context, args ...string) (interface{}, error) {
+	deadline := time.Now().Add(static.Cache.RedisTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	command := fmt.Appendf(nil, "*%d\r\n", len(args))
+	for _, arg := range args {
+		command = fmt.Appendf(command, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+
+	if _, err := c.conn.Write(command); err != nil {
+		return nil, err
+	}
+
+	return c.read()
+}
+
+// read decodes a single RESP reply, nil bulk strings and arrays are returned as nil
+func (c *redisConn) read() (interface{}, error) {
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, ErrRedisReply
+	}
+
+	kind, payload := line[0], line[1:len(line)-2]
+	switch kind {
+	case '+':
+		return payload, nil
+	case '-':
+		return nil, redisError(payload)
+	case ':':
+		return strconv.ParseInt(payload, 10, 64)
+	case '$':
+		size, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, ErrRedisReply
+		}
+		if size < 0 {
+			return nil, nil
+		}
+
+		value := make([]byte, size+2)
+		if _, err = io.ReadFull(c.reader, value); err != nil {
+			return nil, err
+		}
+		return value[:size], nil
+	case '*':
+		size, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, ErrRedisReply
+		}
+		if size < 0 {
+			return nil, nil
+		}
+
+		values := make([]interface{}, size)
+		for i := range values {
+			if values[i], err = c.read(); err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	default:
+		return nil, ErrRedisReply
+	}
+}
@@ -0,0 +1,195 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang-project/static"
+)
+
+// redisServer is an in-memory stand-in for Redis answering the commands of the store over RESP
+type redisServer struct {
+	listener net.Listener
+	password string
+
+	mu       sync.Mutex
+	values   map[string]string
+	commands []string
+}
+
+// newRedisServer starts the stand-in on a loopback port until the test ends
+func newRedisServer(t *testing.T, password string) *redisServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	s := &redisServer{listener: listener, password: password, values: map[string]string{}}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+
+	return s
+}
+
+// serve answers the commands of the connection
+func (s *redisServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	authenticated := s.password == ""
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		s.commands = append(s.commands, args[0])
+		reply := "+OK\r\n"
+		switch {
+		case args[0] == "AUTH":
+			authenticated = args[1] == s.password
+			if !authenticated {
+				reply = "-WRONGPASS invalid password\r\n"
+			}
+		case !authenticated:
+			reply = "-NOAUTH Authentication required.\r\n"
+		case args[0] == "PING":
+			reply = "+PONG\r\n"
+		case args[0] == "SELECT":
+		case args[0] == "GET":
+			value, ok := s.values[args[1]]
+			reply = "$-1\r\n"
+			if ok {
+				reply = fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+			}
+		case args[0] == "SET":
+			s.values[args[1]] = args[2]
+		case args[0] == "DEL":
+			deleted := 0
+			for _, key := range args[1:] {
+				if _, ok := s.values[key]; ok {
+					delete(s.values, key)
+					deleted++
+				}
+			}
+			reply = ":" + strconv.Itoa(deleted) + "\r\n"
+		default:
+			reply = "-ERR unknown command\r\n"
+		}
+		s.mu.Unlock()
+
+		if _, err = io.WriteString(conn, reply); err != nil {
+			return
+		}
+	}
+}
+
+// readCommand reads a RESP array of bulk strings
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := strconv.Atoi(strings.TrimSuffix(line[1:], "\r\n"))
+	if err != nil || line[0] != '*' {
+		return nil, errors.New("not an array")
+	}
+
+	args := make([]string, count)
+	for i := range args {
+		if line, err = reader.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSuffix(line[1:], "\r\n"))
+		if err != nil {
+			return nil, err
+		}
+
+		value := make([]byte, size+2)
+		if _, err = io.ReadFull(reader, value); err != nil {
+			return nil, err
+		}
+		args[i] = string(value[:size])
+	}
+
+	return args, nil
+}
+
+func TestRedisStore(t *testing.T) {
+	ctx := context.Background()
+	server := newRedisServer(t, "secret")
+	store := NewRedisStore(server.listener.Addr().String(), "secret", 2)
+	defer store.Close()
+
+	if err := store.Ping(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, found, err := store.Get(ctx, "post:1"); err != nil || found {
+		t.Fatalf("Get(missing) = %t, %v, want a miss", found, err)
+	}
+
+	// Values are binary safe and stored under the key prefix
+	value := []byte("{\"title\":\"line\r\nbreak\"}")
+	if err := store.Set(ctx, "post:1", value, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	server.mu.Lock()
+	_, stored := server.values[static.Cache.KeyPrefix+"post:1"]
+	server.mu.Unlock()
+	if !stored {
+		t.Errorf("the key is not stored under the prefix %q", static.Cache.KeyPrefix)
+	}
+
+	got, found, err := store.Get(ctx, "post:1")
+	if err != nil || !found || string(got) != string(value) {
+		t.Fatalf("Get() = %q, %t, %v, want %q", got, found, err, value)
+	}
+
+	if err = store.Delete(ctx, "post:1", "post:2"); err != nil {
+		t.Fatal(err)
+	}
+	if _, found, _ = store.Get(ctx, "post:1"); found {
+		t.Error("Get() found a deleted key")
+	}
+
+	// The connection is authenticated and selects the database once, then it is reused
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	want := []string{"AUTH", "SELECT", "PING", "GET", "SET", "GET", "DEL", "GET"}
+	if strings.Join(server.commands, " ") != strings.Join(want, " ") {
+		t.Errorf("commands = %v, want %v", server.commands, want)
+	}
+}
+
+func TestRedisStoreReturnsServerErrors(t *testing.T) {
+	server := newRedisServer(t, "secret")
+	store := NewRedisStore(server.listener.Addr().String(), "wrong", 0)
+	defer store.Close()
+
+	var replyErr redisError
+	if err := store.Ping(context.Background()); !errors.As(err, &replyErr) {
+		t.Errorf("Ping() = %v, want a redis error", err)
+	}
+}
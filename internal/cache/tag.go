@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	ct "golang-project/internal/contract"
+	"golang-project/internal/event"
+	svc "golang-project/internal/service"
+)
+
+// tagCacheName labels the tag cache metrics
+const tagCacheName = "tag"
+
+// tagListKey is the cache key of the tag list
+const tagListKey = "tags"
+
+// tagService caches the tag list of the wrapped service.Tag
+type tagService struct {
+	svc.Tag
+	store Store
+}
+
+// NewTagService returns the service.Tag reading the tag list through the cache
+func NewTagService(next svc.Tag, store Store) svc.Tag {
+	return &tagService{Tag: next, store: store}
+}
+
+// List returns the cached tag list or loads it from the wrapped service
+func (s *tagService) List(ctx context.Context) (*ct.ListTagResponse, error) {
+	return readThrough(ctx, s.store, tagCacheName, tagListKey, ttlFromEnv(), func() (*ct.ListTagResponse, error) {
+		return s.Tag.List(ctx)
+	})
+}
+
+// Create creates the tag and drops the cached tag list
+func (s *tagService) Create(ctx context.Context, name string) (*ct.TagResponse, error) {
+	response, err := s.Tag.Create(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	invalidate(ctx, s.store, tagCacheName, tagListKey)
+
+	return response, nil
+}
+
+// Delete deletes the tag and drops the cached tag list, the cached posts are left alone
+// since a tag is only deleted while no post is labelled with it
+func (s *tagService) Delete(ctx context.Context, id primitive.ObjectID) error {
+	if err := s.Tag.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	invalidate(ctx, s.store, tagCacheName, tagListKey)
+
+	return nil
+}
+
+// SubscribeTagChanges drops the cached tag list when a tag is changed outside the tag service
+func SubscribeTagChanges(bus event.Bus, store Store) {
+	event.On(bus, "cache", func(ctx context.Context, e event.TagChanged) error {
+		invalidate(ctx, store, tagCacheName, tagListKey)
+		return nil
+	})
+}
@@ -0,0 +1,40 @@
+package contract
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/static"
+)
+
+// CreateAccessTokenRequest specifies the data and types for the personal access token creation API request,
+// the token expires after 90 days when the expiry is not set
+type CreateAccessTokenRequest struct {
+	Name          string                    `json:"name" validate:"required,max=100"`
+	Scopes        []static.AccessTokenScope `json:"scopes" validate:"required,min=1,dive,oneof=read write"`
+	ExpiresInDays int                       `json:"expires_in_days" validate:"omitempty,min=1,max=365"`
+}
+
+// AccessTokenRequest specifies the personal access token of the current user
+type AccessTokenRequest struct {
+	ID primitive.ObjectID `param:"tokenId" swaggerignore:"true" validate:"objectid"`
+}
+
+// AccessTokenResponse specifies the data and types for the personal access token API response,
+// the token is only returned when it is created
+type AccessTokenResponse struct {
+	ID         primitive.ObjectID        `json:"id"`
+	Name       string                    `json:"name"`
+	Scopes     []static.AccessTokenScope `json:"scopes"`
+	Prefix     string                    `json:"prefix"`
+	Token      string                    `json:"token,omitempty"`
+	ExpiresAt  string                    `json:"expires_at,omitempty"`
+	LastUsedAt string                    `json:"last_used_at,omitempty"`
+	LastUsedIP string                    `json:"last_used_ip,omitempty"`
+	RevokedAt  string                    `json:"revoked_at,omitempty"`
+	CreatedAt  string                    `json:"created_at,omitempty"`
+}
+
+// ListAccessTokenResponse contains the personal access tokens of the current user
+type ListAccessTokenResponse struct {
+	AccessTokens []*AccessTokenResponse `json:"access_tokens"`
+}
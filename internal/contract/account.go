@@ -0,0 +1,12 @@
+package contract
+
+// ScheduleDeletionRequest specifies the data and types for the account deletion API request
+type ScheduleDeletionRequest struct {
+	Reauthentication
+}
+
+// AccountDeletionResponse specifies the data and types for the account deletion API response
+type AccountDeletionResponse struct {
+	Scheduled    bool   `json:"scheduled"`
+	ScheduledFor string `json:"scheduled_for,omitempty"`
+}
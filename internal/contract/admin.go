@@ -0,0 +1,99 @@
+package contract
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/static"
+)
+
+// AdminCreateUserRequest specifies the data and types for the admin user create command
+type AdminCreateUserRequest struct {
+	Email     string          `json:"email" validate:"required,email"`
+	Password  string          `json:"password" validate:"required,min=8"`
+	FirstName string          `json:"first_name" validate:"required,personname"`
+	LastName  string          `json:"last_name" validate:"required,personname"`
+	Role      static.UserRole `json:"role" validate:"required,oneof=user moderator admin"`
+	DryRun    bool            `json:"dry_run"`
+}
+
+// AdminUserRequest specifies the user targeted by an admin user command, identified by email or ID
+type AdminUserRequest struct {
+	Identifier string `json:"identifier" validate:"required,notblank"`
+	DryRun     bool   `json:"dry_run"`
+}
+
+// AdminPromoteUserRequest specifies the data and types for the admin user promote command
+type AdminPromoteUserRequest struct {
+	Identifier string          `json:"identifier" validate:"required,notblank"`
+	Role       static.UserRole `json:"role" validate:"required,oneof=moderator admin"`
+	DryRun     bool            `json:"dry_run"`
+}
+
+// AdminResetPasswordRequest specifies the data and types for the admin user reset-password command,
+// a random password is generated when Password is empty
+type AdminResetPasswordRequest struct {
+	Identifier string `json:"identifier" validate:"required,notblank"`
+	Password   string `json:"password" validate:"omitempty,min=8"`
+	DryRun     bool   `json:"dry_run"`
+}
+
+// AdminUserResponse specifies the data and types for admin user command results
+type AdminUserResponse struct {
+	ID         primitive.ObjectID `json:"id,omitempty"`
+	Email      string             `json:"email"`
+	Pseudonym  string             `json:"pseudonym"`
+	Role       static.UserRole    `json:"role"`
+	Disabled   bool               `json:"disabled"`
+	DisabledAt string             `json:"disabled_at,omitempty"`
+	DryRun     bool               `json:"dry_run"`
+}
+
+// AdminResetPasswordResponse specifies the data and types for the admin user reset-password command result,
+// Password is only returned when it was generated
+type AdminResetPasswordResponse struct {
+	User     *AdminUserResponse `json:"user"`
+	Password string             `json:"password,omitempty"`
+	DryRun   bool               `json:"dry_run"`
+}
+
+// AdminPostRequest specifies the post targeted by an admin post command, identified by slug or ID
+type AdminPostRequest struct {
+	Identifier string `json:"identifier" validate:"required,notblank"`
+	DryRun     bool   `json:"dry_run"`
+}
+
+// AdminPostResponse specifies the data and types for admin post command results
+type AdminPostResponse struct {
+	ID          primitive.ObjectID `json:"id"`
+	Title       string             `json:"title"`
+	Slug        string             `json:"slug"`
+	IsPublished bool               `json:"is_published"`
+	DryRun      bool               `json:"dry_run"`
+}
+
+// AdminMergeTagRequest specifies the tags of the admin tag merge command, identified by name or ID
+type AdminMergeTagRequest struct {
+	Source string `json:"source" validate:"required,notblank"`
+	Target string `json:"target" validate:"required,notblank"`
+	DryRun bool   `json:"dry_run"`
+}
+
+// AdminMergeTagResponse specifies the data and types for the admin tag merge command result
+type AdminMergeTagResponse struct {
+	Source       *TagResponse `json:"source"`
+	Target       *TagResponse `json:"target"`
+	PostsUpdated int64        `json:"posts_updated"`
+	DryRun       bool         `json:"dry_run"`
+}
+
+// AdminRecomputeCountersRequest specifies the data and types for the admin counters recompute command
+type AdminRecomputeCountersRequest struct {
+	DryRun bool `json:"dry_run"`
+}
+
+// AdminRecomputeCountersResponse specifies the number of users and posts whose counters were corrected
+type AdminRecomputeCountersResponse struct {
+	UsersUpdated int64 `json:"users_updated"`
+	PostsUpdated int64 `json:"posts_updated"`
+	DryRun       bool  `json:"dry_run"`
+}
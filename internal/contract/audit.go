@@ -0,0 +1,46 @@
+package contract
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/static"
+)
+
+// ListAuditLogRequest defines the query parameters for searching the audit log,
+// from and to are RFC 3339 date times bounding the creation time
+type ListAuditLogRequest struct {
+	ActorID  primitive.ObjectID `json:"actor_id" query:"actor_id" swaggerignore:"true" validate:"omitempty,objectid"`
+	TargetID primitive.ObjectID `json:"target_id" query:"target_id" swaggerignore:"true" validate:"omitempty,objectid"`
+	Action   static.AuditAction `json:"action" query:"action" validate:"omitempty,max=64"`
+	From     string             `json:"from" query:"from" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	To       string             `json:"to" query:"to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	Page     int                `json:"page" query:"page" validate:"omitempty,min=1"`
+	PageSize int                `json:"page_size" query:"page_size" validate:"omitempty,min=1,max=100"`
+}
+
+// ListSecurityActivityRequest defines the query parameters for retrieving the security activity of the current user
+type ListSecurityActivityRequest struct {
+	Page     int `json:"page" query:"page" validate:"omitempty,min=1"`
+	PageSize int `json:"page_size" query:"page_size" validate:"omitempty,min=1,max=100"`
+}
+
+// AuditLogResponse specifies the data and types for the audit log entry API response
+type AuditLogResponse struct {
+	ID            primitive.ObjectID     `json:"id"`
+	ActorID       *primitive.ObjectID    `json:"actor_id,omitempty"`
+	Action        static.AuditAction     `json:"action"`
+	TargetType    static.AuditTargetType `json:"target_type,omitempty"`
+	TargetID      *primitive.ObjectID    `json:"target_id,omitempty"`
+	Source        static.AuditSource     `json:"source"`
+	IP            string                 `json:"ip,omitempty"`
+	UserAgent     string                 `json:"user_agent,omitempty"`
+	CorrelationID string                 `json:"correlation_id,omitempty"`
+	Metadata      map[string]string      `json:"metadata,omitempty"`
+	CreatedAt     string                 `json:"created_at,omitempty"`
+}
+
+// ListAuditLogResponse contains the page of audit log entries, the latest first
+type ListAuditLogResponse struct {
+	Entries []*AuditLogResponse `json:"entries"`
+	Paging  Paging              `json:"paging"`
+}
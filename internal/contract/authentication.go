@@ -1,7 +1,9 @@
 package contract
 
 import (
-	"golang-project/internal/model"
+	"time"
+
+	"golang-project/static"
 
 	"github.com/golang-jwt/jwt"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -14,10 +16,24 @@ type CustomClaim struct {
 	UserEmail string             `json:"user_email,omitempty"`
 }
 
-// ContextUser represents the authenticated user in API context
+// ContextUser represents the authenticated user in API context, scopes are only set for
+// personal access tokens and limit what the request may do
 type ContextUser struct {
-	ID    primitive.ObjectID `json:"id,omitempty"`
-	Email string             `json:"email,omitempty"`
+	ID            primitive.ObjectID        `json:"id,omitempty"`
+	Email         string                    `json:"email,omitempty"`
+	Scopes        []static.AccessTokenScope `json:"scopes,omitempty"`
+	IsAccessToken bool                      `json:"is_access_token,omitempty"`
+	SignedInAt    time.Time                 `json:"-"`
+}
+
+// Reauthentication specifies the confirmation of a sensitive action by the signed-in user: the password,
+// or for users without a password, such as the users of an OpenID Connect provider, a code of the
+// authenticator app, a recovery code or a sign-in within the last minutes
+type Reauthentication struct {
+	Password string `json:"password,omitempty"`
+	Code     string `json:"code,omitempty" validate:"max=32"`
+	// SignedInAt is the time the JWT of the request was issued
+	SignedInAt time.Time `json:"-" swaggerignore:"true"`
 }
 
 // SignInRequest represents the request payload for Sign In API
@@ -26,25 +42,48 @@ type SignInRequest struct {
 	Password string `json:"password" validate:"required"`
 }
 
-// SignInResponse specifies the data and types for Sign In API response
+// SignInResponse specifies the data and types for Sign In API response, users with two-factor authentication
+// receive a challenge token instead and complete the sign-in with their code
 type SignInResponse struct {
-	UserID       primitive.ObjectID `json:"user_id,omitempty"`
-	Token        string             `json:"token,omitempty"`
-	Type         string             `json:"type,omitempty"`
-	ExpiredAfter int                `json:"expired_at,omitempty"`
+	UserID             primitive.ObjectID `json:"user_id,omitempty"`
+	Token              string             `json:"token,omitempty"`
+	Type               string             `json:"type,omitempty"`
+	ExpiredAfter       int                `json:"expired_at,omitempty"`
+	TwoFactorRequired  bool               `json:"two_factor_required,omitempty"`
+	ChallengeToken     string             `json:"challenge_token,omitempty"`
+	ChallengeExpiresAt string             `json:"challenge_expires_at,omitempty"`
+}
+
+// TwoFactorSignInRequest specifies the challenge token of the sign-in with the code of the authenticator app
+// or a recovery code
+type TwoFactorSignInRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Code           string `json:"code" validate:"required,max=32"`
 }
 
 // SignUpRequest defines the payload required to create a new user account.
 type SignUpRequest struct {
 	Email     string `json:"email" validate:"required,email"`
 	Password  string `json:"password" validate:"required,min=8"`
-	FirstName string `json:"first_name" validate:"required"`
-	LastName  string `json:"last_name" validate:"required"`
+	FirstName string `json:"first_name" validate:"required,personname"`
+	LastName  string `json:"last_name" validate:"required,personname"`
 }
 
 // SignUpResponse defines the data returned after successful registration.
 type SignUpResponse struct {
-	User *model.User `json:"user"`
+	User *SignUpUser `json:"user"`
+}
+
+// SignUpUser defines the registered user returned by the sign up, the password hash is never part of it
+type SignUpUser struct {
+	ID         primitive.ObjectID `json:"id"`
+	Email      string             `json:"email"`
+	FirstName  string             `json:"first_name"`
+	LastName   string             `json:"last_name"`
+	Pseudonym  string             `json:"pseudonym"`
+	IsVerified bool               `json:"is_verified"`
+	Role       static.UserRole    `json:"role"`
+	CreatedAt  string             `json:"created_at,omitempty"`
 }
 
 // VerifyEmailRequest defines the data structure required to verify a user's email.
@@ -56,3 +95,19 @@ type VerifyEmailRequest struct {
 type VerifyEmailResponse struct {
 	Message string `json:"message"`
 }
+
+// OIDCAuthorizationResponse specifies the provider URL the user is redirected to for the OpenID Connect sign-in
+// and the signed state kept in the browser of the user
+type OIDCAuthorizationResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+	StateCookie      string `json:"-"`
+}
+
+// OIDCCallbackRequest represents the query parameters of the provider redirect ending the OpenID Connect sign-in
+type OIDCCallbackRequest struct {
+	State            string `query:"state" validate:"required,max=128"`
+	Code             string `query:"code" validate:"required_without=Error,max=4096"`
+	Error            string `query:"error" validate:"max=256"`
+	ErrorDescription string `query:"error_description" validate:"max=1024"`
+	StateCookie      string `swaggerignore:"true"`
+}
@@ -2,6 +2,8 @@ package contract
 
 import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/static"
 )
 
 // CommentResponse defines the structure of a single comment
@@ -13,6 +15,8 @@ type CommentResponse struct {
 	Post            *PostResponse           `json:"post,omitempty"`
 	ChildComments   []*ChildCommentResponse `json:"child_comments,omitempty" `
 	ParentCommentID *primitive.ObjectID     `json:"parent_comment_id,omitempty"`
+	Reactions       ReactionCounts          `json:"reactions,omitempty"`
+	MyReaction      static.ReactionType     `json:"my_reaction,omitempty"`
 	CreatedAt       string                  `json:"created_at,omitempty"`
 	UpdatedAt       string                  `json:"updated_at,omitempty"`
 }
@@ -23,6 +27,8 @@ type ChildCommentResponse struct {
 	Content         string              `json:"content,omitempty"`
 	ParentCommentID *primitive.ObjectID `json:"parent_comment_id,omitempty"`
 	User            *ProfileResponse    `json:"user,omitempty"`
+	Reactions       ReactionCounts      `json:"reactions,omitempty"`
+	MyReaction      static.ReactionType `json:"my_reaction,omitempty"`
 	CreatedAt       string              `json:"created_at,omitempty"`
 	UpdatedAt       string              `json:"updated_at,omitempty"`
 }
@@ -43,22 +49,27 @@ type ListCommentResponse struct {
 
 // ListCommentRequest defines the query parameters for retrieving comments.
 type ListCommentRequest struct {
-	PostID   primitive.ObjectID `json:"post_id" query:"post_id" validate:"required"`
-	Page     int                `json:"page" query:"page"`           // Page number
-	PageSize int                `json:"page_size" query:"page_size"` // Number of posts per page
+	PostID   primitive.ObjectID `json:"post_id" query:"post_id" validate:"required,objectid"`
+	Page     int                `json:"page" query:"page" validate:"omitempty,min=1"`                   // Page number
+	PageSize int                `json:"page_size" query:"page_size" validate:"omitempty,min=1,max=100"` // Number of posts per page
 }
 
 // CreateCommentRequest defines the expected payload when
 // a user wants to create a new comment.
 type CreateCommentRequest struct {
-	Content         string              `json:"content" validate:"required"`
-	PostID          primitive.ObjectID  `json:"post_id" validate:"required"`
-	ParentCommentID *primitive.ObjectID `json:"parent_comment_id,omitempty"`
+	Content         string              `json:"content" validate:"required,notblank"`
+	PostID          primitive.ObjectID  `json:"post_id" validate:"required,objectid"`
+	ParentCommentID *primitive.ObjectID `json:"parent_comment_id,omitempty" validate:"omitempty,objectid"`
 }
 
 // UpdateCommentRequest defines the expected payload when
 // a user wants to update an exist comment.
 type UpdateCommentRequest struct {
-	ID      primitive.ObjectID `param:"commentId" swaggerignore:"true"`
-	Content string             `json:"content" validate:"required"`
+	ID      primitive.ObjectID `param:"commentId" swaggerignore:"true" validate:"objectid"`
+	Content string             `json:"content" validate:"required,notblank"`
+}
+
+// CommentRequest specifies the comment of the request path
+type CommentRequest struct {
+	ID primitive.ObjectID `param:"commentId" swaggerignore:"true" validate:"objectid"`
 }
@@ -0,0 +1,13 @@
+package contract
+
+import (
+	"golang-project/util/apperror"
+)
+
+// ErrorResponse specifies the error envelope of every failed API response
+type ErrorResponse struct {
+	CID     string                `json:"cid"`
+	Code    string                `json:"code"`
+	Message string                `json:"message"`
+	Details []apperror.FieldError `json:"details,omitempty"`
+}
@@ -0,0 +1,29 @@
+package contract
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/static"
+)
+
+// ExportRequest specifies the export targeted by the export API
+type ExportRequest struct {
+	ID primitive.ObjectID `param:"exportId" swaggerignore:"true" validate:"objectid"`
+}
+
+// ExportResponse specifies the data and types for personal data export API response
+type ExportResponse struct {
+	ID          primitive.ObjectID  `json:"id"`
+	Status      static.ExportStatus `json:"status"`
+	DownloadURL string              `json:"download_url,omitempty"`
+	Size        int64               `json:"size,omitempty"`
+	CreatedAt   string              `json:"created_at,omitempty"`
+	CompletedAt string              `json:"completed_at,omitempty"`
+	ExpiresAt   string              `json:"expires_at,omitempty"`
+}
+
+// ExportFileResponse specifies the archive served by the export download API
+type ExportFileResponse struct {
+	Path     string
+	FileName string
+}
@@ -0,0 +1,28 @@
+package contract
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/static"
+)
+
+// FeedRequest represents the path parameters for syndication feed API
+type FeedRequest struct {
+	Format    static.FeedFormat `param:"format"`
+	Pseudonym string            `param:"pseudonym"`
+	Tag       string            `param:"tag"`
+	// SelfLink is the absolute URL the feed was requested from
+	SelfLink string `swaggerignore:"true"`
+	// ViewerID is the authenticated user reading the feed, nil for anonymous readers
+	ViewerID *primitive.ObjectID `swaggerignore:"true"`
+}
+
+// FeedResponse specifies the rendered syndication document and its validators for conditional requests
+type FeedResponse struct {
+	ContentType  string
+	Body         []byte
+	ETag         string
+	LastModified time.Time
+}
@@ -2,6 +2,30 @@ package contract
 
 // HealthCheckResponse specifies the data and types for health check API response
 type HealthCheckResponse struct {
-	Resource string `json:"resource,omitempty"`
-	Status   string `json:"status,omitempty"`
+	Resource  string `json:"resource,omitempty"`
+	Status    string `json:"status,omitempty"`
+	Duration  string `json:"duration,omitempty"`
+	CheckedAt string `json:"checked_at,omitempty"`
+}
+
+// BuildInfoResponse specifies the build and version information of the running server
+type BuildInfoResponse struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuiltAt   string `json:"built_at,omitempty"`
+	GoVersion string `json:"go_version"`
+}
+
+// LivenessResponse specifies the data and types for liveness probe API response
+type LivenessResponse struct {
+	Status string             `json:"status"`
+	Uptime string             `json:"uptime"`
+	Build  *BuildInfoResponse `json:"build"`
+}
+
+// ReadinessResponse specifies the data and types for readiness probe API response
+type ReadinessResponse struct {
+	Status string                 `json:"status"`
+	Checks []*HealthCheckResponse `json:"checks"`
+	Build  *BuildInfoResponse     `json:"build"`
 }
@@ -0,0 +1,71 @@
+package contract
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/static"
+)
+
+// CreateReportRequest specifies the data and types for the content report API request
+type CreateReportRequest struct {
+	TargetType static.ReportTargetType `json:"target_type" validate:"required,oneof=post comment"`
+	TargetID   primitive.ObjectID      `json:"target_id" validate:"required,objectid"`
+	Reason     static.ReportReason     `json:"reason" validate:"required,oneof=spam harassment hate_speech violence sexual_content misinformation other"`
+	Details    string                  `json:"details,omitempty" validate:"max=1000"`
+}
+
+// ReportResponse specifies the data and types for the content report API response
+type ReportResponse struct {
+	ID         primitive.ObjectID      `json:"id"`
+	TargetType static.ReportTargetType `json:"target_type"`
+	TargetID   primitive.ObjectID      `json:"target_id"`
+	Reason     static.ReportReason     `json:"reason"`
+	Status     static.ReportStatus     `json:"status"`
+	CreatedAt  string                  `json:"created_at,omitempty"`
+}
+
+// ListReportQueueRequest defines the query parameters for retrieving the moderation queue
+type ListReportQueueRequest struct {
+	TargetType static.ReportTargetType `json:"target_type" query:"target_type" validate:"omitempty,oneof=post comment"`
+	Page       int                     `json:"page" query:"page" validate:"omitempty,min=1"`
+	PageSize   int                     `json:"page_size" query:"page_size" validate:"omitempty,min=1,max=100"`
+}
+
+// ReportQueueItemResponse specifies the open reports of a content aggregated in the moderation queue
+type ReportQueueItemResponse struct {
+	TargetType      static.ReportTargetType     `json:"target_type"`
+	TargetID        primitive.ObjectID          `json:"target_id"`
+	AuthorID        primitive.ObjectID          `json:"author_id"`
+	Preview         string                      `json:"preview,omitempty"`
+	IsHidden        bool                        `json:"is_hidden"`
+	ReportCount     int                         `json:"report_count"`
+	Reasons         map[static.ReportReason]int `json:"reasons"`
+	FirstReportedAt string                      `json:"first_reported_at"`
+	LastReportedAt  string                      `json:"last_reported_at"`
+}
+
+// ReportQueueResponse specifies the page of the moderation queue
+type ReportQueueResponse struct {
+	Items  []*ReportQueueItemResponse `json:"items"`
+	Paging Paging                     `json:"paging"`
+}
+
+// ModerationActionRequest specifies the data and types for the moderation action API request
+type ModerationActionRequest struct {
+	TargetType static.ReportTargetType     `json:"target_type" validate:"required,oneof=post comment"`
+	TargetID   primitive.ObjectID          `json:"target_id" validate:"required,objectid"`
+	Action     static.ModerationActionType `json:"action" validate:"required,oneof=dismiss hide warn suspend"`
+	Note       string                      `json:"note,omitempty" validate:"max=1000"`
+}
+
+// ModerationActionResponse specifies the data and types for the moderation action API response
+type ModerationActionResponse struct {
+	ID              primitive.ObjectID          `json:"id"`
+	TargetType      static.ReportTargetType     `json:"target_type"`
+	TargetID        primitive.ObjectID          `json:"target_id"`
+	AuthorID        primitive.ObjectID          `json:"author_id"`
+	Action          static.ModerationActionType `json:"action"`
+	Note            string                      `json:"note,omitempty"`
+	ReportsResolved int64                       `json:"reports_resolved"`
+	CreatedAt       string                      `json:"created_at,omitempty"`
+}
@@ -8,15 +8,20 @@ import (
 
 // PostResponse defines the full details of a blog post returned by the post detail API,
 type PostResponse struct {
-	ID          primitive.ObjectID `json:"id,omitempty"`
-	Title       string             `json:"title,omitempty"`
-	Body        string             `json:"body,omitempty"`
-	Slug        string             `json:"slug,omitempty"`
-	IsPublished bool               `json:"is_published"`
-	User        *ProfileResponse   `json:"user,omitempty"`
-	Tags        []*TagResponse     `json:"tags,omitempty"`
-	CreatedAt   string             `json:"created_at,omitempty"`
-	UpdatedAt   string             `json:"updated_at,omitempty"`
+	ID             primitive.ObjectID  `json:"id,omitempty"`
+	Title          string              `json:"title,omitempty"`
+	Body           string              `json:"body,omitempty"`
+	Slug           string              `json:"slug,omitempty"`
+	IsPublished    bool                `json:"is_published"`
+	User           *ProfileResponse    `json:"user,omitempty"`
+	Tags           []*TagResponse      `json:"tags,omitempty"`
+	FavouriteCount int64               `json:"favourite_count"`
+	CommentCount   int64               `json:"comment_count"`
+	Version        int64               `json:"version,omitempty"`
+	Reactions      ReactionCounts      `json:"reactions,omitempty"`
+	MyReaction     static.ReactionType `json:"my_reaction,omitempty"`
+	CreatedAt      string              `json:"created_at,omitempty"`
+	UpdatedAt      string              `json:"updated_at,omitempty"`
 }
 
 // ListPostResponse defines the summary information of a blog post used in list endpoints,
@@ -27,18 +32,25 @@ type ListPostResponse struct {
 // CreatePostRequest represents the required and optional data needed to create a new blog post.
 type CreatePostRequest struct {
 	IsPublished bool                 `json:"is_published,omitempty" default:"false"`
-	Title       string               `json:"title" validate:"required"`
-	Body        string               `json:"body" validate:"required"`
-	Tags        []primitive.ObjectID `json:"tags,omitempty"`
+	Title       string               `json:"title" validate:"required,notblank,max=255"`
+	Body        string               `json:"body" validate:"required,notblank"`
+	Tags        []primitive.ObjectID `json:"tags,omitempty" validate:"omitempty,objectid"`
 }
 
 // UpdatePostRequest represents the fields that can be updated in an existing blog post.
 type UpdatePostRequest struct {
-	ID          primitive.ObjectID   `param:"postId" swaggerignore:"true"`
-	Title       string               `json:"title,omitempty"`
-	Body        string               `json:"body,omitempty"`
-	Tags        []primitive.ObjectID `json:"tags"`
+	ID          primitive.ObjectID   `param:"postId" swaggerignore:"true" validate:"objectid"`
+	Title       string               `json:"title,omitempty" validate:"omitempty,notblank,max=255"`
+	Body        string               `json:"body,omitempty" validate:"omitempty,notblank"`
+	Tags        []primitive.ObjectID `json:"tags" validate:"omitempty,objectid"`
 	IsPublished bool                 `json:"is_published"`
+	// IfMatch is the version required by the If-Match header, nil skips the precondition
+	IfMatch *int64 `json:"-" swaggerignore:"true"`
+}
+
+// PostRequest specifies the post of the request path
+type PostRequest struct {
+	ID primitive.ObjectID `param:"postId" swaggerignore:"true" validate:"objectid"`
 }
 
 // ListPostRequest defines the filter parameters for retrieving posts.
@@ -46,8 +58,8 @@ type ListPostRequest struct {
 	Tag       string `query:"tag"`
 	Pseudonym string `query:"pseudonym"`
 	Title     string `query:"title"`
-	Page      int    `query:"page"`     // Page number
-	PageSize  int    `query:"pageSize"` // Number of posts per page
+	Page      int    `query:"page" validate:"omitempty,min=1"`             // Page number
+	PageSize  int    `query:"pageSize" validate:"omitempty,min=1,max=100"` // Number of posts per page
 }
 
 // PostFavouriteStatusResponse represents the response when a user marks/unmarks a post as favourite,
@@ -60,5 +72,5 @@ type PostFavouriteStatusResponse struct {
 // PostFavouriteRequest represents the request payload for add to/remove from favourites actions
 type PostFavouriteRequest struct {
 	Action static.PostFavouriteAction `json:"action" validate:"required,oneof=favourite unfavourite"`
-	PostID primitive.ObjectID         `json:"post_id"`
+	PostID primitive.ObjectID         `json:"post_id" validate:"required,objectid"`
 }
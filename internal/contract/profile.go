@@ -8,15 +8,19 @@ import (
 
 // ProfileResponse specifies the data and types for profile API response
 type ProfileResponse struct {
-	ID           primitive.ObjectID `json:"id,omitempty"`
-	FirstName    string             `json:"first_name,omitempty"`
-	LastName     string             `json:"last_name,omitempty"`
-	Email        string             `json:"email,omitempty"`
-	Pseudonym    string             `json:"pseudonym,omitempty"`
-	ProfileImage string             `json:"profile_image"`
-	Biography    string             `json:"biography"`
-	CreatedAt    string             `json:"created_at,omitempty"`
-	UpdatedAt    string             `json:"updated_at,omitempty"`
+	ID             primitive.ObjectID `json:"id,omitempty"`
+	FirstName      string             `json:"first_name,omitempty"`
+	LastName       string             `json:"last_name,omitempty"`
+	Email          string             `json:"email,omitempty"`
+	Pseudonym      string             `json:"pseudonym,omitempty"`
+	ProfileImage   string             `json:"profile_image"`
+	Biography      string             `json:"biography"`
+	FollowerCount  int64              `json:"follower_count"`
+	FollowingCount int64              `json:"following_count"`
+	PostCount      int64              `json:"post_count"`
+	Version        int64              `json:"version,omitempty"`
+	CreatedAt      string             `json:"created_at,omitempty"`
+	UpdatedAt      string             `json:"updated_at,omitempty"`
 }
 
 // ListProfileResponse contains the list of profiles that the current user is following
@@ -34,23 +38,35 @@ type BloggerFollowStatusResponse struct {
 // BloggerFollowRequest represents the request payload for follow/unfollow actions
 type BloggerFollowRequest struct {
 	Action static.BloggerFollowAction `json:"action" validate:"required,oneof=follow unfollow"`
-	UserID primitive.ObjectID         `json:"user_id"`
+	UserID primitive.ObjectID         `json:"user_id" validate:"required,objectid"`
+}
+
+// ProfileRequest specifies the user of the request path
+type ProfileRequest struct {
+	ID primitive.ObjectID `param:"userId" swaggerignore:"true" validate:"objectid"`
+}
+
+// ListBloggerPostsRequest defines the filter of the posts of the authenticated user
+type ListBloggerPostsRequest struct {
+	IsPublished string `query:"is_published" validate:"omitempty,oneof=true false"`
 }
 
 // UpdateProfileRequest defines the payload for updating a user's profile information.
 type UpdateProfileRequest struct {
-	FirstName    string `json:"first_name,omitempty"`
-	LastName     string `json:"last_name,omitempty"`
-	Pseudonym    string `json:"pseudonym,omitempty"`
-	ProfileImage string `json:"profile_image,omitempty"`
+	FirstName    string `json:"first_name,omitempty" validate:"omitempty,personname"`
+	LastName     string `json:"last_name,omitempty" validate:"omitempty,personname"`
+	Pseudonym    string `json:"pseudonym,omitempty" validate:"omitempty,pseudonym"`
+	ProfileImage string `json:"profile_image,omitempty" validate:"omitempty,url"`
 	Biography    string `json:"biography,omitempty"`
+	// IfMatch is the version required by the If-Match header, nil skips the precondition
+	IfMatch *int64 `json:"-" swaggerignore:"true"`
 }
 
 // ChangePasswordRequest defines the payload required to change a user's password.
 type ChangePasswordRequest struct {
 	CurrentPassword    string `json:"current_password" validate:"required"`
 	NewPassword        string `json:"new_password" validate:"required,min=8"`
-	ConfirmNewPassword string `json:"confirm_new_password" validate:"eqfield=NewPassword"`
+	ConfirmNewPassword string `json:"confirm_new_password" validate:"required,eqfield=NewPassword"`
 }
 
 type ChangePasswordResponse struct {
@@ -0,0 +1,34 @@
+package contract
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/static"
+)
+
+// ReactionCounts specifies the number of reactions of each type left on a post or a comment
+type ReactionCounts map[static.ReactionType]int
+
+// ReactionTargetRequest specifies the post or comment targeted by the reaction API
+type ReactionTargetRequest struct {
+	TargetType static.ReactionTargetType `param:"targetType" swaggerignore:"true" validate:"required,oneof=post comment"`
+	TargetID   primitive.ObjectID        `param:"targetId" swaggerignore:"true" validate:"objectid"`
+}
+
+// SetReactionRequest specifies the reaction of the user on a post or a comment,
+// it replaces the previous reaction of the user
+type SetReactionRequest struct {
+	TargetType static.ReactionTargetType `param:"targetType" swaggerignore:"true" validate:"required,oneof=post comment"`
+	TargetID   primitive.ObjectID        `param:"targetId" swaggerignore:"true" validate:"objectid"`
+	Type       static.ReactionType       `json:"type" validate:"required,oneof=like love insightful funny celebrate"`
+}
+
+// ReactionSummaryResponse specifies the reactions of a post or a comment
+// and the reaction of the current user when authenticated
+type ReactionSummaryResponse struct {
+	TargetType static.ReactionTargetType `json:"target_type"`
+	TargetID   primitive.ObjectID        `json:"target_id"`
+	Reactions  ReactionCounts            `json:"reactions"`
+	Total      int                       `json:"total"`
+	MyReaction static.ReactionType       `json:"my_reaction,omitempty"`
+}
@@ -0,0 +1,21 @@
+package contract
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RelationshipRequest specifies the user to block, mute, unblock or unmute
+type RelationshipRequest struct {
+	UserID primitive.ObjectID `json:"user_id" param:"userId" validate:"required,objectid"`
+}
+
+// RelationshipResponse specifies a blocked or muted user and when the relationship started
+type RelationshipResponse struct {
+	User      *ProfileResponse `json:"user"`
+	CreatedAt string           `json:"created_at,omitempty"`
+}
+
+// ListRelationshipResponse contains the users blocked or muted by the current user
+type ListRelationshipResponse struct {
+	Users []*RelationshipResponse `json:"users"`
+}
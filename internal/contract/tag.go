@@ -19,5 +19,10 @@ type ListTagResponse struct {
 
 // CreateTagRequest specifies the data and types for tag API request
 type CreateTagRequest struct {
-	Name string `json:"name" validate:"required"`
+	Name string `json:"name" validate:"required,notblank,max=50"`
+}
+
+// TagRequest specifies the tag of the request path
+type TagRequest struct {
+	ID primitive.ObjectID `param:"tagId" swaggerignore:"true" validate:"objectid"`
 }
@@ -0,0 +1,35 @@
+package contract
+
+// TwoFactorStatusResponse specifies the two-factor authentication state of the current user
+type TwoFactorStatusResponse struct {
+	Enabled                bool   `json:"enabled"`
+	EnabledAt              string `json:"enabled_at,omitempty"`
+	RecoveryCodesRemaining int    `json:"recovery_codes_remaining"`
+}
+
+// TwoFactorEnrolmentResponse specifies the TOTP secret to add to an authenticator app,
+// the otpauth URI is usually shown as a QR code
+type TwoFactorEnrolmentResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+// EnrolTwoFactorRequest specifies the confirmation of the two-factor authentication enrolment
+type EnrolTwoFactorRequest struct {
+	Reauthentication
+}
+
+// TwoFactorCodeRequest specifies the current code of the authenticator app
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required,numeric"`
+}
+
+// RecoveryCodesResponse contains the recovery codes, they are only returned once and each one signs in once
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// DisableTwoFactorRequest specifies the confirmation of the two-factor authentication removal
+type DisableTwoFactorRequest struct {
+	Reauthentication
+}
@@ -0,0 +1,76 @@
+package contract
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/static"
+)
+
+// CreateWebhookRequest specifies the data and types for the webhook registration API request
+type CreateWebhookRequest struct {
+	URL      string                `json:"url" validate:"required,url,max=2048"`
+	Events   []static.WebhookEvent `json:"events" validate:"required,min=1,dive,oneof=post.published comment.created user.followed"`
+	AllUsers bool                  `json:"all_users"`
+}
+
+// WebhookRequest specifies the webhook of the current user
+type WebhookRequest struct {
+	ID primitive.ObjectID `param:"webhookId" swaggerignore:"true" validate:"objectid"`
+}
+
+// WebhookResponse specifies the data and types for the webhook API response,
+// the secret is only returned when the webhook is created
+type WebhookResponse struct {
+	ID        primitive.ObjectID    `json:"id"`
+	URL       string                `json:"url"`
+	Events    []static.WebhookEvent `json:"events"`
+	AllUsers  bool                  `json:"all_users"`
+	Secret    string                `json:"secret,omitempty"`
+	CreatedAt string                `json:"created_at,omitempty"`
+}
+
+// ListWebhookResponse contains the webhooks of the current user
+type ListWebhookResponse struct {
+	Webhooks []*WebhookResponse `json:"webhooks"`
+}
+
+// ListWebhookDeliveryRequest defines the parameters for retrieving the delivery log of a webhook
+type ListWebhookDeliveryRequest struct {
+	ID       primitive.ObjectID `param:"webhookId" swaggerignore:"true" validate:"objectid"`
+	Page     int                `json:"page" query:"page" validate:"omitempty,min=1"`
+	PageSize int                `json:"page_size" query:"page_size" validate:"omitempty,min=1,max=100"`
+}
+
+// RedeliverWebhookRequest specifies the delivery of the webhook to send again
+type RedeliverWebhookRequest struct {
+	ID         primitive.ObjectID `param:"webhookId" swaggerignore:"true" validate:"objectid"`
+	DeliveryID primitive.ObjectID `param:"deliveryId" swaggerignore:"true" validate:"objectid"`
+}
+
+// WebhookDeliveryResponse specifies a delivery attempt record of the webhook
+type WebhookDeliveryResponse struct {
+	ID             primitive.ObjectID           `json:"id"`
+	Event          static.WebhookEvent          `json:"event"`
+	Status         static.WebhookDeliveryStatus `json:"status"`
+	Attempts       int                          `json:"attempts"`
+	LastStatusCode int                          `json:"last_status_code,omitempty"`
+	LastError      string                       `json:"last_error,omitempty"`
+	NextAttemptAt  string                       `json:"next_attempt_at,omitempty"`
+	DeliveredAt    string                       `json:"delivered_at,omitempty"`
+	RedeliveryOf   *primitive.ObjectID          `json:"redelivery_of,omitempty"`
+	CreatedAt      string                       `json:"created_at,omitempty"`
+}
+
+// ListWebhookDeliveryResponse specifies the page of the delivery log of a webhook
+type ListWebhookDeliveryResponse struct {
+	Deliveries []*WebhookDeliveryResponse `json:"deliveries"`
+	Paging     Paging                     `json:"paging"`
+}
+
+// WebhookPayload specifies the body posted to the webhook endpoints
+type WebhookPayload struct {
+	ID        primitive.ObjectID  `json:"id"`
+	Event     static.WebhookEvent `json:"event"`
+	CreatedAt string              `json:"created_at"`
+	Data      interface{}         `json:"data"`
+}
@@ -0,0 +1,262 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/internal/metrics"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	"golang-project/internal/tracing"
+	"golang-project/static"
+)
+
+// Envelope represents a dispatched event with its outbox metadata
+type Envelope struct {
+	ID         primitive.ObjectID
+	Name       static.EventName
+	OccurredAt time.Time
+	Payload    []byte
+}
+
+// Handler handles a dispatched event, an event is dispatched at least once so handlers must tolerate duplicates
+type Handler func(ctx context.Context, e *Envelope) error
+
+// Publisher represents the side of the bus used by the services
+type Publisher interface {
+	// Publish writes the events to the outbox, it must run in the UnitOfWork of the changes raising them
+	Publish(ctx context.Context, events ...Event) error
+}
+
+// Bus represents the in-process domain event bus backed by the outbox
+type Bus interface {
+	Publisher
+	// Subscribe adds the handler of the event under the subscriber name, the name must be stable across releases
+	// since it records which subscribers already handled an event
+	Subscribe(name static.EventName, subscriber string, handler Handler)
+	// Work relays the outbox events to the subscribers until ctx is cancelled
+	Work(ctx context.Context)
+}
+
+// subscription represents a registered handler
+type subscription struct {
+	subscriber string
+	handler    Handler
+}
+
+// bus represents the implementation of Bus
+type bus struct {
+	outboxRepo repo.Outbox
+	mu         sync.RWMutex
+	handlers   map[static.EventName][]subscription
+	wake       chan struct{}
+}
+
+// NewBus returns a new implementation of Bus
+func NewBus(outboxRepo repo.Outbox) Bus {
+	return &bus{
+		outboxRepo: outboxRepo,
+		handlers:   make(map[static.EventName][]subscription),
+		wake:       make(chan struct{}, 1),
+	}
+}
+
+// On subscribes the typed handler to the events of its type
+func On[T Event](b Bus, subscriber string, fn func(ctx context.Context, e T) error) {
+	var zero T
+	b.Subscribe(zero.EventName(), subscriber, func(ctx context.Context, envelope *Envelope) error {
+		var e T
+		if err := json.Unmarshal(envelope.Payload, &e); err != nil {
+			return err
+		}
+
+		return fn(ctx, e)
+	})
+}
+
+// OnEnvelope subscribes fn like On and also hands it the envelope, for the handlers keyed by the ID of the event
+func OnEnvelope[T Event](b Bus, subscriber string, fn func(ctx context.Context, envelope *Envelope, e T) error) {
+	var zero T
+	b.Subscribe(zero.EventName(), subscriber, func(ctx context.Context, envelope *Envelope) error {
+		var e T
+		if err := json.Unmarshal(envelope.Payload, &e); err != nil {
+			return err
+		}
+
+		return fn(ctx, envelope, e)
+	})
+}
+
+// Publish writes the events to the outbox
+func (b *bus) Publish(ctx context.Context, events ...Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	documents := make([]*model.OutboxEvent, 0, len(events))
+	for _, e := range events {
+		payload, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+
+		documents = append(documents, &model.OutboxEvent{
+			Name:          e.EventName(),
+			Payload:       payload,
+			Status:        static.OutboxPending,
+			NextAttemptAt: &now,
+		})
+	}
+
+	if err := b.outboxRepo.Insert(ctx, documents); err != nil {
+		return err
+	}
+
+	for _, e := range events {
+		metrics.EventsPublishedTotal.WithLabelValues(string(e.EventName())).Inc()
+	}
+	b.wakeUp()
+
+	return nil
+}
+
+// Subscribe adds the handler of the event under the subscriber name
+func (b *bus) Subscribe(name static.EventName, subscriber string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[name] = append(b.handlers[name], subscription{subscriber: subscriber, handler: handler})
+}
+
+// Work relays the due outbox events until ctx is cancelled
+func (b *bus) Work(ctx context.Context) {
+	ticker := time.NewTicker(static.Outbox.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		b.relayDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-b.wake:
+		}
+	}
+}
+
+// relayDue dispatches the due events one at a time, events left dispatching by a stopped relay are dispatched again
+func (b *bus) relayDue(ctx context.Context) {
+	if err := b.outboxRepo.RequeueStale(ctx, time.Now().Add(-static.Outbox.StaleAfter)); err != nil {
+		log.Println("outbox requeue error:", err)
+	}
+
+	for ctx.Err() == nil {
+		o, err := b.outboxRepo.ClaimDue(ctx, time.Now())
+		if errors.Is(err, static.ErrOutboxEventNotFound) {
+			return
+		}
+		if err != nil {
+			log.Println("outbox claim error:", err)
+			return
+		}
+
+		b.dispatch(ctx, o)
+	}
+}
+
+// dispatch runs the subscribers which have not handled the event yet, a failed subscriber
+// is retried with an exponential backoff without running the others again
+func (b *bus) dispatch(ctx context.Context, o *model.OutboxEvent) {
+	ctx, span := tracing.Start(ctx, "event.Dispatch")
+	defer span.End()
+
+	b.mu.RLock()
+	subscriptions := b.handlers[o.Name]
+	b.mu.RUnlock()
+
+	envelope := &Envelope{ID: o.ID, Name: o.Name, Payload: o.Payload}
+	if o.CreatedAt != nil {
+		envelope.OccurredAt = *o.CreatedAt
+	}
+
+	handled := o.Handled
+	var errs []error
+	for _, s := range subscriptions {
+		if slices.Contains(handled, s.subscriber) {
+			continue
+		}
+
+		if err := s.handler(ctx, envelope); err != nil {
+			metrics.EventHandlersTotal.WithLabelValues(string(o.Name), s.subscriber, metrics.OutcomeFailure).Inc()
+			errs = append(errs, fmt.Errorf("%s: %w", s.subscriber, err))
+			continue
+		}
+
+		metrics.EventHandlersTotal.WithLabelValues(string(o.Name), s.subscriber, metrics.OutcomeSuccess).Inc()
+		handled = append(handled, s.subscriber)
+	}
+
+	o.Attempts++
+	updates := map[string]interface{}{"attempts": o.Attempts, "handled": handled}
+
+	now := time.Now()
+	err := errors.Join(errs...)
+	switch {
+	case err == nil:
+		updates["status"] = static.OutboxDispatched
+		updates["dispatched_at"] = now
+		updates["last_error"] = ""
+	case o.Attempts >= static.Outbox.MaxAttempts:
+		span.RecordError(err)
+		log.Println("outbox event failed:", o.ID.Hex(), o.Name, err)
+		updates["status"] = static.OutboxFailed
+		updates["last_error"] = truncate(err.Error())
+	default:
+		span.RecordError(err)
+		updates["status"] = static.OutboxPending
+		updates["next_attempt_at"] = now.Add(backoff(o.Attempts))
+		updates["last_error"] = truncate(err.Error())
+	}
+
+	if err = b.outboxRepo.Update(ctx, o, updates); err != nil {
+		log.Println("outbox event update error:", o.ID.Hex(), err)
+	}
+}
+
+// wakeUp wakes the relay up, a pending wake up already covers the new events
+func (b *bus) wakeUp() {
+	select {
+	case b.wake <- struct{}{}:
+	default:
+	}
+}
+
+// backoff returns the delay before the next attempt, doubling from the initial backoff up to the maximum
+func backoff(attempts int) time.Duration {
+	delay := static.Outbox.InitialBackoff
+	for i := 1; i < attempts && delay < static.Outbox.MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, static.Outbox.MaxBackoff)
+}
+
+// truncate limits the error recorded on the event
+func truncate(s string) string {
+	runes := []rune(s)
+	if len(runes) <= static.Outbox.MaxErrorLength {
+		return s
+	}
+
+	return string(runes[:static.Outbox.MaxErrorLength])
+}
@@ -0,0 +1,114 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	"golang-project/static"
+)
+
+// outboxRepo keeps the outbox in memory, the events inserted in a unit of work are only kept once it commits
+type outboxRepo struct {
+	repo.Outbox
+	events  []*model.OutboxEvent
+	pending []*model.OutboxEvent
+}
+
+func (r *outboxRepo) Insert(_ context.Context, events []*model.OutboxEvent) error {
+	for _, e := range events {
+		e.ID = primitive.NewObjectID()
+	}
+	r.pending = append(r.pending, events...)
+	return nil
+}
+
+func (r *outboxRepo) ClaimDue(_ context.Context, now time.Time) (*model.OutboxEvent, error) {
+	for _, e := range r.events {
+		if e.Status == static.OutboxPending && !e.NextAttemptAt.After(now) {
+			e.Status = static.OutboxDispatching
+			return e, nil
+		}
+	}
+	return nil, static.ErrOutboxEventNotFound
+}
+
+func (r *outboxRepo) Update(_ context.Context, o *model.OutboxEvent, updates map[string]interface{}) error {
+	o.Status = updates["status"].(static.OutboxStatus)
+	o.Handled = updates["handled"].([]string)
+	return nil
+}
+
+func (r *outboxRepo) RequeueStale(context.Context, time.Time) error {
+	return nil
+}
+
+// unitOfWork commits the events published by the work unless it fails
+type unitOfWork struct {
+	outbox *outboxRepo
+}
+
+func (u unitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	u.outbox.pending = nil
+	if err := fn(ctx); err != nil {
+		return err
+	}
+
+	u.outbox.events = append(u.outbox.events, u.outbox.pending...)
+	return nil
+}
+
+func TestRelayDispatchesCommittedEventsOnce(t *testing.T) {
+	ctx := context.Background()
+	outbox := &outboxRepo{}
+	b := NewBus(outbox).(*bus)
+	unitOfWork := unitOfWork{outbox: outbox}
+
+	var webhook, feed []primitive.ObjectID
+	On(b, "webhook", func(_ context.Context, e PostPublished) error {
+		webhook = append(webhook, e.PostID)
+		return nil
+	})
+	On(b, "feed", func(_ context.Context, e PostPublished) error {
+		feed = append(feed, e.PostID)
+		return nil
+	})
+
+	committed, aborted := primitive.NewObjectID(), primitive.NewObjectID()
+	if err := unitOfWork.Do(ctx, func(ctx context.Context) error {
+		return b.Publish(ctx, PostPublished{PostID: committed})
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	errAbort := errors.New("abort")
+	if err := unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := b.Publish(ctx, PostPublished{PostID: aborted}); err != nil {
+			return err
+		}
+		return errAbort
+	}); !errors.Is(err, errAbort) {
+		t.Fatalf("err = %v, want %v", err, errAbort)
+	}
+
+	// Relaying again finds nothing left to dispatch
+	b.relayDue(ctx)
+	b.relayDue(ctx)
+
+	want := []primitive.ObjectID{committed}
+	if !slices.Equal(webhook, want) {
+		t.Errorf("webhook received %v, want %v", webhook, want)
+	}
+	if !slices.Equal(feed, want) {
+		t.Errorf("feed received %v, want %v", feed, want)
+	}
+	if status := outbox.events[0].Status; status != static.OutboxDispatched {
+		t.Errorf("status = %s, want %s", status, static.OutboxDispatched)
+	}
+}
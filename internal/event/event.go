@@ -0,0 +1,78 @@
+package event
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/static"
+)
+
+// Event represents a domain event, the fields are the JSON payload kept in the outbox
+type Event interface {
+	EventName() static.EventName
+}
+
+// UserSignedUp is published when a user account is created
+type UserSignedUp struct {
+	UserID primitive.ObjectID `json:"user_id"`
+	Email  string             `json:"email"`
+}
+
+// EventName returns the name of the event
+func (UserSignedUp) EventName() static.EventName { return static.EventUserSignedUp }
+
+// PostPublished is published when a post becomes visible to the other users
+type PostPublished struct {
+	PostID primitive.ObjectID `json:"post_id"`
+	UserID primitive.ObjectID `json:"user_id"`
+	Title  string             `json:"title"`
+}
+
+// EventName returns the name of the event
+func (PostPublished) EventName() static.EventName { return static.EventPostPublished }
+
+// CommentCreated is published when a user comments a post, PostUserID is the author of the post
+type CommentCreated struct {
+	CommentID  primitive.ObjectID `json:"comment_id"`
+	PostID     primitive.ObjectID `json:"post_id"`
+	UserID     primitive.ObjectID `json:"user_id"`
+	PostUserID primitive.ObjectID `json:"post_user_id"`
+}
+
+// EventName returns the name of the event
+func (CommentCreated) EventName() static.EventName { return static.EventCommentCreated }
+
+// Followed is published when a user follows another user
+type Followed struct {
+	UserID         primitive.ObjectID `json:"user_id"`
+	FollowedUserID primitive.ObjectID `json:"followed_user_id"`
+}
+
+// EventName returns the name of the event
+func (Followed) EventName() static.EventName { return static.EventFollowed }
+
+// PostChanged is published when a post is changed outside the post service, such as by moderation, admin commands,
+// counter corrections, reactions, comments, favourites or account deletions, so the copies of the post are refreshed
+type PostChanged struct {
+	PostID primitive.ObjectID `json:"post_id"`
+}
+
+// EventName returns the name of the event
+func (PostChanged) EventName() static.EventName { return static.EventPostChanged }
+
+// ProfileChanged is published when the user document is changed outside the profile service, such as by follows,
+// published posts, two-factor authentication, identity linking or account deletion, so the copies of the profile are refreshed
+type ProfileChanged struct {
+	UserID primitive.ObjectID `json:"user_id"`
+}
+
+// EventName returns the name of the event
+func (ProfileChanged) EventName() static.EventName { return static.EventProfileChanged }
+
+// TagChanged is published when a tag is changed outside the tag service, such as by the tag merge command,
+// so the copies of the tag list are refreshed
+type TagChanged struct {
+	TagID primitive.ObjectID `json:"tag_id"`
+}
+
+// EventName returns the name of the event
+func (TagChanged) EventName() static.EventName { return static.EventTagChanged }
@@ -0,0 +1,130 @@
+package accesstoken
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	ct "golang-project/internal/contract"
+	hdl "golang-project/internal/handler"
+	svc "golang-project/internal/service"
+	"golang-project/server"
+)
+
+// handler represents the implementation of handler.AccessToken
+type handler struct {
+	route          string
+	accessTokenSvc svc.AccessToken
+}
+
+// NewHandler returns a new implementation of handler.AccessToken
+func NewHandler(route string, accessTokenSvc svc.AccessToken) hdl.AccessToken {
+	return &handler{
+		route:          route,
+		accessTokenSvc: accessTokenSvc,
+	}
+}
+
+// RegisterRoutes registers the handler routes and returns the server.HandlerRegistry
+func (h *handler) RegisterRoutes() server.HandlerRegistry {
+	return server.HandlerRegistry{
+		Route:           h.route,
+		IsAuthenticated: true,
+		Register: func(group *echo.Group) {
+			group.Use(hdl.RequireSession)
+			group.POST("", h.Create)
+			group.GET("", h.List)
+			group.DELETE("/:tokenId", h.Revoke)
+		},
+	}
+}
+
+// Create handles the request to issue a personal access token
+//
+//	@Summary		Create a personal access token
+//	@Description	Issues a named token accepted as a bearer token in place of the JWT. The read scope allows the GET and HEAD requests, the write scope every request. The token is only returned once
+//	@Tags			access-tokens
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerToken
+//	@Param			request	body		ct.CreateAccessTokenRequest	true	"Access token"
+//	@Success		201		{object}	ct.AccessTokenResponse
+//	@Failure		400		{object}	ct.ErrorResponse
+//	@Failure		401		{object}	ct.ErrorResponse
+//	@Failure		403		{object}	ct.ErrorResponse
+//	@Failure		409		{object}	ct.ErrorResponse
+//	@Router			/v1/access-tokens [post]
+func (h *handler) Create(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.CreateAccessTokenRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+
+	response, err := h.accessTokenSvc.Create(e.Request().Context(), user.ID, request)
+	if err != nil {
+		return err
+	}
+
+	hdl.NoStore(e)
+	return e.JSON(http.StatusCreated, response)
+}
+
+// List handles the request to list the personal access tokens of the authenticated user
+//
+//	@Summary		Personal access tokens
+//	@Description	Lists the personal access tokens of the authenticated user with their last use, the latest first
+//	@Tags			access-tokens
+//	@Produce		json
+//	@Security		BearerToken
+//	@Success		200	{object}	ct.ListAccessTokenResponse
+//	@Failure		401	{object}	ct.ErrorResponse
+//	@Router			/v1/access-tokens [get]
+func (h *handler) List(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.accessTokenSvc.List(e.Request().Context(), user.ID)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, response)
+}
+
+// Revoke handles the request to revoke a personal access token
+//
+//	@Summary		Revoke a personal access token
+//	@Description	Revokes the token, the requests using it are rejected from now on
+//	@Tags			access-tokens
+//	@Security		BearerToken
+//	@Param			tokenId	path	string	true	"Access token ID"
+//	@Success		204
+//	@Failure		400	{object}	ct.ErrorResponse
+//	@Failure		401	{object}	ct.ErrorResponse
+//	@Failure		403	{object}	ct.ErrorResponse
+//	@Failure		404	{object}	ct.ErrorResponse
+//	@Router			/v1/access-tokens/{tokenId} [delete]
+func (h *handler) Revoke(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.AccessTokenRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+
+	if err = h.accessTokenSvc.Revoke(e.Request().Context(), user.ID, request); err != nil {
+		return err
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,123 @@
+package account
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	ct "golang-project/internal/contract"
+	hdl "golang-project/internal/handler"
+	svc "golang-project/internal/service"
+	"golang-project/server"
+)
+
+// handler represents the implementation of handler.Account
+type handler struct {
+	route      string
+	accountSvc svc.Account
+}
+
+// NewHandler returns a new implementation of handler.Account
+func NewHandler(route string, accountSvc svc.Account) hdl.Account {
+	return &handler{
+		route:      route,
+		accountSvc: accountSvc,
+	}
+}
+
+// RegisterRoutes registers the handler routes and returns the server.HandlerRegistry
+func (h *handler) RegisterRoutes() server.HandlerRegistry {
+	return server.HandlerRegistry{
+		Route:           h.route,
+		IsAuthenticated: true,
+		Register: func(group *echo.Group) {
+			group.Use(hdl.RequireSession)
+			group.POST("/deletion", h.ScheduleDeletion)
+			group.GET("/deletion", h.GetDeletion)
+			group.DELETE("/deletion", h.CancelDeletion)
+		},
+	}
+}
+
+// ScheduleDeletion handles the request to delete the account of the authenticated user
+//
+//	@Summary		Schedule the account deletion
+//	@Description	Verifies the password and deletes the account with its posts, comments, follows, favourites and exports once the grace period is over. Users without a password, who sign in with an identity provider, confirm with a code of the authenticator app or a recovery code, or sign in again within 5 minutes before
+//	@Tags			account
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerToken
+//	@Param			request	body		ct.ScheduleDeletionRequest	true	"Password or code confirmation"
+//	@Success		202		{object}	ct.AccountDeletionResponse
+//	@Failure		400		{object}	ct.ErrorResponse
+//	@Failure		401		{object}	ct.ErrorResponse
+//	@Failure		409		{object}	ct.ErrorResponse
+//	@Router			/v1/account/deletion [post]
+func (h *handler) ScheduleDeletion(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.ScheduleDeletionRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+	request.SignedInAt = user.SignedInAt
+
+	response, err := h.accountSvc.ScheduleDeletion(e.Request().Context(), user.ID, request)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusAccepted, response)
+}
+
+// GetDeletion handles the request to read the scheduled deletion of the authenticated user
+//
+//	@Summary		Account deletion status
+//	@Description	Returns whether the account deletion is scheduled and when it happens
+//	@Tags			account
+//	@Produce		json
+//	@Security		BearerToken
+//	@Success		200	{object}	ct.AccountDeletionResponse
+//	@Failure		401	{object}	ct.ErrorResponse
+//	@Router			/v1/account/deletion [get]
+func (h *handler) GetDeletion(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.accountSvc.GetDeletion(e.Request().Context(), user.ID)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, response)
+}
+
+// CancelDeletion handles the request to cancel the scheduled deletion of the authenticated user
+//
+//	@Summary		Cancel the account deletion
+//	@Description	Cancels the scheduled account deletion during the grace period
+//	@Tags			account
+//	@Produce		json
+//	@Security		BearerToken
+//	@Success		200	{object}	ct.AccountDeletionResponse
+//	@Failure		401	{object}	ct.ErrorResponse
+//	@Failure		404	{object}	ct.ErrorResponse
+//	@Router			/v1/account/deletion [delete]
+func (h *handler) CancelDeletion(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.accountSvc.CancelDeletion(e.Request().Context(), user.ID)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, response)
+}
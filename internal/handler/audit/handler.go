@@ -0,0 +1,108 @@
+package audit
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	ct "golang-project/internal/contract"
+	hdl "golang-project/internal/handler"
+	svc "golang-project/internal/service"
+	"golang-project/server"
+)
+
+// handler represents the implementation of handler.Audit
+type handler struct {
+	route    string
+	auditSvc svc.Audit
+}
+
+// NewHandler returns a new implementation of handler.Audit
+func NewHandler(route string, auditSvc svc.Audit) hdl.Audit {
+	return &handler{
+		route:    route,
+		auditSvc: auditSvc,
+	}
+}
+
+// RegisterRoutes registers the handler routes and returns the server.HandlerRegistry
+func (h *handler) RegisterRoutes() server.HandlerRegistry {
+	return server.HandlerRegistry{
+		Route:           h.route,
+		IsAuthenticated: true,
+		Register: func(group *echo.Group) {
+			group.GET("/logs", h.List)
+			group.GET("/security-activity", h.ListSecurityActivity)
+		},
+	}
+}
+
+// List handles the request to search the audit log
+//
+//	@Summary		Audit log
+//	@Description	Searches the security-sensitive actions of every user and admin command, the latest first, for admins only
+//	@Tags			audit
+//	@Produce		json
+//	@Security		BearerToken
+//	@Param			actor_id	query		string	false	"User who performed the action"
+//	@Param			target_id	query		string	false	"Resource the action applies to"
+//	@Param			action		query		string	false	"Action"	example(auth.sign_in_failed)
+//	@Param			from		query		string	false	"Earliest creation time (RFC 3339)"
+//	@Param			to			query		string	false	"Creation time upper bound, exclusive (RFC 3339)"
+//	@Param			page		query		int		false	"Page number"
+//	@Param			page_size	query		int		false	"Page size"
+//	@Success		200			{object}	ct.ListAuditLogResponse
+//	@Failure		400			{object}	ct.ErrorResponse
+//	@Failure		401			{object}	ct.ErrorResponse
+//	@Failure		403			{object}	ct.ErrorResponse
+//	@Router			/v1/audit/logs [get]
+func (h *handler) List(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.ListAuditLogRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+
+	response, err := h.auditSvc.List(e.Request().Context(), user.ID, request)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, response)
+}
+
+// ListSecurityActivity handles the request to read the security activity of the authenticated user
+//
+//	@Summary		Security activity
+//	@Description	Lists the sign-ins and the changes to the account of the authenticated user with their IP address and user agent, the latest first
+//	@Tags			audit
+//	@Produce		json
+//	@Security		BearerToken
+//	@Param			page		query		int	false	"Page number"
+//	@Param			page_size	query		int	false	"Page size"
+//	@Success		200			{object}	ct.ListAuditLogResponse
+//	@Failure		400			{object}	ct.ErrorResponse
+//	@Failure		401			{object}	ct.ErrorResponse
+//	@Router			/v1/audit/security-activity [get]
+func (h *handler) ListSecurityActivity(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.ListSecurityActivityRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+
+	response, err := h.auditSvc.ListSecurityActivity(e.Request().Context(), user.ID, request)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, response)
+}
@@ -9,7 +9,7 @@ import (
 	hdl "golang-project/internal/handler"
 	svc "golang-project/internal/service"
 	"golang-project/server"
-	"golang-project/util/validator"
+	"golang-project/static"
 )
 
 // handler represents the implementation of handler.Authentication
@@ -32,8 +32,11 @@ func (h *handler) RegisterRoutes() server.HandlerRegistry {
 		Route: h.route,
 		Register: func(group *echo.Group) {
 			group.POST("/sign-in", h.SignIn)
+			group.POST("/sign-in/two-factor", h.SignInTwoFactor)
 			group.POST("/sign-up", h.SignUp)
 			group.POST("/verify", h.VerifyEmail)
+			group.GET("/oidc/authorize", h.AuthorizeOIDC)
+			group.GET("/oidc/callback", h.SignInOIDC)
 		},
 	}
 }
@@ -41,25 +44,57 @@ func (h *handler) RegisterRoutes() server.HandlerRegistry {
 // SignIn handles the authentication request via predefined credentials
 //
 //	@Summary		Signs In user into the system
-//	@Description	Authenticates user via predefined credentials and return JWT Token
+//	@Description	Authenticates user via predefined credentials and return JWT Token. Users with two-factor authentication receive a challenge token instead, sent with their code to /v1/auth/sign-in/two-factor
 //	@Tags			authentication
 //	@Accept			json
 //	@Produce		json
 //	@Param			SignInRequest	body		ct.SignInRequest	true	"Sign In Request Payload"
 //	@Success		200				{array}		ct.SignInResponse
-//	@Failure		400				{object}	error
-//	@Router			/auth/sign-in [post]
+//	@Failure		400				{object}	ct.ErrorResponse
+//	@Failure		401				{object}	ct.ErrorResponse
+//	@Failure		429				{object}	ct.ErrorResponse
+//	@Router			/v1/auth/sign-in [post]
 func (h *handler) SignIn(e echo.Context) error {
 	request := new(ct.SignInRequest)
 	if err := e.Bind(request); err != nil {
 		return err
 	}
 
-	response, err := h.authSvc.SignIn(request)
+	response, err := h.authSvc.SignIn(e.Request().Context(), request)
 	if err != nil {
 		return err
 	}
 
+	hdl.NoStore(e)
+	return e.JSON(http.StatusOK, response)
+}
+
+// SignInTwoFactor handles the second step of the sign-in of a user with two-factor authentication
+//
+//	@Summary		Completes a two-factor sign-in
+//	@Description	Checks the code of the authenticator app or a recovery code against the challenge token of the sign-in and returns JWT Token. Each code is accepted once and a challenge allows 5 attempts within 5 minutes. 10 invalid codes in a row lock the codes of the user for 15 minutes
+//	@Tags			authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		ct.TwoFactorSignInRequest	true	"Challenge token and code"
+//	@Success		200		{object}	ct.SignInResponse
+//	@Failure		400		{object}	ct.ErrorResponse
+//	@Failure		401		{object}	ct.ErrorResponse
+//	@Failure		403		{object}	ct.ErrorResponse
+//	@Failure		429		{object}	ct.ErrorResponse
+//	@Router			/v1/auth/sign-in/two-factor [post]
+func (h *handler) SignInTwoFactor(e echo.Context) error {
+	request := new(ct.TwoFactorSignInRequest)
+	if err := e.Bind(request); err != nil {
+		return err
+	}
+
+	response, err := h.authSvc.SignInTwoFactor(e.Request().Context(), request)
+	if err != nil {
+		return err
+	}
+
+	hdl.NoStore(e)
 	return e.JSON(http.StatusOK, response)
 }
 
@@ -70,24 +105,22 @@ func (h *handler) SignIn(e echo.Context) error {
 //	@Tags			authentication
 //	@Accept			json
 //	@Produce		json
-//	@Param			request	body		ct.SignUpRequest	true	"Sign up request"
-//	@Success		200		{object}	ct.SignUpResponse
-//	@Failure		400		{object}	error
-//	@Router			/auth/sign-up [post]
+//	@Param			request			body		ct.SignUpRequest	true	"Sign up request"
+//	@Param			Idempotency-Key	header		string				false	"Key replaying the first response of a retried request"
+//	@Success		200				{object}	ct.SignUpResponse
+//	@Failure		409				{object}	ct.ErrorResponse
+//	@Failure		422				{object}	ct.ErrorResponse
+//	@Router			/v1/auth/sign-up [post]
 func (h *handler) SignUp(e echo.Context) error {
 	var req ct.SignUpRequest
 
 	if err := e.Bind(&req); err != nil {
-		return e.JSON(http.StatusUnprocessableEntity, err)
-	}
-
-	if err := validator.ValidateSignUpRequest(req); err != nil {
-		return e.JSON(http.StatusUnprocessableEntity, err)
+		return err
 	}
 
-	resp, err := h.authSvc.SignUp(&req)
+	resp, err := h.authSvc.SignUp(e.Request().Context(), &req)
 	if err != nil {
-		return e.JSON(http.StatusBadRequest, err.Error())
+		return err
 	}
 	return e.JSON(http.StatusOK, resp)
 }
@@ -101,8 +134,78 @@ func (h *handler) SignUp(e echo.Context) error {
 //	@Produce		json
 //	@Param			request	body		ct.VerifyEmailRequest	true	"Email verification request"
 //	@Success		200		{object}	ct.VerifyEmailResponse
-//	@Failure		400		{object}	error
-//	@Router			/auth/verify [post]
+//	@Failure		400		{object}	ct.ErrorResponse
+//	@Router			/v1/auth/verify [post]
 func (h *handler) VerifyEmail(e echo.Context) error {
 	return nil
 }
+
+// AuthorizeOIDC handles the request to start the OpenID Connect sign-in
+//
+//	@Summary		Start single sign-on
+//	@Description	Redirects the user to the configured OpenID Connect provider with the authorization code flow and PKCE
+//	@Tags			authentication
+//	@Success		302
+//	@Header			302	{string}	Location	"Authorization URL of the provider"
+//	@Failure		404	{object}	ct.ErrorResponse
+//	@Failure		401	{object}	ct.ErrorResponse
+//	@Router			/v1/auth/oidc/authorize [get]
+func (h *handler) AuthorizeOIDC(e echo.Context) error {
+	response, err := h.authSvc.AuthorizeOIDC(e.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	e.SetCookie(stateCookie(e, response.StateCookie, int(static.OIDC.StateTTL.Seconds())))
+	return e.Redirect(http.StatusFound, response.AuthorizationURL)
+}
+
+// SignInOIDC handles the redirect of the OpenID Connect provider ending the sign-in
+//
+//	@Summary		End single sign-on
+//	@Description	Redeems the authorization code and returns the same JWT Token as the sign in. The provider account is linked to the user with the same verified email, or to a new user
+//	@Tags			authentication
+//	@Produce		json
+//	@Param			state				query		string	true	"State of the sign-in"
+//	@Param			code				query		string	false	"Authorization code"
+//	@Param			error				query		string	false	"Error of the provider"
+//	@Param			error_description	query		string	false	"Error description of the provider"
+//	@Success		200					{object}	ct.SignInResponse
+//	@Failure		400					{object}	ct.ErrorResponse
+//	@Failure		401					{object}	ct.ErrorResponse
+//	@Failure		403					{object}	ct.ErrorResponse
+//	@Failure		404					{object}	ct.ErrorResponse
+//	@Router			/v1/auth/oidc/callback [get]
+func (h *handler) SignInOIDC(e echo.Context) error {
+	request := new(ct.OIDCCallbackRequest)
+	if err := e.Bind(request); err != nil {
+		return err
+	}
+
+	if cookie, err := e.Cookie(static.OIDC.StateCookie); err == nil {
+		request.StateCookie = cookie.Value
+	}
+	e.SetCookie(stateCookie(e, "", -1))
+
+	response, err := h.authSvc.SignInOIDC(e.Request().Context(), request)
+	if err != nil {
+		return err
+	}
+
+	hdl.NoStore(e)
+	return e.JSON(http.StatusOK, response)
+}
+
+// stateCookie returns the cookie binding the OpenID Connect sign-in to the browser that started it,
+// a negative max age removes it
+func stateCookie(e echo.Context, value string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     static.OIDC.StateCookie,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   maxAge,
+		Secure:   e.Scheme() == "https",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
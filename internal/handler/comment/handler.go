@@ -0,0 +1,178 @@
+package comment
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	ct "golang-project/internal/contract"
+	hdl "golang-project/internal/handler"
+	svc "golang-project/internal/service"
+	"golang-project/server"
+)
+
+// handler represents the implementation of handler.Comment
+type handler struct {
+	route      string
+	commentSvc svc.Comment
+}
+
+// NewHandler returns a new implementation of handler.Comment
+func NewHandler(route string, commentSvc svc.Comment) hdl.Comment {
+	return &handler{
+		route:      route,
+		commentSvc: commentSvc,
+	}
+}
+
+// RegisterRoutes registers the handler routes and returns the server.HandlerRegistry,
+// the comments of published posts are public and writing a comment requires the user
+func (h *handler) RegisterRoutes() server.HandlerRegistry {
+	return server.HandlerRegistry{
+		Route:           h.route,
+		IsAuthenticated: false,
+		Register: func(group *echo.Group) {
+			group.POST("", h.Create)
+			group.GET("", h.List)
+			group.PUT("/:commentId", h.Update)
+			group.DELETE("/:commentId", h.Delete)
+		},
+	}
+}
+
+// Create handles the request to comment a post
+//
+//	@Summary		Comment a post
+//	@Description	Comments a published post or replies to one of its comments, refused when the user and the author blocked each other
+//	@Tags			comments
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerToken
+//	@Param			request	body		ct.CreateCommentRequest	true	"Comment"
+//	@Success		201		{object}	ct.CommentResponse
+//	@Failure		400		{object}	ct.ErrorResponse
+//	@Failure		401		{object}	ct.ErrorResponse
+//	@Failure		403		{object}	ct.ErrorResponse
+//	@Failure		404		{object}	ct.ErrorResponse
+//	@Failure		422		{object}	ct.ErrorResponse
+//	@Router			/v1/comments [post]
+func (h *handler) Create(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.CreateCommentRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+
+	response, err := h.commentSvc.Create(e.Request().Context(), request, user.ID)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusCreated, response)
+}
+
+// List handles the request to list the comments of a post
+//
+//	@Summary		Comments of a post
+//	@Description	Lists the top-level comments of a published post with their replies and reactions, the oldest first.
+//	@Description	With a bearer token the reaction of the user is filled in.
+//	@Tags			comments
+//	@Produce		json
+//	@Param			post_id		query		string	true	"Post ID"
+//	@Param			page		query		int		false	"Page number"
+//	@Param			page_size	query		int		false	"Page size"
+//	@Success		200			{object}	ct.ListCommentResponse
+//	@Failure		400			{object}	ct.ErrorResponse
+//	@Failure		404			{object}	ct.ErrorResponse
+//	@Failure		422			{object}	ct.ErrorResponse
+//	@Router			/v1/comments [get]
+func (h *handler) List(e echo.Context) error {
+	request := new(ct.ListCommentRequest)
+	if err := e.Bind(request); err != nil {
+		return err
+	}
+
+	var viewerID *primitive.ObjectID
+	if user, err := hdl.GetContextUser(e); err == nil {
+		viewerID = &user.ID
+	}
+
+	response, err := h.commentSvc.List(e.Request().Context(), viewerID, request)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, response)
+}
+
+// Update handles the request to edit a comment
+//
+//	@Summary		Edit a comment
+//	@Description	Updates the content of a comment of the authenticated user
+//	@Tags			comments
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerToken
+//	@Param			commentId	path		string					true	"Comment ID"
+//	@Param			request		body		ct.UpdateCommentRequest	true	"Comment changes"
+//	@Success		200			{object}	ct.CommentResponse
+//	@Failure		400			{object}	ct.ErrorResponse
+//	@Failure		401			{object}	ct.ErrorResponse
+//	@Failure		403			{object}	ct.ErrorResponse
+//	@Failure		404			{object}	ct.ErrorResponse
+//	@Failure		422			{object}	ct.ErrorResponse
+//	@Router			/v1/comments/{commentId} [put]
+func (h *handler) Update(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.UpdateCommentRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+
+	response, err := h.commentSvc.Update(e.Request().Context(), request, user.ID)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, response)
+}
+
+// Delete handles the request to delete a comment
+//
+//	@Summary		Delete a comment
+//	@Description	Deletes a comment of the authenticated user with its replies
+//	@Tags			comments
+//	@Security		BearerToken
+//	@Param			commentId	path	string	true	"Comment ID"
+//	@Success		204
+//	@Failure		400	{object}	ct.ErrorResponse
+//	@Failure		401	{object}	ct.ErrorResponse
+//	@Failure		403	{object}	ct.ErrorResponse
+//	@Failure		404	{object}	ct.ErrorResponse
+//	@Router			/v1/comments/{commentId} [delete]
+func (h *handler) Delete(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.CommentRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+
+	if err = h.commentSvc.Delete(e.Request().Context(), request.ID, user.ID); err != nil {
+		return err
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
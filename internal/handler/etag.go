@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"golang-project/static"
+)
+
+// JSONWithETag responds with the JSON body tagged with a strong ETag, or with 304 Not Modified
+// when the If-None-Match header holds the same tag. The tag starts with the document version
+// so it can be sent back in If-Match, and ends with a checksum of the body as the response
+// also holds data from other documents such as the counters and reactions.
+func JSONWithETag(e echo.Context, code int, version int64, body interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	checksum := sha256.Sum256(payload)
+	etag := fmt.Sprintf(`"%d-%s"`, version, hex.EncodeToString(checksum[:8]))
+
+	header := e.Response().Header()
+	header.Set("ETag", etag)
+	header.Set(echo.HeaderVary, echo.HeaderAuthorization)
+
+	if match := e.Request().Header.Get("If-None-Match"); match != "" {
+		for _, tag := range strings.Split(match, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == etag {
+				return e.NoContent(http.StatusNotModified)
+			}
+		}
+	}
+
+	return e.JSONBlob(code, payload)
+}
+
+// IfMatchVersion returns the document version required by the If-Match header, or nil when the
+// header is missing or "*". Weak and malformed tags can never match so they fail the precondition.
+func IfMatchVersion(e echo.Context) (*int64, error) {
+	match := strings.TrimSpace(e.Request().Header.Get("If-Match"))
+	if match == "" || match == "*" {
+		return nil, nil
+	}
+
+	// A list of tags cannot be satisfied by a single version unless they agree on it
+	var version *int64
+	for _, tag := range strings.Split(match, ",") {
+		tag = strings.TrimSpace(tag)
+		if !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`) || len(tag) < 2 {
+			return nil, static.ErrVersionConflict
+		}
+
+		prefix, _, _ := strings.Cut(strings.Trim(tag, `"`), "-")
+		v, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil || (version != nil && *version != v) {
+			return nil, static.ErrVersionConflict
+		}
+		version = &v
+	}
+
+	return version, nil
+}
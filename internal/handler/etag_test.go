@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"golang-project/static"
+)
+
+// newContext returns the echo context of a request carrying the header
+func newContext(header, value string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if header != "" {
+		req.Header.Set(header, value)
+	}
+	rec := httptest.NewRecorder()
+
+	return echo.New().NewContext(req, rec), rec
+}
+
+func TestJSONWithETag(t *testing.T) {
+	body := map[string]string{"title": "Generics"}
+
+	e, rec := newContext("", "")
+	if err := JSONWithETag(e, http.StatusOK, 3, body); err != nil {
+		t.Fatal(err)
+	}
+	etag := rec.Header().Get("ETag")
+	if rec.Code != http.StatusOK || etag == "" {
+		t.Fatalf("code = %d, ETag = %q, want 200 with an ETag", rec.Code, etag)
+	}
+
+	// The same body is not sent again and a changed body gets a new tag
+	e, rec = newContext("If-None-Match", `"0-stale", `+etag)
+	if err := JSONWithETag(e, http.StatusOK, 3, body); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+		t.Errorf("code = %d, body = %q, want 304 without a body", rec.Code, rec.Body)
+	}
+
+	e, rec = newContext("If-None-Match", etag)
+	if err := JSONWithETag(e, http.StatusOK, 3, map[string]string{"title": "Iterators"}); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Code != http.StatusOK || rec.Header().Get("ETag") == etag {
+		t.Errorf("code = %d, ETag = %q, want 200 with a new ETag", rec.Code, rec.Header().Get("ETag"))
+	}
+}
+
+func TestIfMatchVersion(t *testing.T) {
+	for _, tc := range []struct {
+		header string
+		want   *int64
+		err    error
+	}{
+		{header: "", want: nil},
+		{header: "*", want: nil},
+		{header: `"7-0a1b2c3d4e5f6a7b"`, want: ptr(7)},
+		{header: `"7-aa", "7-bb"`, want: ptr(7)},
+		{header: `"7-aa", "8-bb"`, err: static.ErrVersionConflict},
+		{header: `W/"7-aa"`, err: static.ErrVersionConflict},
+		{header: `7`, err: static.ErrVersionConflict},
+		{header: `"seven"`, err: static.ErrVersionConflict},
+	} {
+		e, _ := newContext("If-Match", tc.header)
+
+		got, err := IfMatchVersion(e)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("IfMatchVersion(%s) err = %v, want %v", tc.header, err, tc.err)
+			continue
+		}
+		if (got == nil) != (tc.want == nil) || (got != nil && *got != *tc.want) {
+			t.Errorf("IfMatchVersion(%s) = %v, want %v", tc.header, got, tc.want)
+		}
+	}
+}
+
+func ptr(v int64) *int64 {
+	return &v
+}
@@ -0,0 +1,128 @@
+package export
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	ct "golang-project/internal/contract"
+	hdl "golang-project/internal/handler"
+	svc "golang-project/internal/service"
+	"golang-project/server"
+)
+
+// handler represents the implementation of handler.Export
+type handler struct {
+	route     string
+	exportSvc svc.Export
+}
+
+// NewHandler returns a new implementation of handler.Export
+func NewHandler(route string, exportSvc svc.Export) hdl.Export {
+	return &handler{
+		route:     route,
+		exportSvc: exportSvc,
+	}
+}
+
+// RegisterRoutes registers the handler routes and returns the server.HandlerRegistry
+func (h *handler) RegisterRoutes() server.HandlerRegistry {
+	return server.HandlerRegistry{
+		Route:           h.route,
+		IsAuthenticated: true,
+		Register: func(group *echo.Group) {
+			group.POST("", h.Create)
+			group.GET("/:exportId", h.Get)
+			group.GET("/:exportId/download", h.Download)
+		},
+	}
+}
+
+// Create handles the request to export the personal data of the authenticated user
+//
+//	@Summary		Request a personal data export
+//	@Description	Queues a ZIP archive of the profile, posts, comments, follows, favourites and media of the user, the user is notified when it is ready
+//	@Tags			export
+//	@Produce		json
+//	@Security		BearerToken
+//	@Success		202	{object}	ct.ExportResponse
+//	@Failure		401	{object}	ct.ErrorResponse
+//	@Router			/v1/exports [post]
+func (h *handler) Create(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.exportSvc.Request(e.Request().Context(), user.ID)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusAccepted, response)
+}
+
+// Get handles the request to read the status of a personal data export
+//
+//	@Summary		Personal data export status
+//	@Description	Returns the status of the export and its download URL once ready
+//	@Tags			export
+//	@Produce		json
+//	@Security		BearerToken
+//	@Param			exportId	path		string	true	"Export ID"
+//	@Success		200			{object}	ct.ExportResponse
+//	@Failure		401			{object}	ct.ErrorResponse
+//	@Failure		404			{object}	ct.ErrorResponse
+//	@Router			/v1/exports/{exportId} [get]
+func (h *handler) Get(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.ExportRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+
+	response, err := h.exportSvc.Get(e.Request().Context(), user.ID, request)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, response)
+}
+
+// Download handles the request to download the archive of a personal data export
+//
+//	@Summary		Download a personal data export
+//	@Description	Downloads the ZIP archive of the export until its download expires
+//	@Tags			export
+//	@Produce		application/zip
+//	@Security		BearerToken
+//	@Param			exportId	path		string	true	"Export ID"
+//	@Success		200			{file}		file
+//	@Failure		401			{object}	ct.ErrorResponse
+//	@Failure		404			{object}	ct.ErrorResponse
+//	@Failure		409			{object}	ct.ErrorResponse
+//	@Failure		410			{object}	ct.ErrorResponse
+//	@Router			/v1/exports/{exportId}/download [get]
+func (h *handler) Download(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.ExportRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+
+	response, err := h.exportSvc.Download(e.Request().Context(), user.ID, request)
+	if err != nil {
+		return err
+	}
+
+	e.Response().Header().Set(echo.HeaderCacheControl, "private, no-store")
+	return e.Attachment(response.Path, response.FileName)
+}
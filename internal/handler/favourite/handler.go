@@ -0,0 +1,180 @@
+package favourite
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	ct "golang-project/internal/contract"
+	hdl "golang-project/internal/handler"
+	svc "golang-project/internal/service"
+	"golang-project/server"
+)
+
+// handler represents the implementation of handler.Favourite
+type handler struct {
+	route        string
+	favouriteSvc svc.Favourite
+}
+
+// NewHandler returns a new implementation of handler.Favourite
+func NewHandler(route string, favouriteSvc svc.Favourite) hdl.Favourite {
+	return &handler{
+		route:        route,
+		favouriteSvc: favouriteSvc,
+	}
+}
+
+// RegisterRoutes registers the handler routes and returns the server.HandlerRegistry
+func (h *handler) RegisterRoutes() server.HandlerRegistry {
+	return server.HandlerRegistry{
+		Route:           h.route,
+		IsAuthenticated: true,
+		Register: func(group *echo.Group) {
+			group.PUT("/bloggers", h.UpdateBlogger)
+			group.GET("/bloggers", h.ListBloggers)
+			group.GET("/bloggers/posts", h.ListBloggerPosts)
+			group.PUT("/posts", h.UpdatePost)
+			group.GET("/posts", h.ListPosts)
+		},
+	}
+}
+
+// UpdateBlogger handles the request to follow or unfollow a blogger
+//
+//	@Summary		Follow a blogger
+//	@Description	Follows or unfollows a blogger, following is refused when the users blocked each other
+//	@Tags			favorites
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerToken
+//	@Param			request	body		ct.BloggerFollowRequest	true	"Blogger and action"
+//	@Success		200		{object}	ct.BloggerFollowStatusResponse
+//	@Failure		400		{object}	ct.ErrorResponse
+//	@Failure		401		{object}	ct.ErrorResponse
+//	@Failure		403		{object}	ct.ErrorResponse
+//	@Failure		404		{object}	ct.ErrorResponse
+//	@Failure		422		{object}	ct.ErrorResponse
+//	@Router			/v1/favorites/bloggers [put]
+func (h *handler) UpdateBlogger(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.BloggerFollowRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+
+	response, err := h.favouriteSvc.UpdateFollowStatus(e.Request().Context(), user.ID, request)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, response)
+}
+
+// ListBloggers handles the request to list the bloggers followed by the authenticated user
+//
+//	@Summary		Followed bloggers
+//	@Description	Lists the bloggers followed by the authenticated user
+//	@Tags			favorites
+//	@Produce		json
+//	@Security		BearerToken
+//	@Success		200	{object}	ct.ListProfileResponse
+//	@Failure		401	{object}	ct.ErrorResponse
+//	@Router			/v1/favorites/bloggers [get]
+func (h *handler) ListBloggers(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.favouriteSvc.ListFollowingUsers(e.Request().Context(), user.ID)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, response)
+}
+
+// ListBloggerPosts handles the request to list the posts of the followed bloggers
+//
+//	@Summary		Posts of followed bloggers
+//	@Description	Lists the published posts of the bloggers followed by the authenticated user, the latest first
+//	@Tags			favorites
+//	@Produce		json
+//	@Security		BearerToken
+//	@Success		200	{object}	ct.ListPostResponse
+//	@Failure		401	{object}	ct.ErrorResponse
+//	@Router			/v1/favorites/bloggers/posts [get]
+func (h *handler) ListBloggerPosts(e echo.Context) error {
+	return h.listPosts(e, h.favouriteSvc.ListUserPosts)
+}
+
+// UpdatePost handles the request to add a post to or remove it from the favourites
+//
+//	@Summary		Favourite a post
+//	@Description	Adds a published post to or removes it from the favourites, adding is refused when the user and the author blocked each other
+//	@Tags			favorites
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerToken
+//	@Param			request	body		ct.PostFavouriteRequest	true	"Post and action"
+//	@Success		200		{object}	ct.PostFavouriteStatusResponse
+//	@Failure		400		{object}	ct.ErrorResponse
+//	@Failure		401		{object}	ct.ErrorResponse
+//	@Failure		403		{object}	ct.ErrorResponse
+//	@Failure		404		{object}	ct.ErrorResponse
+//	@Failure		422		{object}	ct.ErrorResponse
+//	@Router			/v1/favorites/posts [put]
+func (h *handler) UpdatePost(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.PostFavouriteRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+
+	response, err := h.favouriteSvc.UpdateFavouriteStatus(e.Request().Context(), user.ID, request)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, response)
+}
+
+// ListPosts handles the request to list the favourite posts
+//
+//	@Summary		Favourite posts
+//	@Description	Lists the published posts favourited by the authenticated user, the latest first
+//	@Tags			favorites
+//	@Produce		json
+//	@Security		BearerToken
+//	@Success		200	{object}	ct.ListPostResponse
+//	@Failure		401	{object}	ct.ErrorResponse
+//	@Router			/v1/favorites/posts [get]
+func (h *handler) ListPosts(e echo.Context) error {
+	return h.listPosts(e, h.favouriteSvc.ListFavouritePosts)
+}
+
+// listPosts handles the requests listing posts for the authenticated user
+func (h *handler) listPosts(e echo.Context, list func(context.Context, primitive.ObjectID) (*ct.ListPostResponse, error)) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	response, err := list(e.Request().Context(), user.ID)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, response)
+}
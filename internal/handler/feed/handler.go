@@ -0,0 +1,150 @@
+package feed
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	ct "golang-project/internal/contract"
+	hdl "golang-project/internal/handler"
+	svc "golang-project/internal/service"
+	"golang-project/server"
+)
+
+// handler represents the implementation of handler.Feed
+type handler struct {
+	route   string
+	feedSvc svc.Feed
+}
+
+// NewHandler returns a new implementation of handler.Feed
+func NewHandler(route string, feedSvc svc.Feed) hdl.Feed {
+	return &handler{
+		route:   route,
+		feedSvc: feedSvc,
+	}
+}
+
+// RegisterRoutes registers the handler routes and returns the server.HandlerRegistry
+func (h *handler) RegisterRoutes() server.HandlerRegistry {
+	return server.HandlerRegistry{
+		Route: h.route,
+		Register: func(group *echo.Group) {
+			group.GET("/:format", h.Site)
+			group.GET("/bloggers/:pseudonym/:format", h.Blogger)
+			group.GET("/tags/:tag/:format", h.Tag)
+		},
+	}
+}
+
+// Site handles the request to render the site-wide feed
+//
+//	@Summary		Site-wide feed
+//	@Description	Renders the latest published posts of all bloggers as RSS 2.0 or Atom 1.0
+//	@Tags			feed
+//	@Produce		xml
+//	@Param			format			path		string	true	"Feed format"	Enums(rss, atom)
+//	@Param			If-None-Match		header		string	false	"ETag of the cached feed"
+//	@Param			If-Modified-Since	header		string	false	"Last-Modified of the cached feed"
+//	@Success		200				{string}	string
+//	@Success		304
+//	@Failure		404				{object}	ct.ErrorResponse
+//	@Router			/feeds/{format} [get]
+func (h *handler) Site(e echo.Context) error {
+	return h.serve(e, h.feedSvc.Site)
+}
+
+// Blogger handles the request to render the feed of a blogger
+//
+//	@Summary		Blogger feed
+//	@Description	Renders the latest published posts of a blogger as RSS 2.0 or Atom 1.0
+//	@Tags			feed
+//	@Produce		xml
+//	@Param			pseudonym			path		string	true	"Blogger pseudonym"
+//	@Param			format				path		string	true	"Feed format"	Enums(rss, atom)
+//	@Param			If-None-Match		header		string	false	"ETag of the cached feed"
+//	@Param			If-Modified-Since	header		string	false	"Last-Modified of the cached feed"
+//	@Success		200					{string}	string
+//	@Success		304
+//	@Failure		404					{object}	ct.ErrorResponse
+//	@Router			/feeds/bloggers/{pseudonym}/{format} [get]
+func (h *handler) Blogger(e echo.Context) error {
+	return h.serve(e, h.feedSvc.Blogger)
+}
+
+// Tag handles the request to render the feed of a tag
+//
+//	@Summary		Tag feed
+//	@Description	Renders the latest published posts of a tag as RSS 2.0 or Atom 1.0
+//	@Tags			feed
+//	@Produce		xml
+//	@Param			tag					path		string	true	"Tag name"
+//	@Param			format				path		string	true	"Feed format"	Enums(rss, atom)
+//	@Param			If-None-Match		header		string	false	"ETag of the cached feed"
+//	@Param			If-Modified-Since	header		string	false	"Last-Modified of the cached feed"
+//	@Success		200					{string}	string
+//	@Success		304
+//	@Failure		404					{object}	ct.ErrorResponse
+//	@Router			/feeds/tags/{tag}/{format} [get]
+func (h *handler) Tag(e echo.Context) error {
+	return h.serve(e, h.feedSvc.Tag)
+}
+
+// serve binds the feed request, renders it and answers conditional requests with 304 Not Modified
+func (h *handler) serve(e echo.Context, render func(context.Context, *ct.FeedRequest) (*ct.FeedResponse, error)) error {
+	request := new(ct.FeedRequest)
+	if err := e.Bind(request); err != nil {
+		return err
+	}
+	request.SelfLink = e.Scheme() + "://" + e.Request().Host + e.Request().URL.Path
+	if user, err := hdl.GetContextUser(e); err == nil {
+		request.ViewerID = &user.ID
+	}
+
+	response, err := render(e.Request().Context(), request)
+	if err != nil {
+		return err
+	}
+
+	header := e.Response().Header()
+	header.Set("ETag", response.ETag)
+	// Feeds of authenticated readers leave out the blocked and muted users and must not be shared
+	header.Set(echo.HeaderVary, echo.HeaderAuthorization)
+	if request.ViewerID != nil {
+		header.Set(echo.HeaderCacheControl, "private, max-age=300")
+	} else {
+		header.Set(echo.HeaderCacheControl, "public, max-age=300")
+	}
+	if !response.LastModified.IsZero() {
+		header.Set(echo.HeaderLastModified, response.LastModified.Format(http.TimeFormat))
+	}
+
+	if isNotModified(e.Request(), response) {
+		return e.NoContent(http.StatusNotModified)
+	}
+
+	return e.Blob(http.StatusOK, response.ContentType, response.Body)
+}
+
+// isNotModified evaluates If-None-Match first and falls back to If-Modified-Since as in RFC 9110
+func isNotModified(r *http.Request, response *ct.FeedResponse) bool {
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		for _, tag := range strings.Split(match, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == response.ETag {
+				return true
+			}
+		}
+		return false
+	}
+
+	since, err := time.Parse(http.TimeFormat, r.Header.Get(echo.HeaderIfModifiedSince))
+	if err != nil || response.LastModified.IsZero() {
+		return false
+	}
+
+	return !response.LastModified.After(since)
+}
@@ -7,6 +7,7 @@ import (
 
 	ct "golang-project/internal/contract"
 	"golang-project/server"
+	"golang-project/static"
 )
 
 // ResourceHandler             represents all API resource handler
@@ -31,18 +32,30 @@ type ResourceHandler interface {
 	RegisterRoutes() server.HandlerRegistry
 }
 
+// Health represents all health probe resource handler
+type Health interface {
+	ResourceHandler
+	HealthCheck(echo.Context) error
+	Live(echo.Context) error
+	Ready(echo.Context) error
+}
+
 // Authentication represents all authentication resource handler
 type Authentication interface {
 	ResourceHandler
 	SignIn(echo.Context) error
 	SignUp(echo.Context) error
 	VerifyEmail(echo.Context) error
+	AuthorizeOIDC(echo.Context) error
+	SignInOIDC(echo.Context) error
+	SignInTwoFactor(echo.Context) error
 }
 
 // Profile represents all profile resource handler
 type Profile interface {
 	ResourceHandler
 	Get(echo.Context) error
+	View(echo.Context) error
 	ListBloggerPosts(echo.Context) error
 	GetPostDetail(echo.Context) error
 	Update(echo.Context) error
@@ -87,6 +100,57 @@ type Comment interface {
 	Delete(echo.Context) error
 }
 
+// Feed represents all syndication feed resource handler
+type Feed interface {
+	ResourceHandler
+	Site(echo.Context) error
+	Blogger(echo.Context) error
+	Tag(echo.Context) error
+}
+
+// Export represents all personal data export resource handler
+type Export interface {
+	ResourceHandler
+	Create(echo.Context) error
+	Get(echo.Context) error
+	Download(echo.Context) error
+}
+
+// Account represents all account lifecycle resource handler
+type Account interface {
+	ResourceHandler
+	ScheduleDeletion(echo.Context) error
+	GetDeletion(echo.Context) error
+	CancelDeletion(echo.Context) error
+}
+
+// Moderation represents all content report and moderation queue resource handler
+type Moderation interface {
+	ResourceHandler
+	Report(echo.Context) error
+	ListQueue(echo.Context) error
+	TakeAction(echo.Context) error
+}
+
+// Relationship represents all blocked and muted user resource handler
+type Relationship interface {
+	ResourceHandler
+	Block(echo.Context) error
+	Unblock(echo.Context) error
+	ListBlocked(echo.Context) error
+	Mute(echo.Context) error
+	Unmute(echo.Context) error
+	ListMuted(echo.Context) error
+}
+
+// Reaction represents all post and comment reaction resource handler
+type Reaction interface {
+	ResourceHandler
+	Summary(echo.Context) error
+	React(echo.Context) error
+	Unreact(echo.Context) error
+}
+
 // GetContextUser returns the authenticated user in echo Context
 func GetContextUser(e echo.Context) (*ct.ContextUser, error) {
 	ctxUser, ok := e.Get("user").(*ct.ContextUser)
@@ -96,3 +160,60 @@ func GetContextUser(e echo.Context) (*ct.ContextUser, error) {
 
 	return ctxUser, nil
 }
+
+// RequireSession refuses the requests authenticated with a personal access token, the routes managing
+// the credentials and the account itself need the JWT of a sign-in
+func RequireSession(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(e echo.Context) error {
+		user, err := GetContextUser(e)
+		if err != nil {
+			return err
+		}
+		if user.IsAccessToken {
+			return static.ErrSessionRequired
+		}
+
+		return next(e)
+	}
+}
+
+// NoStore marks the response as carrying credentials, it is neither cached nor stored
+// by the idempotency middleware for replays
+func NoStore(e echo.Context) {
+	e.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+}
+
+// Webhook represents all outbound webhook resource handler
+type Webhook interface {
+	ResourceHandler
+	Create(echo.Context) error
+	List(echo.Context) error
+	Delete(echo.Context) error
+	ListDeliveries(echo.Context) error
+	Redeliver(echo.Context) error
+}
+
+// Audit represents all audit log resource handler
+type Audit interface {
+	ResourceHandler
+	List(echo.Context) error
+	ListSecurityActivity(echo.Context) error
+}
+
+// AccessToken represents all personal access token resource handler
+type AccessToken interface {
+	ResourceHandler
+	Create(echo.Context) error
+	List(echo.Context) error
+	Revoke(echo.Context) error
+}
+
+// TwoFactor represents all two-factor authentication resource handler
+type TwoFactor interface {
+	ResourceHandler
+	Status(echo.Context) error
+	Enrol(echo.Context) error
+	Confirm(echo.Context) error
+	RenewRecoveryCodes(echo.Context) error
+	Disable(echo.Context) error
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	ct "golang-project/internal/contract"
+	"golang-project/static"
+)
+
+func TestRequireSession(t *testing.T) {
+	next := func(e echo.Context) error {
+		return e.NoContent(http.StatusNoContent)
+	}
+
+	for name, tc := range map[string]struct {
+		user *ct.ContextUser
+		want error
+	}{
+		"sign-in":                      {&ct.ContextUser{}, nil},
+		"access token":                 {&ct.ContextUser{Scopes: []static.AccessTokenScope{static.AccessTokenWrite}, IsAccessToken: true}, static.ErrSessionRequired},
+		"access token without scopes":  {&ct.ContextUser{Scopes: []static.AccessTokenScope{}, IsAccessToken: true}, static.ErrSessionRequired},
+		"access token with nil scopes": {&ct.ContextUser{IsAccessToken: true}, static.ErrSessionRequired},
+	} {
+		e, _ := newContext("", "")
+		e.Set("user", tc.user)
+
+		if err := RequireSession(next)(e); !errors.Is(err, tc.want) {
+			t.Errorf("%s: err = %v, want %v", name, err, tc.want)
+		}
+	}
+}
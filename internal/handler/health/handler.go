@@ -1,28 +1,35 @@
 package health
 
 import (
-	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/labstack/echo/v4"
 
-	"golang-project/database"
 	ct "golang-project/internal/contract"
 	hdl "golang-project/internal/handler"
+	"golang-project/internal/healthcheck"
 	"golang-project/server"
+	"golang-project/static"
 )
 
-// handler represents the implementation of handler.ResourceHandler
+// Health status values of the probe responses
+const (
+	statusOK    = "ok"
+	statusError = "error"
+)
+
+// handler represents the implementation of handler.Health
 type handler struct {
-	route string
-	db    database.Connection
+	route  string
+	checks *healthcheck.Registry
 }
 
-// NewHandler returns a new implementation of handler.ResourceHandler
-func NewHandler(route string, db database.Connection) hdl.ResourceHandler {
+// NewHandler returns a new implementation of handler.Health
+func NewHandler(route string, checks *healthcheck.Registry) hdl.Health {
 	return &handler{
-		route: route,
-		db:    db,
+		route:  route,
+		checks: checks,
 	}
 }
 
@@ -32,42 +39,114 @@ func (h *handler) RegisterRoutes() server.HandlerRegistry {
 		Route: h.route,
 		Register: func(group *echo.Group) {
 			group.GET("", h.HealthCheck)
+			group.GET("/live", h.Live)
+			group.GET("/ready", h.Ready)
 		},
 	}
 }
 
-// HealthCheck   handles the checking of server and database liveness
+// HealthCheck   handles the checking of server and dependent resources
 //
-//	@Summary		Show server liveness
-//	@Description	Perform server and dependent resource liveness check
+//	@Summary		Show server and resource health
+//	@Description	Perform server and dependent resource health check, responding 503 when any check fails
 //	@Tags			health
 //	@Accept			json
 //	@Produce		json
-//	@Success		200	{object}	contract.HealthCheckResponse
-//	@Failure		400	{object}	error
+//	@Success		200	{array}	contract.HealthCheckResponse
+//	@Failure		503	{array}	contract.HealthCheckResponse
 //	@Router			/health [get]
 func (h *handler) HealthCheck(e echo.Context) error {
-	response := []*ct.HealthCheckResponse{{Resource: "server", Status: "ok"}}
-	response = append(response, h.CheckDatabase())
+	checks, healthy := h.runChecks(e)
+	response := append([]*ct.HealthCheckResponse{{Resource: "server", Status: statusOK}}, checks...)
+
+	return e.JSON(statusCode(healthy), response)
+}
 
-	return e.JSON(http.StatusOK, response)
+// Live handles the liveness probe, which only verifies the process is able to serve requests
+//
+//	@Summary		Liveness probe
+//	@Description	Reports the server process is alive together with the build information
+//	@Tags			health
+//	@Produce		json
+//	@Success		200	{object}	contract.LivenessResponse
+//	@Router			/health/live [get]
+func (h *handler) Live(e echo.Context) error {
+	return e.JSON(http.StatusOK, &ct.LivenessResponse{
+		Status: statusOK,
+		Uptime: time.Since(static.StartedAt).Truncate(time.Second).String(),
+		Build:  prepareBuildInfoResponse(static.GetBuildInfo()),
+	})
 }
 
-// CheckDatabase return contract.HealthCheckResponse with the server and database result
-func (h *handler) CheckDatabase() *ct.HealthCheckResponse {
-	result := &ct.HealthCheckResponse{Resource: "database", Status: "ok"}
+// Ready handles the readiness probe, which verifies every registered dependency is healthy
+//
+//	@Summary		Readiness probe
+//	@Description	Runs the registered health checks and responds 503 when any of them fails
+//	@Tags			health
+//	@Produce		json
+//	@Success		200	{object}	contract.ReadinessResponse
+//	@Failure		503	{object}	contract.ReadinessResponse
+//	@Router			/health/ready [get]
+func (h *handler) Ready(e echo.Context) error {
+	checks, healthy := h.runChecks(e)
 
-	if h.db == nil {
-		result.Status = "error: database instance is missing"
-		return result
+	response := &ct.ReadinessResponse{
+		Status: statusOK,
+		Checks: checks,
+		Build:  prepareBuildInfoResponse(static.GetBuildInfo()),
+	}
+	if !healthy {
+		response.Status = statusError
 	}
 
-	// Use MongoDB ping method
-	err := h.db.Ping()
-	if err != nil {
-		result.Status = fmt.Sprintf("error: %s", err)
-		return result
+	return e.JSON(statusCode(healthy), response)
+}
+
+// runChecks runs the registered health checks and reports whether all of them passed
+func (h *handler) runChecks(e echo.Context) ([]*ct.HealthCheckResponse, bool) {
+	results := h.checks.Run(e.Request().Context())
+
+	healthy := true
+	response := make([]*ct.HealthCheckResponse, 0, len(results))
+	for _, result := range results {
+		healthy = healthy && result.Healthy()
+		response = append(response, prepareHealthCheckResponse(result))
 	}
 
-	return result
+	return response, healthy
+}
+
+// statusCode returns the HTTP status code of the probe outcome
+func statusCode(healthy bool) int {
+	if healthy {
+		return http.StatusOK
+	}
+
+	return http.StatusServiceUnavailable
+}
+
+// prepareHealthCheckResponse transforms healthcheck.Result to contract.HealthCheckResponse
+func prepareHealthCheckResponse(result *healthcheck.Result) *ct.HealthCheckResponse {
+	data := &ct.HealthCheckResponse{
+		Resource:  result.Name,
+		Status:    statusOK,
+		Duration:  result.Duration.String(),
+		CheckedAt: result.CheckedAt.Format(time.RFC3339),
+	}
+
+	if !result.Healthy() {
+		data.Status = statusError + ": " + result.Err.Error()
+	}
+
+	return data
+}
+
+// prepareBuildInfoResponse transforms static.BuildInfo to contract.BuildInfoResponse
+func prepareBuildInfoResponse(info static.BuildInfo) *ct.BuildInfoResponse {
+	return &ct.BuildInfoResponse{
+		Version:   info.Version,
+		Commit:    info.Commit,
+		BuiltAt:   info.BuiltAt,
+		GoVersion: info.GoVersion,
+	}
 }
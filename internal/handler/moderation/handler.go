@@ -0,0 +1,143 @@
+package moderation
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	ct "golang-project/internal/contract"
+	hdl "golang-project/internal/handler"
+	svc "golang-project/internal/service"
+	"golang-project/server"
+)
+
+// handler represents the implementation of handler.Moderation
+type handler struct {
+	route         string
+	moderationSvc svc.Moderation
+}
+
+// NewHandler returns a new implementation of handler.Moderation
+func NewHandler(route string, moderationSvc svc.Moderation) hdl.Moderation {
+	return &handler{
+		route:         route,
+		moderationSvc: moderationSvc,
+	}
+}
+
+// RegisterRoutes registers the handler routes and returns the server.HandlerRegistry
+func (h *handler) RegisterRoutes() server.HandlerRegistry {
+	return server.HandlerRegistry{
+		Route:           h.route,
+		IsAuthenticated: true,
+		Register: func(group *echo.Group) {
+			group.POST("/reports", h.Report)
+			group.GET("/queue", h.ListQueue)
+			group.POST("/actions", h.TakeAction)
+		},
+	}
+}
+
+// Report handles the request to report a post or a comment
+//
+//	@Summary		Report content
+//	@Description	Reports a post or a comment to the moderators with a reason category
+//	@Tags			moderation
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerToken
+//	@Param			request	body		ct.CreateReportRequest	true	"Reported content and reason"
+//	@Success		201		{object}	ct.ReportResponse
+//	@Failure		400		{object}	ct.ErrorResponse
+//	@Failure		401		{object}	ct.ErrorResponse
+//	@Failure		404		{object}	ct.ErrorResponse
+//	@Failure		409		{object}	ct.ErrorResponse
+//	@Router			/v1/moderation/reports [post]
+func (h *handler) Report(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.CreateReportRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+
+	response, err := h.moderationSvc.Report(e.Request().Context(), user.ID, request)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusCreated, response)
+}
+
+// ListQueue handles the request to read the moderation queue
+//
+//	@Summary		Moderation queue
+//	@Description	Lists the open reports aggregated per content, the most reported content first, for moderators only
+//	@Tags			moderation
+//	@Produce		json
+//	@Security		BearerToken
+//	@Param			target_type	query		string	false	"Content type"	Enums(post, comment)
+//	@Param			page		query		int		false	"Page number"
+//	@Param			page_size	query		int		false	"Page size"
+//	@Success		200			{object}	ct.ReportQueueResponse
+//	@Failure		400			{object}	ct.ErrorResponse
+//	@Failure		401			{object}	ct.ErrorResponse
+//	@Failure		403			{object}	ct.ErrorResponse
+//	@Router			/v1/moderation/queue [get]
+func (h *handler) ListQueue(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.ListReportQueueRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+
+	response, err := h.moderationSvc.ListQueue(e.Request().Context(), user.ID, request)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, response)
+}
+
+// TakeAction handles the request to act on a reported content
+//
+//	@Summary		Take a moderation action
+//	@Description	Dismisses the reports, hides the content, warns or suspends the author, the action is recorded and resolves the open reports of the content.
+//	@Description	The content of the moderator, of other moderators and of admins cannot be moderated.
+//	@Tags			moderation
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerToken
+//	@Param			request	body		ct.ModerationActionRequest	true	"Reported content and action"
+//	@Success		201		{object}	ct.ModerationActionResponse
+//	@Failure		400		{object}	ct.ErrorResponse
+//	@Failure		401		{object}	ct.ErrorResponse
+//	@Failure		403		{object}	ct.ErrorResponse
+//	@Failure		404		{object}	ct.ErrorResponse
+//	@Failure		409		{object}	ct.ErrorResponse
+//	@Router			/v1/moderation/actions [post]
+func (h *handler) TakeAction(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.ModerationActionRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+
+	response, err := h.moderationSvc.TakeAction(e.Request().Context(), user.ID, request)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusCreated, response)
+}
@@ -0,0 +1,219 @@
+package post
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	ct "golang-project/internal/contract"
+	hdl "golang-project/internal/handler"
+	svc "golang-project/internal/service"
+	"golang-project/server"
+)
+
+// handler represents the implementation of handler.Post
+type handler struct {
+	route   string
+	postSvc svc.Post
+}
+
+// NewHandler returns a new implementation of handler.Post
+func NewHandler(route string, postSvc svc.Post) hdl.Post {
+	return &handler{
+		route:   route,
+		postSvc: postSvc,
+	}
+}
+
+// RegisterRoutes registers the handler routes and returns the server.HandlerRegistry,
+// the published posts are public and writing a post requires the user
+func (h *handler) RegisterRoutes() server.HandlerRegistry {
+	return server.HandlerRegistry{
+		Route:           h.route,
+		IsAuthenticated: false,
+		Register: func(group *echo.Group) {
+			group.POST("", h.Create)
+			group.GET("", h.List)
+			group.GET("/:postId", h.Get)
+			group.PUT("/:postId", h.Update)
+			group.DELETE("/:postId", h.Delete)
+		},
+	}
+}
+
+// Create handles the request to write a post
+//
+//	@Summary		Write a post
+//	@Description	Creates a post of the authenticated user, the slug is made from the title
+//	@Tags			posts
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerToken
+//	@Param			request	body		ct.CreatePostRequest	true	"Post"
+//	@Success		201		{object}	ct.PostResponse
+//	@Failure		400		{object}	ct.ErrorResponse
+//	@Failure		401		{object}	ct.ErrorResponse
+//	@Failure		422		{object}	ct.ErrorResponse
+//	@Router			/v1/posts [post]
+func (h *handler) Create(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.CreatePostRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+
+	response, err := h.postSvc.Create(e.Request().Context(), request, user.ID)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusCreated, response)
+}
+
+// List handles the request to list the published posts
+//
+//	@Summary		Posts
+//	@Description	Lists the published posts filtered by tag name, author pseudonym and title, the latest first.
+//	@Description	With a bearer token the reaction of the user is filled in.
+//	@Tags			posts
+//	@Produce		json
+//	@Param			tag			query		string	false	"Tag name"
+//	@Param			pseudonym	query		string	false	"Author pseudonym"
+//	@Param			title		query		string	false	"Part of the title"
+//	@Param			page		query		int		false	"Page number"
+//	@Param			pageSize	query		int		false	"Page size"
+//	@Success		200			{object}	ct.ListPostResponse
+//	@Failure		400			{object}	ct.ErrorResponse
+//	@Failure		422			{object}	ct.ErrorResponse
+//	@Router			/v1/posts [get]
+func (h *handler) List(e echo.Context) error {
+	request := new(ct.ListPostRequest)
+	if err := e.Bind(request); err != nil {
+		return err
+	}
+
+	var viewerID *primitive.ObjectID
+	if user, err := hdl.GetContextUser(e); err == nil {
+		viewerID = &user.ID
+	}
+
+	response, err := h.postSvc.List(e.Request().Context(), viewerID, request)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, response)
+}
+
+// Get handles the request to read a published post
+//
+//	@Summary		Post detail
+//	@Description	Returns the published post with its author, tags and reactions, tagged with an ETag.
+//	@Description	With a bearer token the reaction of the user is filled in.
+//	@Tags			posts
+//	@Produce		json
+//	@Param			postId			path		string	true	"Post ID"
+//	@Param			If-None-Match	header		string	false	"ETag of the cached post"
+//	@Success		200				{object}	ct.PostResponse
+//	@Success		304
+//	@Failure		400	{object}	ct.ErrorResponse
+//	@Failure		404	{object}	ct.ErrorResponse
+//	@Router			/v1/posts/{postId} [get]
+func (h *handler) Get(e echo.Context) error {
+	request := new(ct.PostRequest)
+	if err := e.Bind(request); err != nil {
+		return err
+	}
+
+	var viewerID *primitive.ObjectID
+	if user, err := hdl.GetContextUser(e); err == nil {
+		viewerID = &user.ID
+	}
+
+	response, err := h.postSvc.GetByID(e.Request().Context(), viewerID, request.ID)
+	if err != nil {
+		return err
+	}
+
+	return hdl.JSONWithETag(e, http.StatusOK, response.Version, response)
+}
+
+// Update handles the request to edit a post
+//
+//	@Summary		Edit a post
+//	@Description	Updates the post of the authenticated user, the tags are replaced when sent.
+//	@Description	With If-Match the update fails with 412 when the post has changed since it was read.
+//	@Tags			posts
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerToken
+//	@Param			postId		path		string					true	"Post ID"
+//	@Param			If-Match	header		string					false	"ETag of the post that was read"
+//	@Param			request		body		ct.UpdatePostRequest	true	"Post changes"
+//	@Success		200			{object}	ct.PostResponse
+//	@Failure		400			{object}	ct.ErrorResponse
+//	@Failure		401			{object}	ct.ErrorResponse
+//	@Failure		403			{object}	ct.ErrorResponse
+//	@Failure		404			{object}	ct.ErrorResponse
+//	@Failure		409			{object}	ct.ErrorResponse
+//	@Failure		412			{object}	ct.ErrorResponse
+//	@Failure		422			{object}	ct.ErrorResponse
+//	@Router			/v1/posts/{postId} [put]
+func (h *handler) Update(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.UpdatePostRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+
+	if request.IfMatch, err = hdl.IfMatchVersion(e); err != nil {
+		return err
+	}
+
+	response, err := h.postSvc.Update(e.Request().Context(), user.ID, request)
+	if err != nil {
+		return err
+	}
+
+	return hdl.JSONWithETag(e, http.StatusOK, response.Version, response)
+}
+
+// Delete handles the request to delete a post
+//
+//	@Summary		Delete a post
+//	@Description	Deletes the post of the authenticated user
+//	@Tags			posts
+//	@Security		BearerToken
+//	@Param			postId	path	string	true	"Post ID"
+//	@Success		204
+//	@Failure		400	{object}	ct.ErrorResponse
+//	@Failure		401	{object}	ct.ErrorResponse
+//	@Failure		403	{object}	ct.ErrorResponse
+//	@Failure		404	{object}	ct.ErrorResponse
+//	@Router			/v1/posts/{postId} [delete]
+func (h *handler) Delete(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.PostRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+
+	if err = h.postSvc.Delete(e.Request().Context(), request.ID, user.ID); err != nil {
+		return err
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,243 @@
+package profile
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	ct "golang-project/internal/contract"
+	hdl "golang-project/internal/handler"
+	svc "golang-project/internal/service"
+	"golang-project/server"
+)
+
+// handler represents the implementation of handler.Profile
+type handler struct {
+	route      string
+	profileSvc svc.Profile
+}
+
+// NewHandler returns a new implementation of handler.Profile
+func NewHandler(route string, profileSvc svc.Profile) hdl.Profile {
+	return &handler{
+		route:      route,
+		profileSvc: profileSvc,
+	}
+}
+
+// RegisterRoutes registers the handler routes and returns the server.HandlerRegistry,
+// the profiles of other users are public and the own profile requires the user
+func (h *handler) RegisterRoutes() server.HandlerRegistry {
+	return server.HandlerRegistry{
+		Route:           h.route,
+		IsAuthenticated: false,
+		Register: func(group *echo.Group) {
+			group.GET("", h.Get)
+			group.PUT("", h.Update)
+			group.PUT("/password", h.ChangePassword, hdl.RequireSession)
+			group.GET("/posts", h.ListBloggerPosts)
+			group.GET("/posts/:postId", h.GetPostDetail)
+			group.GET("/:userId", h.View)
+		},
+	}
+}
+
+// Get handles the request to read the profile of the authenticated user
+//
+//	@Summary		Own profile
+//	@Description	Returns the profile of the authenticated user, tagged with an ETag
+//	@Tags			profile
+//	@Produce		json
+//	@Security		BearerToken
+//	@Param			If-None-Match	header		string	false	"ETag of the cached profile"
+//	@Success		200				{object}	ct.ProfileResponse
+//	@Success		304
+//	@Failure		401	{object}	ct.ErrorResponse
+//	@Router			/v1/profile [get]
+func (h *handler) Get(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.profileSvc.GetByID(e.Request().Context(), user.ID)
+	if err != nil {
+		return err
+	}
+
+	return hdl.JSONWithETag(e, http.StatusOK, response.Version, response)
+}
+
+// View handles the request to read the public profile of a user
+//
+//	@Summary		User profile
+//	@Description	Returns the public profile of a user, refused when the user and the authenticated user blocked each other
+//	@Tags			profile
+//	@Produce		json
+//	@Param			userId	path		string	true	"User ID"
+//	@Success		200		{object}	ct.ProfileResponse
+//	@Failure		400		{object}	ct.ErrorResponse
+//	@Failure		403		{object}	ct.ErrorResponse
+//	@Failure		404		{object}	ct.ErrorResponse
+//	@Router			/v1/profile/{userId} [get]
+func (h *handler) View(e echo.Context) error {
+	request := new(ct.ProfileRequest)
+	if err := e.Bind(request); err != nil {
+		return err
+	}
+
+	var viewerID *primitive.ObjectID
+	if user, err := hdl.GetContextUser(e); err == nil {
+		viewerID = &user.ID
+	}
+
+	response, err := h.profileSvc.View(e.Request().Context(), viewerID, request.ID)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, response)
+}
+
+// Update handles the request to update the profile of the authenticated user
+//
+//	@Summary		Update profile
+//	@Description	Updates the profile of the authenticated user.
+//	@Description	With If-Match the update fails with 412 when the profile has changed since it was read.
+//	@Tags			profile
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerToken
+//	@Param			If-Match	header		string					false	"ETag of the profile that was read"
+//	@Param			request		body		ct.UpdateProfileRequest	true	"Profile changes"
+//	@Success		200			{object}	ct.ProfileResponse
+//	@Failure		400			{object}	ct.ErrorResponse
+//	@Failure		401			{object}	ct.ErrorResponse
+//	@Failure		409			{object}	ct.ErrorResponse
+//	@Failure		412			{object}	ct.ErrorResponse
+//	@Failure		422			{object}	ct.ErrorResponse
+//	@Router			/v1/profile [put]
+func (h *handler) Update(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.UpdateProfileRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+
+	if request.IfMatch, err = hdl.IfMatchVersion(e); err != nil {
+		return err
+	}
+
+	response, err := h.profileSvc.Update(e.Request().Context(), user.ID, request)
+	if err != nil {
+		return err
+	}
+
+	return hdl.JSONWithETag(e, http.StatusOK, response.Version, response)
+}
+
+// ChangePassword handles the request to change the password of the authenticated user
+//
+//	@Summary		Change password
+//	@Description	Changes the password of the authenticated user, access tokens cannot change it
+//	@Tags			profile
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerToken
+//	@Param			request	body		ct.ChangePasswordRequest	true	"Current and new password"
+//	@Success		200		{object}	ct.ChangePasswordResponse
+//	@Failure		400		{object}	ct.ErrorResponse
+//	@Failure		401		{object}	ct.ErrorResponse
+//	@Failure		403		{object}	ct.ErrorResponse
+//	@Failure		409		{object}	ct.ErrorResponse
+//	@Failure		422		{object}	ct.ErrorResponse
+//	@Router			/v1/profile/password [put]
+func (h *handler) ChangePassword(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.ChangePasswordRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+
+	response, err := h.profileSvc.ChangePassword(e.Request().Context(), user.ID, request)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, response)
+}
+
+// ListBloggerPosts handles the request to list the posts of the authenticated user
+//
+//	@Summary		Own posts
+//	@Description	Lists the posts of the authenticated user, drafts included unless filtered out
+//	@Tags			profile
+//	@Produce		json
+//	@Security		BearerToken
+//	@Param			is_published	query		bool	false	"Published or draft posts only"
+//	@Success		200				{object}	ct.ListPostResponse
+//	@Failure		401				{object}	ct.ErrorResponse
+//	@Failure		422				{object}	ct.ErrorResponse
+//	@Router			/v1/profile/posts [get]
+func (h *handler) ListBloggerPosts(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.ListBloggerPostsRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+
+	response, err := h.profileSvc.ListBloggerPosts(e.Request().Context(), user.ID, request.IsPublished)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, response)
+}
+
+// GetPostDetail handles the request to read a post of the authenticated user
+//
+//	@Summary		Own post detail
+//	@Description	Returns a post of the authenticated user, published or not, tagged with an ETag to send back in If-Match
+//	@Tags			profile
+//	@Produce		json
+//	@Security		BearerToken
+//	@Param			postId			path		string	true	"Post ID"
+//	@Param			If-None-Match	header		string	false	"ETag of the cached post"
+//	@Success		200				{object}	ct.PostResponse
+//	@Success		304
+//	@Failure		400	{object}	ct.ErrorResponse
+//	@Failure		401		{object}	ct.ErrorResponse
+//	@Failure		403		{object}	ct.ErrorResponse
+//	@Failure		404		{object}	ct.ErrorResponse
+//	@Router			/v1/profile/posts/{postId} [get]
+func (h *handler) GetPostDetail(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.PostRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+
+	response, err := h.profileSvc.GetPost(e.Request().Context(), request.ID, user.ID)
+	if err != nil {
+		return err
+	}
+
+	return hdl.JSONWithETag(e, http.StatusOK, response.Version, response)
+}
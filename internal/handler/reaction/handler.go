@@ -0,0 +1,141 @@
+package reaction
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	ct "golang-project/internal/contract"
+	hdl "golang-project/internal/handler"
+	svc "golang-project/internal/service"
+	"golang-project/server"
+)
+
+// handler represents the implementation of handler.Reaction
+type handler struct {
+	route       string
+	reactionSvc svc.Reaction
+}
+
+// NewHandler returns a new implementation of handler.Reaction
+func NewHandler(route string, reactionSvc svc.Reaction) hdl.Reaction {
+	return &handler{
+		route:       route,
+		reactionSvc: reactionSvc,
+	}
+}
+
+// RegisterRoutes registers the handler routes and returns the server.HandlerRegistry,
+// the reaction counts are public and setting or removing a reaction requires the user
+func (h *handler) RegisterRoutes() server.HandlerRegistry {
+	return server.HandlerRegistry{
+		Route:           h.route,
+		IsAuthenticated: false,
+		Register: func(group *echo.Group) {
+			group.GET("/:targetType/:targetId", h.Summary)
+			group.PUT("/:targetType/:targetId", h.React)
+			group.DELETE("/:targetType/:targetId", h.Unreact)
+		},
+	}
+}
+
+// Summary handles the request to read the reactions of a post or a comment
+//
+//	@Summary		Reactions of a content
+//	@Description	Returns the reaction counts of a post or a comment and the reaction of the user when a token is sent
+//	@Tags			reactions
+//	@Produce		json
+//	@Param			targetType	path		string	true	"Content type"	Enums(post, comment)
+//	@Param			targetId	path		string	true	"Content ID"
+//	@Success		200			{object}	ct.ReactionSummaryResponse
+//	@Failure		400			{object}	ct.ErrorResponse
+//	@Failure		404			{object}	ct.ErrorResponse
+//	@Router			/v1/reactions/{targetType}/{targetId} [get]
+func (h *handler) Summary(e echo.Context) error {
+	request := new(ct.ReactionTargetRequest)
+	if err := e.Bind(request); err != nil {
+		return err
+	}
+
+	var viewerID *primitive.ObjectID
+	if user, err := hdl.GetContextUser(e); err == nil {
+		viewerID = &user.ID
+	}
+
+	response, err := h.reactionSvc.Summary(e.Request().Context(), viewerID, request)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, response)
+}
+
+// React handles the request to react to a post or a comment
+//
+//	@Summary		React to a content
+//	@Description	Sets the reaction of the authenticated user on a post or a comment, replacing the previous one
+//	@Tags			reactions
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerToken
+//	@Param			targetType	path		string					true	"Content type"	Enums(post, comment)
+//	@Param			targetId	path		string					true	"Content ID"
+//	@Param			request		body		ct.SetReactionRequest	true	"Reaction type"
+//	@Success		200			{object}	ct.ReactionSummaryResponse
+//	@Failure		400			{object}	ct.ErrorResponse
+//	@Failure		401			{object}	ct.ErrorResponse
+//	@Failure		403			{object}	ct.ErrorResponse
+//	@Failure		404			{object}	ct.ErrorResponse
+//	@Router			/v1/reactions/{targetType}/{targetId} [put]
+func (h *handler) React(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.SetReactionRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+
+	response, err := h.reactionSvc.React(e.Request().Context(), user.ID, request)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, response)
+}
+
+// Unreact handles the request to remove the reaction on a post or a comment
+//
+//	@Summary		Remove a reaction
+//	@Description	Removes the reaction of the authenticated user on a post or a comment
+//	@Tags			reactions
+//	@Produce		json
+//	@Security		BearerToken
+//	@Param			targetType	path		string	true	"Content type"	Enums(post, comment)
+//	@Param			targetId	path		string	true	"Content ID"
+//	@Success		200			{object}	ct.ReactionSummaryResponse
+//	@Failure		400			{object}	ct.ErrorResponse
+//	@Failure		401			{object}	ct.ErrorResponse
+//	@Failure		404			{object}	ct.ErrorResponse
+//	@Router			/v1/reactions/{targetType}/{targetId} [delete]
+func (h *handler) Unreact(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.ReactionTargetRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+
+	response, err := h.reactionSvc.Unreact(e.Request().Context(), user.ID, request)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,196 @@
+package relationship
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	ct "golang-project/internal/contract"
+	hdl "golang-project/internal/handler"
+	svc "golang-project/internal/service"
+	"golang-project/server"
+)
+
+// handler represents the implementation of handler.Relationship
+type handler struct {
+	route           string
+	relationshipSvc svc.Relationship
+}
+
+// NewHandler returns a new implementation of handler.Relationship
+func NewHandler(route string, relationshipSvc svc.Relationship) hdl.Relationship {
+	return &handler{
+		route:           route,
+		relationshipSvc: relationshipSvc,
+	}
+}
+
+// RegisterRoutes registers the handler routes and returns the server.HandlerRegistry
+func (h *handler) RegisterRoutes() server.HandlerRegistry {
+	return server.HandlerRegistry{
+		Route:           h.route,
+		IsAuthenticated: true,
+		Register: func(group *echo.Group) {
+			group.POST("/blocks", h.Block)
+			group.GET("/blocks", h.ListBlocked)
+			group.DELETE("/blocks/:userId", h.Unblock)
+			group.POST("/mutes", h.Mute)
+			group.GET("/mutes", h.ListMuted)
+			group.DELETE("/mutes/:userId", h.Unmute)
+		},
+	}
+}
+
+// Block handles the request to block a user
+//
+//	@Summary		Block a user
+//	@Description	Blocks a user, follows, comments, favourites and profile views between the two users are refused and existing follows are removed
+//	@Tags			relationships
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerToken
+//	@Param			request	body		ct.RelationshipRequest	true	"User to block"
+//	@Success		201		{object}	ct.RelationshipResponse
+//	@Failure		400		{object}	ct.ErrorResponse
+//	@Failure		401		{object}	ct.ErrorResponse
+//	@Failure		404		{object}	ct.ErrorResponse
+//	@Failure		409		{object}	ct.ErrorResponse
+//	@Router			/v1/relationships/blocks [post]
+func (h *handler) Block(e echo.Context) error {
+	return h.create(e, h.relationshipSvc.Block)
+}
+
+// Unblock handles the request to unblock a user
+//
+//	@Summary		Unblock a user
+//	@Description	Removes the block of a user
+//	@Tags			relationships
+//	@Security		BearerToken
+//	@Param			userId	path	string	true	"Blocked user ID"
+//	@Success		204
+//	@Failure		400	{object}	ct.ErrorResponse
+//	@Failure		401	{object}	ct.ErrorResponse
+//	@Failure		404	{object}	ct.ErrorResponse
+//	@Router			/v1/relationships/blocks/{userId} [delete]
+func (h *handler) Unblock(e echo.Context) error {
+	return h.remove(e, h.relationshipSvc.Unblock)
+}
+
+// ListBlocked handles the request to list the users blocked by the authenticated user
+//
+//	@Summary		Blocked users
+//	@Description	Lists the users blocked by the authenticated user, the latest first
+//	@Tags			relationships
+//	@Produce		json
+//	@Security		BearerToken
+//	@Success		200	{object}	ct.ListRelationshipResponse
+//	@Failure		401	{object}	ct.ErrorResponse
+//	@Router			/v1/relationships/blocks [get]
+func (h *handler) ListBlocked(e echo.Context) error {
+	return h.list(e, h.relationshipSvc.ListBlocked)
+}
+
+// Mute handles the request to mute a user
+//
+//	@Summary		Mute a user
+//	@Description	Hides the content of a user from the feeds and notifications of the authenticated user
+//	@Tags			relationships
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerToken
+//	@Param			request	body		ct.RelationshipRequest	true	"User to mute"
+//	@Success		201		{object}	ct.RelationshipResponse
+//	@Failure		400		{object}	ct.ErrorResponse
+//	@Failure		401		{object}	ct.ErrorResponse
+//	@Failure		404		{object}	ct.ErrorResponse
+//	@Failure		409		{object}	ct.ErrorResponse
+//	@Router			/v1/relationships/mutes [post]
+func (h *handler) Mute(e echo.Context) error {
+	return h.create(e, h.relationshipSvc.Mute)
+}
+
+// Unmute handles the request to unmute a user
+//
+//	@Summary		Unmute a user
+//	@Description	Removes the mute of a user
+//	@Tags			relationships
+//	@Security		BearerToken
+//	@Param			userId	path	string	true	"Muted user ID"
+//	@Success		204
+//	@Failure		400	{object}	ct.ErrorResponse
+//	@Failure		401	{object}	ct.ErrorResponse
+//	@Failure		404	{object}	ct.ErrorResponse
+//	@Router			/v1/relationships/mutes/{userId} [delete]
+func (h *handler) Unmute(e echo.Context) error {
+	return h.remove(e, h.relationshipSvc.Unmute)
+}
+
+// ListMuted handles the request to list the users muted by the authenticated user
+//
+//	@Summary		Muted users
+//	@Description	Lists the users muted by the authenticated user, the latest first
+//	@Tags			relationships
+//	@Produce		json
+//	@Security		BearerToken
+//	@Success		200	{object}	ct.ListRelationshipResponse
+//	@Failure		401	{object}	ct.ErrorResponse
+//	@Router			/v1/relationships/mutes [get]
+func (h *handler) ListMuted(e echo.Context) error {
+	return h.list(e, h.relationshipSvc.ListMuted)
+}
+
+// create binds the relationship request and answers with the created relationship
+func (h *handler) create(e echo.Context, action func(context.Context, primitive.ObjectID, *ct.RelationshipRequest) (*ct.RelationshipResponse, error)) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.RelationshipRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+
+	response, err := action(e.Request().Context(), user.ID, request)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusCreated, response)
+}
+
+// remove binds the relationship request and answers with no content once removed
+func (h *handler) remove(e echo.Context, action func(context.Context, primitive.ObjectID, *ct.RelationshipRequest) error) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.RelationshipRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+
+	if err = action(e.Request().Context(), user.ID, request); err != nil {
+		return err
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+// list answers with the relationships of the authenticated user
+func (h *handler) list(e echo.Context, action func(context.Context, primitive.ObjectID) (*ct.ListRelationshipResponse, error)) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	response, err := action(e.Request().Context(), user.ID)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,146 @@
+package tag
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	ct "golang-project/internal/contract"
+	hdl "golang-project/internal/handler"
+	svc "golang-project/internal/service"
+	"golang-project/server"
+)
+
+// handler represents the implementation of handler.Tag
+type handler struct {
+	route  string
+	tagSvc svc.Tag
+}
+
+// NewHandler returns a new implementation of handler.Tag
+func NewHandler(route string, tagSvc svc.Tag) hdl.Tag {
+	return &handler{
+		route:  route,
+		tagSvc: tagSvc,
+	}
+}
+
+// RegisterRoutes registers the handler routes and returns the server.HandlerRegistry,
+// the tags are public and creating or deleting a tag requires the user
+func (h *handler) RegisterRoutes() server.HandlerRegistry {
+	return server.HandlerRegistry{
+		Route:           h.route,
+		IsAuthenticated: false,
+		Register: func(group *echo.Group) {
+			group.POST("", h.Create)
+			group.GET("", h.List)
+			group.DELETE("/:tagId", h.Delete)
+			group.GET("/:tagId/posts", h.ListPosts)
+		},
+	}
+}
+
+// Create handles the request to create a tag
+//
+//	@Summary		Create a tag
+//	@Description	Creates a tag, tag names are unique
+//	@Tags			tags
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerToken
+//	@Param			request	body		ct.CreateTagRequest	true	"Tag"
+//	@Success		201		{object}	ct.TagResponse
+//	@Failure		400		{object}	ct.ErrorResponse
+//	@Failure		401		{object}	ct.ErrorResponse
+//	@Failure		409		{object}	ct.ErrorResponse
+//	@Failure		422		{object}	ct.ErrorResponse
+//	@Router			/v1/tags [post]
+func (h *handler) Create(e echo.Context) error {
+	if _, err := hdl.GetContextUser(e); err != nil {
+		return err
+	}
+
+	request := new(ct.CreateTagRequest)
+	if err := e.Bind(request); err != nil {
+		return err
+	}
+
+	response, err := h.tagSvc.Create(e.Request().Context(), request.Name)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusCreated, response)
+}
+
+// Delete handles the request to delete a tag
+//
+//	@Summary		Delete a tag
+//	@Description	Deletes a tag no post is labelled with
+//	@Tags			tags
+//	@Security		BearerToken
+//	@Param			tagId	path	string	true	"Tag ID"
+//	@Success		204
+//	@Failure		400	{object}	ct.ErrorResponse
+//	@Failure		401	{object}	ct.ErrorResponse
+//	@Failure		404	{object}	ct.ErrorResponse
+//	@Failure		409	{object}	ct.ErrorResponse
+//	@Router			/v1/tags/{tagId} [delete]
+func (h *handler) Delete(e echo.Context) error {
+	if _, err := hdl.GetContextUser(e); err != nil {
+		return err
+	}
+
+	request := new(ct.TagRequest)
+	if err := e.Bind(request); err != nil {
+		return err
+	}
+
+	if err := h.tagSvc.Delete(e.Request().Context(), request.ID); err != nil {
+		return err
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+// List handles the request to list the tags
+//
+//	@Summary		Tags
+//	@Description	Lists every tag ordered by name
+//	@Tags			tags
+//	@Produce		json
+//	@Success		200	{object}	ct.ListTagResponse
+//	@Router			/v1/tags [get]
+func (h *handler) List(e echo.Context) error {
+	response, err := h.tagSvc.List(e.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, response)
+}
+
+// ListPosts handles the request to list the posts labelled with a tag
+//
+//	@Summary		Posts of a tag
+//	@Description	Lists the published posts labelled with the tag, the latest first
+//	@Tags			tags
+//	@Produce		json
+//	@Param			tagId	path		string	true	"Tag ID"
+//	@Success		200		{object}	ct.ListPostResponse
+//	@Failure		400		{object}	ct.ErrorResponse
+//	@Failure		404		{object}	ct.ErrorResponse
+//	@Router			/v1/tags/{tagId}/posts [get]
+func (h *handler) ListPosts(e echo.Context) error {
+	request := new(ct.TagRequest)
+	if err := e.Bind(request); err != nil {
+		return err
+	}
+
+	response, err := h.tagSvc.ListPosts(e.Request().Context(), request.ID)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, response)
+}
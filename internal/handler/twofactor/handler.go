@@ -0,0 +1,203 @@
+package twofactor
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	ct "golang-project/internal/contract"
+	hdl "golang-project/internal/handler"
+	svc "golang-project/internal/service"
+	"golang-project/server"
+)
+
+// handler represents the implementation of handler.TwoFactor
+type handler struct {
+	route        string
+	twoFactorSvc svc.TwoFactor
+}
+
+// NewHandler returns a new implementation of handler.TwoFactor
+func NewHandler(route string, twoFactorSvc svc.TwoFactor) hdl.TwoFactor {
+	return &handler{
+		route:        route,
+		twoFactorSvc: twoFactorSvc,
+	}
+}
+
+// RegisterRoutes registers the handler routes and returns the server.HandlerRegistry
+func (h *handler) RegisterRoutes() server.HandlerRegistry {
+	return server.HandlerRegistry{
+		Route:           h.route,
+		IsAuthenticated: true,
+		Register: func(group *echo.Group) {
+			group.Use(hdl.RequireSession)
+			group.GET("", h.Status)
+			group.POST("/enrolment", h.Enrol)
+			group.POST("/confirmation", h.Confirm)
+			group.POST("/recovery-codes", h.RenewRecoveryCodes)
+			group.POST("/disable", h.Disable)
+		},
+	}
+}
+
+// Status handles the request to read the two-factor authentication state of the authenticated user
+//
+//	@Summary		Two-factor authentication status
+//	@Description	Returns whether two-factor authentication is enabled and the number of recovery codes left
+//	@Tags			two-factor
+//	@Produce		json
+//	@Security		BearerToken
+//	@Success		200	{object}	ct.TwoFactorStatusResponse
+//	@Failure		401	{object}	ct.ErrorResponse
+//	@Router			/v1/two-factor [get]
+func (h *handler) Status(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.twoFactorSvc.Status(e.Request().Context(), user.ID)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, response)
+}
+
+// Enrol handles the request to start the two-factor authentication enrolment
+//
+//	@Summary		Start two-factor enrolment
+//	@Description	Generates a TOTP secret with its otpauth URI for an authenticator app after confirming the password. Users without a password, who sign in with an identity provider, sign in again within 5 minutes before. Two-factor authentication is enabled once a code is confirmed, enrolling again replaces the unconfirmed secret
+//	@Tags			two-factor
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerToken
+//	@Param			request	body		ct.EnrolTwoFactorRequest	true	"Password confirmation"
+//	@Success		200		{object}	ct.TwoFactorEnrolmentResponse
+//	@Failure		400		{object}	ct.ErrorResponse
+//	@Failure		401		{object}	ct.ErrorResponse
+//	@Failure		409		{object}	ct.ErrorResponse
+//	@Router			/v1/two-factor/enrolment [post]
+func (h *handler) Enrol(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.EnrolTwoFactorRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+	request.SignedInAt = user.SignedInAt
+
+	response, err := h.twoFactorSvc.Enrol(e.Request().Context(), user.ID, request)
+	if err != nil {
+		return err
+	}
+
+	hdl.NoStore(e)
+	return e.JSON(http.StatusOK, response)
+}
+
+// Confirm handles the request to enable the two-factor authentication with a code of the enrolled secret
+//
+//	@Summary		Confirm two-factor enrolment
+//	@Description	Enables two-factor authentication when the code matches the enrolled secret and returns the recovery codes. The recovery codes are only returned once
+//	@Tags			two-factor
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerToken
+//	@Param			request	body		ct.TwoFactorCodeRequest	true	"Authenticator code"
+//	@Success		200		{object}	ct.RecoveryCodesResponse
+//	@Failure		400		{object}	ct.ErrorResponse
+//	@Failure		401		{object}	ct.ErrorResponse
+//	@Failure		409		{object}	ct.ErrorResponse
+//	@Router			/v1/two-factor/confirmation [post]
+func (h *handler) Confirm(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.TwoFactorCodeRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+
+	response, err := h.twoFactorSvc.Confirm(e.Request().Context(), user.ID, request)
+	if err != nil {
+		return err
+	}
+
+	hdl.NoStore(e)
+	return e.JSON(http.StatusOK, response)
+}
+
+// RenewRecoveryCodes handles the request to replace the recovery codes
+//
+//	@Summary		Renew recovery codes
+//	@Description	Replaces the recovery codes after checking a code of the authenticator app, the previous codes stop working
+//	@Tags			two-factor
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerToken
+//	@Param			request	body		ct.TwoFactorCodeRequest	true	"Authenticator code"
+//	@Success		200		{object}	ct.RecoveryCodesResponse
+//	@Failure		400		{object}	ct.ErrorResponse
+//	@Failure		401		{object}	ct.ErrorResponse
+//	@Failure		409		{object}	ct.ErrorResponse
+//	@Failure		429		{object}	ct.ErrorResponse
+//	@Router			/v1/two-factor/recovery-codes [post]
+func (h *handler) RenewRecoveryCodes(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.TwoFactorCodeRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+
+	response, err := h.twoFactorSvc.RenewRecoveryCodes(e.Request().Context(), user.ID, request)
+	if err != nil {
+		return err
+	}
+
+	hdl.NoStore(e)
+	return e.JSON(http.StatusOK, response)
+}
+
+// Disable handles the request to remove the two-factor authentication
+//
+//	@Summary		Disable two-factor authentication
+//	@Description	Removes two-factor authentication and its recovery codes after confirming the password. Users without a password, who sign in with an identity provider, confirm with a code of the authenticator app or a recovery code, or sign in again within 5 minutes before
+//	@Tags			two-factor
+//	@Accept			json
+//	@Security		BearerToken
+//	@Param			request	body	ct.DisableTwoFactorRequest	true	"Password or code confirmation"
+//	@Success		204
+//	@Failure		400	{object}	ct.ErrorResponse
+//	@Failure		401	{object}	ct.ErrorResponse
+//	@Failure		409	{object}	ct.ErrorResponse
+//	@Failure		429	{object}	ct.ErrorResponse
+//	@Router			/v1/two-factor/disable [post]
+func (h *handler) Disable(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.DisableTwoFactorRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+	request.SignedInAt = user.SignedInAt
+
+	if err = h.twoFactorSvc.Disable(e.Request().Context(), user.ID, request); err != nil {
+		return err
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
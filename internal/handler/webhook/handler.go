@@ -0,0 +1,197 @@
+package webhook
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	ct "golang-project/internal/contract"
+	hdl "golang-project/internal/handler"
+	svc "golang-project/internal/service"
+	"golang-project/server"
+)
+
+// handler represents the implementation of handler.Webhook
+type handler struct {
+	route      string
+	webhookSvc svc.Webhook
+}
+
+// NewHandler returns a new implementation of handler.Webhook
+func NewHandler(route string, webhookSvc svc.Webhook) hdl.Webhook {
+	return &handler{
+		route:      route,
+		webhookSvc: webhookSvc,
+	}
+}
+
+// RegisterRoutes registers the handler routes and returns the server.HandlerRegistry
+func (h *handler) RegisterRoutes() server.HandlerRegistry {
+	return server.HandlerRegistry{
+		Route:           h.route,
+		IsAuthenticated: true,
+		Register: func(group *echo.Group) {
+			group.POST("", h.Create, hdl.RequireSession)
+			group.GET("", h.List)
+			group.DELETE("/:webhookId", h.Delete)
+			group.GET("/:webhookId/deliveries", h.ListDeliveries)
+			group.POST("/:webhookId/deliveries/:deliveryId/redeliver", h.Redeliver)
+		},
+	}
+}
+
+// Create handles the request to register a webhook endpoint
+//
+//	@Summary		Register a webhook
+//	@Description	Registers an endpoint receiving the subscribed events of the authenticated user, admins can subscribe to the events of all users. The URL must use https and resolve to a public address. The signing secret is only returned once
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerToken
+//	@Param			request	body		ct.CreateWebhookRequest	true	"Webhook endpoint"
+//	@Success		201		{object}	ct.WebhookResponse
+//	@Failure		400		{object}	ct.ErrorResponse
+//	@Failure		401		{object}	ct.ErrorResponse
+//	@Failure		403		{object}	ct.ErrorResponse
+//	@Failure		422		{object}	ct.ErrorResponse
+//	@Router			/v1/webhooks [post]
+func (h *handler) Create(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.CreateWebhookRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+
+	response, err := h.webhookSvc.Create(e.Request().Context(), user.ID, request)
+	if err != nil {
+		return err
+	}
+
+	hdl.NoStore(e)
+	return e.JSON(http.StatusCreated, response)
+}
+
+// List handles the request to list the webhooks of the authenticated user
+//
+//	@Summary		Webhooks
+//	@Description	Lists the webhooks of the authenticated user, the latest first
+//	@Tags			webhooks
+//	@Produce		json
+//	@Security		BearerToken
+//	@Success		200	{object}	ct.ListWebhookResponse
+//	@Failure		401	{object}	ct.ErrorResponse
+//	@Router			/v1/webhooks [get]
+func (h *handler) List(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.webhookSvc.List(e.Request().Context(), user.ID)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, response)
+}
+
+// Delete handles the request to remove a webhook
+//
+//	@Summary		Remove a webhook
+//	@Description	Removes the webhook with its delivery log, the queued deliveries are dropped
+//	@Tags			webhooks
+//	@Security		BearerToken
+//	@Param			webhookId	path	string	true	"Webhook ID"
+//	@Success		204
+//	@Failure		400	{object}	ct.ErrorResponse
+//	@Failure		401	{object}	ct.ErrorResponse
+//	@Failure		404	{object}	ct.ErrorResponse
+//	@Router			/v1/webhooks/{webhookId} [delete]
+func (h *handler) Delete(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.WebhookRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+
+	if err = h.webhookSvc.Delete(e.Request().Context(), user.ID, request); err != nil {
+		return err
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+// ListDeliveries handles the request to read the delivery log of a webhook
+//
+//	@Summary		Webhook deliveries
+//	@Description	Lists the deliveries of the webhook with their attempts and last response, the latest first
+//	@Tags			webhooks
+//	@Produce		json
+//	@Security		BearerToken
+//	@Param			webhookId	path		string	true	"Webhook ID"
+//	@Param			page		query		int		false	"Page number"
+//	@Param			page_size	query		int		false	"Page size"
+//	@Success		200			{object}	ct.ListWebhookDeliveryResponse
+//	@Failure		400			{object}	ct.ErrorResponse
+//	@Failure		401			{object}	ct.ErrorResponse
+//	@Failure		404			{object}	ct.ErrorResponse
+//	@Router			/v1/webhooks/{webhookId}/deliveries [get]
+func (h *handler) ListDeliveries(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.ListWebhookDeliveryRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+
+	response, err := h.webhookSvc.ListDeliveries(e.Request().Context(), user.ID, request)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, response)
+}
+
+// Redeliver handles the request to send a past delivery again
+//
+//	@Summary		Redeliver a webhook delivery
+//	@Description	Queues a new delivery of the same payload, the receivers can deduplicate it by the payload ID
+//	@Tags			webhooks
+//	@Produce		json
+//	@Security		BearerToken
+//	@Param			webhookId	path		string	true	"Webhook ID"
+//	@Param			deliveryId	path		string	true	"Delivery ID"
+//	@Success		202			{object}	ct.WebhookDeliveryResponse
+//	@Failure		400			{object}	ct.ErrorResponse
+//	@Failure		401			{object}	ct.ErrorResponse
+//	@Failure		404			{object}	ct.ErrorResponse
+//	@Router			/v1/webhooks/{webhookId}/deliveries/{deliveryId}/redeliver [post]
+func (h *handler) Redeliver(e echo.Context) error {
+	user, err := hdl.GetContextUser(e)
+	if err != nil {
+		return err
+	}
+
+	request := new(ct.RedeliverWebhookRequest)
+	if err = e.Bind(request); err != nil {
+		return err
+	}
+
+	response, err := h.webhookSvc.Redeliver(e.Request().Context(), user.ID, request)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusAccepted, response)
+}
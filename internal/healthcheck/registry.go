@@ -0,0 +1,157 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"golang-project/static"
+)
+
+var (
+	ErrCheckTimeout = errors.New("health check timed out")
+)
+
+// CheckFunc represents the function that verifies the health of a subsystem
+type CheckFunc func(ctx context.Context) error
+
+// Result represents the outcome of a single health check
+type Result struct {
+	Name      string
+	Err       error
+	Duration  time.Duration
+	CheckedAt time.Time
+}
+
+// Healthy returns whether the check has passed
+func (r *Result) Healthy() bool {
+	return r.Err == nil
+}
+
+// Option represents the option of a registered health check
+type Option func(*check)
+
+// WithTimeout overrides the registry default timeout of the check
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *check) { c.timeout = timeout }
+}
+
+// WithCacheTTL overrides the registry default duration the check result is reused
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(c *check) { c.ttl = ttl }
+}
+
+// check is a registered health check with its cached result
+type check struct {
+	name    string
+	fn      CheckFunc
+	timeout time.Duration
+	ttl     time.Duration
+
+	mu     sync.Mutex
+	result *Result
+}
+
+// Registry holds the health checks that subsystems register into
+type Registry struct {
+	timeout time.Duration
+	ttl     time.Duration
+
+	mu     sync.RWMutex
+	checks map[string]*check
+}
+
+// NewRegistry creates and returns a health check registry with the default timeout and cache TTL of each check
+func NewRegistry(timeout, ttl time.Duration) *Registry {
+	return &Registry{
+		timeout: timeout,
+		ttl:     ttl,
+		checks:  map[string]*check{},
+	}
+}
+
+// NewRegistryFromEnv creates and returns a health check registry configured from environment variables
+func NewRegistryFromEnv() *Registry {
+	timeout := viper.GetDuration(static.EnvHealthCheckTimeout)
+	if timeout <= 0 {
+		timeout = static.HealthCheck.Timeout
+	}
+
+	ttl := static.HealthCheck.CacheTTL
+	if viper.IsSet(static.EnvHealthCheckCacheTTL) {
+		ttl = viper.GetDuration(static.EnvHealthCheckCacheTTL)
+	}
+
+	return NewRegistry(timeout, ttl)
+}
+
+// Register adds the named check into the registry, replacing any check with the same name
+func (r *Registry) Register(name string, fn CheckFunc, opts ...Option) {
+	c := &check{name: name, fn: fn, timeout: r.timeout, ttl: r.ttl}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.checks[name] = c
+}
+
+// Run executes all registered checks concurrently and returns their results sorted by name
+func (r *Registry) Run(ctx context.Context) []*Result {
+	r.mu.RLock()
+	checks := make([]*check, 0, len(r.checks))
+	for _, c := range r.checks {
+		checks = append(checks, c)
+	}
+	r.mu.RUnlock()
+
+	results := make([]*Result, len(checks))
+
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c *check) {
+			defer wg.Done()
+			results[i] = c.run(ctx)
+		}(i, c)
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+
+	return results
+}
+
+// run returns the cached result while it is fresh, otherwise executes the check within its timeout
+func (c *check) run(ctx context.Context) *Result {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.result != nil && time.Since(c.result.CheckedAt) < c.ttl {
+		return c.result
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() { done <- c.fn(ctx) }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ErrCheckTimeout
+	}
+
+	c.result = &Result{Name: c.name, Err: err, Duration: time.Since(start), CheckedAt: start}
+
+	return c.result
+}
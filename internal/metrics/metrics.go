@@ -0,0 +1,173 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// namespace prefixes every metric exposed by the server
+const namespace = "social_blog"
+
+// Registry is the Prometheus registry that holds all server collectors
+var Registry = prometheus.NewRegistry()
+
+// HTTP metrics
+var (
+	HTTPRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Total number of HTTP requests by route group, route, method and status code.",
+	}, []string{"group", "route", "method", "code"})
+
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by route group, route and method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"group", "route", "method"})
+
+	HTTPRequestsInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_in_flight",
+		Help:      "Number of HTTP requests currently being served.",
+	})
+)
+
+// MongoDB metrics
+var (
+	MongoCommandsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "mongo",
+		Name:      "commands_total",
+		Help:      "Total number of MongoDB commands by command name and outcome.",
+	}, []string{"command", "outcome"})
+
+	MongoCommandDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "mongo",
+		Name:      "command_duration_seconds",
+		Help:      "MongoDB command latency by command name.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"command"})
+
+	MongoPoolConnections = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "mongo",
+		Name:      "pool_connections",
+		Help:      "Number of MongoDB pool connections by state.",
+	}, []string{"state"})
+
+	MongoPoolEventsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "mongo",
+		Name:      "pool_events_total",
+		Help:      "Total number of MongoDB connection pool events by type.",
+	}, []string{"type"})
+)
+
+// Cache metrics
+var (
+	CacheRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cache",
+		Name:      "requests_total",
+		Help:      "Total number of read-through cache lookups by cache name and result.",
+	}, []string{"cache", "result"})
+
+	CacheInvalidationsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cache",
+		Name:      "invalidations_total",
+		Help:      "Total number of cache keys invalidated by cache name.",
+	}, []string{"cache"})
+
+	CacheErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cache",
+		Name:      "errors_total",
+		Help:      "Total number of cache store failures by cache name and operation.",
+	}, []string{"cache", "operation"})
+)
+
+// Event metrics
+var (
+	EventsPublishedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "events",
+		Name:      "published_total",
+		Help:      "Total number of domain events written to the outbox by event name.",
+	}, []string{"event"})
+
+	EventHandlersTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "events",
+		Name:      "handled_total",
+		Help:      "Total number of domain event subscriber runs by event name, subscriber and outcome.",
+	}, []string{"event", "subscriber", "outcome"})
+)
+
+// Business metrics
+var (
+	SignUpsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "sign_ups_total",
+		Help:      "Total number of successful user sign-ups.",
+	})
+
+	SignInsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "sign_ins_total",
+		Help:      "Total number of sign-in attempts by outcome.",
+	}, []string{"outcome"})
+
+	PostsCreatedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "posts_created_total",
+		Help:      "Total number of created posts.",
+	})
+
+	CommentsCreatedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "comments_created_total",
+		Help:      "Total number of created comments.",
+	})
+)
+
+// Outcome label values shared by the counters
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+// Result label values of the cache lookups
+const (
+	CacheHit  = "hit"
+	CacheMiss = "miss"
+)
+
+// init registers all collectors together with the Go runtime and process collectors
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequestsTotal,
+		HTTPRequestDuration,
+		HTTPRequestsInFlight,
+		MongoCommandsTotal,
+		MongoCommandDuration,
+		MongoPoolConnections,
+		MongoPoolEventsTotal,
+		CacheRequestsTotal,
+		CacheInvalidationsTotal,
+		CacheErrorsTotal,
+		EventsPublishedTotal,
+		EventHandlersTotal,
+		SignUpsTotal,
+		SignInsTotal,
+		PostsCreatedTotal,
+		CommentsCreatedTotal,
+	)
+}
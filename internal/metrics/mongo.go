@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoMonitor attaches the command and pool monitors that feed the MongoDB metrics to the client options
+func MongoMonitor(clientOptions *options.ClientOptions) {
+	clientOptions.SetMonitor(&event.CommandMonitor{
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			MongoCommandsTotal.WithLabelValues(e.CommandName, OutcomeSuccess).Inc()
+			MongoCommandDuration.WithLabelValues(e.CommandName).Observe(e.Duration.Seconds())
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			MongoCommandsTotal.WithLabelValues(e.CommandName, OutcomeFailure).Inc()
+			MongoCommandDuration.WithLabelValues(e.CommandName).Observe(e.Duration.Seconds())
+		},
+	})
+
+	clientOptions.SetPoolMonitor(&event.PoolMonitor{
+		Event: func(e *event.PoolEvent) {
+			MongoPoolEventsTotal.WithLabelValues(e.Type).Inc()
+
+			switch e.Type {
+			case event.ConnectionCreated:
+				MongoPoolConnections.WithLabelValues("open").Inc()
+			case event.ConnectionClosed:
+				MongoPoolConnections.WithLabelValues("open").Dec()
+			case event.GetSucceeded:
+				MongoPoolConnections.WithLabelValues("in_use").Inc()
+			case event.ConnectionReturned:
+				MongoPoolConnections.WithLabelValues("in_use").Dec()
+			}
+		},
+	})
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"golang-project/internal/audit"
+	"golang-project/static"
+)
+
+// Audit provides the middleware carrying the client IP, user agent and correlation ID of the request
+// to the audit log entries recorded by the services, it runs after Correlation
+func Audit() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			ctx := audit.WithRequest(req.Context(), &audit.Request{
+				Source:        static.AuditSourceAPI,
+				IP:            c.RealIP(),
+				UserAgent:     req.UserAgent(),
+				CorrelationID: c.Response().Header().Get(echo.HeaderXCorrelationID),
+			})
+			c.SetRequest(req.WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
@@ -1,9 +1,11 @@
 package middleware
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/golang-jwt/jwt"
 	echoJwt "github.com/labstack/echo-jwt/v4"
@@ -11,24 +13,46 @@ import (
 	"github.com/spf13/viper"
 
 	ct "golang-project/internal/contract"
+	repo "golang-project/internal/repository"
+	svc "golang-project/internal/service"
 	"golang-project/server"
 	"golang-project/static"
+	"golang-project/util/apperror"
 )
 
-// Authentication provides the middleware for any API requires user authentication
-func Authentication(registries []server.HandlerRegistry) echo.MiddlewareFunc {
-	pathSkipper := map[string]bool{}
-	if len(registries) > 0 {
-		pathSkipper = mapPathSkipper(registries)
+// Authentication provides the middleware for any API requires user authentication,
+// public route groups read the user of a valid token and ignore missing or invalid tokens.
+// Bearer tokens with the personal access token prefix are checked by the access token service instead of as JWTs.
+// The user of the JWT is read on every request so disabling a user ends the sessions already issued
+func Authentication(registries []server.HandlerRegistry, userRepo repo.User, accessTokenSvc svc.AccessToken) echo.MiddlewareFunc {
+	matcher := newRegistryMatcher(registries)
+	publicPaths := map[string]bool{"/": true, "/favicon.ico": true}
+	isPublic := func(c echo.Context) bool {
+		r, ok := matcher.match(c.Request().URL.Path)
+		return ok && !r.IsAuthenticated
 	}
 
 	return echoJwt.WithConfig(echoJwt.Config{
 		Skipper: func(c echo.Context) bool {
-			return pathSkipper[getRouteGroup(c.Request().URL.Path)]
+			return publicPaths[c.Request().URL.Path]
 		},
 		SigningKey:    []byte(viper.GetString(static.EnvAuthSecret)),
 		SigningMethod: echoJwt.AlgorithmHS256,
 		ParseTokenFunc: func(c echo.Context, auth string) (interface{}, error) {
+			if strings.HasPrefix(auth, static.AccessToken.Prefix) {
+				user, err := accessTokenSvc.Authenticate(c.Request().Context(), auth, c.RealIP())
+				if err != nil {
+					return nil, err
+				}
+
+				if !allowsMethod(user.Scopes, c.Request().Method) {
+					return nil, static.ErrAccessTokenScope
+				}
+
+				user.IsAccessToken = true
+				return user, nil
+			}
+
 			keyFunc := func(token *jwt.Token) (interface{}, error) {
 				return []byte(viper.GetString(static.EnvAuthSecret)), nil
 			}
@@ -43,22 +67,66 @@ func Authentication(registries []server.HandlerRegistry) echo.MiddlewareFunc {
 				return nil, echo.NewHTTPError(http.StatusUnauthorized, "parse jwt custom claim failed")
 			}
 
-			return &ct.ContextUser{ID: claim.UserID, Email: claim.UserEmail}, nil
+			user, err := userRepo.Read(c.Request().Context(), claim.UserID)
+			if errors.Is(err, static.ErrUserNotFound) {
+				return nil, echo.NewHTTPError(http.StatusUnauthorized, "user of jwt not found")
+			}
+			if err != nil {
+				return nil, static.ErrDatabaseOperation.Wrap(err)
+			}
+
+			if user.DisabledAt != nil {
+				return nil, static.ErrUserDisabled
+			}
+
+			// The claims are in seconds, a JWT issued in the second of the revocation is kept
+			if user.SessionsRevokedAt != nil && claim.IssuedAt < user.SessionsRevokedAt.Unix() {
+				return nil, echo.NewHTTPError(http.StatusUnauthorized, "jwt was revoked")
+			}
+
+			return &ct.ContextUser{ID: user.ID, Email: user.Email, SignedInAt: time.Unix(claim.IssuedAt, 0)}, nil
 		},
+		ErrorHandler: func(c echo.Context, err error) error {
+			// A valid token without the scope of the request is refused rather than treated as anonymous
+			if errors.Is(err, static.ErrAccessTokenScope) {
+				return static.ErrAccessTokenScope
+			}
+
+			if isPublic(c) {
+				return nil
+			}
+
+			var appErr *apperror.Error
+			if errors.As(err, &appErr) {
+				return appErr
+			}
+
+			var parsingErr *echoJwt.TokenParsingError
+			if errors.As(err, &parsingErr) {
+				return echo.NewHTTPError(http.StatusUnauthorized, "invalid or expired jwt").SetInternal(err)
+			}
+
+			return echo.NewHTTPError(http.StatusBadRequest, "missing or malformed jwt").SetInternal(err)
+		},
+		ContinueOnIgnoredError: true,
 	})
 }
 
-func mapPathSkipper(registries []server.HandlerRegistry) map[string]bool {
-	result := map[string]bool{"/": true, "/favicon.ico": true}
-
-	for _, r := range registries {
-		if r.IsAuthenticated {
-			continue
+// allowsMethod reports whether the access token scopes allow the request method,
+// the read scope allows the safe methods and the write scope every method
+func allowsMethod(scopes []static.AccessTokenScope, method string) bool {
+	for _, scope := range scopes {
+		switch scope {
+		case static.AccessTokenWrite:
+			return true
+		case static.AccessTokenRead:
+			if method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions {
+				return true
+			}
 		}
-		result[r.Route] = true
 	}
 
-	return result
+	return false
 }
 
 func getRouteGroup(path string) string {
@@ -0,0 +1,148 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+	"github.com/spf13/viper"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	ct "golang-project/internal/contract"
+	"golang-project/internal/model"
+	svc "golang-project/internal/service"
+	accessTokenSvc "golang-project/internal/service/accesstoken"
+	"golang-project/internal/service/servicetest"
+	"golang-project/server"
+	"golang-project/static"
+)
+
+// newAuthenticatedServer returns the server with an authenticated posts route group answering every method
+func newAuthenticatedServer(t *testing.T, users servicetest.Users, tokens svc.AccessToken) *echo.Echo {
+	t.Helper()
+
+	viper.Set(static.EnvAuthSecret, "test-secret")
+	t.Cleanup(func() { viper.Set(static.EnvAuthSecret, nil) })
+
+	registries := []server.HandlerRegistry{{Route: "posts", Version: "v1", IsAuthenticated: true}}
+
+	e := echo.New()
+	e.HTTPErrorHandler = ErrorHandler
+	e.Use(Authentication(registries, users, tokens))
+	e.Any("/v1/posts", func(c echo.Context) error { return c.NoContent(http.StatusNoContent) })
+
+	return e
+}
+
+// signJWT returns a JWT of the user issued now
+func signJWT(t *testing.T, userID primitive.ObjectID) string {
+	t.Helper()
+
+	claims := &ct.CustomClaim{StandardClaims: jwt.StandardClaims{IssuedAt: time.Now().Unix(), ExpiresAt: time.Now().Add(time.Hour).Unix()}, UserID: userID}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("test-secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return token
+}
+
+// request sends the request with the bearer token and returns the status code
+func request(e *echo.Echo, method, token string) int {
+	req := httptest.NewRequest(method, "/v1/posts", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	return rec.Code
+}
+
+// createToken returns a new personal access token of the user with the scope
+func createToken(t *testing.T, tokens svc.AccessToken, userID primitive.ObjectID, scope static.AccessTokenScope) *ct.AccessTokenResponse {
+	t.Helper()
+
+	created, err := tokens.Create(context.Background(), userID, &ct.CreateAccessTokenRequest{Name: "ci", Scopes: []static.AccessTokenScope{scope}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return created
+}
+
+func TestAuthenticationLimitsReadOnlyTokensToSafeMethods(t *testing.T) {
+	users := servicetest.Users{}
+	tokens := accessTokenSvc.NewService(users, &servicetest.AccessTokens{}, &servicetest.Recorder{})
+	reader := createToken(t, tokens, primitive.NewObjectID(), static.AccessTokenRead).Token
+	writer := createToken(t, tokens, primitive.NewObjectID(), static.AccessTokenWrite).Token
+	e := newAuthenticatedServer(t, users, tokens)
+
+	for _, method := range []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPatch, http.MethodPut, http.MethodDelete} {
+		want := http.StatusForbidden
+		if method == http.MethodGet || method == http.MethodHead {
+			want = http.StatusNoContent
+		}
+
+		if code := request(e, method, reader); code != want {
+			t.Errorf("%s with a read-only token: code = %d, want %d", method, code, want)
+		}
+		if code := request(e, method, writer); code != http.StatusNoContent {
+			t.Errorf("%s with a write token: code = %d, want %d", method, code, http.StatusNoContent)
+		}
+	}
+}
+
+func TestAuthenticationRefusesInactiveAccessTokens(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	disabledID := primitive.NewObjectID()
+	users := servicetest.Users{Users: map[primitive.ObjectID]*model.User{disabledID: {DisabledAt: &past}}}
+	stored := &servicetest.AccessTokens{}
+	tokens := accessTokenSvc.NewService(users, stored, &servicetest.Recorder{})
+	e := newAuthenticatedServer(t, users, tokens)
+
+	expired := createToken(t, tokens, primitive.NewObjectID(), static.AccessTokenWrite)
+	stored.Tokens[expired.ID].ExpiresAt = &past
+	revoked := createToken(t, tokens, primitive.NewObjectID(), static.AccessTokenWrite)
+	stored.Tokens[revoked.ID].RevokedAt = &past
+
+	for name, tc := range map[string]struct {
+		token string
+		want  int
+	}{
+		"expired":       {expired.Token, http.StatusUnauthorized},
+		"revoked":       {revoked.Token, http.StatusUnauthorized},
+		"unknown":       {static.AccessToken.Prefix + "unknown", http.StatusUnauthorized},
+		"disabled user": {createToken(t, tokens, disabledID, static.AccessTokenWrite).Token, http.StatusForbidden},
+	} {
+		if code := request(e, http.MethodGet, tc.token); code != tc.want {
+			t.Errorf("%s: code = %d, want %d", name, code, tc.want)
+		}
+	}
+}
+
+func TestAuthenticationRefusesTheJWTsOfDisabledOrSignedOutUsers(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Minute)
+	activeID, disabledID, signedOutID := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	users := servicetest.Users{Users: map[primitive.ObjectID]*model.User{
+		disabledID:  {DisabledAt: &past},
+		signedOutID: {SessionsRevokedAt: &future},
+	}}
+	e := newAuthenticatedServer(t, users, accessTokenSvc.NewService(users, &servicetest.AccessTokens{}, &servicetest.Recorder{}))
+
+	for name, tc := range map[string]struct {
+		userID primitive.ObjectID
+		want   int
+	}{
+		"active":     {activeID, http.StatusNoContent},
+		"disabled":   {disabledID, http.StatusForbidden},
+		"signed out": {signedOutID, http.StatusUnauthorized},
+	} {
+		if code := request(e, http.MethodGet, signJWT(t, tc.userID)); code != tc.want {
+			t.Errorf("%s: code = %d, want %d", name, code, tc.want)
+		}
+	}
+}
@@ -4,12 +4,16 @@ import (
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"go.opentelemetry.io/otel/trace"
+
+	"golang-project/internal/tracing"
 )
 
-// Correlation provides the middleware for any request and response correlation ID
+// Correlation provides the middleware for any request and response correlation ID,
+// reusing the trace ID when the request has no correlation ID and tagging the span with it
 func Correlation() echo.MiddlewareFunc {
 	config := middleware.RequestIDConfig{
-		Skipper:      func(c echo.Context) bool { return c.Request().URL.String() == "/health" },
+		Skipper:      func(c echo.Context) bool { return getRouteGroup(c.Request().URL.Path) == "/health" },
 		Generator:    func() string { return uuid.New().String() },
 		TargetHeader: echo.HeaderXCorrelationID,
 	}
@@ -23,12 +27,20 @@ func Correlation() echo.MiddlewareFunc {
 			req := c.Request()
 			res := c.Response()
 
+			span := trace.SpanFromContext(req.Context())
+
 			cid := req.Header.Get(config.TargetHeader)
+			if cid == "" && span.SpanContext().HasTraceID() {
+				cid = span.SpanContext().TraceID().String()
+				req.Header.Set(config.TargetHeader, cid)
+			}
 			if cid == "" {
 				cid = config.Generator()
 				req.Header.Set(config.TargetHeader, cid)
 			}
 
+			span.SetAttributes(tracing.AttributeCorrelationID.String(cid))
+
 			res.Header().Set(config.TargetHeader, cid)
 
 			if config.RequestIDHandler != nil {
@@ -0,0 +1,155 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/spf13/viper"
+
+	ct "golang-project/internal/contract"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	"golang-project/static"
+)
+
+// Idempotency headers
+const (
+	HeaderIdempotencyKey      = "Idempotency-Key"
+	HeaderIdempotencyReplayed = "Idempotency-Replayed"
+)
+
+// captureWriter copies the response body written through it
+type captureWriter struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *captureWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+// Idempotency provides the middleware for POST requests sent with the Idempotency-Key header,
+// the first response per key and user is stored and replayed for the retries of the same request,
+// reusing the key for a different request is rejected. Responses marked with handler.NoStore are not
+// stored and the key is released. Keys of anonymous requests are ignored. It must run after the authentication.
+func Idempotency(idempotencyRepo repo.Idempotency) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			key := req.Header.Get(HeaderIdempotencyKey)
+			// Anonymous requests such as the sign up have no user to scope the key, different clients
+			// sending the same key would get each other's responses, so their keys are ignored
+			user, ok := c.Get("user").(*ct.ContextUser)
+			if req.Method != http.MethodPost || key == "" || !ok {
+				return next(c)
+			}
+			if len(key) > static.Idempotency.MaxKeyLength {
+				return static.ErrIdempotencyKeyInvalid
+			}
+
+			// The body is held in memory to be hashed, so its size is capped before reading it
+			maxBodySize := viper.GetInt64(static.EnvIdempotencyMaxBodySize)
+			if maxBodySize <= 0 {
+				maxBodySize = static.Idempotency.MaxBodySize
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(c.Response(), req.Body, maxBodySize))
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return static.ErrIdempotencyBodyTooLarge
+			}
+			if err != nil {
+				return err
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+
+			checksum := sha256.Sum256(append([]byte(req.Method+" "+req.URL.RequestURI()+"\n"), body...))
+
+			ttl := viper.GetDuration(static.EnvIdempotencyTTL)
+			if ttl <= 0 {
+				ttl = static.Idempotency.TTL
+			}
+
+			record := &model.IdempotencyKey{
+				Key:         key,
+				UserID:      user.ID,
+				Method:      req.Method,
+				Path:        req.URL.Path,
+				RequestHash: hex.EncodeToString(checksum[:]),
+				ExpiresAt:   time.Now().Add(ttl),
+			}
+
+			stored, reserved, err := idempotencyRepo.Reserve(req.Context(), record)
+			if err != nil {
+				return static.ErrDatabaseOperation.Wrap(err)
+			}
+			if !reserved {
+				return replay(c, stored, record.RequestHash)
+			}
+
+			// The key is released unless the response is stored, also when the handler panics
+			// since the recovery runs outside of this middleware
+			completed := false
+			defer func() {
+				if !completed {
+					_ = idempotencyRepo.Release(context.WithoutCancel(req.Context()), stored.ID)
+				}
+			}()
+
+			writer := &captureWriter{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = writer
+
+			// Write the error response here so it is captured like any other response
+			if err = next(c); err != nil {
+				c.Error(err)
+			}
+
+			status := c.Response().Status
+			if status >= http.StatusInternalServerError || errors.Is(err, static.ErrConcurrentUpdate) {
+				// Server errors and concurrent updates are not final, let the client retry with the same key
+				return nil
+			}
+			if strings.Contains(c.Response().Header().Get(echo.HeaderCacheControl), "no-store") {
+				// Responses carrying credentials such as tokens, secrets or recovery codes are never kept
+				return nil
+			}
+
+			stored.StatusCode = status
+			stored.ContentType = c.Response().Header().Get(echo.HeaderContentType)
+			stored.Body = writer.body.Bytes()
+			if err = idempotencyRepo.Complete(req.Context(), stored); err != nil {
+				c.Logger().Errorf("idempotency key %s: %v", key, err)
+				return nil
+			}
+			completed = true
+
+			return nil
+		}
+	}
+}
+
+// replay writes the stored response of the key when the retry is the same request
+func replay(c echo.Context, stored *model.IdempotencyKey, requestHash string) error {
+	if stored.RequestHash != requestHash {
+		return static.ErrIdempotencyKeyReused
+	}
+	if stored.CompletedAt == nil {
+		return static.ErrIdempotencyKeyInProgress
+	}
+
+	c.Response().Header().Set(HeaderIdempotencyReplayed, "true")
+	if stored.ContentType == "" {
+		return c.NoContent(stored.StatusCode)
+	}
+
+	return c.Blob(stored.StatusCode, stored.ContentType, stored.Body)
+}
@@ -0,0 +1,214 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/spf13/viper"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	ct "golang-project/internal/contract"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	"golang-project/static"
+)
+
+// idempotencyKeys keeps the keys in memory, like the unique index of the key per user
+type idempotencyKeys struct {
+	repo.Idempotency
+	mu   sync.Mutex
+	keys map[string]model.IdempotencyKey
+}
+
+func (r *idempotencyKeys) Reserve(_ context.Context, o *model.IdempotencyKey) (*model.IdempotencyKey, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if stored, ok := r.keys[o.Key+o.UserID.Hex()]; ok {
+		return &stored, false, nil
+	}
+
+	o.ID = primitive.NewObjectID()
+	r.keys[o.Key+o.UserID.Hex()] = *o
+	reserved := *o
+	return &reserved, true, nil
+}
+
+func (r *idempotencyKeys) Complete(_ context.Context, o *model.IdempotencyKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	o.CompletedAt = &now
+	r.keys[o.Key+o.UserID.Hex()] = *o
+	return nil
+}
+
+func (r *idempotencyKeys) Release(_ context.Context, id primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for k, stored := range r.keys {
+		if stored.ID == id && stored.CompletedAt == nil {
+			delete(r.keys, k)
+		}
+	}
+	return nil
+}
+
+// newIdempotentServer returns the server running the handler behind the idempotency middleware,
+// the requests are sent by the same signed in user unless they carry the Anonymous header
+func newIdempotentServer(handler echo.HandlerFunc) *echo.Echo {
+	userID := primitive.NewObjectID()
+
+	e := echo.New()
+	e.HTTPErrorHandler = ErrorHandler
+	e.Use(
+		Recover(),
+		func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				if c.Request().Header.Get("Anonymous") == "" {
+					c.Set("user", &ct.ContextUser{ID: userID})
+				}
+				return next(c)
+			}
+		},
+		Idempotency(&idempotencyKeys{keys: map[string]model.IdempotencyKey{}}),
+	)
+	e.POST("/v1/posts", handler)
+
+	return e
+}
+
+// send posts the body with the idempotency key and returns the response
+func send(e *echo.Echo, key, body string, header ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/v1/posts", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(HeaderIdempotencyKey, key)
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestIdempotencyReplaysTheStoredResponse(t *testing.T) {
+	calls := 0
+	e := newIdempotentServer(func(c echo.Context) error {
+		calls++
+		return c.JSON(http.StatusCreated, map[string]int{"call": calls})
+	})
+
+	first := send(e, "key-1", `{"title":"Generics"}`)
+	retry := send(e, "key-1", `{"title":"Generics"}`)
+
+	if calls != 1 {
+		t.Errorf("handler calls = %d, want 1", calls)
+	}
+	if retry.Code != first.Code || retry.Body.String() != first.Body.String() {
+		t.Errorf("replay = %d %s, want %d %s", retry.Code, retry.Body, first.Code, first.Body)
+	}
+	if retry.Header().Get(HeaderIdempotencyReplayed) != "true" {
+		t.Errorf("%s = %q, want true", HeaderIdempotencyReplayed, retry.Header().Get(HeaderIdempotencyReplayed))
+	}
+}
+
+func TestIdempotencyRefusesAnotherBodyUnderTheSameKey(t *testing.T) {
+	e := newIdempotentServer(func(c echo.Context) error {
+		return c.NoContent(http.StatusCreated)
+	})
+
+	send(e, "key-1", `{"title":"Generics"}`)
+	if rec := send(e, "key-1", `{"title":"Iterators"}`); rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("code = %d, want %d", rec.Code, http.StatusUnprocessableEntity)
+	}
+}
+
+func TestIdempotencyRefusesARetryWhileTheFirstRequestRuns(t *testing.T) {
+	started, finish := make(chan struct{}), make(chan struct{})
+	e := newIdempotentServer(func(c echo.Context) error {
+		close(started)
+		<-finish
+		return c.NoContent(http.StatusCreated)
+	})
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- send(e, "key-1", `{}`) }()
+	<-started
+
+	if rec := send(e, "key-1", `{}`); rec.Code != http.StatusConflict {
+		t.Errorf("code while in progress = %d, want %d", rec.Code, http.StatusConflict)
+	}
+
+	close(finish)
+	if rec := <-done; rec.Code != http.StatusCreated {
+		t.Errorf("code of the first request = %d, want %d", rec.Code, http.StatusCreated)
+	}
+}
+
+func TestIdempotencyReleasesTheKeyOfResponsesNotStored(t *testing.T) {
+	for name, handler := range map[string]func(c echo.Context) error{
+		"server error":      func(echo.Context) error { return static.ErrDatabaseOperation },
+		"concurrent update": func(echo.Context) error { return static.ErrConcurrentUpdate },
+		"panic":             func(echo.Context) error { panic("handler failed") },
+		"no-store": func(c echo.Context) error {
+			c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+			return c.JSON(http.StatusCreated, map[string]string{"token": "secret"})
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			e := newIdempotentServer(func(c echo.Context) error {
+				calls++
+				if calls == 1 {
+					return handler(c)
+				}
+				return c.NoContent(http.StatusCreated)
+			})
+
+			send(e, "key-1", `{}`)
+			rec := send(e, "key-1", `{}`)
+
+			if calls != 2 || rec.Code != http.StatusCreated || rec.Header().Get(HeaderIdempotencyReplayed) != "" {
+				t.Errorf("retry: handler calls = %d, code = %d, want the request to run again", calls, rec.Code)
+			}
+		})
+	}
+}
+
+func TestIdempotencyRefusesBodiesOverTheLimit(t *testing.T) {
+	viper.Set(static.EnvIdempotencyMaxBodySize, 16)
+	t.Cleanup(func() { viper.Set(static.EnvIdempotencyMaxBodySize, 0) })
+
+	e := newIdempotentServer(func(c echo.Context) error {
+		t.Error("the handler ran for a body over the limit")
+		return nil
+	})
+
+	if rec := send(e, "key-1", `{"title":"Generics and iterators"}`); rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("code = %d, want %d", rec.Code, http.StatusRequestEntityTooLarge)
+	}
+}
+
+func TestIdempotencyIgnoresTheKeysOfAnonymousRequests(t *testing.T) {
+	calls := 0
+	e := newIdempotentServer(func(c echo.Context) error {
+		calls++
+		return c.NoContent(http.StatusCreated)
+	})
+
+	send(e, "key-1", `{}`, "Anonymous", "true")
+	send(e, "key-1", `{}`, "Anonymous", "true")
+
+	if calls != 2 {
+		t.Errorf("handler calls = %d, want 2", calls)
+	}
+}
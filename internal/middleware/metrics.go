@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"golang-project/internal/metrics"
+	"golang-project/server"
+)
+
+// Metrics provides the middleware for recording request count and latency per handler registry route
+func Metrics(registries []server.HandlerRegistry) echo.MiddlewareFunc {
+	matcher := newRegistryMatcher(registries)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			metrics.HTTPRequestsInFlight.Inc()
+			defer metrics.HTTPRequestsInFlight.Dec()
+
+			err := next(c)
+
+			group := "other"
+			if r, ok := matcher.match(c.Request().URL.Path); ok {
+				group = r.Prefix()
+			}
+
+			route := c.Path()
+			if route == "" {
+				route = "unmatched"
+			}
+
+			method := c.Request().Method
+			code := strconv.Itoa(responseStatus(c, err))
+
+			metrics.HTTPRequestsTotal.WithLabelValues(group, route, method, code).Inc()
+			metrics.HTTPRequestDuration.WithLabelValues(group, route, method).Observe(time.Since(start).Seconds())
+
+			return err
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"sort"
+	"strings"
+
+	"golang-project/server"
+)
+
+// registryMatcher finds the handler registry serving a request path
+type registryMatcher struct {
+	registries []server.HandlerRegistry
+}
+
+// newRegistryMatcher returns the registry matcher preferring the longest route prefix
+func newRegistryMatcher(registries []server.HandlerRegistry) *registryMatcher {
+	sorted := append([]server.HandlerRegistry{}, registries...)
+	sort.SliceStable(sorted, func(i, j int) bool { return len(sorted[i].Prefix()) > len(sorted[j].Prefix()) })
+
+	return &registryMatcher{registries: sorted}
+}
+
+// match returns the handler registry whose prefix the path is mounted under
+func (m *registryMatcher) match(path string) (server.HandlerRegistry, bool) {
+	for _, r := range m.registries {
+		prefix := r.Prefix()
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return r, true
+		}
+	}
+
+	return server.HandlerRegistry{}, false
+}
@@ -1,12 +1,18 @@
 package middleware
 
 import (
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+
+	ct "golang-project/internal/contract"
+	"golang-project/util/apperror"
 )
 
 // Timeout provides the middleware for API timeout
@@ -24,21 +30,86 @@ func Recover() echo.MiddlewareFunc {
 	})
 }
 
+// IPExtractor returns how the client IP is read for the audit log and the access tokens from the comma
+// separated addresses or CIDR ranges of the trusted proxies. Without trusted proxies it is the address
+// of the connection, otherwise X-Forwarded-For is only read through them so a client cannot spoof its address.
+func IPExtractor(trustedProxies string) echo.IPExtractor {
+	var ranges []*net.IPNet
+	for _, proxy := range strings.Split(trustedProxies, ",") {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		if !strings.Contains(proxy, "/") {
+			if strings.Contains(proxy, ":") {
+				proxy += "/128"
+			} else {
+				proxy += "/32"
+			}
+		}
+
+		_, ipRange, err := net.ParseCIDR(proxy)
+		if err != nil {
+			fmt.Println("trusted proxy error:", proxy, err)
+			continue
+		}
+		ranges = append(ranges, ipRange)
+	}
+
+	if len(ranges) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, ipRange := range ranges {
+		options = append(options, echo.TrustIPRange(ipRange))
+	}
+
+	return echo.ExtractIPFromXFFHeader(options...)
+}
+
 // ErrorHandler provides custom error response when API encounters error
 func ErrorHandler(err error, e echo.Context) {
 	if e.Response().Committed {
 		return
 	}
 
-	httpCode := http.StatusInternalServerError
-	message := http.StatusText(http.StatusInternalServerError)
-	cid := e.Response().Header().Get(echo.HeaderXCorrelationID)
+	appErr := toAppError(err)
+	if appErr.Status >= http.StatusInternalServerError {
+		e.Logger().Errorf("cid %s: %v", e.Response().Header().Get(echo.HeaderXCorrelationID), err)
+	}
+
+	response := &ct.ErrorResponse{
+		CID:     e.Response().Header().Get(echo.HeaderXCorrelationID),
+		Code:    appErr.Code,
+		Message: appErr.Message,
+		Details: appErr.Fields,
+	}
+
+	if e.Request().Method == http.MethodHead {
+		_ = e.NoContent(appErr.Status)
+		return
+	}
+
+	_ = e.JSON(appErr.Status, response)
+}
+
+// toAppError converts the Echo HTTP error or any other error into the application error
+func toAppError(err error) *apperror.Error {
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		code := strings.ReplaceAll(strings.ToLower(http.StatusText(httpErr.Code)), " ", "_")
+		return apperror.New(httpErr.Code, code, httpErr.Error(), fmt.Sprintf("%v", httpErr.Message))
+	}
+
+	return apperror.From(err)
+}
 
-	httpErr, ok := err.(*echo.HTTPError)
-	if ok {
-		httpCode = httpErr.Code
-		message = fmt.Sprintf("%s", httpErr.Message)
+// responseStatus returns the status code the response is going to be written with
+func responseStatus(c echo.Context, err error) int {
+	if err == nil || c.Response().Committed {
+		return c.Response().Status
 	}
 
-	_ = e.JSON(httpCode, map[string]any{"cid": cid, "message": message})
+	return toAppError(err).Status
 }
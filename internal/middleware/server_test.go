@@ -0,0 +1,219 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	ct "golang-project/internal/contract"
+	"golang-project/static"
+	"golang-project/util/apperror"
+)
+
+// appErrors lists every application error declared in static, TestErrorHandlerCoversEveryDeclaredError
+// fails when an error is declared without being added here
+var appErrors = []*apperror.Error{
+	static.ErrValidation,
+	static.ErrInvalidCredentials,
+	static.ErrUserDisabled,
+	static.ErrUserPermission,
+	static.ErrPostOwner,
+	static.ErrListBloggerPosts,
+	static.ErrParamInvalid,
+	static.ErrReadTagID,
+	static.ErrHasPosts,
+	static.ErrTagNotFound,
+	static.ErrTagExists,
+	static.ErrUserNotFound,
+	static.ErrSelfFollow,
+	static.ErrDatabaseOperation,
+	static.ErrFollowStatusUpdate,
+	static.ErrUnsupportedFollowAction,
+	static.ErrGetFavouritePosts,
+	static.ErrGetFollowedBloggerPosts,
+	static.ErrFavouriteStatusUpdate,
+	static.ErrUnsupportedFavouriteAction,
+	static.ErrUserAlreadyHasRole,
+	static.ErrUserAlreadyDisabled,
+	static.ErrPostNotPublished,
+	static.ErrMergeSameTag,
+	static.ErrUnsupportedFeedFormat,
+	static.ErrBloggerNotFound,
+	static.ErrRenderFeed,
+	static.ErrDeletionAlreadyScheduled,
+	static.ErrDeletionNotScheduled,
+	static.ErrAlreadyReported,
+	static.ErrSelfReport,
+	static.ErrReportTargetNotFound,
+	static.ErrModeratorRequired,
+	static.ErrNoOpenReports,
+	static.ErrSelfModeration,
+	static.ErrPrivilegedTarget,
+	static.ErrSelfRelationship,
+	static.ErrUserBlocked,
+	static.ErrAlreadyBlocked,
+	static.ErrNotBlocked,
+	static.ErrAlreadyMuted,
+	static.ErrNotMuted,
+	static.ErrReactionTargetNotFound,
+	static.ErrReactionNotFound,
+	static.ErrExportNotFound,
+	static.ErrExportNotReady,
+	static.ErrExportExpired,
+	static.ErrExportFailed,
+	static.ErrEmailAlreadyExists,
+	static.ErrInvalidEmail,
+	static.ErrPasswordHashingFailed,
+	static.ErrSaveUserFailed,
+	static.ErrInvalidName,
+	static.ErrCheckEmailFailed,
+	static.ErrInsertPost,
+	static.ErrTagNotFoundOrDeleted,
+	static.ErrInsertPostTags,
+	static.ErrFetchPostDetail,
+	static.ErrPostNotFound,
+	static.ErrInvalidPostID,
+	static.ErrCommentNotFound,
+	static.ErrInvalidCommentID,
+	static.ErrVersionConflict,
+	static.ErrConcurrentUpdate,
+	static.ErrWebhookNotFound,
+	static.ErrWebhookDeliveryNotFound,
+	static.ErrWebhookAdminRequired,
+	static.ErrWebhookURLNotAllowed,
+	static.ErrOIDCDisabled,
+	static.ErrOIDCStateInvalid,
+	static.ErrOIDCAuthorizationFailed,
+	static.ErrOIDCEmailNotVerified,
+	static.ErrAccessTokenNotFound,
+	static.ErrAccessTokenInvalid,
+	static.ErrAccessTokenScope,
+	static.ErrAccessTokenLimit,
+	static.ErrSessionRequired,
+	static.ErrTwoFactorAlreadyEnabled,
+	static.ErrTwoFactorNotEnrolled,
+	static.ErrTwoFactorNotEnabled,
+	static.ErrTwoFactorCodeInvalid,
+	static.ErrReauthenticationRequired,
+	static.ErrTwoFactorLocked,
+	static.ErrTwoFactorChallengeInvalid,
+	static.ErrTwoFactorSecretSealing,
+	static.ErrOutboxEventNotFound,
+	static.ErrIdempotencyKeyInvalid,
+	static.ErrIdempotencyKeyReused,
+	static.ErrIdempotencyBodyTooLarge,
+	static.ErrIdempotencyKeyInProgress,
+	static.ErrInvalidPassword,
+	static.ErrComfirmPassword,
+}
+
+// newFailingServer returns the server answering every request with the error returned by fail
+func newFailingServer(fail func() error) *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = ErrorHandler
+	e.Use(Correlation())
+	e.Any("/v1/posts", func(echo.Context) error { return fail() })
+
+	return e
+}
+
+func TestErrorHandlerMapsTheApplicationErrors(t *testing.T) {
+	for _, appErr := range appErrors {
+		// The error is wrapped like the services wrap the cause
+		e := newFailingServer(func() error { return appErr.Wrap(errors.New("cause")) })
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/posts", nil)
+		req.Header.Set(echo.HeaderXCorrelationID, "cid-"+appErr.Code)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		var response ct.ErrorResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("%s: body %q: %v", appErr.Code, rec.Body, err)
+		}
+		if rec.Code != appErr.Status || response.Code != appErr.Code || response.Message != appErr.Message {
+			t.Errorf("%s: response = %d %+v, want %d %s %q", appErr.Code, rec.Code, response, appErr.Status, appErr.Code, appErr.Message)
+		}
+		if response.CID != "cid-"+appErr.Code || rec.Header().Get(echo.HeaderXCorrelationID) != response.CID {
+			t.Errorf("%s: cid = %q, header = %q, want the correlation ID of the request", appErr.Code, response.CID, rec.Header().Get(echo.HeaderXCorrelationID))
+		}
+	}
+}
+
+func TestErrorHandlerMapsOtherErrors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		err     error
+		status  int
+		code    string
+		details int
+	}{
+		"unknown":     {errors.New("connection reset"), http.StatusInternalServerError, apperror.CodeInternal, 0},
+		"echo":        {echo.NewHTTPError(http.StatusMethodNotAllowed, "method not allowed"), http.StatusMethodNotAllowed, "method_not_allowed", 0},
+		"with fields": {static.ErrTwoFactorCodeInvalid.WithField("code", "is invalid"), http.StatusUnauthorized, "two_factor_code_invalid", 1},
+	} {
+		rec := httptest.NewRecorder()
+		newFailingServer(func() error { return tc.err }).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/posts", nil))
+
+		var response ct.ErrorResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("%s: body %q: %v", name, rec.Body, err)
+		}
+		if rec.Code != tc.status || response.Code != tc.code || len(response.Details) != tc.details {
+			t.Errorf("%s: response = %d %+v, want %d %s with %d details", name, rec.Code, response, tc.status, tc.code, tc.details)
+		}
+		if response.CID == "" {
+			t.Errorf("%s: the response has no correlation ID", name)
+		}
+	}
+
+	// The cause of an unknown error is not sent to the client, and a HEAD response has no body
+	rec := httptest.NewRecorder()
+	newFailingServer(func() error { return errors.New("connection reset") }).ServeHTTP(rec, httptest.NewRequest(http.MethodHead, "/v1/posts", nil))
+	if rec.Code != http.StatusInternalServerError || rec.Body.Len() != 0 {
+		t.Errorf("HEAD: response = %d %q, want %d without a body", rec.Code, rec.Body, http.StatusInternalServerError)
+	}
+}
+
+func TestErrorHandlerCoversEveryDeclaredError(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "../../static/error.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	declared := map[string]bool{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) != 4 {
+			return true
+		}
+		if selector, ok := call.Fun.(*ast.SelectorExpr); !ok || selector.Sel.Name != "New" {
+			return true
+		}
+		if literal, ok := call.Args[1].(*ast.BasicLit); ok {
+			code, _ := strconv.Unquote(literal.Value)
+			declared[code] = true
+		}
+		return true
+	})
+
+	listed := map[string]bool{}
+	for _, appErr := range appErrors {
+		if listed[appErr.Code] {
+			t.Errorf("code %s is used by two errors", appErr.Code)
+		}
+		listed[appErr.Code] = true
+	}
+	for code := range declared {
+		if !listed[code] {
+			t.Errorf("error %s is declared in static but missing from appErrors", code)
+		}
+	}
+}
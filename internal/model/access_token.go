@@ -0,0 +1,24 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/static"
+)
+
+// AccessToken represents access_tokens collection from the database, only the SHA-256 hash of the token is kept
+// and the prefix is its first characters identifying it in the token list
+type AccessToken struct {
+	BaseModel
+	UserID     primitive.ObjectID        `bson:"user_id" json:"user_id"`
+	Name       string                    `bson:"name" json:"name"`
+	Scopes     []static.AccessTokenScope `bson:"scopes" json:"scopes"`
+	TokenHash  string                    `bson:"token_hash" json:"-"`
+	Prefix     string                    `bson:"prefix" json:"prefix"`
+	ExpiresAt  *time.Time                `bson:"expires_at" json:"expires_at"`
+	LastUsedAt *time.Time                `bson:"last_used_at,omitempty" json:"last_used_at,omitempty"`
+	LastUsedIP string                    `bson:"last_used_ip,omitempty" json:"last_used_ip,omitempty"`
+	RevokedAt  *time.Time                `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+}
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/static"
+)
+
+// AuditLog represents audit_logs collection from the database, the entries are only ever inserted.
+// The actor is empty for the actions of the system and of anonymous requests
+type AuditLog struct {
+	BaseModel
+	ActorID       *primitive.ObjectID    `bson:"actor_id,omitempty" json:"actor_id,omitempty"`
+	Action        static.AuditAction     `bson:"action" json:"action"`
+	TargetType    static.AuditTargetType `bson:"target_type,omitempty" json:"target_type,omitempty"`
+	TargetID      *primitive.ObjectID    `bson:"target_id,omitempty" json:"target_id,omitempty"`
+	Source        static.AuditSource     `bson:"source" json:"source"`
+	IP            string                 `bson:"ip,omitempty" json:"ip,omitempty"`
+	UserAgent     string                 `bson:"user_agent,omitempty" json:"user_agent,omitempty"`
+	CorrelationID string                 `bson:"correlation_id,omitempty" json:"correlation_id,omitempty"`
+	Metadata      map[string]string      `bson:"metadata,omitempty" json:"metadata,omitempty"`
+}
+
+// AuditLogFilter specifies the audit log entries to select, the empty fields match every entry.
+// UserID matches the entries where the user is either the actor or the target
+type AuditLogFilter struct {
+	ActorID  *primitive.ObjectID
+	TargetID *primitive.ObjectID
+	UserID   *primitive.ObjectID
+	Actions  []static.AuditAction
+	From     *time.Time
+	To       *time.Time
+}
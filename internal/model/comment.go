@@ -1,6 +1,8 @@
 package model
 
 import (
+	"time"
+
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -11,4 +13,5 @@ type Comment struct {
 	PostID          primitive.ObjectID  `bson:"post_id" json:"post_id"`
 	UserID          primitive.ObjectID  `bson:"user_id" json:"user_id"`
 	ParentCommentID *primitive.ObjectID `bson:"parent_comment_id,omitempty" json:"parent_comment_id,omitempty"`
+	HiddenAt        *time.Time          `bson:"hidden_at,omitempty" json:"hidden_at,omitempty"`
 }
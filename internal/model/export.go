@@ -0,0 +1,21 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/static"
+)
+
+// Export represents export collection from the database
+type Export struct {
+	BaseModel
+	UserID      primitive.ObjectID  `bson:"user_id" json:"user_id"`
+	Status      static.ExportStatus `bson:"status" json:"status"`
+	FileName    string              `bson:"file_name,omitempty" json:"file_name,omitempty"`
+	Size        int64               `bson:"size,omitempty" json:"size,omitempty"`
+	Error       string              `bson:"error,omitempty" json:"error,omitempty"`
+	CompletedAt *time.Time          `bson:"completed_at,omitempty" json:"completed_at,omitempty"`
+	ExpiresAt   *time.Time          `bson:"expires_at,omitempty" json:"expires_at,omitempty"`
+}
@@ -1,6 +1,8 @@
 package model
 
 import (
+	"time"
+
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -8,10 +10,12 @@ import (
 type FollowUser struct {
 	UserID       primitive.ObjectID `bson:"user_id" json:"user_id"`
 	FollowUserID primitive.ObjectID `bson:"follow_user_id" json:"follow_user_id"`
+	CreatedAt    *time.Time         `bson:"created_at,omitempty" json:"created_at,omitempty"`
 }
 
 // FavoritePost represents favorite_post collection from the database
 type FavoritePost struct {
-	PostID primitive.ObjectID `bson:"post_id" json:"post_id"`
-	UserID primitive.ObjectID `bson:"user_id" json:"user_id"`
+	PostID    primitive.ObjectID `bson:"post_id" json:"post_id"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	CreatedAt *time.Time         `bson:"created_at,omitempty" json:"created_at,omitempty"`
 }
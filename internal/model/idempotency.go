@@ -0,0 +1,23 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// IdempotencyKey represents idempotency_keys collection from the database, it holds the first
+// response to a request sent with the Idempotency-Key header so retries can be replayed
+type IdempotencyKey struct {
+	BaseModel
+	Key         string             `bson:"key" json:"key"`
+	UserID      primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Method      string             `bson:"method" json:"method"`
+	Path        string             `bson:"path" json:"path"`
+	RequestHash string             `bson:"request_hash" json:"request_hash"`
+	StatusCode  int                `bson:"status_code,omitempty" json:"status_code,omitempty"`
+	ContentType string             `bson:"content_type,omitempty" json:"content_type,omitempty"`
+	Body        []byte             `bson:"body,omitempty" json:"body,omitempty"`
+	CompletedAt *time.Time         `bson:"completed_at,omitempty" json:"completed_at,omitempty"`
+	ExpiresAt   time.Time          `bson:"expires_at" json:"expires_at"`
+}
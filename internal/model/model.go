@@ -6,10 +6,12 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-// BaseModel represents the fundamental fields in all database collections
+// BaseModel represents the fundamental fields in all database collections,
+// Version is incremented on every update of the document and is missing until the first update
 type BaseModel struct {
 	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
 	CreatedAt *time.Time         `bson:"created_at,omitempty" json:"created_at,omitempty"`
 	UpdatedAt *time.Time         `bson:"updated_at,omitempty" json:"updated_at,omitempty"`
 	DeletedAt *time.Time         `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+	Version   int64              `bson:"version,omitempty" json:"version,omitempty"`
 }
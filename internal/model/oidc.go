@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// OIDCState represents oidc_states collection from the database, it keeps the nonce and the PKCE verifier
+// of a started OpenID Connect sign-in until the provider redirects back with the state
+type OIDCState struct {
+	BaseModel
+	State        string     `bson:"state" json:"state"`
+	Nonce        string     `bson:"nonce" json:"-"`
+	CodeVerifier string     `bson:"code_verifier" json:"-"`
+	ExpiresAt    *time.Time `bson:"expires_at" json:"expires_at"`
+}
@@ -0,0 +1,21 @@
+package model
+
+import (
+	"time"
+
+	"golang-project/static"
+)
+
+// OutboxEvent represents outbox collection from the database, the events are written with the changes
+// that raised them and dispatched to the subscribers by the relay
+type OutboxEvent struct {
+	BaseModel
+	Name          static.EventName    `bson:"name" json:"name"`
+	Payload       []byte              `bson:"payload" json:"payload"`
+	Status        static.OutboxStatus `bson:"status" json:"status"`
+	Attempts      int                 `bson:"attempts" json:"attempts"`
+	Handled       []string            `bson:"handled,omitempty" json:"handled,omitempty"`
+	LastError     string              `bson:"last_error,omitempty" json:"last_error,omitempty"`
+	NextAttemptAt *time.Time          `bson:"next_attempt_at,omitempty" json:"next_attempt_at,omitempty"`
+	DispatchedAt  *time.Time          `bson:"dispatched_at,omitempty" json:"dispatched_at,omitempty"`
+}
@@ -1,16 +1,21 @@
 package model
 
 import (
+	"time"
+
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // Post represents post collection from the database
 type Post struct {
 	BaseModel
-	Title       string               `bson:"title" json:"title"`
-	Body        string               `bson:"body" json:"body"`
-	Slug        string               `bson:"slug" json:"slug"`
-	IsPublished bool                 `bson:"is_published" json:"is_published"`
-	UserID      primitive.ObjectID   `bson:"user_id" json:"user_id"`
-	TagIDs      []primitive.ObjectID `bson:"tag_ids" json:"tag_ids"`
+	Title          string               `bson:"title" json:"title"`
+	Body           string               `bson:"body" json:"body"`
+	Slug           string               `bson:"slug" json:"slug"`
+	IsPublished    bool                 `bson:"is_published" json:"is_published"`
+	UserID         primitive.ObjectID   `bson:"user_id" json:"user_id"`
+	TagIDs         []primitive.ObjectID `bson:"tag_ids" json:"tag_ids"`
+	HiddenAt       *time.Time           `bson:"hidden_at,omitempty" json:"hidden_at,omitempty"`
+	FavouriteCount int64                `bson:"favourite_count" json:"favourite_count"`
+	CommentCount   int64                `bson:"comment_count" json:"comment_count"`
 }
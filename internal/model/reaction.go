@@ -0,0 +1,23 @@
+package model
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/static"
+)
+
+// Reaction represents reactions collection from the database
+type Reaction struct {
+	BaseModel
+	UserID     primitive.ObjectID        `bson:"user_id" json:"user_id"`
+	TargetType static.ReactionTargetType `bson:"target_type" json:"target_type"`
+	TargetID   primitive.ObjectID        `bson:"target_id" json:"target_id"`
+	Type       static.ReactionType       `bson:"type" json:"type"`
+}
+
+// ReactionCount represents the number of reactions of a type left on a post or a comment
+type ReactionCount struct {
+	TargetID primitive.ObjectID  `bson:"target_id"`
+	Type     static.ReactionType `bson:"type"`
+	Count    int                 `bson:"count"`
+}
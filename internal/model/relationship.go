@@ -0,0 +1,19 @@
+package model
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// BlockUser represents blocks collection from the database
+type BlockUser struct {
+	BaseModel
+	UserID        primitive.ObjectID `bson:"user_id" json:"user_id"`
+	BlockedUserID primitive.ObjectID `bson:"blocked_user_id" json:"blocked_user_id"`
+}
+
+// MuteUser represents mutes collection from the database
+type MuteUser struct {
+	BaseModel
+	UserID      primitive.ObjectID `bson:"user_id" json:"user_id"`
+	MutedUserID primitive.ObjectID `bson:"muted_user_id" json:"muted_user_id"`
+}
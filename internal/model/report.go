@@ -0,0 +1,46 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/static"
+)
+
+// Report represents report collection from the database
+type Report struct {
+	BaseModel
+	ReporterID primitive.ObjectID      `bson:"reporter_id" json:"reporter_id"`
+	TargetType static.ReportTargetType `bson:"target_type" json:"target_type"`
+	TargetID   primitive.ObjectID      `bson:"target_id" json:"target_id"`
+	AuthorID   primitive.ObjectID      `bson:"author_id" json:"author_id"`
+	Reason     static.ReportReason     `bson:"reason" json:"reason"`
+	Details    string                  `bson:"details,omitempty" json:"details,omitempty"`
+	Status     static.ReportStatus     `bson:"status" json:"status"`
+	ActionID   *primitive.ObjectID     `bson:"action_id,omitempty" json:"action_id,omitempty"`
+	ResolvedAt *time.Time              `bson:"resolved_at,omitempty" json:"resolved_at,omitempty"`
+}
+
+// ReportGroup represents the open reports of a content aggregated by the moderation queue
+type ReportGroup struct {
+	TargetType      static.ReportTargetType `bson:"target_type"`
+	TargetID        primitive.ObjectID      `bson:"target_id"`
+	AuthorID        primitive.ObjectID      `bson:"author_id"`
+	Count           int                     `bson:"count"`
+	Reasons         []static.ReportReason   `bson:"reasons"`
+	FirstReportedAt time.Time               `bson:"first_reported_at"`
+	LastReportedAt  time.Time               `bson:"last_reported_at"`
+}
+
+// ModerationAction represents moderation_actions collection from the database
+type ModerationAction struct {
+	BaseModel
+	ModeratorID primitive.ObjectID          `bson:"moderator_id" json:"moderator_id"`
+	TargetType  static.ReportTargetType     `bson:"target_type" json:"target_type"`
+	TargetID    primitive.ObjectID          `bson:"target_id" json:"target_id"`
+	AuthorID    primitive.ObjectID          `bson:"author_id" json:"author_id"`
+	Action      static.ModerationActionType `bson:"action" json:"action"`
+	Note        string                      `bson:"note,omitempty" json:"note,omitempty"`
+	ReportCount int                         `bson:"report_count" json:"report_count"`
+}
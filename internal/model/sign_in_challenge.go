@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SignInChallenge represents sign_in_challenges collection from the database, the pending sign-in of a user
+// with two-factor authentication between the first factor and the code. Only the hash of the token is kept
+type SignInChallenge struct {
+	BaseModel
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	TokenHash string             `bson:"token_hash" json:"-"`
+	Method    string             `bson:"method" json:"method"`
+	Attempts  int                `bson:"attempts" json:"attempts"`
+	ExpiresAt *time.Time         `bson:"expires_at" json:"expires_at"`
+}
@@ -1,7 +1,11 @@
 package model
 
-// Tag represents tag collection from the database
+import "time"
+
+// Tag represents tag collection from the database,
+// LinkedAt is written when a post is labelled with the tag so the labelling conflicts with a concurrent delete
 type Tag struct {
 	BaseModel
-	Name string `bson:"name" json:"name"`
+	Name     string     `bson:"name" json:"name"`
+	LinkedAt *time.Time `bson:"linked_at,omitempty" json:"linked_at,omitempty"`
 }
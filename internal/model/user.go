@@ -1,14 +1,50 @@
 package model
 
+import (
+	"time"
+
+	"golang-project/static"
+)
+
 // User represents user collection from the database
 type User struct {
 	BaseModel
-	FirstName    string `bson:"first_name" json:"first_name"`
-	LastName     string `bson:"last_name" json:"last_name"`
-	Email        string `bson:"email" json:"email"`
-	Password     string `bson:"password" json:"password"`
-	Pseudonym    string `bson:"pseudonym" json:"pseudonym"`
-	ProfileImage string `bson:"profile_image" json:"profile_image"`
-	Biography    string `bson:"biography" json:"biography"`
-	IsVerified   bool   `bson:"is_verified" json:"is_verified"`
+	FirstName           string              `bson:"first_name" json:"first_name"`
+	LastName            string              `bson:"last_name" json:"last_name"`
+	Email               string              `bson:"email" json:"email"`
+	Password            string              `bson:"password" json:"password"`
+	Pseudonym           string              `bson:"pseudonym" json:"pseudonym"`
+	ProfileImage        string              `bson:"profile_image" json:"profile_image"`
+	Biography           string              `bson:"biography" json:"biography"`
+	IsVerified          bool                `bson:"is_verified" json:"is_verified"`
+	Role                static.UserRole     `bson:"role,omitempty" json:"role,omitempty"`
+	DisabledAt          *time.Time          `bson:"disabled_at,omitempty" json:"disabled_at,omitempty"`
+	DeletionScheduledAt *time.Time          `bson:"deletion_scheduled_at,omitempty" json:"deletion_scheduled_at,omitempty"`
+	FollowerCount       int64               `bson:"follower_count" json:"follower_count"`
+	FollowingCount      int64               `bson:"following_count" json:"following_count"`
+	PostCount           int64               `bson:"post_count" json:"post_count"`
+	Identities          []*ExternalIdentity `bson:"identities,omitempty" json:"identities,omitempty"`
+	TwoFactor           *TwoFactor          `bson:"two_factor,omitempty" json:"-"`
+	// SessionsRevokedAt ends the JWTs issued and the access tokens created before it
+	SessionsRevokedAt *time.Time `bson:"sessions_revoked_at,omitempty" json:"-"`
+}
+
+// ExternalIdentity represents the account of the user at an OpenID Connect provider
+type ExternalIdentity struct {
+	Issuer   string     `bson:"issuer" json:"issuer"`
+	Subject  string     `bson:"subject" json:"subject"`
+	Email    string     `bson:"email" json:"email"`
+	LinkedAt *time.Time `bson:"linked_at,omitempty" json:"linked_at,omitempty"`
+}
+
+// TwoFactor represents the TOTP two-factor authentication of the user, it is enabled once the enrolment
+// is confirmed. The last used time step prevents replaying a code and the recovery codes are stored hashed
+type TwoFactor struct {
+	Secret        string     `bson:"secret" json:"-"`
+	EnabledAt     *time.Time `bson:"enabled_at,omitempty" json:"enabled_at,omitempty"`
+	LastUsedStep  int64      `bson:"last_used_step" json:"-"`
+	RecoveryCodes []string   `bson:"recovery_codes,omitempty" json:"-"`
+	// FailedCodes counts the invalid codes since the last valid one, reaching the maximum locks the codes until LockedUntil
+	FailedCodes int        `bson:"failed_codes,omitempty" json:"-"`
+	LockedUntil *time.Time `bson:"locked_until,omitempty" json:"-"`
 }
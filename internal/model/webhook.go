@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/static"
+)
+
+// Webhook represents webhooks collection from the database, the secret signs the deliveries
+// so it is kept in clear and only returned when the webhook is created
+type Webhook struct {
+	BaseModel
+	UserID   primitive.ObjectID    `bson:"user_id" json:"user_id"`
+	URL      string                `bson:"url" json:"url"`
+	Events   []static.WebhookEvent `bson:"events" json:"events"`
+	AllUsers bool                  `bson:"all_users" json:"all_users"`
+	Secret   string                `bson:"secret" json:"-"`
+}
+
+// WebhookDelivery represents webhook_deliveries collection from the database
+type WebhookDelivery struct {
+	BaseModel
+	WebhookID      primitive.ObjectID           `bson:"webhook_id" json:"webhook_id"`
+	UserID         primitive.ObjectID           `bson:"user_id" json:"user_id"`
+	Event          static.WebhookEvent          `bson:"event" json:"event"`
+	Payload        []byte                       `bson:"payload" json:"payload"`
+	Status         static.WebhookDeliveryStatus `bson:"status" json:"status"`
+	Attempts       int                          `bson:"attempts" json:"attempts"`
+	LastStatusCode int                          `bson:"last_status_code,omitempty" json:"last_status_code,omitempty"`
+	LastError      string                       `bson:"last_error,omitempty" json:"last_error,omitempty"`
+	NextAttemptAt  *time.Time                   `bson:"next_attempt_at,omitempty" json:"next_attempt_at,omitempty"`
+	DeliveredAt    *time.Time                   `bson:"delivered_at,omitempty" json:"delivered_at,omitempty"`
+	RedeliveryOf   *primitive.ObjectID          `bson:"redelivery_of,omitempty" json:"redelivery_of,omitempty"`
+}
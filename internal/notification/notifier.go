@@ -0,0 +1,37 @@
+package notification
+
+import (
+	"context"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Message represents the notification delivered to a user
+type Message struct {
+	UserID  primitive.ObjectID
+	Email   string
+	Subject string
+	Body    string
+	Link    string
+}
+
+// Notifier represents the delivery channel of user notifications
+type Notifier interface {
+	Notify(context.Context, *Message) error
+}
+
+// logNotifier is an implementation of Notifier writing the notifications to the server log
+type logNotifier struct{}
+
+// NewLogNotifier returns the Notifier writing the notifications to the server log,
+// it stands in until an email delivery channel is configured
+func NewLogNotifier() Notifier {
+	return &logNotifier{}
+}
+
+// Notify writes the notification to the server log
+func (n *logNotifier) Notify(ctx context.Context, m *Message) error {
+	log.Printf("notification to %s (%s): %s %s", m.Email, m.UserID.Hex(), m.Subject, m.Link)
+	return nil
+}
@@ -0,0 +1,245 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"golang-project/static"
+)
+
+// ErrDisabled is returned by the provider when no issuer is configured
+var ErrDisabled = errors.New("oidc: no issuer configured")
+
+// Claims represents the verified claims of the ID token
+type Claims struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	GivenName     string
+	FamilyName    string
+	Name          string
+}
+
+// Provider represents the OpenID Connect provider signing the users in with the authorization code flow and PKCE
+type Provider interface {
+	// AuthorizationURL returns the URL of the provider the user is redirected to
+	AuthorizationURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+	// Exchange redeems the authorization code with the PKCE verifier and returns the claims of the verified ID token
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*Claims, error)
+}
+
+// Config represents the client registration at the provider
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// metadata represents the provider configuration published at the discovery endpoint
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// provider is an implementation of Provider discovering the provider configuration on first use
+type provider struct {
+	config       Config
+	client       *http.Client
+	mu           sync.Mutex
+	metadata     *metadata
+	keys         *keySet
+	discoveredAt time.Time
+}
+
+// NewProvider returns the Provider of the configuration, the provider is disabled without an issuer
+func NewProvider(config Config) Provider {
+	return &provider{
+		config: config,
+		client: &http.Client{Timeout: static.OIDC.Timeout},
+	}
+}
+
+// NewProviderFromEnv returns the Provider configured by the OIDC_* variables,
+// the redirect URL defaults to the callback route on SERVER_BASE_URL
+func NewProviderFromEnv() Provider {
+	redirectURL := viper.GetString(static.EnvOIDCRedirectURL)
+	if redirectURL == "" {
+		redirectURL = strings.TrimSuffix(viper.GetString(static.EnvServerBaseURL), "/") + static.OIDC.CallbackPath
+	}
+
+	scopes := viper.GetString(static.EnvOIDCScopes)
+	if scopes == "" {
+		scopes = static.OIDC.Scopes
+	}
+
+	return NewProvider(Config{
+		Issuer:       strings.TrimSuffix(viper.GetString(static.EnvOIDCIssuer), "/"),
+		ClientID:     viper.GetString(static.EnvOIDCClientID),
+		ClientSecret: viper.GetString(static.EnvOIDCClientSecret),
+		RedirectURL:  redirectURL,
+		Scopes:       strings.Fields(scopes),
+	})
+}
+
+// AuthorizationURL returns the authorization endpoint URL with the S256 code challenge
+func (p *provider) AuthorizationURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	m, _, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.config.ClientID},
+		"redirect_uri":          {p.config.RedirectURL},
+		"scope":                 {strings.Join(p.config.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+
+	separator := "?"
+	if strings.Contains(m.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	return m.AuthorizationEndpoint + separator + query.Encode(), nil
+}
+
+// Exchange redeems the code at the token endpoint and verifies the returned ID token
+func (p *provider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*Claims, error) {
+	m, keys, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.config.RedirectURL},
+		"code_verifier": {codeVerifier},
+	}
+	// Confidential clients authenticate with HTTP basic, public clients only identify themselves
+	if p.config.ClientSecret == "" {
+		form.Set("client_id", p.config.ClientID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.config.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+	}
+
+	var token struct {
+		IDToken string `json:"id_token"`
+	}
+	if err = doJSON(p.client, req, &token); err != nil {
+		return nil, fmt.Errorf("oidc: token exchange: %w", err)
+	}
+	if token.IDToken == "" {
+		return nil, errors.New("oidc: token response has no id_token")
+	}
+
+	claims, err := verifyIDToken(ctx, token.IDToken, keys, m.Issuer, p.config.ClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.nonce != nonce {
+		return nil, errors.New("oidc: id_token nonce does not match")
+	}
+
+	return &claims.Claims, nil
+}
+
+// discover returns the provider configuration and signing keys, fetched again once they are older than the discovery TTL
+func (p *provider) discover(ctx context.Context) (*metadata, *keySet, error) {
+	if p.config.Issuer == "" {
+		return nil, nil, ErrDisabled
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.metadata != nil && time.Since(p.discoveredAt) < static.OIDC.DiscoveryTTL {
+		return p.metadata, p.keys, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.config.Issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var m metadata
+	if err = doJSON(p.client, req, &m); err != nil {
+		return nil, nil, fmt.Errorf("oidc: discovery: %w", err)
+	}
+	if strings.TrimSuffix(m.Issuer, "/") != p.config.Issuer {
+		return nil, nil, fmt.Errorf("oidc: discovered issuer %q does not match %q", m.Issuer, p.config.Issuer)
+	}
+
+	p.metadata = &m
+	p.keys = newKeySet(p.client, m.JWKSURI)
+	p.discoveredAt = time.Now()
+
+	return p.metadata, p.keys, nil
+}
+
+// doJSON sends the request and decodes the JSON response, non 2xx responses are errors
+func doJSON(client *http.Client, req *http.Request, v interface{}) error {
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%s: %s", res.Status, strings.TrimSpace(string(body)))
+	}
+
+	return json.Unmarshal(body, v)
+}
+
+// RandomString returns a URL safe random string of the entropy in bytes, used for the state, nonce and verifier
+func RandomString(entropy int) (string, error) {
+	b := make([]byte, entropy)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// CodeChallenge returns the S256 PKCE challenge of the verifier
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
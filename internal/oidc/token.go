@@ -0,0 +1,186 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// idTokenClaims represents the ID token claims read by the verification
+type idTokenClaims struct {
+	jwt.StandardClaims
+	Nonce         string      `json:"nonce"`
+	Email         string      `json:"email"`
+	EmailVerified interface{} `json:"email_verified"`
+	GivenName     string      `json:"given_name"`
+	FamilyName    string      `json:"family_name"`
+	Name          string      `json:"name"`
+	Audiences     audience    `json:"aud"`
+}
+
+// verifiedClaims represents the claims of a verified ID token with its nonce
+type verifiedClaims struct {
+	Claims
+	nonce string
+}
+
+// audience represents the aud claim, a single audience or a list of audiences
+type audience []string
+
+// UnmarshalJSON reads the audience from a string or an array of strings
+func (a *audience) UnmarshalJSON(b []byte) error {
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(b, &list); err != nil {
+		return err
+	}
+	*a = list
+
+	return nil
+}
+
+// verifyIDToken verifies the signature, issuer, audience and lifetime of the ID token and returns its claims
+func verifyIDToken(ctx context.Context, raw string, keys *keySet, issuer, clientID string) (*verifiedClaims, error) {
+	claims := &idTokenClaims{}
+	parser := &jwt.Parser{ValidMethods: []string{"RS256", "RS384", "RS512"}}
+
+	_, err := parser.ParseWithClaims(raw, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return keys.key(ctx, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("oidc: id_token: %w", err)
+	}
+
+	if claims.Issuer != issuer {
+		return nil, fmt.Errorf("oidc: id_token issuer %q does not match", claims.Issuer)
+	}
+
+	audienceMatch := false
+	for _, aud := range claims.Audiences {
+		audienceMatch = audienceMatch || aud == clientID
+	}
+	if !audienceMatch {
+		return nil, errors.New("oidc: id_token audience does not contain the client")
+	}
+
+	if claims.Subject == "" {
+		return nil, errors.New("oidc: id_token has no subject")
+	}
+
+	// Some providers send email_verified as a string
+	verified := claims.EmailVerified == true || claims.EmailVerified == "true"
+
+	return &verifiedClaims{
+		Claims: Claims{
+			Issuer:        claims.Issuer,
+			Subject:       claims.Subject,
+			Email:         claims.Email,
+			EmailVerified: verified,
+			GivenName:     claims.GivenName,
+			FamilyName:    claims.FamilyName,
+			Name:          claims.Name,
+		},
+		nonce: claims.Nonce,
+	}, nil
+}
+
+// keySet represents the RSA signing keys of the provider, fetched again when a token uses an unknown key ID
+type keySet struct {
+	client *http.Client
+	uri    string
+	mu     sync.Mutex
+	keys   map[string]*rsa.PublicKey
+}
+
+// newKeySet returns the key set published at the JWKS URI
+func newKeySet(client *http.Client, uri string) *keySet {
+	return &keySet{client: client, uri: uri}
+}
+
+// key returns the public key of the key ID, a token without key ID is accepted when the set holds a single key
+func (s *keySet) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if k, ok := s.lookup(kid); ok {
+		return k, nil
+	}
+
+	if err := s.fetch(ctx); err != nil {
+		return nil, err
+	}
+
+	if k, ok := s.lookup(kid); ok {
+		return k, nil
+	}
+
+	return nil, fmt.Errorf("oidc: unknown signing key %q", kid)
+}
+
+// lookup returns the cached key of the key ID
+func (s *keySet) lookup(kid string) (*rsa.PublicKey, bool) {
+	if kid == "" && len(s.keys) == 1 {
+		for _, k := range s.keys {
+			return k, true
+		}
+	}
+
+	k, ok := s.keys[kid]
+	return k, ok
+}
+
+// fetch replaces the cached keys with the RSA keys published at the JWKS URI
+func (s *keySet) fetch(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.uri, nil)
+	if err != nil {
+		return err
+	}
+
+	var document struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err = doJSON(s.client, req, &document); err != nil {
+		return fmt.Errorf("oidc: jwks: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(document.Keys))
+	for _, k := range document.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			continue
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			continue
+		}
+
+		keys[k.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+	s.keys = keys
+
+	return nil
+}
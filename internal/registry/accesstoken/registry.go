@@ -0,0 +1,18 @@
+package accesstoken
+
+import (
+	"golang-project/database"
+	"golang-project/internal/audit"
+	"golang-project/internal/handler"
+	hdl "golang-project/internal/handler/accesstoken"
+	accessTokenRepo "golang-project/internal/repository/accesstoken"
+	auditRepo "golang-project/internal/repository/audit"
+	userRepo "golang-project/internal/repository/user"
+	svc "golang-project/internal/service/accesstoken"
+)
+
+// NewRegistry returns new resource handler for personal access token API
+func NewRegistry(route string, db database.Connection) handler.ResourceHandler {
+	accessTokenSvc := svc.NewService(userRepo.NewRepository(db), accessTokenRepo.NewRepository(db), audit.NewRecorder(auditRepo.NewRepository(db)))
+	return hdl.NewHandler(route, accessTokenSvc)
+}
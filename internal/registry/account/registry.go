@@ -0,0 +1,40 @@
+package account
+
+import (
+	"golang-project/database"
+	"golang-project/internal/audit"
+	"golang-project/internal/event"
+	"golang-project/internal/handler"
+	hdl "golang-project/internal/handler/account"
+	"golang-project/internal/notification"
+	accountRepo "golang-project/internal/repository/account"
+	auditRepo "golang-project/internal/repository/audit"
+	exportRepo "golang-project/internal/repository/export"
+	userRepo "golang-project/internal/repository/user"
+	svc "golang-project/internal/service/account"
+	exportSvc "golang-project/internal/service/export"
+	twoFactorSvc "golang-project/internal/service/twofactor"
+	"golang-project/internal/worker"
+	"golang-project/util/hashing"
+)
+
+// NewRegistry returns new resource handler for account API and registers the account deletion job
+func NewRegistry(route string, db database.Connection, publisher event.Publisher, workers *worker.Group) handler.ResourceHandler {
+	notifier := notification.NewLogNotifier()
+	users := userRepo.NewRepository(db)
+	unitOfWork := database.NewUnitOfWork(db)
+	auditor := audit.NewRecorder(auditRepo.NewRepository(db))
+	accountSvc := svc.NewService(
+		users,
+		accountRepo.NewRepository(db),
+		exportSvc.NewService(exportRepo.NewRepository(db), notifier),
+		twoFactorSvc.NewService(users, hashing.NewBcrypt(), unitOfWork, publisher, auditor),
+		unitOfWork,
+		publisher,
+		notifier,
+		auditor,
+	)
+	workers.Register("account-deletion", accountSvc.Work)
+
+	return hdl.NewHandler(route, accountSvc)
+}
@@ -0,0 +1,15 @@
+package audit
+
+import (
+	"golang-project/database"
+	"golang-project/internal/handler"
+	hdl "golang-project/internal/handler/audit"
+	auditRepo "golang-project/internal/repository/audit"
+	userRepo "golang-project/internal/repository/user"
+	svc "golang-project/internal/service/audit"
+)
+
+// NewRegistry returns new resource handler for audit log API
+func NewRegistry(route string, db database.Connection) handler.ResourceHandler {
+	return hdl.NewHandler(route, svc.NewService(userRepo.NewRepository(db), auditRepo.NewRepository(db)))
+}
@@ -1,14 +1,52 @@
 package authentication
 
 import (
+	"context"
+
+	"golang-project/database"
+	"golang-project/internal/audit"
+	"golang-project/internal/event"
 	"golang-project/internal/handler"
 	hdl "golang-project/internal/handler/authentication"
+	"golang-project/internal/notification"
+	"golang-project/internal/oidc"
+	auditRepo "golang-project/internal/repository/audit"
+	challengeRepo "golang-project/internal/repository/challenge"
+	oidcRepo "golang-project/internal/repository/oidc"
 	repo "golang-project/internal/repository/user"
 	svc "golang-project/internal/service/authentication"
+	twoFactorSvc "golang-project/internal/service/twofactor"
 	"golang-project/util/hashing"
 )
 
-// NewRegistry returns new resource handler for authentication API
-func NewRegistry(route string) handler.ResourceHandler {
-	return hdl.NewHandler(route, svc.NewService(repo.NewRepository(), hashing.NewBcrypt()))
+// NewRegistry returns new resource handler for authentication API, the OpenID Connect sign-in is configured from env
+// and users with two-factor authentication complete their sign-in with a code, the new users are welcomed once signed up
+func NewRegistry(route string, db database.Connection, bus event.Bus) handler.ResourceHandler {
+	userRepo := repo.NewRepository(db)
+	hash := hashing.NewBcrypt()
+	auditor := audit.NewRecorder(auditRepo.NewRepository(db))
+
+	authenticationSvc := svc.NewService(
+		userRepo,
+		hash,
+		database.NewUnitOfWork(db),
+		bus,
+		auditor,
+		oidc.NewProviderFromEnv(),
+		oidcRepo.NewRepository(db),
+		challengeRepo.NewRepository(db),
+		twoFactorSvc.NewService(userRepo, hash, database.NewUnitOfWork(db), bus, auditor),
+	)
+
+	notifier := notification.NewLogNotifier()
+	event.On(bus, "welcome", func(ctx context.Context, e event.UserSignedUp) error {
+		return notifier.Notify(ctx, &notification.Message{
+			UserID:  e.UserID,
+			Email:   e.Email,
+			Subject: "Welcome",
+			Body:    "Your account has been created.",
+		})
+	})
+
+	return hdl.NewHandler(route, authenticationSvc)
 }
@@ -0,0 +1,33 @@
+package comment
+
+import (
+	"golang-project/database"
+	"golang-project/internal/event"
+	"golang-project/internal/handler"
+	hdl "golang-project/internal/handler/comment"
+	commentRepo "golang-project/internal/repository/comment"
+	postRepo "golang-project/internal/repository/post"
+	reactionRepo "golang-project/internal/repository/reaction"
+	relationshipRepo "golang-project/internal/repository/relationship"
+	userRepo "golang-project/internal/repository/user"
+	svc "golang-project/internal/service/comment"
+	reactionSvc "golang-project/internal/service/reaction"
+	relationshipSvc "golang-project/internal/service/relationship"
+)
+
+// NewRegistry returns new resource handler for comment API
+func NewRegistry(route string, db database.Connection, publisher event.Publisher) handler.ResourceHandler {
+	relationships := relationshipSvc.NewService(userRepo.NewRepository(db), relationshipRepo.NewRepository(db), database.NewUnitOfWork(db), publisher)
+
+	commentSvc := svc.NewService(
+		commentRepo.NewRepository(db),
+		postRepo.NewRepository(db),
+		userRepo.NewRepository(db),
+		relationships,
+		reactionSvc.NewService(reactionRepo.NewRepository(db), relationships, database.NewUnitOfWork(db), publisher),
+		database.NewUnitOfWork(db),
+		publisher,
+	)
+
+	return hdl.NewHandler(route, commentSvc)
+}
@@ -0,0 +1,19 @@
+package export
+
+import (
+	"golang-project/database"
+	"golang-project/internal/handler"
+	hdl "golang-project/internal/handler/export"
+	"golang-project/internal/notification"
+	repo "golang-project/internal/repository/export"
+	svc "golang-project/internal/service/export"
+	"golang-project/internal/worker"
+)
+
+// NewRegistry returns new resource handler for personal data export API and registers the archive builder
+func NewRegistry(route string, db database.Connection, workers *worker.Group) handler.ResourceHandler {
+	exportSvc := svc.NewService(repo.NewRepository(db), notification.NewLogNotifier())
+	workers.Register("export", exportSvc.Work)
+
+	return hdl.NewHandler(route, exportSvc)
+}
@@ -0,0 +1,30 @@
+package favourite
+
+import (
+	"golang-project/database"
+	"golang-project/internal/event"
+	"golang-project/internal/handler"
+	hdl "golang-project/internal/handler/favourite"
+	favouriteRepo "golang-project/internal/repository/favourite"
+	postRepo "golang-project/internal/repository/post"
+	relationshipRepo "golang-project/internal/repository/relationship"
+	tagRepo "golang-project/internal/repository/tag"
+	userRepo "golang-project/internal/repository/user"
+	svc "golang-project/internal/service/favourite"
+	relationshipSvc "golang-project/internal/service/relationship"
+)
+
+// NewRegistry returns new resource handler for follow and favourite API
+func NewRegistry(route string, db database.Connection, publisher event.Publisher) handler.ResourceHandler {
+	favouriteSvc := svc.NewService(
+		favouriteRepo.NewRepository(db),
+		userRepo.NewRepository(db),
+		postRepo.NewRepository(db),
+		tagRepo.NewRepository(db),
+		relationshipSvc.NewService(userRepo.NewRepository(db), relationshipRepo.NewRepository(db), database.NewUnitOfWork(db), publisher),
+		database.NewUnitOfWork(db),
+		publisher,
+	)
+
+	return hdl.NewHandler(route, favouriteSvc)
+}
@@ -0,0 +1,23 @@
+package feed
+
+import (
+	"golang-project/database"
+	"golang-project/internal/event"
+	"golang-project/internal/handler"
+	hdl "golang-project/internal/handler/feed"
+	repo "golang-project/internal/repository/feed"
+	relationshipRepo "golang-project/internal/repository/relationship"
+	userRepo "golang-project/internal/repository/user"
+	svc "golang-project/internal/service/feed"
+	relationshipSvc "golang-project/internal/service/relationship"
+)
+
+// NewRegistry returns new resource handler for syndication feed API
+func NewRegistry(route string, db database.Connection, publisher event.Publisher) handler.ResourceHandler {
+	feedSvc := svc.NewService(
+		repo.NewRepository(db),
+		relationshipSvc.NewService(userRepo.NewRepository(db), relationshipRepo.NewRepository(db), database.NewUnitOfWork(db), publisher),
+	)
+
+	return hdl.NewHandler(route, feedSvc)
+}
@@ -1,13 +1,12 @@
 package health
 
 import (
-	"golang-project/database"
-
 	"golang-project/internal/handler"
 	hdl "golang-project/internal/handler/health"
+	"golang-project/internal/healthcheck"
 )
 
 // NewRegistry returns new resource handler for health API
-func NewRegistry(route string, db database.Connection) handler.ResourceHandler {
-	return hdl.NewHandler(route, db)
+func NewRegistry(route string, checks *healthcheck.Registry) handler.ResourceHandler {
+	return hdl.NewHandler(route, checks)
 }
@@ -0,0 +1,28 @@
+package moderation
+
+import (
+	"golang-project/database"
+	"golang-project/internal/audit"
+	"golang-project/internal/event"
+	"golang-project/internal/handler"
+	hdl "golang-project/internal/handler/moderation"
+	"golang-project/internal/notification"
+	auditRepo "golang-project/internal/repository/audit"
+	moderationRepo "golang-project/internal/repository/moderation"
+	userRepo "golang-project/internal/repository/user"
+	svc "golang-project/internal/service/moderation"
+)
+
+// NewRegistry returns new resource handler for moderation API
+func NewRegistry(route string, db database.Connection, publisher event.Publisher) handler.ResourceHandler {
+	moderationSvc := svc.NewService(
+		userRepo.NewRepository(db),
+		moderationRepo.NewRepository(db),
+		notification.NewLogNotifier(),
+		audit.NewRecorder(auditRepo.NewRepository(db)),
+		database.NewUnitOfWork(db),
+		publisher,
+	)
+
+	return hdl.NewHandler(route, moderationSvc)
+}
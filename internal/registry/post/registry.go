@@ -0,0 +1,37 @@
+package post
+
+import (
+	"golang-project/database"
+	"golang-project/internal/cache"
+	"golang-project/internal/event"
+	"golang-project/internal/handler"
+	hdl "golang-project/internal/handler/post"
+	postRepo "golang-project/internal/repository/post"
+	reactionRepo "golang-project/internal/repository/reaction"
+	relationshipRepo "golang-project/internal/repository/relationship"
+	tagRepo "golang-project/internal/repository/tag"
+	userRepo "golang-project/internal/repository/user"
+	svc "golang-project/internal/service/post"
+	reactionSvc "golang-project/internal/service/reaction"
+	relationshipSvc "golang-project/internal/service/relationship"
+)
+
+// NewRegistry returns new resource handler for post API, the post details are read through the cache store
+func NewRegistry(route string, db database.Connection, store cache.Store, publisher event.Publisher) handler.ResourceHandler {
+	reactions := reactionSvc.NewService(
+		reactionRepo.NewRepository(db),
+		relationshipSvc.NewService(userRepo.NewRepository(db), relationshipRepo.NewRepository(db), database.NewUnitOfWork(db), publisher),
+		database.NewUnitOfWork(db),
+		publisher,
+	)
+
+	postSvc := svc.NewService(
+		postRepo.NewRepository(db),
+		tagRepo.NewRepository(db),
+		reactions,
+		database.NewUnitOfWork(db),
+		publisher,
+	)
+
+	return hdl.NewHandler(route, cache.NewPostService(postSvc, reactions, store))
+}
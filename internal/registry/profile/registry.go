@@ -0,0 +1,35 @@
+package profile
+
+import (
+	"golang-project/database"
+	"golang-project/internal/audit"
+	"golang-project/internal/cache"
+	"golang-project/internal/event"
+	"golang-project/internal/handler"
+	hdl "golang-project/internal/handler/profile"
+	auditRepo "golang-project/internal/repository/audit"
+	postRepo "golang-project/internal/repository/post"
+	reactionRepo "golang-project/internal/repository/reaction"
+	relationshipRepo "golang-project/internal/repository/relationship"
+	tagRepo "golang-project/internal/repository/tag"
+	userRepo "golang-project/internal/repository/user"
+	svc "golang-project/internal/service/profile"
+	reactionSvc "golang-project/internal/service/reaction"
+	relationshipSvc "golang-project/internal/service/relationship"
+)
+
+// NewRegistry returns new resource handler for profile API, the profiles are read through the cache store
+func NewRegistry(route string, db database.Connection, store cache.Store, publisher event.Publisher) handler.ResourceHandler {
+	relationships := relationshipSvc.NewService(userRepo.NewRepository(db), relationshipRepo.NewRepository(db), database.NewUnitOfWork(db), publisher)
+
+	profileSvc := svc.NewService(
+		userRepo.NewRepository(db),
+		postRepo.NewRepository(db),
+		tagRepo.NewRepository(db),
+		reactionSvc.NewService(reactionRepo.NewRepository(db), relationships, database.NewUnitOfWork(db), publisher),
+		relationships,
+		audit.NewRecorder(auditRepo.NewRepository(db)),
+	)
+
+	return hdl.NewHandler(route, cache.NewProfileService(profileSvc, store))
+}
@@ -0,0 +1,25 @@
+package reaction
+
+import (
+	"golang-project/database"
+	"golang-project/internal/event"
+	"golang-project/internal/handler"
+	hdl "golang-project/internal/handler/reaction"
+	reactionRepo "golang-project/internal/repository/reaction"
+	relationshipRepo "golang-project/internal/repository/relationship"
+	userRepo "golang-project/internal/repository/user"
+	svc "golang-project/internal/service/reaction"
+	relationshipSvc "golang-project/internal/service/relationship"
+)
+
+// NewRegistry returns new resource handler for reaction API
+func NewRegistry(route string, db database.Connection, publisher event.Publisher) handler.ResourceHandler {
+	reactionSvc := svc.NewService(
+		reactionRepo.NewRepository(db),
+		relationshipSvc.NewService(userRepo.NewRepository(db), relationshipRepo.NewRepository(db), database.NewUnitOfWork(db), publisher),
+		database.NewUnitOfWork(db),
+		publisher,
+	)
+
+	return hdl.NewHandler(route, reactionSvc)
+}
@@ -1,33 +1,88 @@
 package registry
 
 import (
+	"path"
+	"time"
+
 	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/viper"
 	swagger "github.com/swaggo/echo-swagger"
 
 	"golang-project/database"
 	_ "golang-project/docs/swagger"
+	"golang-project/internal/cache"
+	"golang-project/internal/event"
 	"golang-project/internal/handler"
+	"golang-project/internal/healthcheck"
+	"golang-project/internal/metrics"
+	"golang-project/internal/registry/accesstoken"
+	"golang-project/internal/registry/account"
+	"golang-project/internal/registry/audit"
 	"golang-project/internal/registry/authentication"
+	"golang-project/internal/registry/comment"
+	"golang-project/internal/registry/export"
+	"golang-project/internal/registry/favourite"
+	"golang-project/internal/registry/feed"
 	"golang-project/internal/registry/health"
-
-	// TODO: Migrate remaining services to MongoDB
-	// "golang-project/internal/registry/comment"
-	// "golang-project/internal/registry/favourite"
-	// "golang-project/internal/registry/post"
-	// "golang-project/internal/registry/profile"
-	// "golang-project/internal/registry/tag"
+	"golang-project/internal/registry/moderation"
+	"golang-project/internal/registry/post"
+	"golang-project/internal/registry/profile"
+	"golang-project/internal/registry/reaction"
+	"golang-project/internal/registry/relationship"
+	"golang-project/internal/registry/tag"
+	"golang-project/internal/registry/twofactor"
+	"golang-project/internal/registry/webhook"
+	"golang-project/internal/worker"
 	"golang-project/server"
+	"golang-project/static"
 )
 
 // NewHandlerRegistries returns all server handler registries
-func NewHandlerRegistries(db database.Connection) ([]server.HandlerRegistry, error) {
+func NewHandlerRegistries(db database.Connection, store cache.Store, bus event.Bus, checks *healthcheck.Registry, workers *worker.Group) ([]server.HandlerRegistry, error) {
 	registries := []server.HandlerRegistry{
 		initSwaggerRegistry(),
-		initHealthCheckHandler(db).RegisterRoutes(),
+		initMetricsRegistry(),
+		initHealthCheckHandler(checks).RegisterRoutes(),
+		initFeedHandler(db, bus).RegisterRoutes(),
+	}
+
+	// Posts, profiles and tags changed outside their services drop their cached copies
+	cache.SubscribePostChanges(bus, store)
+	cache.SubscribeProfileChanges(bus, store)
+	cache.SubscribeTagChanges(bus, store)
+
+	v1 := initResourceHandlers(db, store, bus, workers)
+	versions := []struct {
+		name     string
+		handlers []handler.ResourceHandler
+	}{
+		{name: static.APIVersionV1, handlers: v1},
+		{name: static.APIVersionV2, handlers: inheritResourceHandlers(v1, initResourceHandlersV2(db))},
+	}
+
+	for _, version := range versions {
+		for _, hdl := range version.handlers {
+			registry := hdl.RegisterRoutes()
+			registry.Version = version.name
+			registries = append(registries, registry)
+		}
 	}
 
-	for _, hdl := range initResourceHandlers(db) {
-		registries = append(registries, hdl.RegisterRoutes())
+	legacyDeprecation, err := initLegacyDeprecation()
+	if err != nil {
+		return nil, err
+	}
+
+	// Unversioned routes are kept as deprecated aliases of v1 for the clients released before versioning
+	for _, hdl := range v1 {
+		registry := hdl.RegisterRoutes()
+		if registry.Deprecation == nil {
+			deprecation := *legacyDeprecation
+			deprecation.Link = path.Join("/", static.APIVersionV1, registry.Route)
+			registry.Deprecation = &deprecation
+		}
+		registries = append(registries, registry)
 	}
 
 	return registries, nil
@@ -43,20 +98,82 @@ func initSwaggerRegistry() server.HandlerRegistry {
 	}
 }
 
-// initHealthCheckHandler returns the health check handler for MongoDB
-func initHealthCheckHandler(db database.Connection) handler.ResourceHandler {
-	return health.NewRegistry("/health", db)
+// initMetricsRegistry returns the Prometheus metrics handler registry
+func initMetricsRegistry() server.HandlerRegistry {
+	return server.HandlerRegistry{
+		Route: "/metrics",
+		Register: func(group *echo.Group) {
+			group.GET("", echo.WrapHandler(promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{Registry: metrics.Registry})))
+		},
+	}
+}
+
+// initHealthCheckHandler returns the health check handler running the registered checks
+func initHealthCheckHandler(checks *healthcheck.Registry) handler.ResourceHandler {
+	return health.NewRegistry("/health", checks)
+}
+
+// initFeedHandler returns the syndication feed handler, which is not versioned as feed readers keep its URLs
+func initFeedHandler(db database.Connection, publisher event.Publisher) handler.ResourceHandler {
+	return feed.NewRegistry("/feeds", db, publisher)
+}
+
+// initLegacyDeprecation returns the deprecation schedule of the unversioned routes
+func initLegacyDeprecation() (*server.Deprecation, error) {
+	deprecation := &server.Deprecation{Since: static.APIVersioning.LegacyDeprecatedAt}
+
+	if sunset := viper.GetString(static.EnvAPILegacySunset); sunset != "" {
+		t, err := time.Parse(time.RFC3339, sunset)
+		if err != nil {
+			return nil, err
+		}
+		deprecation.Sunset = t
+	}
+
+	return deprecation, nil
+}
+
+// inheritResourceHandlers returns the handlers of the new API version,
+// falling back to the handlers of the base version for the routes it does not replace
+func inheritResourceHandlers(base, overrides []handler.ResourceHandler) []handler.ResourceHandler {
+	replaced := map[string]bool{}
+	for _, hdl := range overrides {
+		replaced[hdl.RegisterRoutes().Route] = true
+	}
+
+	result := append([]handler.ResourceHandler{}, overrides...)
+	for _, hdl := range base {
+		if !replaced[hdl.RegisterRoutes().Route] {
+			result = append(result, hdl)
+		}
+	}
+
+	return result
+}
+
+// initResourceHandlersV2 returns the v2 resource handlers with breaking contract changes from v1
+func initResourceHandlersV2(db database.Connection) []handler.ResourceHandler {
+	return []handler.ResourceHandler{}
 }
 
-// initResourceHandlers returns the service resource handler registry
-func initResourceHandlers(db database.Connection) []handler.ResourceHandler {
+// initResourceHandlers returns the v1 service resource handler registry,
+// the post, profile and tag services read through the cache store and the services publish their events on the bus
+func initResourceHandlers(db database.Connection, store cache.Store, bus event.Bus, workers *worker.Group) []handler.ResourceHandler {
 	return []handler.ResourceHandler{
-		authentication.NewRegistry("/auth"),
-		// TODO: Migrate remaining services to MongoDB
-		// profile.NewRegistry("/profile", db),
-		// tag.NewRegistry("/tags", db),
-		// favourite.NewRegistry("/favorites", db),
-		// comment.NewRegistry("/comments", db),
-		// post.NewRegistry("/posts", db),
+		authentication.NewRegistry("/auth", db, bus),
+		export.NewRegistry("/exports", db, workers),
+		account.NewRegistry("/account", db, bus, workers),
+		moderation.NewRegistry("/moderation", db, bus),
+		relationship.NewRegistry("/relationships", db, bus),
+		reaction.NewRegistry("/reactions", db, bus),
+		webhook.NewRegistry("/webhooks", db, bus, workers),
+		audit.NewRegistry("/audit", db),
+		accesstoken.NewRegistry("/access-tokens", db),
+		twofactor.NewRegistry("/two-factor", db, bus),
+		post.NewRegistry("/posts", db, store, bus),
+		tag.NewRegistry("/tags", db, store),
+		profile.NewRegistry("/profile", db, store, bus),
+		favourite.NewRegistry("/favorites", db, bus),
+		comment.NewRegistry("/comments", db, bus),
 	}
 }
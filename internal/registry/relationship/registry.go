@@ -0,0 +1,18 @@
+package relationship
+
+import (
+	"golang-project/database"
+	"golang-project/internal/event"
+	"golang-project/internal/handler"
+	hdl "golang-project/internal/handler/relationship"
+	relationshipRepo "golang-project/internal/repository/relationship"
+	userRepo "golang-project/internal/repository/user"
+	svc "golang-project/internal/service/relationship"
+)
+
+// NewRegistry returns new resource handler for blocked and muted users API
+func NewRegistry(route string, db database.Connection, publisher event.Publisher) handler.ResourceHandler {
+	relationshipSvc := svc.NewService(userRepo.NewRepository(db), relationshipRepo.NewRepository(db), database.NewUnitOfWork(db), publisher)
+
+	return hdl.NewHandler(route, relationshipSvc)
+}
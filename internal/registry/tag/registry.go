@@ -0,0 +1,17 @@
+package tag
+
+import (
+	"golang-project/database"
+	"golang-project/internal/cache"
+	"golang-project/internal/handler"
+	hdl "golang-project/internal/handler/tag"
+	tagRepo "golang-project/internal/repository/tag"
+	svc "golang-project/internal/service/tag"
+)
+
+// NewRegistry returns new resource handler for tag API, the tag list is read through the cache store
+func NewRegistry(route string, db database.Connection, store cache.Store) handler.ResourceHandler {
+	tagSvc := svc.NewService(tagRepo.NewRepository(db), database.NewUnitOfWork(db))
+
+	return hdl.NewHandler(route, cache.NewTagService(tagSvc, store))
+}
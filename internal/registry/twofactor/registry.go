@@ -0,0 +1,26 @@
+package twofactor
+
+import (
+	"golang-project/database"
+	"golang-project/internal/audit"
+	"golang-project/internal/event"
+	"golang-project/internal/handler"
+	hdl "golang-project/internal/handler/twofactor"
+	auditRepo "golang-project/internal/repository/audit"
+	userRepo "golang-project/internal/repository/user"
+	svc "golang-project/internal/service/twofactor"
+	"golang-project/util/hashing"
+)
+
+// NewRegistry returns new resource handler for two-factor authentication API
+func NewRegistry(route string, db database.Connection, publisher event.Publisher) handler.ResourceHandler {
+	twoFactorSvc := svc.NewService(
+		userRepo.NewRepository(db),
+		hashing.NewBcrypt(),
+		database.NewUnitOfWork(db),
+		publisher,
+		audit.NewRecorder(auditRepo.NewRepository(db)),
+	)
+
+	return hdl.NewHandler(route, twoFactorSvc)
+}
@@ -0,0 +1,36 @@
+package webhook
+
+import (
+	"context"
+
+	"golang-project/database"
+	"golang-project/internal/audit"
+	"golang-project/internal/event"
+	"golang-project/internal/handler"
+	hdl "golang-project/internal/handler/webhook"
+	auditRepo "golang-project/internal/repository/audit"
+	userRepo "golang-project/internal/repository/user"
+	webhookRepo "golang-project/internal/repository/webhook"
+	svc "golang-project/internal/service/webhook"
+	"golang-project/internal/worker"
+	"golang-project/static"
+)
+
+// NewRegistry returns new resource handler for outbound webhook API, subscribes the webhooks to the domain events
+// and registers the delivery worker
+func NewRegistry(route string, db database.Connection, bus event.Bus, workers *worker.Group) handler.ResourceHandler {
+	webhookSvc := svc.NewService(userRepo.NewRepository(db), webhookRepo.NewRepository(db), audit.NewRecorder(auditRepo.NewRepository(db)))
+	workers.Register("webhook", webhookSvc.Work)
+
+	event.OnEnvelope(bus, "webhook", func(ctx context.Context, envelope *event.Envelope, e event.PostPublished) error {
+		return webhookSvc.Dispatch(ctx, envelope.ID, static.WebhookPostPublished, e.UserID, e)
+	})
+	event.OnEnvelope(bus, "webhook", func(ctx context.Context, envelope *event.Envelope, e event.CommentCreated) error {
+		return webhookSvc.Dispatch(ctx, envelope.ID, static.WebhookCommentCreated, e.PostUserID, e)
+	})
+	event.OnEnvelope(bus, "webhook", func(ctx context.Context, envelope *event.Envelope, e event.Followed) error {
+		return webhookSvc.Dispatch(ctx, envelope.ID, static.WebhookUserFollowed, e.FollowedUserID, e)
+	})
+
+	return hdl.NewHandler(route, webhookSvc)
+}
@@ -0,0 +1,140 @@
+package accesstoken
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"golang-project/database"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	"golang-project/static"
+)
+
+// repository represents the implementation of repository.AccessToken
+type repository struct {
+	tokens *mongo.Collection
+}
+
+// NewRepository returns a new implementation of repository.AccessToken
+func NewRepository(db database.Connection) repo.AccessToken {
+	return &repository{
+		tokens: db.GetDatabase().Collection(static.CollectionAccessTokens),
+	}
+}
+
+// EnsureIndexes creates the unique index of the token hash looked up on every request and the index of the user tokens
+func (r *repository) EnsureIndexes(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := r.tokens.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "token_hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	})
+	return err
+}
+
+// Insert performs insert action into access_tokens collection
+func (r *repository) Insert(ctx context.Context, o *model.AccessToken) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if o.ID.IsZero() {
+		o.ID = primitive.NewObjectID()
+	}
+
+	now := time.Now()
+	o.CreatedAt = &now
+	o.UpdatedAt = &now
+
+	_, err := r.tokens.InsertOne(ctx, o)
+	return err
+}
+
+// Read finds and returns the access token by ID
+func (r *repository) Read(ctx context.Context, id primitive.ObjectID) (*model.AccessToken, error) {
+	return r.readOne(ctx, bson.M{"_id": id})
+}
+
+// ReadByHash finds and returns the access token by the hash of the token
+func (r *repository) ReadByHash(ctx context.Context, hash string) (*model.AccessToken, error) {
+	return r.readOne(ctx, bson.M{"token_hash": hash})
+}
+
+// readOne finds and returns the access token matching the filter
+func (r *repository) readOne(ctx context.Context, filter bson.M) (*model.AccessToken, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var result model.AccessToken
+	err := r.tokens.FindOne(ctx, filter).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, static.ErrAccessTokenNotFound
+		}
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// SelectByUser finds and returns the access tokens of the user, the latest first
+func (r *repository) SelectByUser(ctx context.Context, userID primitive.ObjectID) ([]*model.AccessToken, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.tokens.Find(ctx, bson.M{"user_id": userID}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []*model.AccessToken
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// CountActive counts the access tokens of the user neither revoked nor expired at the time
+func (r *repository) CountActive(ctx context.Context, userID primitive.ObjectID, now time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	return r.tokens.CountDocuments(ctx, bson.M{
+		"user_id":    userID,
+		"revoked_at": bson.M{"$exists": false},
+		"expires_at": bson.M{"$gt": now},
+	})
+}
+
+// Revoke performs update action of the revocation time of the access token
+func (r *repository) Revoke(ctx context.Context, o *model.AccessToken, at time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	update := bson.M{"$set": bson.M{"revoked_at": at, "updated_at": time.Now()}, "$inc": bson.M{"version": 1}}
+
+	_, err := r.tokens.UpdateOne(ctx, bson.M{"_id": o.ID, "revoked_at": bson.M{"$exists": false}}, update)
+	return err
+}
+
+// TouchLastUsed performs update action of the last use of the access token
+func (r *repository) TouchLastUsed(ctx context.Context, o *model.AccessToken, at time.Time, ip string) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	update := bson.M{"$set": bson.M{"last_used_at": at, "last_used_ip": ip}}
+
+	_, err := r.tokens.UpdateOne(ctx, bson.M{"_id": o.ID}, update)
+	return err
+}
@@ -0,0 +1,296 @@
+package account
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"golang-project/database"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	"golang-project/static"
+)
+
+// repository represents the implementation of repository.Account
+type repository struct {
+	users      *mongo.Collection
+	posts      *mongo.Collection
+	postTags   *mongo.Collection
+	comments   *mongo.Collection
+	follows    *mongo.Collection
+	favourites *mongo.Collection
+	blocks     *mongo.Collection
+	mutes      *mongo.Collection
+	reactions  *mongo.Collection
+	webhooks   *mongo.Collection
+	deliveries *mongo.Collection
+	tokens     *mongo.Collection
+	reports    *mongo.Collection
+	actions    *mongo.Collection
+	challenges *mongo.Collection
+	keys       *mongo.Collection
+}
+
+// NewRepository returns a new implementation of repository.Account
+func NewRepository(db database.Connection) repo.Account {
+	mongoDB := db.GetDatabase()
+
+	return &repository{
+		users:      mongoDB.Collection(static.CollectionUsers),
+		posts:      mongoDB.Collection(static.CollectionPosts),
+		postTags:   mongoDB.Collection(static.CollectionPostTags),
+		comments:   mongoDB.Collection(static.CollectionComments),
+		follows:    mongoDB.Collection(static.CollectionFollows),
+		favourites: mongoDB.Collection(static.CollectionFavorites),
+		blocks:     mongoDB.Collection(static.CollectionBlocks),
+		mutes:      mongoDB.Collection(static.CollectionMutes),
+		reactions:  mongoDB.Collection(static.CollectionReactions),
+		webhooks:   mongoDB.Collection(static.CollectionWebhooks),
+		deliveries: mongoDB.Collection(static.CollectionWebhookDeliveries),
+		tokens:     mongoDB.Collection(static.CollectionAccessTokens),
+		reports:    mongoDB.Collection(static.CollectionReports),
+		actions:    mongoDB.Collection(static.CollectionModerationActions),
+		challenges: mongoDB.Collection(static.CollectionSignInChallenges),
+		keys:       mongoDB.Collection(static.CollectionIdempotencyKeys),
+	}
+}
+
+// SelectDueDeletions finds and returns the users whose scheduled deletion is due at now
+func (r *repository) SelectDueDeletions(ctx context.Context, now time.Time) ([]*model.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	cursor, err := r.users.Find(ctx, bson.M{"deletion_scheduled_at": bson.M{"$lte": now}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []*model.User
+	if err = cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// DeletePosts performs delete action of the posts of the user together with their tags, comments, favourites
+// and the reactions on the posts and their comments, it returns the deleted posts
+func (r *repository) DeletePosts(ctx context.Context, userID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	postIDs, err := r.posts.Distinct(ctx, "_id", bson.M{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+	if len(postIDs) == 0 {
+		return nil, nil
+	}
+
+	// Dependents go first so a failed run leaves the posts to be found again by the retry
+	byPost := bson.M{"post_id": bson.M{"$in": postIDs}}
+	commentIDs, err := r.comments.Distinct(ctx, "_id", byPost)
+	if err != nil {
+		return nil, err
+	}
+
+	byTarget := bson.M{"target_id": bson.M{"$in": append(postIDs, commentIDs...)}}
+	if _, err = r.reactions.DeleteMany(ctx, byTarget); err != nil {
+		return nil, err
+	}
+
+	for _, collection := range []*mongo.Collection{r.postTags, r.comments, r.favourites} {
+		if _, err = collection.DeleteMany(ctx, byPost); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err = r.posts.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": postIDs}}); err != nil {
+		return nil, err
+	}
+
+	return objectIDs(postIDs), nil
+}
+
+// AnonymiseComments detaches the comments of the user on other posts and replaces their content,
+// the comments are kept so the replies of the other users stay in their thread
+func (r *repository) AnonymiseComments(ctx context.Context, userID primitive.ObjectID, content string) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	update := bson.M{"$set": bson.M{"user_id": primitive.NilObjectID, "content": content, "updated_at": time.Now()}, "$inc": bson.M{"version": 1}}
+
+	_, err := r.comments.UpdateMany(ctx, bson.M{"user_id": userID}, update)
+	return err
+}
+
+// DeleteFollows performs delete action of the follows from and to the user
+// and decrements the follower and following counters of the other users, it returns the other users.
+// Counters left by an interrupted run are repaired by the counters recompute command
+func (r *repository) DeleteFollows(ctx context.Context, userID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	following, err := r.follows.Distinct(ctx, "follow_user_id", bson.M{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+
+	followers, err := r.follows.Distinct(ctx, "user_id", bson.M{"follow_user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"$or": []bson.M{{"user_id": userID}, {"follow_user_id": userID}}}
+	if _, err = r.follows.DeleteMany(ctx, filter); err != nil {
+		return nil, err
+	}
+
+	if err = r.decrement(ctx, r.users, following, "follower_count"); err != nil {
+		return nil, err
+	}
+	if err = r.decrement(ctx, r.users, followers, "following_count"); err != nil {
+		return nil, err
+	}
+
+	return objectIDs(append(following, followers...)), nil
+}
+
+// DeleteFavourites performs delete action of the favourites of the user
+// and decrements the favourite counters of the posts, it returns the posts
+func (r *repository) DeleteFavourites(ctx context.Context, userID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	postIDs, err := r.favourites.Distinct(ctx, "post_id", bson.M{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = r.favourites.DeleteMany(ctx, bson.M{"user_id": userID}); err != nil {
+		return nil, err
+	}
+
+	if err = r.decrement(ctx, r.posts, postIDs, "favourite_count"); err != nil {
+		return nil, err
+	}
+
+	return objectIDs(postIDs), nil
+}
+
+// decrement decrements the counter of the documents by one
+func (r *repository) decrement(ctx context.Context, collection *mongo.Collection, ids []interface{}, counter string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := collection.UpdateMany(ctx, bson.M{"_id": bson.M{"$in": ids}}, bson.M{"$inc": bson.M{counter: -1}})
+	return err
+}
+
+// DeleteRelationships performs delete action of the blocks and mutes from and to the user
+func (r *repository) DeleteRelationships(ctx context.Context, userID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := r.blocks.DeleteMany(ctx, bson.M{"$or": []bson.M{{"user_id": userID}, {"blocked_user_id": userID}}})
+	if err != nil {
+		return err
+	}
+
+	_, err = r.mutes.DeleteMany(ctx, bson.M{"$or": []bson.M{{"user_id": userID}, {"muted_user_id": userID}}})
+	return err
+}
+
+// DeleteReactions performs delete action of the reactions of the user
+func (r *repository) DeleteReactions(ctx context.Context, userID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := r.reactions.DeleteMany(ctx, bson.M{"user_id": userID})
+	return err
+}
+
+// DeleteWebhooks performs delete action of the webhooks of the user with their delivery log
+func (r *repository) DeleteWebhooks(ctx context.Context, userID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := r.deliveries.DeleteMany(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return err
+	}
+
+	_, err = r.webhooks.DeleteMany(ctx, bson.M{"user_id": userID})
+	return err
+}
+
+// DeleteAccessTokens performs delete action of the personal access tokens of the user
+func (r *repository) DeleteAccessTokens(ctx context.Context, userID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := r.tokens.DeleteMany(ctx, bson.M{"user_id": userID})
+	return err
+}
+
+// DeleteReports performs delete action of the reports filed by the user and of the reports of the content of the user,
+// which is deleted or anonymised by the previous steps. The moderation actions are kept as the record of the moderators
+// with the author anonymised
+func (r *repository) DeleteReports(ctx context.Context, userID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := r.reports.DeleteMany(ctx, bson.M{"$or": []bson.M{{"reporter_id": userID}, {"author_id": userID}}})
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{"$set": bson.M{"author_id": primitive.NilObjectID, "updated_at": time.Now()}, "$inc": bson.M{"version": 1}}
+
+	_, err = r.actions.UpdateMany(ctx, bson.M{"author_id": userID}, update)
+	return err
+}
+
+// DeleteSignInChallenges performs delete action of the pending two-factor sign-ins of the user
+func (r *repository) DeleteSignInChallenges(ctx context.Context, userID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := r.challenges.DeleteMany(ctx, bson.M{"user_id": userID})
+	return err
+}
+
+// DeleteIdempotencyKeys performs delete action of the idempotency keys of the user with their stored responses
+func (r *repository) DeleteIdempotencyKeys(ctx context.Context, userID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := r.keys.DeleteMany(ctx, bson.M{"user_id": userID})
+	return err
+}
+
+// DeleteUser performs delete action of the user
+func (r *repository) DeleteUser(ctx context.Context, userID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := r.users.DeleteOne(ctx, bson.M{"_id": userID})
+	return err
+}
+
+// objectIDs returns the object IDs of the distinct values
+func objectIDs(values []interface{}) []primitive.ObjectID {
+	ids := make([]primitive.ObjectID, 0, len(values))
+	for _, value := range values {
+		if id, ok := value.(primitive.ObjectID); ok {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
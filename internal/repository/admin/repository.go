@@ -0,0 +1,290 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"golang-project/database"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	"golang-project/static"
+)
+
+// repository represents the implementation of repository.Admin
+type repository struct {
+	users      *mongo.Collection
+	posts      *mongo.Collection
+	tags       *mongo.Collection
+	postTags   *mongo.Collection
+	comments   *mongo.Collection
+	follows    *mongo.Collection
+	favourites *mongo.Collection
+}
+
+// NewRepository returns a new implementation of repository.Admin
+func NewRepository(db database.Connection) repo.Admin {
+	mongoDB := db.GetDatabase()
+
+	return &repository{
+		users:      mongoDB.Collection(static.CollectionUsers),
+		posts:      mongoDB.Collection(static.CollectionPosts),
+		tags:       mongoDB.Collection(static.CollectionTags),
+		postTags:   mongoDB.Collection(static.CollectionPostTags),
+		comments:   mongoDB.Collection(static.CollectionComments),
+		follows:    mongoDB.Collection(static.CollectionFollows),
+		favourites: mongoDB.Collection(static.CollectionFavorites),
+	}
+}
+
+// ReadPost finds and returns the post model by ID
+func (r *repository) ReadPost(ctx context.Context, id primitive.ObjectID) (*model.Post, error) {
+	return r.readPost(ctx, bson.M{"_id": id})
+}
+
+// ReadPostBySlug finds and returns the post model by slug
+func (r *repository) ReadPostBySlug(ctx context.Context, slug string) (*model.Post, error) {
+	return r.readPost(ctx, bson.M{"slug": slug})
+}
+
+// readPost finds and returns the post model matching the filter
+func (r *repository) readPost(ctx context.Context, filter bson.M) (*model.Post, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter["deleted_at"] = bson.M{"$exists": false}
+
+	var result model.Post
+	err := r.posts.FindOne(ctx, filter).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, static.ErrPostNotFound
+		}
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// UnpublishPost marks the published post as not published and decrements the post counter of its author
+func (r *repository) UnpublishPost(ctx context.Context, id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var post model.Post
+	update := bson.M{"$set": bson.M{"is_published": false, "updated_at": time.Now()}, "$inc": bson.M{"version": 1}}
+	err := r.posts.FindOneAndUpdate(ctx, bson.M{"_id": id, "is_published": true}, update).Decode(&post)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return static.ErrPostNotPublished
+		}
+		return err
+	}
+
+	_, err = r.users.UpdateByID(ctx, post.UserID, bson.M{"$inc": bson.M{"post_count": -1}})
+	return err
+}
+
+// ReadTag finds and returns the tag model by ID
+func (r *repository) ReadTag(ctx context.Context, id primitive.ObjectID) (*model.Tag, error) {
+	return r.readTag(ctx, bson.M{"_id": id})
+}
+
+// ReadTagByName finds and returns the tag model by name
+func (r *repository) ReadTagByName(ctx context.Context, name string) (*model.Tag, error) {
+	return r.readTag(ctx, bson.M{"name": name})
+}
+
+// readTag finds and returns the tag model matching the filter
+func (r *repository) readTag(ctx context.Context, filter bson.M) (*model.Tag, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter["deleted_at"] = bson.M{"$exists": false}
+
+	var result model.Tag
+	err := r.tags.FindOne(ctx, filter).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, static.ErrTagNotFound
+		}
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// CountTagPosts returns the number of posts labelled with the tag
+func (r *repository) CountTagPosts(ctx context.Context, id primitive.ObjectID) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	return r.posts.CountDocuments(ctx, bson.M{"tag_ids": id})
+}
+
+// MergeTag relabels the posts of the source tag with the target tag, soft deletes the source tag
+// and returns the posts that were relabelled
+func (r *repository) MergeTag(ctx context.Context, sourceID, targetID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{"tag_ids": sourceID}
+
+	postIDs, err := r.posts.Distinct(ctx, "_id", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add the target first so the posts can still be matched by the source afterwards
+	_, err = r.posts.UpdateMany(ctx, filter, bson.M{"$addToSet": bson.M{"tag_ids": targetID}, "$set": bson.M{"updated_at": now}, "$inc": bson.M{"version": 1}})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = r.posts.UpdateMany(ctx, filter, bson.M{"$pull": bson.M{"tag_ids": sourceID}})
+	if err != nil {
+		return nil, err
+	}
+
+	// Drop the post_tags links that would duplicate an existing target link before relabelling the rest
+	taggedPostIDs, err := r.postTags.Distinct(ctx, "post_id", bson.M{"tag_id": targetID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(taggedPostIDs) > 0 {
+		_, err = r.postTags.DeleteMany(ctx, bson.M{"tag_id": sourceID, "post_id": bson.M{"$in": taggedPostIDs}})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = r.postTags.UpdateMany(ctx, bson.M{"tag_id": sourceID}, bson.M{"$set": bson.M{"tag_id": targetID}, "$inc": bson.M{"version": 1}})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = r.tags.UpdateOne(ctx, bson.M{"_id": sourceID}, bson.M{"$set": bson.M{"deleted_at": now, "updated_at": now}, "$inc": bson.M{"version": 1}})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(postIDs))
+	for _, id := range postIDs {
+		if id, ok := id.(primitive.ObjectID); ok {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
+}
+
+// RecomputeUserCounters recounts the followers, following and published posts of every user from the source
+// collections, it returns the users whose counters were wrong and fixes them unless dryRun is set
+func (r *repository) RecomputeUserCounters(ctx context.Context, dryRun bool) ([]primitive.ObjectID, error) {
+	counters := []counter{
+		{name: "follower_count", from: static.CollectionFollows, match: bson.M{"$eq": bson.A{"$follow_user_id", "$$id"}}},
+		{name: "following_count", from: static.CollectionFollows, match: bson.M{"$eq": bson.A{"$user_id", "$$id"}}},
+		{name: "post_count", from: static.CollectionPosts, match: bson.M{"$and": bson.A{
+			bson.M{"$eq": bson.A{"$user_id", "$$id"}},
+			bson.M{"$eq": bson.A{"$is_published", true}},
+			bson.M{"$eq": bson.A{bson.M{"$type": "$deleted_at"}, "missing"}},
+		}}},
+	}
+
+	return r.recompute(ctx, r.users, counters, dryRun)
+}
+
+// RecomputePostCounters recounts the favourites and comments of every post from the source collections,
+// it returns the posts whose counters were wrong and fixes them unless dryRun is set
+func (r *repository) RecomputePostCounters(ctx context.Context, dryRun bool) ([]primitive.ObjectID, error) {
+	counters := []counter{
+		{name: "favourite_count", from: static.CollectionFavorites, match: bson.M{"$eq": bson.A{"$post_id", "$$id"}}},
+		{name: "comment_count", from: static.CollectionComments, match: bson.M{"$and": bson.A{
+			bson.M{"$eq": bson.A{"$post_id", "$$id"}},
+			bson.M{"$eq": bson.A{bson.M{"$type": "$deleted_at"}, "missing"}},
+		}}},
+	}
+
+	return r.recompute(ctx, r.posts, counters, dryRun)
+}
+
+// counter represents a denormalised counter field and the source documents it counts
+type counter struct {
+	name  string
+	from  string
+	match bson.M
+}
+
+// recompute counts the counters of every document of the collection, writes the ones that differ and returns their IDs
+func (r *repository) recompute(ctx context.Context, collection *mongo.Collection, counters []counter, dryRun bool) ([]primitive.ObjectID, error) {
+	// Every document of the collection is scanned, the regular query timeout is too short
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{}
+	counts := bson.M{}
+	differs := bson.A{}
+	project := bson.M{"_id": 1}
+	for _, c := range counters {
+		count := "_" + c.name
+		pipeline = append(pipeline, bson.D{{Key: "$lookup", Value: bson.M{
+			"from":     c.from,
+			"let":      bson.M{"id": "$_id"},
+			"pipeline": bson.A{bson.M{"$match": bson.M{"$expr": c.match}}, bson.M{"$count": "n"}},
+			"as":       count,
+		}}})
+		counts[count] = bson.M{"$toLong": bson.M{"$ifNull": bson.A{bson.M{"$arrayElemAt": bson.A{"$" + count + ".n", 0}}, 0}}}
+		differs = append(differs, bson.M{"$ne": bson.A{"$" + c.name, "$" + count}})
+		project[count] = 1
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$set", Value: counts}},
+		bson.D{{Key: "$match", Value: bson.M{"$expr": bson.M{"$or": differs}}}},
+		bson.D{{Key: "$project", Value: project}},
+	)
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var ids []primitive.ObjectID
+	var writes []mongo.WriteModel
+	for cursor.Next(ctx) {
+		var document bson.M
+		if err = cursor.Decode(&document); err != nil {
+			return nil, err
+		}
+
+		if id, ok := document["_id"].(primitive.ObjectID); ok {
+			ids = append(ids, id)
+		}
+
+		values := bson.M{}
+		for _, c := range counters {
+			values[c.name] = document["_"+c.name]
+		}
+
+		writes = append(writes, mongo.NewUpdateOneModel().SetFilter(bson.M{"_id": document["_id"]}).SetUpdate(bson.M{"$set": values}))
+	}
+	if err = cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	if dryRun || len(writes) == 0 {
+		return ids, nil
+	}
+
+	if _, err = collection.BulkWrite(ctx, writes); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
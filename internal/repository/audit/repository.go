@@ -0,0 +1,110 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"golang-project/database"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	"golang-project/static"
+)
+
+// repository represents the implementation of repository.Audit
+type repository struct {
+	logs *mongo.Collection
+}
+
+// NewRepository returns a new implementation of repository.Audit
+func NewRepository(db database.Connection) repo.Audit {
+	return &repository{
+		logs: db.GetDatabase().Collection(static.CollectionAuditLogs),
+	}
+}
+
+// EnsureIndexes creates the indexes of the actor, target and action queries
+func (r *repository) EnsureIndexes(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := r.logs.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "actor_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "target_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "action", Value: 1}, {Key: "created_at", Value: -1}}},
+	})
+	return err
+}
+
+// Insert performs insert action into audit_logs collection
+func (r *repository) Insert(ctx context.Context, o *model.AuditLog) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if o.ID.IsZero() {
+		o.ID = primitive.NewObjectID()
+	}
+
+	now := time.Now()
+	o.CreatedAt = &now
+
+	_, err := r.logs.InsertOne(ctx, o)
+	return err
+}
+
+// Select finds and returns the page of the entries matching the filter, the latest first, with their total
+func (r *repository) Select(ctx context.Context, f *model.AuditLogFilter, offset, limit int) ([]*model.AuditLog, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	if f.ActorID != nil {
+		filter["actor_id"] = *f.ActorID
+	}
+	if f.TargetID != nil {
+		filter["target_id"] = *f.TargetID
+	}
+	if f.UserID != nil {
+		filter["$or"] = []bson.M{{"actor_id": *f.UserID}, {"target_id": *f.UserID}}
+	}
+	if len(f.Actions) > 0 {
+		filter["action"] = bson.M{"$in": f.Actions}
+	}
+	if f.From != nil || f.To != nil {
+		createdAt := bson.M{}
+		if f.From != nil {
+			createdAt["$gte"] = *f.From
+		}
+		if f.To != nil {
+			createdAt["$lt"] = *f.To
+		}
+		filter["created_at"] = createdAt
+	}
+
+	total, err := r.logs.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	cursor, err := r.logs.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []*model.AuditLog
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, 0, err
+	}
+
+	return results, total, nil
+}
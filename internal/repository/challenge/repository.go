@@ -0,0 +1,91 @@
+package challenge
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"golang-project/database"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	"golang-project/static"
+)
+
+// repository represents the implementation of repository.SignInChallenge
+type repository struct {
+	challenges *mongo.Collection
+}
+
+// NewRepository returns a new implementation of repository.SignInChallenge
+func NewRepository(db database.Connection) repo.SignInChallenge {
+	return &repository{
+		challenges: db.GetDatabase().Collection(static.CollectionSignInChallenges),
+	}
+}
+
+// EnsureIndexes creates the unique index of the token hash and the TTL index removing the abandoned sign-ins
+func (r *repository) EnsureIndexes(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := r.challenges.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "token_hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	})
+	return err
+}
+
+// Insert performs insert action into sign_in_challenges collection
+func (r *repository) Insert(ctx context.Context, o *model.SignInChallenge) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if o.ID.IsZero() {
+		o.ID = primitive.NewObjectID()
+	}
+
+	now := time.Now()
+	o.CreatedAt = &now
+
+	_, err := r.challenges.InsertOne(ctx, o)
+	return err
+}
+
+// Attempt performs update action of the attempts of the challenge in one step,
+// so concurrent attempts cannot exceed the maximum
+func (r *repository) Attempt(ctx context.Context, tokenHash string, maxAttempts int) (*model.SignInChallenge, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"token_hash": tokenHash,
+		"expires_at": bson.M{"$gt": time.Now()},
+		"attempts":   bson.M{"$lt": maxAttempts},
+	}
+	update := bson.M{"$inc": bson.M{"attempts": 1, "version": 1}}
+
+	var result model.SignInChallenge
+	err := r.challenges.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, static.ErrTwoFactorChallengeInvalid
+		}
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// Delete performs delete action of the challenge
+func (r *repository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := r.challenges.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
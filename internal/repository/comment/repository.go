@@ -0,0 +1,179 @@
+package comment
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"golang-project/database"
+	"golang-project/internal/contract"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	"golang-project/static"
+	"golang-project/util/pagination"
+)
+
+// repository represents the implementation of repository.Comment
+type repository struct {
+	comments *mongo.Collection
+	posts    *mongo.Collection
+}
+
+// NewRepository returns a new implementation of repository.Comment
+func NewRepository(db database.Connection) repo.Comment {
+	mongoDB := db.GetDatabase()
+
+	return &repository{
+		comments: mongoDB.Collection(static.CollectionComments),
+		posts:    mongoDB.Collection(static.CollectionPosts),
+	}
+}
+
+// Select finds the page of top-level comments of the post followed by their replies, the oldest first,
+// and counts the top-level comments
+func (r *repository) Select(ctx context.Context, req *contract.ListCommentRequest) ([]*model.Comment, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"post_id": req.PostID, "parent_comment_id": nil, "deleted_at": bson.M{"$exists": false}, "hidden_at": nil}
+
+	total, err := r.comments.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetSkip(int64(pagination.CalculateOffset(req.Page, req.PageSize))).
+		SetLimit(int64(req.PageSize))
+
+	comments, err := r.find(ctx, filter, findOptions)
+	if err != nil || len(comments) == 0 {
+		return comments, total, err
+	}
+
+	parentIDs := make([]primitive.ObjectID, 0, len(comments))
+	for _, comment := range comments {
+		parentIDs = append(parentIDs, comment.ID)
+	}
+
+	filter = bson.M{"parent_comment_id": bson.M{"$in": parentIDs}, "deleted_at": bson.M{"$exists": false}, "hidden_at": nil}
+	replies, err := r.find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return append(comments, replies...), total, nil
+}
+
+// Insert performs insert action into comment collection and increments the comment counter of the post
+func (r *repository) Insert(ctx context.Context, o *model.Comment) (*model.Comment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if o.ID.IsZero() {
+		o.ID = primitive.NewObjectID()
+	}
+
+	now := time.Now()
+	o.CreatedAt = &now
+	o.UpdatedAt = &now
+
+	if _, err := r.comments.InsertOne(ctx, o); err != nil {
+		return nil, err
+	}
+
+	if _, err := r.posts.UpdateByID(ctx, o.PostID, bson.M{"$inc": bson.M{"comment_count": 1}}); err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+// Read finds and returns the comment model by ID
+func (r *repository) Read(ctx context.Context, id primitive.ObjectID) (*model.Comment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var result model.Comment
+	err := r.comments.FindOne(ctx, bson.M{"_id": id, "deleted_at": bson.M{"$exists": false}}).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, static.ErrCommentNotFound
+		}
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// UpdateCommentByID performs update action into comment collection
+func (r *repository) UpdateCommentByID(ctx context.Context, id primitive.ObjectID, updates map[string]interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	updates["updated_at"] = time.Now()
+
+	filter := bson.M{"_id": id, "deleted_at": bson.M{"$exists": false}}
+	result, err := r.comments.UpdateOne(ctx, filter, bson.M{"$set": updates, "$inc": bson.M{"version": 1}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return static.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+// Delete performs soft delete action of the comment and its replies and decrements the comment counter
+// of the post, it returns the number of deleted comments
+func (r *repository) Delete(ctx context.Context, id primitive.ObjectID) (int64, error) {
+	comment, err := r.Read(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{"$or": []bson.M{{"_id": id}, {"parent_comment_id": id}}, "deleted_at": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"deleted_at": now, "updated_at": now}, "$inc": bson.M{"version": 1}}
+
+	result, err := r.comments.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	if result.ModifiedCount == 0 {
+		return 0, static.ErrCommentNotFound
+	}
+
+	_, err = r.posts.UpdateByID(ctx, comment.PostID, bson.M{"$inc": bson.M{"comment_count": -result.ModifiedCount}})
+	if err != nil {
+		return 0, err
+	}
+
+	return result.ModifiedCount, nil
+}
+
+// find finds and returns the comments matching the filter
+func (r *repository) find(ctx context.Context, filter bson.M, findOptions *options.FindOptions) ([]*model.Comment, error) {
+	cursor, err := r.comments.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var comments []*model.Comment
+	if err = cursor.All(ctx, &comments); err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
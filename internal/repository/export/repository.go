@@ -0,0 +1,247 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"golang-project/database"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	"golang-project/static"
+)
+
+// repository represents the implementation of repository.Export
+type repository struct {
+	exports    *mongo.Collection
+	users      *mongo.Collection
+	posts      *mongo.Collection
+	comments   *mongo.Collection
+	tags       *mongo.Collection
+	follows    *mongo.Collection
+	favourites *mongo.Collection
+}
+
+// NewRepository returns a new implementation of repository.Export
+func NewRepository(db database.Connection) repo.Export {
+	mongoDB := db.GetDatabase()
+
+	return &repository{
+		exports:    mongoDB.Collection(static.CollectionExports),
+		users:      mongoDB.Collection(static.CollectionUsers),
+		posts:      mongoDB.Collection(static.CollectionPosts),
+		comments:   mongoDB.Collection(static.CollectionComments),
+		tags:       mongoDB.Collection(static.CollectionTags),
+		follows:    mongoDB.Collection(static.CollectionFollows),
+		favourites: mongoDB.Collection(static.CollectionFavorites),
+	}
+}
+
+// Insert performs insert action into export collection
+func (r *repository) Insert(ctx context.Context, o *model.Export) (*model.Export, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if o.ID.IsZero() {
+		o.ID = primitive.NewObjectID()
+	}
+
+	now := time.Now()
+	o.CreatedAt = &now
+	o.UpdatedAt = &now
+
+	_, err := r.exports.InsertOne(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+// Read finds and returns the export model by ID
+func (r *repository) Read(ctx context.Context, id primitive.ObjectID) (*model.Export, error) {
+	return r.readExport(ctx, bson.M{"_id": id})
+}
+
+// ReadActiveByUser finds and returns the pending or processing export of the user
+func (r *repository) ReadActiveByUser(ctx context.Context, userID primitive.ObjectID) (*model.Export, error) {
+	filter := bson.M{
+		"user_id": userID,
+		"status":  bson.M{"$in": []static.ExportStatus{static.ExportPending, static.ExportProcessing}},
+	}
+
+	return r.readExport(ctx, filter)
+}
+
+// readExport finds and returns the export model matching the filter
+func (r *repository) readExport(ctx context.Context, filter bson.M) (*model.Export, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var result model.Export
+	err := r.exports.FindOne(ctx, filter).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, static.ErrExportNotFound
+		}
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ClaimPending atomically moves the oldest pending export to processing and returns it
+func (r *repository) ClaimPending(ctx context.Context) (*model.Export, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	update := bson.M{"$set": bson.M{"status": static.ExportProcessing, "updated_at": time.Now()}, "$inc": bson.M{"version": 1}}
+	findOptions := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var result model.Export
+	err := r.exports.FindOneAndUpdate(ctx, bson.M{"status": static.ExportPending}, update, findOptions).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, static.ErrExportNotFound
+		}
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// RequeueStale moves the exports left processing since before the time back to pending
+func (r *repository) RequeueStale(ctx context.Context, before time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"status": static.ExportProcessing, "updated_at": bson.M{"$lt": before}}
+	update := bson.M{"$set": bson.M{"status": static.ExportPending, "updated_at": time.Now()}, "$inc": bson.M{"version": 1}}
+
+	_, err := r.exports.UpdateMany(ctx, filter, update)
+	return err
+}
+
+// Update performs update action into export collection
+func (r *repository) Update(ctx context.Context, o *model.Export, updates map[string]interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	updates["updated_at"] = time.Now()
+
+	_, err := r.exports.UpdateOne(ctx, bson.M{"_id": o.ID}, bson.M{"$set": updates, "$inc": bson.M{"version": 1}})
+	return err
+}
+
+// SelectExpired finds and returns the ready exports whose download expired before now
+func (r *repository) SelectExpired(ctx context.Context, now time.Time) ([]*model.Export, error) {
+	filter := bson.M{"status": static.ExportReady, "expires_at": bson.M{"$lte": now}}
+
+	return selectMany[model.Export](ctx, r.exports, filter)
+}
+
+// SelectByUser finds and returns every export of the user
+func (r *repository) SelectByUser(ctx context.Context, userID primitive.ObjectID) ([]*model.Export, error) {
+	return selectMany[model.Export](ctx, r.exports, bson.M{"user_id": userID})
+}
+
+// DeleteByUser performs delete action of every export of the user
+func (r *repository) DeleteByUser(ctx context.Context, userID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := r.exports.DeleteMany(ctx, bson.M{"user_id": userID})
+	return err
+}
+
+// ReadUser finds and returns the user model by ID
+func (r *repository) ReadUser(ctx context.Context, id primitive.ObjectID) (*model.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var result model.User
+	err := r.users.FindOne(ctx, bson.M{"_id": id}).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, static.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// SelectUserPosts finds and returns every post written by the user, including drafts
+func (r *repository) SelectUserPosts(ctx context.Context, userID primitive.ObjectID) ([]*model.Post, error) {
+	return selectMany[model.Post](ctx, r.posts, bson.M{"user_id": userID})
+}
+
+// SelectUserComments finds and returns every comment written by the user
+func (r *repository) SelectUserComments(ctx context.Context, userID primitive.ObjectID) ([]*model.Comment, error) {
+	return selectMany[model.Comment](ctx, r.comments, bson.M{"user_id": userID})
+}
+
+// SelectFollowing finds and returns the bloggers followed by the user
+func (r *repository) SelectFollowing(ctx context.Context, userID primitive.ObjectID) ([]*model.User, error) {
+	follows, err := selectMany[model.FollowUser](ctx, r.follows, bson.M{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(follows))
+	for _, follow := range follows {
+		ids = append(ids, follow.FollowUserID)
+	}
+
+	return selectMany[model.User](ctx, r.users, bson.M{"_id": bson.M{"$in": ids}})
+}
+
+// SelectFavouritePosts finds and returns the posts favourited by the user
+func (r *repository) SelectFavouritePosts(ctx context.Context, userID primitive.ObjectID) ([]*model.Post, error) {
+	favourites, err := selectMany[model.FavoritePost](ctx, r.favourites, bson.M{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(favourites))
+	for _, favourite := range favourites {
+		ids = append(ids, favourite.PostID)
+	}
+
+	return selectMany[model.Post](ctx, r.posts, bson.M{"_id": bson.M{"$in": ids}})
+}
+
+// SelectTags finds and returns the tag models by IDs
+func (r *repository) SelectTags(ctx context.Context, ids []primitive.ObjectID) ([]*model.Tag, error) {
+	if ids == nil {
+		ids = []primitive.ObjectID{}
+	}
+
+	return selectMany[model.Tag](ctx, r.tags, bson.M{"_id": bson.M{"$in": ids}})
+}
+
+// selectMany finds and returns the documents of the collection matching the filter
+func selectMany[T any](ctx context.Context, collection *mongo.Collection, filter bson.M) ([]*T, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []*T
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
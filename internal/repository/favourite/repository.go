@@ -0,0 +1,260 @@
+package favourite
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"golang-project/database"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	"golang-project/static"
+)
+
+// repository represents the implementation of repository.Favourite
+type repository struct {
+	follows   *mongo.Collection
+	favorites *mongo.Collection
+	posts     *mongo.Collection
+	users     *mongo.Collection
+}
+
+// NewRepository returns a new implementation of repository.Favourite
+func NewRepository(db database.Connection) repo.Favourite {
+	mongoDB := db.GetDatabase()
+
+	return &repository{
+		follows:   mongoDB.Collection(static.CollectionFollows),
+		favorites: mongoDB.Collection(static.CollectionFavorites),
+		posts:     mongoDB.Collection(static.CollectionPosts),
+		users:     mongoDB.Collection(static.CollectionUsers),
+	}
+}
+
+// EnsureIndexes creates the unique indexes keeping one follow per followed user and one favourite per post for a user
+func (r *repository) EnsureIndexes(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := r.follows.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "follow_user_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = r.favorites.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "post_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+// IsFollowing checks whether the user follows the other user
+func (r *repository) IsFollowing(ctx context.Context, userID, followUserID primitive.ObjectID) (bool, error) {
+	return exists(ctx, r.follows, bson.M{"user_id": userID, "follow_user_id": followUserID})
+}
+
+// SelectFollowing finds and returns the users followed by the user
+func (r *repository) SelectFollowing(ctx context.Context, userID primitive.ObjectID) ([]*model.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	ids, err := r.follows.Distinct(ctx, "follow_user_id", bson.M{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	cursor, err := r.users.Find(ctx, bson.M{"_id": bson.M{"$in": ids}}, options.Find().SetSort(bson.D{{Key: "pseudonym", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []*model.User
+	if err = cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// Follow performs insert action into follow collection unless the user already follows the other user
+// and increments the following and follower counters of the two users, it returns whether the follow was inserted
+func (r *repository) Follow(ctx context.Context, o *model.FollowUser) (bool, error) {
+	filter := bson.M{"user_id": o.UserID, "follow_user_id": o.FollowUserID}
+
+	inserted, err := insertOnce(ctx, r.follows, filter, &o.CreatedAt)
+	if err != nil || !inserted {
+		return inserted, err
+	}
+
+	return true, r.countFollow(ctx, o.UserID, o.FollowUserID, 1)
+}
+
+// Unfollow performs delete action of the follow and decrements the following and follower counters
+// of the two users, it returns whether the follow existed
+func (r *repository) Unfollow(ctx context.Context, userID, followUserID primitive.ObjectID) (bool, error) {
+	deleted, err := deleteOne(ctx, r.follows, bson.M{"user_id": userID, "follow_user_id": followUserID})
+	if err != nil || !deleted {
+		return deleted, err
+	}
+
+	return true, r.countFollow(ctx, userID, followUserID, -1)
+}
+
+// SelectFollowingUsersPosts finds the published posts of the users followed by the user, the latest first
+func (r *repository) SelectFollowingUsersPosts(ctx context.Context, userID primitive.ObjectID) ([]*model.Post, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	ids, err := r.follows.Distinct(ctx, "follow_user_id", bson.M{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	return r.selectPublishedPosts(ctx, bson.M{"user_id": bson.M{"$in": ids}})
+}
+
+// SelectFavouritePosts finds the published posts favourited by the user, the latest first
+func (r *repository) SelectFavouritePosts(ctx context.Context, userID primitive.ObjectID) ([]*model.Post, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	ids, err := r.favorites.Distinct(ctx, "post_id", bson.M{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	return r.selectPublishedPosts(ctx, bson.M{"_id": bson.M{"$in": ids}})
+}
+
+// IsFavourite checks whether the user favourited the post
+func (r *repository) IsFavourite(ctx context.Context, userID, postID primitive.ObjectID) (bool, error) {
+	return exists(ctx, r.favorites, bson.M{"user_id": userID, "post_id": postID})
+}
+
+// Favourite performs insert action into favourite collection unless the user already favourited the post
+// and increments the favourite counter of the post, it returns whether the favourite was inserted
+func (r *repository) Favourite(ctx context.Context, o *model.FavoritePost) (bool, error) {
+	filter := bson.M{"user_id": o.UserID, "post_id": o.PostID}
+
+	inserted, err := insertOnce(ctx, r.favorites, filter, &o.CreatedAt)
+	if err != nil || !inserted {
+		return inserted, err
+	}
+
+	return true, increment(ctx, r.posts, o.PostID, "favourite_count", 1)
+}
+
+// Unfavourite performs delete action of the favourite and decrements the favourite counter of the post,
+// it returns whether the favourite existed
+func (r *repository) Unfavourite(ctx context.Context, userID, postID primitive.ObjectID) (bool, error) {
+	deleted, err := deleteOne(ctx, r.favorites, bson.M{"user_id": userID, "post_id": postID})
+	if err != nil || !deleted {
+		return deleted, err
+	}
+
+	return true, increment(ctx, r.posts, postID, "favourite_count", -1)
+}
+
+// countFollow adds the delta to the following counter of the user and to the follower counter of the followed user
+func (r *repository) countFollow(ctx context.Context, userID, followUserID primitive.ObjectID, delta int) error {
+	if err := increment(ctx, r.users, userID, "following_count", delta); err != nil {
+		return err
+	}
+
+	return increment(ctx, r.users, followUserID, "follower_count", delta)
+}
+
+// selectPublishedPosts finds the published posts matching the filter, the latest first
+func (r *repository) selectPublishedPosts(ctx context.Context, filter bson.M) ([]*model.Post, error) {
+	filter["is_published"] = true
+	filter["deleted_at"] = bson.M{"$exists": false}
+	filter["hidden_at"] = nil
+
+	cursor, err := r.posts.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var posts []*model.Post
+	if err = cursor.All(ctx, &posts); err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+// exists checks whether a document of the collection matches the filter
+func exists(ctx context.Context, collection *mongo.Collection, filter bson.M) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	count, err := collection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// insertOnce inserts the document matching the filter unless it exists, the creation time is filled
+// and it returns whether the document was inserted. Two concurrent upserts can both miss the document,
+// the unique index then refuses the second one, which is reported as not inserted.
+func insertOnce(ctx context.Context, collection *mongo.Collection, filter bson.M, createdAt **time.Time) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	*createdAt = &now
+
+	update := bson.M{"$setOnInsert": bson.M{"created_at": now}}
+	result, err := collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return result.UpsertedCount > 0, nil
+}
+
+// deleteOne deletes the document matching the filter and returns whether it existed
+func deleteOne(ctx context.Context, collection *mongo.Collection, filter bson.M) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	result, err := collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return false, err
+	}
+
+	return result.DeletedCount > 0, nil
+}
+
+// increment adds the delta to the counter of the document, the version is left alone so the counters
+// do not fail the conditional updates of the document
+func increment(ctx context.Context, collection *mongo.Collection, id primitive.ObjectID, counter string, delta int) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := collection.UpdateByID(ctx, id, bson.M{"$inc": bson.M{counter: delta}})
+	return err
+}
@@ -0,0 +1,100 @@
+package favourite
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/database"
+	"golang-project/database/databasetest"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	postRepo "golang-project/internal/repository/post"
+	userRepo "golang-project/internal/repository/user"
+)
+
+func TestFollowCountsOnce(t *testing.T) {
+	db := databasetest.Connect(t)
+	ctx := context.Background()
+	users := userRepo.NewRepository(db)
+	r := NewRepository(db)
+	unitOfWork := database.NewUnitOfWork(db)
+
+	follower, err := users.Insert(ctx, &model.User{Pseudonym: "follower"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	blogger, err := users.Insert(ctx, &model.User{Pseudonym: "blogger"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Following twice counts the follow once and unfollowing takes it back
+	for _, step := range []struct {
+		follow              bool
+		following, follower int64
+	}{
+		{follow: true, following: 1, follower: 1},
+		{follow: true, following: 1, follower: 1},
+		{follow: false, following: 0, follower: 0},
+	} {
+		err = unitOfWork.Do(ctx, func(ctx context.Context) error {
+			var err error
+			if step.follow {
+				_, err = r.Follow(ctx, &model.FollowUser{UserID: follower.ID, FollowUserID: blogger.ID})
+			} else {
+				_, err = r.Unfollow(ctx, follower.ID, blogger.ID)
+			}
+			return err
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := readUser(t, users, follower.ID).FollowingCount; got != step.following {
+			t.Errorf("following_count = %d, want %d", got, step.following)
+		}
+		if got := readUser(t, users, blogger.ID).FollowerCount; got != step.follower {
+			t.Errorf("follower_count = %d, want %d", got, step.follower)
+		}
+	}
+}
+
+func TestFavouriteCountsOnce(t *testing.T) {
+	db := databasetest.Connect(t)
+	ctx := context.Background()
+	posts := postRepo.NewRepository(db)
+	r := NewRepository(db)
+	userID := primitive.NewObjectID()
+
+	post, err := posts.Insert(ctx, &model.Post{Title: "Generics", IsPublished: true, UserID: primitive.NewObjectID()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for range 2 {
+		if _, err = r.Favourite(ctx, &model.FavoritePost{UserID: userID, PostID: post.ID}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if post, err = posts.Read(ctx, post.ID); err != nil {
+		t.Fatal(err)
+	}
+	if post.FavouriteCount != 1 {
+		t.Errorf("favourite_count = %d, want 1", post.FavouriteCount)
+	}
+}
+
+// readUser reads the counters of the user
+func readUser(t *testing.T, users repo.User, id primitive.ObjectID) *model.User {
+	t.Helper()
+
+	user, err := users.Read(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return user
+}
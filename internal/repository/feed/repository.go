@@ -0,0 +1,150 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"golang-project/database"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	"golang-project/static"
+)
+
+// repository represents the implementation of repository.Feed
+type repository struct {
+	posts *mongo.Collection
+	users *mongo.Collection
+	tags  *mongo.Collection
+}
+
+// NewRepository returns a new implementation of repository.Feed
+func NewRepository(db database.Connection) repo.Feed {
+	mongoDB := db.GetDatabase()
+
+	return &repository{
+		posts: mongoDB.Collection(static.CollectionPosts),
+		users: mongoDB.Collection(static.CollectionUsers),
+		tags:  mongoDB.Collection(static.CollectionTags),
+	}
+}
+
+// SelectPublishedPosts finds the latest published posts, optionally filtered by author and tag,
+// the posts of the excluded authors are left out
+func (r *repository) SelectPublishedPosts(ctx context.Context, userID, tagID *primitive.ObjectID, excludeUserIDs []primitive.ObjectID, limit int) ([]*model.Post, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"is_published": true, "deleted_at": bson.M{"$exists": false}, "hidden_at": nil}
+	if userID != nil {
+		filter["user_id"] = *userID
+	}
+	if tagID != nil {
+		filter["tag_ids"] = *tagID
+	}
+	if len(excludeUserIDs) > 0 && userID == nil {
+		filter["user_id"] = bson.M{"$nin": excludeUserIDs}
+	}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(int64(limit))
+
+	cursor, err := r.posts.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var posts []*model.Post
+	if err = cursor.All(ctx, &posts); err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+// ReadUserByPseudonym finds and returns the user model by pseudonym unless the user is disabled or
+// scheduled for deletion. Users signed up by earlier versions may still have their email as pseudonym,
+// their feed is not served so the URL of the feed does not publish the email.
+func (r *repository) ReadUserByPseudonym(ctx context.Context, pseudonym string) (*model.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"pseudonym":             pseudonym,
+		"disabled_at":           nil,
+		"deletion_scheduled_at": nil,
+		"$expr":                 bson.M{"$ne": bson.A{bson.M{"$toLower": "$pseudonym"}, bson.M{"$toLower": "$email"}}},
+	}
+
+	var result model.User
+	err := r.users.FindOne(ctx, filter).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, static.ErrBloggerNotFound
+		}
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ReadTagByName finds and returns the tag model by name
+func (r *repository) ReadTagByName(ctx context.Context, name string) (*model.Tag, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var result model.Tag
+	filter := bson.M{"name": name, "deleted_at": bson.M{"$exists": false}}
+	err := r.tags.FindOne(ctx, filter).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, static.ErrTagNotFound
+		}
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// SelectUsers finds and returns the user models by IDs
+func (r *repository) SelectUsers(ctx context.Context, ids []primitive.ObjectID) ([]*model.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	cursor, err := r.users.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []*model.User
+	if err = cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// SelectTags finds and returns the tag models by IDs
+func (r *repository) SelectTags(ctx context.Context, ids []primitive.ObjectID) ([]*model.Tag, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	cursor, err := r.tags.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tags []*model.Tag
+	if err = cursor.All(ctx, &tags); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
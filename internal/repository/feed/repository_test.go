@@ -0,0 +1,39 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"golang-project/database/databasetest"
+	"golang-project/internal/model"
+	userRepo "golang-project/internal/repository/user"
+	"golang-project/static"
+)
+
+func TestReadUserByPseudonymRefusesHiddenBloggers(t *testing.T) {
+	db := databasetest.Connect(t)
+	ctx := context.Background()
+	users := userRepo.NewRepository(db)
+	r := NewRepository(db)
+	now := time.Now()
+
+	for name, tc := range map[string]struct {
+		user *model.User
+		want error
+	}{
+		"active":                 {&model.User{Pseudonym: "jane-3f9a1c", Email: "jane@example.com"}, nil},
+		"disabled":               {&model.User{Pseudonym: "john-8b2d4e", Email: "john@example.com", DisabledAt: &now}, static.ErrBloggerNotFound},
+		"scheduled for deletion": {&model.User{Pseudonym: "ann-c41f07", Email: "ann@example.com", DeletionScheduledAt: &now}, static.ErrBloggerNotFound},
+		"email as pseudonym":     {&model.User{Pseudonym: "Max@example.com", Email: "max@example.com"}, static.ErrBloggerNotFound},
+	} {
+		if _, err := users.Insert(ctx, tc.user); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := r.ReadUserByPseudonym(ctx, tc.user.Pseudonym); !errors.Is(err, tc.want) {
+			t.Errorf("%s: err = %v, want %v", name, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"golang-project/database"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	"golang-project/static"
+)
+
+// repository represents the implementation of repository.Idempotency
+type repository struct {
+	keys *mongo.Collection
+}
+
+// NewRepository returns a new implementation of repository.Idempotency
+func NewRepository(db database.Connection) repo.Idempotency {
+	return &repository{keys: db.GetDatabase().Collection(static.CollectionIdempotencyKeys)}
+}
+
+// EnsureIndexes creates the unique index of the keys per user and the TTL index removing the expired keys
+func (r *repository) EnsureIndexes(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := r.keys.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "key", Value: 1}, {Key: "user_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	})
+	return err
+}
+
+// Reserve inserts the key of the user in progress and returns true, or returns the stored key and false
+// when the user already sent it. The keys expired but not yet removed by the TTL index are replaced.
+func (r *repository) Reserve(ctx context.Context, o *model.IdempotencyKey) (*model.IdempotencyKey, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{"key": o.Key, "user_id": o.UserID}
+
+	_, err := r.keys.DeleteOne(ctx, bson.M{"key": o.Key, "user_id": o.UserID, "expires_at": bson.M{"$lte": now}})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if o.ID.IsZero() {
+		o.ID = primitive.NewObjectID()
+	}
+	o.CreatedAt = &now
+	o.UpdatedAt = &now
+
+	_, err = r.keys.InsertOne(ctx, o)
+	if err == nil {
+		return o, true, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return nil, false, err
+	}
+
+	var result model.IdempotencyKey
+	if err = r.keys.FindOne(ctx, filter).Decode(&result); err != nil {
+		return nil, false, err
+	}
+
+	return &result, false, nil
+}
+
+// Complete stores the response of the key
+func (r *repository) Complete(ctx context.Context, o *model.IdempotencyKey) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	o.CompletedAt = &now
+
+	update := bson.M{
+		"$set": bson.M{
+			"status_code":  o.StatusCode,
+			"content_type": o.ContentType,
+			"body":         o.Body,
+			"completed_at": now,
+			"updated_at":   now,
+		},
+		"$inc": bson.M{"version": 1},
+	}
+
+	_, err := r.keys.UpdateByID(ctx, o.ID, update)
+	return err
+}
+
+// Release removes the key in progress so the request can be retried
+func (r *repository) Release(ctx context.Context, id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := r.keys.DeleteOne(ctx, bson.M{"_id": id, "completed_at": bson.M{"$exists": false}})
+	return err
+}
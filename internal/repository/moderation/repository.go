@@ -0,0 +1,225 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"golang-project/database"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	"golang-project/static"
+)
+
+// repository represents the implementation of repository.Moderation
+type repository struct {
+	reports  *mongo.Collection
+	actions  *mongo.Collection
+	posts    *mongo.Collection
+	comments *mongo.Collection
+	users    *mongo.Collection
+}
+
+// NewRepository returns a new implementation of repository.Moderation
+func NewRepository(db database.Connection) repo.Moderation {
+	mongoDB := db.GetDatabase()
+
+	return &repository{
+		reports:  mongoDB.Collection(static.CollectionReports),
+		actions:  mongoDB.Collection(static.CollectionModerationActions),
+		posts:    mongoDB.Collection(static.CollectionPosts),
+		comments: mongoDB.Collection(static.CollectionComments),
+		users:    mongoDB.Collection(static.CollectionUsers),
+	}
+}
+
+// ReadPost finds and returns the post model by ID
+func (r *repository) ReadPost(ctx context.Context, id primitive.ObjectID) (*model.Post, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var result model.Post
+	err := r.posts.FindOne(ctx, bson.M{"_id": id, "deleted_at": bson.M{"$exists": false}}).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, static.ErrReportTargetNotFound
+		}
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ReadComment finds and returns the comment model by ID
+func (r *repository) ReadComment(ctx context.Context, id primitive.ObjectID) (*model.Comment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var result model.Comment
+	err := r.comments.FindOne(ctx, bson.M{"_id": id, "deleted_at": bson.M{"$exists": false}}).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, static.ErrReportTargetNotFound
+		}
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// HasOpenReport checks whether the reporter has an open report on the content
+func (r *repository) HasOpenReport(ctx context.Context, reporterID primitive.ObjectID, targetType static.ReportTargetType, targetID primitive.ObjectID) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"reporter_id": reporterID, "target_type": targetType, "target_id": targetID, "status": static.ReportOpen}
+	count, err := r.reports.CountDocuments(ctx, filter)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// InsertReport performs insert action into report collection
+func (r *repository) InsertReport(ctx context.Context, o *model.Report) (*model.Report, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if o.ID.IsZero() {
+		o.ID = primitive.NewObjectID()
+	}
+
+	now := time.Now()
+	o.CreatedAt = &now
+	o.UpdatedAt = &now
+
+	_, err := r.reports.InsertOne(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+// SelectReportGroups aggregates the open reports per content, most reported first,
+// and returns the page of groups with the total number of reported contents
+func (r *repository) SelectReportGroups(ctx context.Context, targetType static.ReportTargetType, offset, limit int) ([]*model.ReportGroup, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	match := bson.M{"status": static.ReportOpen}
+	if targetType != "" {
+		match["target_type"] = targetType
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{
+			"_id":               bson.M{"target_type": "$target_type", "target_id": "$target_id"},
+			"author_id":         bson.M{"$first": "$author_id"},
+			"count":             bson.M{"$sum": 1},
+			"reasons":           bson.M{"$push": "$reason"},
+			"first_reported_at": bson.M{"$min": "$created_at"},
+			"last_reported_at":  bson.M{"$max": "$created_at"},
+		}}},
+		{{Key: "$set", Value: bson.M{"target_type": "$_id.target_type", "target_id": "$_id.target_id"}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "last_reported_at", Value: -1}}}},
+		{{Key: "$facet", Value: bson.M{
+			"groups": bson.A{bson.M{"$skip": offset}, bson.M{"$limit": limit}},
+			"total":  bson.A{bson.M{"$count": "value"}},
+		}}},
+	}
+
+	cursor, err := r.reports.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Groups []*model.ReportGroup `bson:"groups"`
+		Total  []struct {
+			Value int64 `bson:"value"`
+		} `bson:"total"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, 0, err
+	}
+
+	if len(results) == 0 || len(results[0].Total) == 0 {
+		return nil, 0, nil
+	}
+
+	return results[0].Groups, results[0].Total[0].Value, nil
+}
+
+// InsertAction performs insert action into moderation_actions collection
+func (r *repository) InsertAction(ctx context.Context, o *model.ModerationAction) (*model.ModerationAction, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if o.ID.IsZero() {
+		o.ID = primitive.NewObjectID()
+	}
+
+	now := time.Now()
+	o.CreatedAt = &now
+	o.UpdatedAt = &now
+
+	_, err := r.actions.InsertOne(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+// ResolveReports resolves the open reports of the content with the moderation action
+// and returns the number of resolved reports
+func (r *repository) ResolveReports(ctx context.Context, targetType static.ReportTargetType, targetID, actionID primitive.ObjectID) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{"target_type": targetType, "target_id": targetID, "status": static.ReportOpen}
+	update := bson.M{"$set": bson.M{"status": static.ReportResolved, "action_id": actionID, "resolved_at": now, "updated_at": now}, "$inc": bson.M{"version": 1}}
+
+	result, err := r.reports.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.ModifiedCount, nil
+}
+
+// HideContent hides the post or comment from the reads
+func (r *repository) HideContent(ctx context.Context, targetType static.ReportTargetType, targetID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := r.posts
+	if targetType == static.ReportTargetComment {
+		collection = r.comments
+	}
+
+	now := time.Now()
+	_, err := collection.UpdateOne(ctx, bson.M{"_id": targetID}, bson.M{"$set": bson.M{"hidden_at": now, "updated_at": now}, "$inc": bson.M{"version": 1}})
+	return err
+}
+
+// SuspendUser disables the account of the user unless it is already disabled
+func (r *repository) SuspendUser(ctx context.Context, userID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{"_id": userID, "disabled_at": nil}
+
+	_, err := r.users.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"disabled_at": now, "updated_at": now}, "$inc": bson.M{"version": 1}})
+	return err
+}
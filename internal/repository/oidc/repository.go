@@ -0,0 +1,76 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"golang-project/database"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	"golang-project/static"
+)
+
+// repository represents the implementation of repository.OIDCState
+type repository struct {
+	states *mongo.Collection
+}
+
+// NewRepository returns a new implementation of repository.OIDCState
+func NewRepository(db database.Connection) repo.OIDCState {
+	return &repository{
+		states: db.GetDatabase().Collection(static.CollectionOIDCStates),
+	}
+}
+
+// EnsureIndexes creates the unique index of the state and the TTL index removing the abandoned sign-ins
+func (r *repository) EnsureIndexes(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := r.states.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "state", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	})
+	return err
+}
+
+// Insert performs insert action into oidc_states collection
+func (r *repository) Insert(ctx context.Context, o *model.OIDCState) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if o.ID.IsZero() {
+		o.ID = primitive.NewObjectID()
+	}
+
+	now := time.Now()
+	o.CreatedAt = &now
+
+	_, err := r.states.InsertOne(ctx, o)
+	return err
+}
+
+// Consume performs delete action of the unexpired state and returns it
+func (r *repository) Consume(ctx context.Context, state string) (*model.OIDCState, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"state": state, "expires_at": bson.M{"$gt": time.Now()}}
+
+	var result model.OIDCState
+	err := r.states.FindOneAndDelete(ctx, filter).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, static.ErrOIDCStateInvalid
+		}
+		return nil, err
+	}
+
+	return &result, nil
+}
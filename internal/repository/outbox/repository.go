@@ -0,0 +1,109 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"golang-project/database"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	"golang-project/static"
+)
+
+// repository represents the implementation of repository.Outbox
+type repository struct {
+	events *mongo.Collection
+}
+
+// NewRepository returns a new implementation of repository.Outbox
+func NewRepository(db database.Connection) repo.Outbox {
+	return &repository{
+		events: db.GetDatabase().Collection(static.CollectionOutbox),
+	}
+}
+
+// EnsureIndexes creates the indexes of the due and stale events polled by the relay and the TTL index
+// removing the dispatched events after the retention, the failed events are kept for inspection
+func (r *repository) EnsureIndexes(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := r.events.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "updated_at", Value: 1}}},
+		{Keys: bson.D{{Key: "dispatched_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(int32(static.Outbox.Retention.Seconds()))},
+	})
+	return err
+}
+
+// Insert performs insert action of the events into outbox collection
+func (r *repository) Insert(ctx context.Context, events []*model.OutboxEvent) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	documents := make([]interface{}, 0, len(events))
+	for _, o := range events {
+		if o.ID.IsZero() {
+			o.ID = primitive.NewObjectID()
+		}
+		o.CreatedAt = &now
+		o.UpdatedAt = &now
+		documents = append(documents, o)
+	}
+
+	_, err := r.events.InsertMany(ctx, documents)
+	return err
+}
+
+// ClaimDue atomically moves the oldest pending event due at the time to dispatching and returns it
+func (r *repository) ClaimDue(ctx context.Context, now time.Time) (*model.OutboxEvent, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"status": static.OutboxPending, "next_attempt_at": bson.M{"$lte": now}}
+	update := bson.M{"$set": bson.M{"status": static.OutboxDispatching, "updated_at": time.Now()}, "$inc": bson.M{"version": 1}}
+	findOptions := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var result model.OutboxEvent
+	err := r.events.FindOneAndUpdate(ctx, filter, update, findOptions).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, static.ErrOutboxEventNotFound
+		}
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// Update performs update action into outbox collection
+func (r *repository) Update(ctx context.Context, o *model.OutboxEvent, updates map[string]interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	updates["updated_at"] = time.Now()
+
+	_, err := r.events.UpdateOne(ctx, bson.M{"_id": o.ID}, bson.M{"$set": updates, "$inc": bson.M{"version": 1}})
+	return err
+}
+
+// RequeueStale moves the events left dispatching since before the time back to pending
+func (r *repository) RequeueStale(ctx context.Context, before time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"status": static.OutboxDispatching, "updated_at": bson.M{"$lt": before}}
+	update := bson.M{"$set": bson.M{"status": static.OutboxPending, "updated_at": time.Now()}, "$inc": bson.M{"version": 1}}
+
+	_, err := r.events.UpdateMany(ctx, filter, update)
+	return err
+}
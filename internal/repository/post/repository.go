@@ -0,0 +1,318 @@
+package post
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"golang-project/database"
+	"golang-project/internal/contract"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	"golang-project/static"
+	"golang-project/util/pagination"
+)
+
+// repository represents the implementation of repository.Post
+type repository struct {
+	posts    *mongo.Collection
+	postTags *mongo.Collection
+	tags     *mongo.Collection
+	users    *mongo.Collection
+}
+
+// NewRepository returns a new implementation of repository.Post
+func NewRepository(db database.Connection) repo.Post {
+	mongoDB := db.GetDatabase()
+
+	return &repository{
+		posts:    mongoDB.Collection(static.CollectionPosts),
+		postTags: mongoDB.Collection(static.CollectionPostTags),
+		tags:     mongoDB.Collection(static.CollectionTags),
+		users:    mongoDB.Collection(static.CollectionUsers),
+	}
+}
+
+// Read finds and returns the post model by ID
+func (r *repository) Read(ctx context.Context, id primitive.ObjectID) (*model.Post, error) {
+	return r.ReadByCondition(ctx, map[string]interface{}{"_id": id})
+}
+
+// Insert performs insert action into post collection and increments the post counter of the author when published
+func (r *repository) Insert(ctx context.Context, o *model.Post) (*model.Post, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if o.ID.IsZero() {
+		o.ID = primitive.NewObjectID()
+	}
+
+	now := time.Now()
+	o.CreatedAt = &now
+	o.UpdatedAt = &now
+
+	if _, err := r.posts.InsertOne(ctx, o); err != nil {
+		return nil, err
+	}
+
+	if o.IsPublished {
+		if err := r.countPost(ctx, o.UserID, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	return o, nil
+}
+
+// AddPostTags links the post to the tags. The tags are written along with the links so a concurrent delete
+// of one of them conflicts with the transaction, it returns static.ErrTagNotFoundOrDeleted when a tag is missing
+func (r *repository) AddPostTags(ctx context.Context, postID primitive.ObjectID, tagIDs []primitive.ObjectID) error {
+	if len(tagIDs) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": bson.M{"$in": tagIDs}, "deleted_at": bson.M{"$exists": false}}
+	result, err := r.tags.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"linked_at": time.Now()}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount != int64(len(tagIDs)) {
+		return static.ErrTagNotFoundOrDeleted
+	}
+
+	links := make([]interface{}, 0, len(tagIDs))
+	for _, tagID := range tagIDs {
+		links = append(links, &model.PostTag{TagID: tagID, PostID: postID})
+	}
+
+	_, err = r.postTags.InsertMany(ctx, links)
+	return err
+}
+
+// FindSlugsLike returns the slugs taken by the base slug and its numbered variants, deleted posts included
+func (r *repository) FindSlugsLike(ctx context.Context, base string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"slug": bson.M{"$regex": "^" + regexp.QuoteMeta(base) + "(-[0-9]+)?$"}}
+	slugs, err := r.posts.Distinct(ctx, "slug", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, len(slugs))
+	for _, slug := range slugs {
+		if s, ok := slug.(string); ok {
+			result = append(result, s)
+		}
+	}
+
+	return result, nil
+}
+
+// GetTags finds and returns the tags linked to the post
+func (r *repository) GetTags(ctx context.Context, postID primitive.ObjectID) ([]*model.Tag, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	tagIDs, err := r.postTags.Distinct(ctx, "tag_id", bson.M{"post_id": postID})
+	if err != nil {
+		return nil, err
+	}
+	if len(tagIDs) == 0 {
+		return nil, nil
+	}
+
+	cursor, err := r.tags.Find(ctx, bson.M{"_id": bson.M{"$in": tagIDs}, "deleted_at": bson.M{"$exists": false}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tags []*model.Tag
+	if err = cursor.All(ctx, &tags); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// ReadByCondition finds and returns the post model matching the conditions, limited to the fields when given
+func (r *repository) ReadByCondition(ctx context.Context, conditions map[string]interface{}, fields ...string) (*model.Post, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"deleted_at": bson.M{"$exists": false}}
+	for field, value := range conditions {
+		filter[field] = value
+	}
+
+	findOptions := options.FindOne()
+	if len(fields) > 0 {
+		projection := bson.M{}
+		for _, field := range fields {
+			projection[field] = 1
+		}
+		findOptions.SetProjection(projection)
+	}
+
+	var result model.Post
+	err := r.posts.FindOne(ctx, filter, findOptions).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, static.ErrPostNotFound
+		}
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// Select finds the published posts matching the filters of the request, the latest first
+func (r *repository) Select(ctx context.Context, req *contract.ListPostRequest) ([]*model.Post, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"is_published": true, "deleted_at": bson.M{"$exists": false}, "hidden_at": nil}
+
+	if req.Tag != "" {
+		var tag model.Tag
+		err := r.tags.FindOne(ctx, bson.M{"name": req.Tag, "deleted_at": bson.M{"$exists": false}}).Decode(&tag)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		filter["tag_ids"] = tag.ID
+	}
+
+	if req.Pseudonym != "" {
+		var user model.User
+		err := r.users.FindOne(ctx, bson.M{"pseudonym": req.Pseudonym}).Decode(&user)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		filter["user_id"] = user.ID
+	}
+
+	if req.Title != "" {
+		filter["title"] = bson.M{"$regex": regexp.QuoteMeta(req.Title), "$options": "i"}
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip(int64(pagination.CalculateOffset(req.Page, req.PageSize))).
+		SetLimit(int64(req.PageSize))
+
+	cursor, err := r.posts.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var posts []*model.Post
+	if err = cursor.All(ctx, &posts); err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+// UpdatePost performs update action into post collection, publishing or unpublishing the post
+// increments or decrements the post counter of the author
+func (r *repository) UpdatePost(ctx context.Context, o *model.Post, updates map[string]interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	updates["updated_at"] = time.Now()
+
+	// Only update the version that was read so concurrent updates do not overwrite each other
+	filter := bson.M{"_id": o.ID, "version": o.Version, "deleted_at": bson.M{"$exists": false}}
+	if o.Version == 0 {
+		filter["version"] = bson.M{"$exists": false}
+	}
+
+	var before model.Post
+	err := r.posts.FindOneAndUpdate(ctx, filter, bson.M{"$set": updates, "$inc": bson.M{"version": 1}}).Decode(&before)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return static.ErrVersionConflict
+	}
+	if err != nil {
+		return err
+	}
+
+	isPublished, ok := updates["is_published"].(bool)
+	if !ok || isPublished == before.IsPublished {
+		return nil
+	}
+	if isPublished {
+		return r.countPost(ctx, before.UserID, 1)
+	}
+
+	return r.countPost(ctx, before.UserID, -1)
+}
+
+// UpdatePostTag replaces the tag links of the post
+func (r *repository) UpdatePostTag(ctx context.Context, o *model.Post, tagIDs []primitive.ObjectID) error {
+	if err := r.deletePostTags(ctx, o.ID); err != nil {
+		return err
+	}
+
+	return r.AddPostTags(ctx, o.ID, tagIDs)
+}
+
+// Delete performs soft delete action of the post and removes its tag links so its tags can be deleted,
+// deleting a published post decrements the post counter of the author
+func (r *repository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{"_id": id, "deleted_at": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"deleted_at": now, "updated_at": now}, "$inc": bson.M{"version": 1}}
+
+	var before model.Post
+	err := r.posts.FindOneAndUpdate(ctx, filter, update).Decode(&before)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return static.ErrPostNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if before.IsPublished {
+		if err = r.countPost(ctx, before.UserID, -1); err != nil {
+			return err
+		}
+	}
+
+	return r.deletePostTags(ctx, id)
+}
+
+// countPost adds the delta to the published post counter of the user
+func (r *repository) countPost(ctx context.Context, userID primitive.ObjectID, delta int) error {
+	_, err := r.users.UpdateByID(ctx, userID, bson.M{"$inc": bson.M{"post_count": delta}})
+	return err
+}
+
+// deletePostTags performs delete action of the tag links of the post
+func (r *repository) deletePostTags(ctx context.Context, postID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := r.postTags.DeleteMany(ctx, bson.M{"post_id": postID})
+	return err
+}
@@ -0,0 +1,188 @@
+package reaction
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"golang-project/database"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	"golang-project/static"
+)
+
+// repository represents the implementation of repository.Reaction
+type repository struct {
+	reactions *mongo.Collection
+	posts     *mongo.Collection
+	comments  *mongo.Collection
+}
+
+// NewRepository returns a new implementation of repository.Reaction
+func NewRepository(db database.Connection) repo.Reaction {
+	mongoDB := db.GetDatabase()
+
+	return &repository{
+		reactions: mongoDB.Collection(static.CollectionReactions),
+		posts:     mongoDB.Collection(static.CollectionPosts),
+		comments:  mongoDB.Collection(static.CollectionComments),
+	}
+}
+
+// EnsureIndexes creates the unique index keeping one reaction per user per content
+func (r *repository) EnsureIndexes(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := r.reactions.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "target_type", Value: 1}, {Key: "target_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+// ReadPost finds and returns the visible post model by ID, drafts are only visible to their author through the profile
+func (r *repository) ReadPost(ctx context.Context, id primitive.ObjectID) (*model.Post, error) {
+	var result model.Post
+	if err := r.readVisible(ctx, r.posts, bson.M{"_id": id, "is_published": true}, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ReadComment finds and returns the visible comment model by ID, the comments of a draft are not visible
+func (r *repository) ReadComment(ctx context.Context, id primitive.ObjectID) (*model.Comment, error) {
+	var result model.Comment
+	if err := r.readVisible(ctx, r.comments, bson.M{"_id": id}, &result); err != nil {
+		return nil, err
+	}
+
+	if _, err := r.ReadPost(ctx, result.PostID); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// readVisible decodes the document matching the filter unless it is deleted or hidden by a moderator
+func (r *repository) readVisible(ctx context.Context, collection *mongo.Collection, filter bson.M, result interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter["deleted_at"] = bson.M{"$exists": false}
+	filter["hidden_at"] = nil
+	err := collection.FindOne(ctx, filter).Decode(result)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return static.ErrReactionTargetNotFound
+	}
+
+	return err
+}
+
+// Upsert inserts the reaction of the user on the content or replaces its type. Concurrent upserts
+// can both try to insert, the one losing on the unique index is retried and updates the inserted reaction
+func (r *repository) Upsert(ctx context.Context, o *model.Reaction) (*model.Reaction, error) {
+	result, err := r.upsert(ctx, o)
+	if mongo.IsDuplicateKeyError(err) {
+		return r.upsert(ctx, o)
+	}
+
+	return result, err
+}
+
+// upsert performs the insert or update action of the reaction
+func (r *repository) upsert(ctx context.Context, o *model.Reaction) (*model.Reaction, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{"user_id": o.UserID, "target_type": o.TargetType, "target_id": o.TargetID}
+	update := bson.M{
+		"$set":         bson.M{"type": o.Type, "updated_at": now},
+		"$setOnInsert": bson.M{"created_at": now},
+	}
+	updateOptions := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var result model.Reaction
+	err := r.reactions.FindOneAndUpdate(ctx, filter, update, updateOptions).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// Delete performs delete action of the reaction of the user on the content, it returns whether the reaction existed
+func (r *repository) Delete(ctx context.Context, userID primitive.ObjectID, targetType static.ReactionTargetType, targetID primitive.ObjectID) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	result, err := r.reactions.DeleteOne(ctx, bson.M{"user_id": userID, "target_type": targetType, "target_id": targetID})
+	if err != nil {
+		return false, err
+	}
+
+	return result.DeletedCount > 0, nil
+}
+
+// SelectCounts aggregates the number of reactions of each type left on the contents
+func (r *repository) SelectCounts(ctx context.Context, targetType static.ReactionTargetType, targetIDs []primitive.ObjectID) ([]*model.ReactionCount, error) {
+	if len(targetIDs) == 0 {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"target_type": targetType, "target_id": bson.M{"$in": targetIDs}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"target_id": "$target_id", "type": "$type"},
+			"count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$project", Value: bson.M{"_id": 0, "target_id": "$_id.target_id", "type": "$_id.type", "count": 1}}},
+	}
+
+	cursor, err := r.reactions.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []*model.ReactionCount
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// SelectUserReactions finds and returns the reactions of the user on the contents
+func (r *repository) SelectUserReactions(ctx context.Context, userID primitive.ObjectID, targetType static.ReactionTargetType, targetIDs []primitive.ObjectID) ([]*model.Reaction, error) {
+	if len(targetIDs) == 0 {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"user_id": userID, "target_type": targetType, "target_id": bson.M{"$in": targetIDs}}
+	cursor, err := r.reactions.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []*model.Reaction
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
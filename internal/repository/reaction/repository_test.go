@@ -0,0 +1,45 @@
+package reaction
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/database/databasetest"
+	"golang-project/internal/model"
+	commentRepo "golang-project/internal/repository/comment"
+	postRepo "golang-project/internal/repository/post"
+	"golang-project/static"
+)
+
+func TestReadRefusesDraftsAndTheirComments(t *testing.T) {
+	db := databasetest.Connect(t)
+	ctx := context.Background()
+	posts := postRepo.NewRepository(db)
+	comments := commentRepo.NewRepository(db)
+	r := NewRepository(db)
+
+	for _, isPublished := range []bool{true, false} {
+		post, err := posts.Insert(ctx, &model.Post{Title: "Generics", IsPublished: isPublished, UserID: primitive.NewObjectID()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		comment, err := comments.Insert(ctx, &model.Comment{Content: "Thanks", PostID: post.ID, UserID: primitive.NewObjectID()})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var want error
+		if !isPublished {
+			want = static.ErrReactionTargetNotFound
+		}
+		if _, err = r.ReadPost(ctx, post.ID); !errors.Is(err, want) {
+			t.Errorf("ReadPost() of a post published %v: err = %v, want %v", isPublished, err, want)
+		}
+		if _, err = r.ReadComment(ctx, comment.ID); !errors.Is(err, want) {
+			t.Errorf("ReadComment() on a post published %v: err = %v, want %v", isPublished, err, want)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package relationship
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"golang-project/database"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	"golang-project/static"
+)
+
+// repository represents the implementation of repository.Relationship
+type repository struct {
+	blocks  *mongo.Collection
+	mutes   *mongo.Collection
+	follows *mongo.Collection
+	users   *mongo.Collection
+}
+
+// NewRepository returns a new implementation of repository.Relationship
+func NewRepository(db database.Connection) repo.Relationship {
+	mongoDB := db.GetDatabase()
+
+	return &repository{
+		blocks:  mongoDB.Collection(static.CollectionBlocks),
+		mutes:   mongoDB.Collection(static.CollectionMutes),
+		follows: mongoDB.Collection(static.CollectionFollows),
+		users:   mongoDB.Collection(static.CollectionUsers),
+	}
+}
+
+// InsertBlock performs insert action into block collection unless the user is already blocked,
+// it returns whether the block was inserted
+func (r *repository) InsertBlock(ctx context.Context, o *model.BlockUser) (bool, error) {
+	filter := bson.M{"user_id": o.UserID, "blocked_user_id": o.BlockedUserID}
+
+	return insertOnce(ctx, r.blocks, filter, &o.BaseModel)
+}
+
+// DeleteBlock performs delete action of the block, it returns whether the block existed
+func (r *repository) DeleteBlock(ctx context.Context, userID, blockedUserID primitive.ObjectID) (bool, error) {
+	return deleteOne(ctx, r.blocks, bson.M{"user_id": userID, "blocked_user_id": blockedUserID})
+}
+
+// IsBlocked checks whether one of the users blocked the other
+func (r *repository) IsBlocked(ctx context.Context, userID, otherUserID primitive.ObjectID) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"$or": []bson.M{
+		{"user_id": userID, "blocked_user_id": otherUserID},
+		{"user_id": otherUserID, "blocked_user_id": userID},
+	}}
+
+	count, err := r.blocks.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// SelectBlocks finds and returns the blocks of the user, the latest first
+func (r *repository) SelectBlocks(ctx context.Context, userID primitive.ObjectID) ([]*model.BlockUser, error) {
+	return selectLatest[model.BlockUser](ctx, r.blocks, bson.M{"user_id": userID})
+}
+
+// InsertMute performs insert action into mute collection unless the user is already muted,
+// it returns whether the mute was inserted
+func (r *repository) InsertMute(ctx context.Context, o *model.MuteUser) (bool, error) {
+	filter := bson.M{"user_id": o.UserID, "muted_user_id": o.MutedUserID}
+
+	return insertOnce(ctx, r.mutes, filter, &o.BaseModel)
+}
+
+// DeleteMute performs delete action of the mute, it returns whether the mute existed
+func (r *repository) DeleteMute(ctx context.Context, userID, mutedUserID primitive.ObjectID) (bool, error) {
+	return deleteOne(ctx, r.mutes, bson.M{"user_id": userID, "muted_user_id": mutedUserID})
+}
+
+// SelectMutes finds and returns the mutes of the user, the latest first
+func (r *repository) SelectMutes(ctx context.Context, userID primitive.ObjectID) ([]*model.MuteUser, error) {
+	return selectLatest[model.MuteUser](ctx, r.mutes, bson.M{"user_id": userID})
+}
+
+// SelectHiddenUserIDs finds and returns the users whose content is hidden from the user:
+// the users blocked by or blocking the user and the users muted by the user
+func (r *repository) SelectHiddenUserIDs(ctx context.Context, userID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	blocks, err := selectLatest[model.BlockUser](ctx, r.blocks, bson.M{"$or": []bson.M{{"user_id": userID}, {"blocked_user_id": userID}}})
+	if err != nil {
+		return nil, err
+	}
+
+	mutes, err := r.SelectMutes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(blocks)+len(mutes))
+	for _, block := range blocks {
+		if block.UserID == userID {
+			ids = append(ids, block.BlockedUserID)
+		} else {
+			ids = append(ids, block.UserID)
+		}
+	}
+	for _, mute := range mutes {
+		ids = append(ids, mute.MutedUserID)
+	}
+
+	return ids, nil
+}
+
+// DeleteFollows performs delete action of the follows between the two users in both directions
+// and decrements the follower and following counters of the two users
+func (r *repository) DeleteFollows(ctx context.Context, userID, otherUserID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	for _, follow := range []model.FollowUser{
+		{UserID: userID, FollowUserID: otherUserID},
+		{UserID: otherUserID, FollowUserID: userID},
+	} {
+		result, err := r.follows.DeleteOne(ctx, bson.M{"user_id": follow.UserID, "follow_user_id": follow.FollowUserID})
+		if err != nil {
+			return err
+		}
+		if result.DeletedCount == 0 {
+			continue
+		}
+
+		if _, err = r.users.UpdateByID(ctx, follow.UserID, bson.M{"$inc": bson.M{"following_count": -1}}); err != nil {
+			return err
+		}
+		if _, err = r.users.UpdateByID(ctx, follow.FollowUserID, bson.M{"$inc": bson.M{"follower_count": -1}}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SelectUsers finds and returns the user models by IDs
+func (r *repository) SelectUsers(ctx context.Context, ids []primitive.ObjectID) ([]*model.User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	cursor, err := r.users.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []*model.User
+	if err = cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// insertOnce inserts the document matching the filter unless it exists,
+// the base fields are filled and it returns whether the document was inserted
+func insertOnce(ctx context.Context, collection *mongo.Collection, filter bson.M, base *model.BaseModel) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if base.ID.IsZero() {
+		base.ID = primitive.NewObjectID()
+	}
+
+	now := time.Now()
+	base.CreatedAt = &now
+	base.UpdatedAt = &now
+
+	update := bson.M{"$setOnInsert": bson.M{"_id": base.ID, "created_at": now, "updated_at": now}}
+	result, err := collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return false, err
+	}
+
+	return result.UpsertedCount > 0, nil
+}
+
+// deleteOne deletes the document matching the filter and returns whether it existed
+func deleteOne(ctx context.Context, collection *mongo.Collection, filter bson.M) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	result, err := collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return false, err
+	}
+
+	return result.DeletedCount > 0, nil
+}
+
+// selectLatest finds and returns the documents of the collection matching the filter, the latest first
+func selectLatest[T any](ctx context.Context, collection *mongo.Collection, filter bson.M) ([]*T, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	cursor, err := collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []*T
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -1,65 +1,280 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"golang-project/internal/contract"
 	"golang-project/internal/model"
+	"golang-project/internal/seed"
+	"golang-project/static"
 )
 
 // User represents the repository actions to the user collection
 type User interface {
-	Read(primitive.ObjectID) (*model.User, error)
-	Insert(*model.User) (*model.User, error)
-	Update(*model.User, map[string]interface{}) (*model.User, error)
-	ReadByEmail(string) (*model.User, error)
-	ReadOwnPosts(id primitive.ObjectID, isPublishedFilter *bool) ([]*model.Post, error)
+	EnsureIndexes(context.Context) error
+	Read(context.Context, primitive.ObjectID) (*model.User, error)
+	Insert(context.Context, *model.User) (*model.User, error)
+	Update(context.Context, *model.User, map[string]interface{}) (*model.User, error)
+	ReadByEmail(context.Context, string) (*model.User, error)
+	ReadByPseudonym(context.Context, string) (*model.User, error)
+	ReadByIdentity(ctx context.Context, issuer, subject string) (*model.User, error)
+	ReadOwnPosts(ctx context.Context, id primitive.ObjectID, isPublishedFilter *bool) ([]*model.Post, error)
+	Select(context.Context, []primitive.ObjectID) ([]*model.User, error)
+	// UseTwoFactorStep records the time step of an authenticator code unless the same or a later step was recorded,
+	// it returns whether the step was recorded so concurrent uses of a code cannot both succeed
+	UseTwoFactorStep(ctx context.Context, id primitive.ObjectID, step int64) (bool, error)
+	// UseRecoveryCode removes the hashed recovery code unless it was already removed and returns whether it was removed
+	UseRecoveryCode(ctx context.Context, id primitive.ObjectID, hashed string) (bool, error)
+	// FailTwoFactorCode counts an invalid two-factor code of the user and locks the codes until the time once
+	// the invalid codes reach the maximum, it returns whether this code locked them
+	FailTwoFactorCode(ctx context.Context, id primitive.ObjectID, maxFailed int, lockedUntil time.Time) (bool, error)
+	// SelectPlainTwoFactorSecrets returns the users whose TOTP secret is not encrypted
+	SelectPlainTwoFactorSecrets(context.Context) ([]*model.User, error)
+	// ReplaceTwoFactorSecret replaces the TOTP secret of the user unless it changed and returns whether it was replaced
+	ReplaceTwoFactorSecret(ctx context.Context, id primitive.ObjectID, secret, replacement string) (bool, error)
 }
 
+// Tag represents the repository actions for managing tags. HasPosts and Delete must run in the
+// same database.UnitOfWork so a post cannot be linked to the tag between the check and the delete
 type Tag interface {
-	Insert(*model.Tag) error
-	Read(primitive.ObjectID) (*model.Tag, error)
-	Delete(primitive.ObjectID) error
-	HasPosts(primitive.ObjectID) (bool, error)
-	Select([]primitive.ObjectID) ([]*model.Tag, error)
-	SelectPost(primitive.ObjectID) ([]*model.Post, error)
-	SelectPostTag([]primitive.ObjectID) ([]*model.PostTag, error)
-	SelectUser([]primitive.ObjectID) ([]*model.User, error)
+	Insert(context.Context, *model.Tag) error
+	Read(context.Context, primitive.ObjectID) (*model.Tag, error)
+	Delete(context.Context, primitive.ObjectID) error
+	HasPosts(context.Context, primitive.ObjectID) (bool, error)
+	// Select returns the tags by IDs, or every tag when the IDs are nil
+	Select(context.Context, []primitive.ObjectID) ([]*model.Tag, error)
+	SelectPost(context.Context, primitive.ObjectID) ([]*model.Post, error)
+	SelectPostTag(context.Context, []primitive.ObjectID) ([]*model.PostTag, error)
+	SelectUser(context.Context, []primitive.ObjectID) ([]*model.User, error)
 }
 
+// Comment represents the repository actions for managing the comments of posts
 type Comment interface {
-	Select(*contract.ListCommentRequest) ([]*model.Comment, int64, error)
-	Insert(*model.Comment) (*model.Comment, error)
-	Read(primitive.ObjectID) (*model.Comment, error)
-	UpdateCommentByID(primitive.ObjectID, map[string]interface{}) error
-	Delete(primitive.ObjectID) error
+	// Select returns the page of top-level comments of the post followed by their replies,
+	// and the number of top-level comments
+	Select(context.Context, *contract.ListCommentRequest) ([]*model.Comment, int64, error)
+	// Insert inserts the comment and increments the comment counter of the post, it must run in a database.UnitOfWork
+	Insert(context.Context, *model.Comment) (*model.Comment, error)
+	Read(context.Context, primitive.ObjectID) (*model.Comment, error)
+	UpdateCommentByID(context.Context, primitive.ObjectID, map[string]interface{}) error
+	// Delete deletes the comment with its replies and returns the number of deleted comments,
+	// the comment counter of the post is decremented with it so it must run in a database.UnitOfWork
+	Delete(context.Context, primitive.ObjectID) (int64, error)
 }
 
+// Post represents the repository actions for managing posts. Insert and AddPostTags must run in the
+// same database.UnitOfWork so a post is never stored without its tag links. Insert, UpdatePost and Delete
+// update the published post counter of the author and must run in a database.UnitOfWork as well
 type Post interface {
-	Read(primitive.ObjectID) (*model.Post, error)
-	Insert(*model.Post) (*model.Post, error)
-	AddPostTags(primitive.ObjectID, []primitive.ObjectID) error
-	FindSlugsLike(string) ([]string, error)
-	GetTags(primitive.ObjectID) ([]*model.Tag, error)
-	ReadByCondition(map[string]interface{}, ...string) (*model.Post, error)
-	Select(*contract.ListPostRequest) ([]*model.Post, error)
-	UpdatePost(*model.Post, map[string]interface{}) error
-	UpdatePostTag(*model.Post, []*model.Tag) error
-	Delete(primitive.ObjectID) error
+	Read(context.Context, primitive.ObjectID) (*model.Post, error)
+	Insert(context.Context, *model.Post) (*model.Post, error)
+	AddPostTags(context.Context, primitive.ObjectID, []primitive.ObjectID) error
+	FindSlugsLike(context.Context, string) ([]string, error)
+	GetTags(context.Context, primitive.ObjectID) ([]*model.Tag, error)
+	ReadByCondition(context.Context, map[string]interface{}, ...string) (*model.Post, error)
+	Select(context.Context, *contract.ListPostRequest) ([]*model.Post, error)
+	UpdatePost(context.Context, *model.Post, map[string]interface{}) error
+	// UpdatePostTag replaces the tag links of the post, it must run in the UnitOfWork of the UpdatePost setting the tag IDs
+	UpdatePostTag(context.Context, *model.Post, []primitive.ObjectID) error
+	Delete(context.Context, primitive.ObjectID) error
 }
 
 // Favourite represents the repository actions for managing user follows and post favorites
 type Favourite interface {
+	EnsureIndexes(context.Context) error
 	// User following operations
-	IsFollowing(userID, followUserID primitive.ObjectID) (bool, error)
-	SelectFollowing(userID primitive.ObjectID) ([]*model.User, error)
-	Follow(*model.FollowUser) error
-	Unfollow(userID, followUserID primitive.ObjectID) error
-	SelectFollowingUsersPosts(userID primitive.ObjectID) ([]*model.Post, error)
+	IsFollowing(ctx context.Context, userID, followUserID primitive.ObjectID) (bool, error)
+	SelectFollowing(ctx context.Context, userID primitive.ObjectID) ([]*model.User, error)
+	// Follow inserts the follow unless it exists and returns whether it was inserted, the follower and following
+	// counters are incremented with it so it must run in a database.UnitOfWork
+	Follow(context.Context, *model.FollowUser) (bool, error)
+	// Unfollow deletes the follow and returns whether it existed, the counters are decremented with it
+	Unfollow(ctx context.Context, userID, followUserID primitive.ObjectID) (bool, error)
+	SelectFollowingUsersPosts(ctx context.Context, userID primitive.ObjectID) ([]*model.Post, error)
 
 	// Post favourite operations
-	SelectFavouritePosts(userID primitive.ObjectID) ([]*model.Post, error)
-	IsFavourite(userID, postID primitive.ObjectID) (bool, error)
-	Favourite(*model.FavoritePost) error
-	Unfavourite(userID, postID primitive.ObjectID) error
+	SelectFavouritePosts(ctx context.Context, userID primitive.ObjectID) ([]*model.Post, error)
+	IsFavourite(ctx context.Context, userID, postID primitive.ObjectID) (bool, error)
+	// Favourite inserts the favourite unless it exists and returns whether it was inserted, the favourite
+	// counter of the post is incremented with it so it must run in a database.UnitOfWork
+	Favourite(context.Context, *model.FavoritePost) (bool, error)
+	// Unfavourite deletes the favourite and returns whether it existed, the counter is decremented with it
+	Unfavourite(ctx context.Context, userID, postID primitive.ObjectID) (bool, error)
+}
+
+// Feed represents the repository actions for reading published posts into syndication feeds
+type Feed interface {
+	SelectPublishedPosts(ctx context.Context, userID, tagID *primitive.ObjectID, excludeUserIDs []primitive.ObjectID, limit int) ([]*model.Post, error)
+	ReadUserByPseudonym(context.Context, string) (*model.User, error)
+	ReadTagByName(context.Context, string) (*model.Tag, error)
+	SelectUsers(context.Context, []primitive.ObjectID) ([]*model.User, error)
+	SelectTags(context.Context, []primitive.ObjectID) ([]*model.Tag, error)
+}
+
+// Admin represents the repository actions behind the operator commands on posts and tags
+type Admin interface {
+	ReadPost(context.Context, primitive.ObjectID) (*model.Post, error)
+	ReadPostBySlug(context.Context, string) (*model.Post, error)
+	UnpublishPost(context.Context, primitive.ObjectID) error
+	ReadTag(context.Context, primitive.ObjectID) (*model.Tag, error)
+	ReadTagByName(context.Context, string) (*model.Tag, error)
+	CountTagPosts(context.Context, primitive.ObjectID) (int64, error)
+	MergeTag(ctx context.Context, sourceID, targetID primitive.ObjectID) ([]primitive.ObjectID, error)
+	RecomputeUserCounters(ctx context.Context, dryRun bool) ([]primitive.ObjectID, error)
+	RecomputePostCounters(ctx context.Context, dryRun bool) ([]primitive.ObjectID, error)
+}
+
+// Seed represents the repository actions writing the generated local data
+type Seed interface {
+	Reset(context.Context) error
+	Insert(context.Context, *seed.Dataset) error
+}
+
+// Export represents the repository actions for personal data exports and the user data they contain
+type Export interface {
+	Insert(context.Context, *model.Export) (*model.Export, error)
+	Read(context.Context, primitive.ObjectID) (*model.Export, error)
+	ReadActiveByUser(context.Context, primitive.ObjectID) (*model.Export, error)
+	ClaimPending(context.Context) (*model.Export, error)
+	RequeueStale(ctx context.Context, before time.Time) error
+	Update(context.Context, *model.Export, map[string]interface{}) error
+	SelectExpired(ctx context.Context, now time.Time) ([]*model.Export, error)
+	SelectByUser(context.Context, primitive.ObjectID) ([]*model.Export, error)
+	DeleteByUser(context.Context, primitive.ObjectID) error
+
+	// User data operations
+	ReadUser(context.Context, primitive.ObjectID) (*model.User, error)
+	SelectUserPosts(context.Context, primitive.ObjectID) ([]*model.Post, error)
+	SelectUserComments(context.Context, primitive.ObjectID) ([]*model.Comment, error)
+	SelectFollowing(context.Context, primitive.ObjectID) ([]*model.User, error)
+	SelectFavouritePosts(context.Context, primitive.ObjectID) ([]*model.Post, error)
+	SelectTags(context.Context, []primitive.ObjectID) ([]*model.Tag, error)
+}
+
+// Account represents the repository actions for deleting a user account and its data
+type Account interface {
+	SelectDueDeletions(ctx context.Context, now time.Time) ([]*model.User, error)
+	DeletePosts(context.Context, primitive.ObjectID) ([]primitive.ObjectID, error)
+	AnonymiseComments(ctx context.Context, userID primitive.ObjectID, content string) error
+	DeleteFollows(context.Context, primitive.ObjectID) ([]primitive.ObjectID, error)
+	DeleteFavourites(context.Context, primitive.ObjectID) ([]primitive.ObjectID, error)
+	DeleteRelationships(context.Context, primitive.ObjectID) error
+	DeleteReactions(context.Context, primitive.ObjectID) error
+	DeleteWebhooks(context.Context, primitive.ObjectID) error
+	DeleteAccessTokens(context.Context, primitive.ObjectID) error
+	DeleteReports(context.Context, primitive.ObjectID) error
+	DeleteSignInChallenges(context.Context, primitive.ObjectID) error
+	DeleteIdempotencyKeys(context.Context, primitive.ObjectID) error
+	DeleteUser(context.Context, primitive.ObjectID) error
+}
+
+// Moderation represents the repository actions for content reports and moderation actions
+type Moderation interface {
+	ReadPost(context.Context, primitive.ObjectID) (*model.Post, error)
+	ReadComment(context.Context, primitive.ObjectID) (*model.Comment, error)
+	HasOpenReport(ctx context.Context, reporterID primitive.ObjectID, targetType static.ReportTargetType, targetID primitive.ObjectID) (bool, error)
+	InsertReport(context.Context, *model.Report) (*model.Report, error)
+	SelectReportGroups(ctx context.Context, targetType static.ReportTargetType, offset, limit int) ([]*model.ReportGroup, int64, error)
+	InsertAction(context.Context, *model.ModerationAction) (*model.ModerationAction, error)
+	ResolveReports(ctx context.Context, targetType static.ReportTargetType, targetID, actionID primitive.ObjectID) (int64, error)
+	HideContent(ctx context.Context, targetType static.ReportTargetType, targetID primitive.ObjectID) error
+	SuspendUser(context.Context, primitive.ObjectID) error
+}
+
+// Relationship represents the repository actions for blocked and muted users
+type Relationship interface {
+	InsertBlock(context.Context, *model.BlockUser) (bool, error)
+	DeleteBlock(ctx context.Context, userID, blockedUserID primitive.ObjectID) (bool, error)
+	IsBlocked(ctx context.Context, userID, otherUserID primitive.ObjectID) (bool, error)
+	SelectBlocks(context.Context, primitive.ObjectID) ([]*model.BlockUser, error)
+	InsertMute(context.Context, *model.MuteUser) (bool, error)
+	DeleteMute(ctx context.Context, userID, mutedUserID primitive.ObjectID) (bool, error)
+	SelectMutes(context.Context, primitive.ObjectID) ([]*model.MuteUser, error)
+	SelectHiddenUserIDs(context.Context, primitive.ObjectID) ([]primitive.ObjectID, error)
+	DeleteFollows(ctx context.Context, userID, otherUserID primitive.ObjectID) error
+	SelectUsers(context.Context, []primitive.ObjectID) ([]*model.User, error)
+}
+
+// Reaction represents the repository actions for reactions on posts and comments
+type Reaction interface {
+	EnsureIndexes(context.Context) error
+	ReadPost(context.Context, primitive.ObjectID) (*model.Post, error)
+	ReadComment(context.Context, primitive.ObjectID) (*model.Comment, error)
+	Upsert(context.Context, *model.Reaction) (*model.Reaction, error)
+	Delete(ctx context.Context, userID primitive.ObjectID, targetType static.ReactionTargetType, targetID primitive.ObjectID) (bool, error)
+	SelectCounts(ctx context.Context, targetType static.ReactionTargetType, targetIDs []primitive.ObjectID) ([]*model.ReactionCount, error)
+	SelectUserReactions(ctx context.Context, userID primitive.ObjectID, targetType static.ReactionTargetType, targetIDs []primitive.ObjectID) ([]*model.Reaction, error)
+}
+
+// Idempotency represents the repository actions for the stored responses of idempotent requests
+type Idempotency interface {
+	EnsureIndexes(context.Context) error
+	Reserve(context.Context, *model.IdempotencyKey) (*model.IdempotencyKey, bool, error)
+	Complete(context.Context, *model.IdempotencyKey) error
+	Release(context.Context, primitive.ObjectID) error
+}
+
+// Webhook represents the repository actions for webhook endpoints and their deliveries
+type Webhook interface {
+	EnsureIndexes(context.Context) error
+	Insert(context.Context, *model.Webhook) (*model.Webhook, error)
+	Read(context.Context, primitive.ObjectID) (*model.Webhook, error)
+	SelectByUser(context.Context, primitive.ObjectID) ([]*model.Webhook, error)
+	SelectSubscribers(ctx context.Context, event static.WebhookEvent, ownerID primitive.ObjectID) ([]*model.Webhook, error)
+	Delete(context.Context, primitive.ObjectID) error
+	InsertDeliveries(context.Context, []*model.WebhookDelivery) error
+	ReadDelivery(ctx context.Context, webhookID, id primitive.ObjectID) (*model.WebhookDelivery, error)
+	SelectDeliveries(ctx context.Context, webhookID primitive.ObjectID, offset, limit int) ([]*model.WebhookDelivery, int64, error)
+	ClaimDueDelivery(ctx context.Context, now time.Time) (*model.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, o *model.WebhookDelivery, updates map[string]interface{}) error
+	RequeueStaleDeliveries(ctx context.Context, before time.Time) error
+}
+
+// Outbox represents the repository actions for the domain events waiting to be dispatched,
+// Insert must run in the UnitOfWork of the changes raising the events so both are written or neither
+type Outbox interface {
+	EnsureIndexes(context.Context) error
+	Insert(context.Context, []*model.OutboxEvent) error
+	ClaimDue(ctx context.Context, now time.Time) (*model.OutboxEvent, error)
+	Update(ctx context.Context, o *model.OutboxEvent, updates map[string]interface{}) error
+	RequeueStale(ctx context.Context, before time.Time) error
+}
+
+// Audit represents the repository actions for the append-only audit log
+type Audit interface {
+	EnsureIndexes(context.Context) error
+	Insert(context.Context, *model.AuditLog) error
+	Select(ctx context.Context, filter *model.AuditLogFilter, offset, limit int) ([]*model.AuditLog, int64, error)
+}
+
+// OIDCState represents the repository actions for the OpenID Connect sign-ins in progress
+type OIDCState interface {
+	EnsureIndexes(context.Context) error
+	Insert(context.Context, *model.OIDCState) error
+	// Consume removes and returns the unexpired state so it can only be used once
+	Consume(ctx context.Context, state string) (*model.OIDCState, error)
+}
+
+// AccessToken represents the repository actions for personal access tokens
+type AccessToken interface {
+	EnsureIndexes(context.Context) error
+	Insert(context.Context, *model.AccessToken) error
+	Read(context.Context, primitive.ObjectID) (*model.AccessToken, error)
+	ReadByHash(context.Context, string) (*model.AccessToken, error)
+	SelectByUser(context.Context, primitive.ObjectID) ([]*model.AccessToken, error)
+	CountActive(ctx context.Context, userID primitive.ObjectID, now time.Time) (int64, error)
+	Revoke(ctx context.Context, o *model.AccessToken, at time.Time) error
+	TouchLastUsed(ctx context.Context, o *model.AccessToken, at time.Time, ip string) error
+}
+
+// SignInChallenge represents the repository actions for the pending two-factor sign-ins
+type SignInChallenge interface {
+	EnsureIndexes(context.Context) error
+	Insert(context.Context, *model.SignInChallenge) error
+	// Attempt counts an attempt at the unexpired challenge with attempts left and returns it
+	Attempt(ctx context.Context, tokenHash string, maxAttempts int) (*model.SignInChallenge, error)
+	Delete(context.Context, primitive.ObjectID) error
 }
@@ -0,0 +1,92 @@
+package seed
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"golang-project/database"
+	repo "golang-project/internal/repository"
+	"golang-project/internal/seed"
+	"golang-project/static"
+)
+
+// seededCollections lists the collections written by the seed, in insertion order
+var seededCollections = []string{
+	static.CollectionUsers,
+	static.CollectionTags,
+	static.CollectionPosts,
+	static.CollectionPostTags,
+	static.CollectionComments,
+	static.CollectionFollows,
+	static.CollectionFavorites,
+}
+
+// repository represents the implementation of repository.Seed
+type repository struct {
+	database *mongo.Database
+}
+
+// NewRepository returns a new implementation of repository.Seed
+func NewRepository(db database.Connection) repo.Seed {
+	return &repository{database: db.GetDatabase()}
+}
+
+// Reset drops every seeded collection
+func (r *repository) Reset(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	for _, name := range seededCollections {
+		if err := r.database.Collection(name).Drop(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Insert writes the documents of the dataset into their collections
+func (r *repository) Insert(ctx context.Context, data *seed.Dataset) error {
+	documents := map[string][]interface{}{
+		static.CollectionUsers:     toDocuments(data.Users),
+		static.CollectionTags:      toDocuments(data.Tags),
+		static.CollectionPosts:     toDocuments(data.Posts),
+		static.CollectionPostTags:  toDocuments(data.PostTags),
+		static.CollectionComments:  toDocuments(data.Comments),
+		static.CollectionFollows:   toDocuments(data.Follows),
+		static.CollectionFavorites: toDocuments(data.Favourites),
+	}
+
+	for _, name := range seededCollections {
+		if err := r.insertMany(ctx, name, documents[name]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// insertMany performs the insert action of the documents into the collection
+func (r *repository) insertMany(ctx context.Context, name string, documents []interface{}) error {
+	if len(documents) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := r.database.Collection(name).InsertMany(ctx, documents)
+	return err
+}
+
+// toDocuments converts the typed models into the documents accepted by InsertMany
+func toDocuments[T any](models []*T) []interface{} {
+	documents := make([]interface{}, 0, len(models))
+	for _, m := range models {
+		documents = append(documents, m)
+	}
+
+	return documents
+}
@@ -0,0 +1,211 @@
+package tag
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"golang-project/database"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	"golang-project/static"
+)
+
+// repository represents the implementation of repository.Tag
+type repository struct {
+	tags     *mongo.Collection
+	posts    *mongo.Collection
+	postTags *mongo.Collection
+	users    *mongo.Collection
+}
+
+// NewRepository returns a new implementation of repository.Tag
+func NewRepository(db database.Connection) repo.Tag {
+	mongoDB := db.GetDatabase()
+
+	return &repository{
+		tags:     mongoDB.Collection(static.CollectionTags),
+		posts:    mongoDB.Collection(static.CollectionPosts),
+		postTags: mongoDB.Collection(static.CollectionPostTags),
+		users:    mongoDB.Collection(static.CollectionUsers),
+	}
+}
+
+// Insert performs insert action into tag collection unless a tag with the same name exists,
+// it returns static.ErrTagExists in that case
+func (r *repository) Insert(ctx context.Context, o *model.Tag) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if o.ID.IsZero() {
+		o.ID = primitive.NewObjectID()
+	}
+
+	now := time.Now()
+	o.CreatedAt = &now
+	o.UpdatedAt = &now
+
+	filter := bson.M{"name": o.Name, "deleted_at": bson.M{"$exists": false}}
+	// The name is copied from the filter into the inserted tag
+	update := bson.M{"$setOnInsert": bson.M{"_id": o.ID, "created_at": now, "updated_at": now}}
+
+	result, err := r.tags.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return err
+	}
+	if result.UpsertedCount == 0 {
+		return static.ErrTagExists
+	}
+
+	return nil
+}
+
+// Read finds and returns the tag model by ID
+func (r *repository) Read(ctx context.Context, id primitive.ObjectID) (*model.Tag, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var result model.Tag
+	err := r.tags.FindOne(ctx, bson.M{"_id": id, "deleted_at": bson.M{"$exists": false}}).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, static.ErrTagNotFound
+		}
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// Delete performs soft delete action of the tag
+func (r *repository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{"_id": id, "deleted_at": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"deleted_at": now, "updated_at": now}, "$inc": bson.M{"version": 1}}
+
+	result, err := r.tags.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return static.ErrTagNotFound
+	}
+
+	return nil
+}
+
+// HasPosts checks whether a post is linked to the tag
+func (r *repository) HasPosts(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	count, err := r.postTags.CountDocuments(ctx, bson.M{"tag_id": id}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// Select finds and returns the tag models by IDs, or every tag when the IDs are nil, ordered by name
+func (r *repository) Select(ctx context.Context, ids []primitive.ObjectID) ([]*model.Tag, error) {
+	if ids != nil && len(ids) == 0 {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"deleted_at": bson.M{"$exists": false}}
+	if ids != nil {
+		filter["_id"] = bson.M{"$in": ids}
+	}
+
+	cursor, err := r.tags.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tags []*model.Tag
+	if err = cursor.All(ctx, &tags); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// SelectPost finds the published posts labelled with the tag, the latest first
+func (r *repository) SelectPost(ctx context.Context, tagID primitive.ObjectID) ([]*model.Post, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"tag_ids": tagID, "is_published": true, "deleted_at": bson.M{"$exists": false}, "hidden_at": nil}
+
+	cursor, err := r.posts.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var posts []*model.Post
+	if err = cursor.All(ctx, &posts); err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+// SelectPostTag finds and returns the tag links of the posts
+func (r *repository) SelectPostTag(ctx context.Context, postIDs []primitive.ObjectID) ([]*model.PostTag, error) {
+	if len(postIDs) == 0 {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	cursor, err := r.postTags.Find(ctx, bson.M{"post_id": bson.M{"$in": postIDs}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var postTags []*model.PostTag
+	if err = cursor.All(ctx, &postTags); err != nil {
+		return nil, err
+	}
+
+	return postTags, nil
+}
+
+// SelectUser finds and returns the user models by IDs
+func (r *repository) SelectUser(ctx context.Context, ids []primitive.ObjectID) ([]*model.User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	cursor, err := r.users.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []*model.User
+	if err = cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
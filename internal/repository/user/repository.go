@@ -3,16 +3,19 @@ package user
 import (
 	"context"
 	"errors"
+	"regexp"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"golang-project/database"
 	"golang-project/internal/model"
 	repo "golang-project/internal/repository"
 	"golang-project/static"
+	"golang-project/util/encryption"
 )
 
 // repository represents the implementation of repository.User
@@ -21,49 +24,87 @@ type repository struct {
 }
 
 // NewRepository returns a new implementation of repository.User
-func NewRepository() repo.User {
-	conn, err := database.NewConnectionFromEnv()
+func NewRepository(db database.Connection) repo.User {
+	return &repository{collection: db.GetDatabase().Collection(static.CollectionUsers)}
+}
+
+// emailCollation compares the emails without case, so one address cannot belong to two users
+var emailCollation = &options.Collation{Locale: "en", Strength: 2}
+
+// EnsureIndexes creates the unique index of the emails, compared without case
+func (r *repository) EnsureIndexes(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "email", Value: 1}},
+		Options: options.Index().SetUnique(true).SetCollation(emailCollation),
+	})
+	return err
+}
+
+// Read finds and returns the user model by ID
+func (r *repository) Read(ctx context.Context, id primitive.ObjectID) (*model.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var result model.User
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&result)
 	if err != nil {
-		panic("Failed to create database connection: " + err.Error())
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, static.ErrUserNotFound
+		}
+		return nil, err
 	}
+	return &result, nil
+}
 
-	_, err = conn.Connect()
+// ReadByEmail finds and returns the user model by email, the case of the email is ignored
+func (r *repository) ReadByEmail(ctx context.Context, email string) (*model.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var result model.User
+	err := r.collection.FindOne(ctx, bson.M{"email": email}, options.FindOne().SetCollation(emailCollation)).Decode(&result)
 	if err != nil {
-		panic("Failed to connect to database: " + err.Error())
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, static.ErrUserNotFound
+		}
+		return nil, err
 	}
 
-	db := conn.GetDatabase()
-	collection := db.Collection(static.CollectionUsers)
-
-	return &repository{collection: collection}
+	return &result, nil
 }
 
-// Read finds and returns the user model by ID
-func (r *repository) Read(id primitive.ObjectID) (*model.User, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// ReadByPseudonym finds and returns the user with the pseudonym
+func (r *repository) ReadByPseudonym(ctx context.Context, pseudonym string) (*model.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	var result model.User
-	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&result)
+	err := r.collection.FindOne(ctx, bson.M{"pseudonym": pseudonym}).Decode(&result)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
-			return nil, errors.New("user not found")
+			return nil, static.ErrUserNotFound
 		}
 		return nil, err
 	}
+
 	return &result, nil
 }
 
-// ReadByEmail finds and returns the user model by email
-func (r *repository) ReadByEmail(email string) (*model.User, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// ReadByIdentity finds and returns the user linked to the account of the OpenID Connect provider
+func (r *repository) ReadByIdentity(ctx context.Context, issuer, subject string) (*model.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
+	filter := bson.M{"identities": bson.M{"$elemMatch": bson.M{"issuer": issuer, "subject": subject}}}
+
 	var result model.User
-	err := r.collection.FindOne(ctx, bson.M{"email": email}).Decode(&result)
+	err := r.collection.FindOne(ctx, filter).Decode(&result)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
-			return nil, errors.New("user not found")
+			return nil, static.ErrUserNotFound
 		}
 		return nil, err
 	}
@@ -72,8 +113,8 @@ func (r *repository) ReadByEmail(email string) (*model.User, error) {
 }
 
 // Insert performs insert action into user collection
-func (r *repository) Insert(o *model.User) (*model.User, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (r *repository) Insert(ctx context.Context, o *model.User) (*model.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	// Generate ObjectID if not set
@@ -86,7 +127,11 @@ func (r *repository) Insert(o *model.User) (*model.User, error) {
 	o.CreatedAt = &now
 	o.UpdatedAt = &now
 
+	// The email index refuses a second user with the same email signing up concurrently
 	_, err := r.collection.InsertOne(ctx, o)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, static.ErrEmailAlreadyExists
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -95,28 +140,37 @@ func (r *repository) Insert(o *model.User) (*model.User, error) {
 }
 
 // Update performs update action into user collection
-func (r *repository) Update(o *model.User, updates map[string]interface{}) (*model.User, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (r *repository) Update(ctx context.Context, o *model.User, updates map[string]interface{}) (*model.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	// Add updated timestamp
 	updates["updated_at"] = time.Now()
 
-	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": o.ID}, bson.M{"$set": updates})
+	// Only update the version that was read so concurrent updates do not overwrite each other
+	filter := bson.M{"_id": o.ID, "version": o.Version}
+	if o.Version == 0 {
+		filter["version"] = bson.M{"$exists": false}
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": updates, "$inc": bson.M{"version": 1}})
 	if err != nil {
 		return nil, err
 	}
+	if result.MatchedCount == 0 {
+		return nil, static.ErrVersionConflict
+	}
 
 	// Return updated user
-	return r.Read(o.ID)
+	return r.Read(ctx, o.ID)
 }
 
-func (r *repository) ReadOwnPosts(id primitive.ObjectID, isPublishedFilter *bool) ([]*model.Post, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (r *repository) ReadOwnPosts(ctx context.Context, id primitive.ObjectID, isPublishedFilter *bool) ([]*model.Post, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	// Build filter
-	filter := bson.M{"user_id": id}
+	// Build filter, deleted and hidden posts are left out
+	filter := bson.M{"user_id": id, "deleted_at": bson.M{"$exists": false}, "hidden_at": nil}
 	if isPublishedFilter != nil {
 		filter["is_published"] = *isPublishedFilter
 	}
@@ -134,3 +188,124 @@ func (r *repository) ReadOwnPosts(id primitive.ObjectID, isPublishedFilter *bool
 
 	return posts, nil
 }
+
+// Select finds and returns the user models by IDs
+func (r *repository) Select(ctx context.Context, ids []primitive.ObjectID) ([]*model.User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []*model.User
+	if err = cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// UseTwoFactorStep records the time step of an authenticator code unless the same or a later step was recorded
+func (r *repository) UseTwoFactorStep(ctx context.Context, id primitive.ObjectID, step int64) (bool, error) {
+	filter := bson.M{"_id": id, "two_factor.last_used_step": bson.M{"$lt": step}}
+	update := bson.M{
+		"$set":   bson.M{"two_factor.last_used_step": step, "updated_at": time.Now()},
+		"$unset": bson.M{"two_factor.failed_codes": ""},
+		"$inc":   bson.M{"version": 1},
+	}
+
+	return r.updateOne(ctx, filter, update)
+}
+
+// UseRecoveryCode removes the hashed recovery code unless it was already removed
+func (r *repository) UseRecoveryCode(ctx context.Context, id primitive.ObjectID, hashed string) (bool, error) {
+	filter := bson.M{"_id": id, "two_factor.recovery_codes": hashed}
+	update := bson.M{
+		"$pull":  bson.M{"two_factor.recovery_codes": hashed},
+		"$set":   bson.M{"updated_at": time.Now()},
+		"$unset": bson.M{"two_factor.failed_codes": ""},
+		"$inc":   bson.M{"version": 1},
+	}
+
+	return r.updateOne(ctx, filter, update)
+}
+
+// FailTwoFactorCode counts an invalid two-factor code of the user and locks the codes once the invalid codes
+// reach the maximum, the count starts over with the lock. The version is left alone like the other counters.
+func (r *repository) FailTwoFactorCode(ctx context.Context, id primitive.ObjectID, maxFailed int, lockedUntil time.Time) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	findOptions := options.FindOneAndUpdate().SetReturnDocument(options.After).SetProjection(bson.M{"two_factor": 1})
+
+	var result model.User
+	err := r.collection.FindOneAndUpdate(ctx, bson.M{"_id": id, "two_factor": bson.M{"$ne": nil}},
+		bson.M{"$inc": bson.M{"two_factor.failed_codes": 1}}, findOptions).Decode(&result)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if result.TwoFactor == nil || result.TwoFactor.FailedCodes < maxFailed {
+		return false, nil
+	}
+
+	// Only one of the concurrent codes reaching the maximum sets the lock
+	filter := bson.M{"_id": id, "two_factor.failed_codes": bson.M{"$gte": maxFailed}}
+	update := bson.M{"$set": bson.M{"two_factor.locked_until": lockedUntil}, "$unset": bson.M{"two_factor.failed_codes": ""}}
+
+	return r.updateOne(ctx, filter, update)
+}
+
+// SelectPlainTwoFactorSecrets finds and returns the users whose TOTP secret was stored before the encryption
+func (r *repository) SelectPlainTwoFactorSecrets(ctx context.Context) ([]*model.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"two_factor.secret": bson.M{
+		"$exists": true,
+		"$not":    primitive.Regex{Pattern: "^" + regexp.QuoteMeta(encryption.Prefix)},
+	}}
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"two_factor.secret": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []*model.User
+	if err = cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// ReplaceTwoFactorSecret replaces the TOTP secret of the user unless another secret was enrolled in the meantime.
+// The secret itself is unchanged so the version is left alone like the counters.
+func (r *repository) ReplaceTwoFactorSecret(ctx context.Context, id primitive.ObjectID, secret, replacement string) (bool, error) {
+	filter := bson.M{"_id": id, "two_factor.secret": secret}
+	update := bson.M{"$set": bson.M{"two_factor.secret": replacement}}
+
+	return r.updateOne(ctx, filter, update)
+}
+
+// updateOne applies the update to the user matching the filter and returns whether a user matched
+func (r *repository) updateOne(ctx context.Context, filter, update bson.M) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount > 0, nil
+}
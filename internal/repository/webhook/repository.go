@@ -0,0 +1,271 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"golang-project/database"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	"golang-project/static"
+)
+
+// repository represents the implementation of repository.Webhook
+type repository struct {
+	webhooks   *mongo.Collection
+	deliveries *mongo.Collection
+}
+
+// NewRepository returns a new implementation of repository.Webhook
+func NewRepository(db database.Connection) repo.Webhook {
+	mongoDB := db.GetDatabase()
+
+	return &repository{
+		webhooks:   mongoDB.Collection(static.CollectionWebhooks),
+		deliveries: mongoDB.Collection(static.CollectionWebhookDeliveries),
+	}
+}
+
+// EnsureIndexes creates the indexes of the subscribers selected for each event and of the due, stale and logged
+// deliveries, and the TTL index removing the deliveries after the retention
+func (r *repository) EnsureIndexes(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := r.webhooks.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "events", Value: 1}, {Key: "all_users", Value: 1}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = r.deliveries.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "updated_at", Value: 1}}},
+		{Keys: bson.D{{Key: "webhook_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "created_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(int32(static.Webhook.Retention.Seconds()))},
+	})
+	return err
+}
+
+// Insert performs insert action into webhook collection
+func (r *repository) Insert(ctx context.Context, o *model.Webhook) (*model.Webhook, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if o.ID.IsZero() {
+		o.ID = primitive.NewObjectID()
+	}
+
+	now := time.Now()
+	o.CreatedAt = &now
+	o.UpdatedAt = &now
+
+	_, err := r.webhooks.InsertOne(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+// Read finds and returns the webhook model by ID
+func (r *repository) Read(ctx context.Context, id primitive.ObjectID) (*model.Webhook, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var result model.Webhook
+	err := r.webhooks.FindOne(ctx, bson.M{"_id": id}).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, static.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// SelectByUser returns the webhooks of the user, the latest first
+func (r *repository) SelectByUser(ctx context.Context, userID primitive.ObjectID) ([]*model.Webhook, error) {
+	return r.selectWebhooks(ctx, bson.M{"user_id": userID})
+}
+
+// SelectSubscribers returns the webhooks subscribed to the event of the owner,
+// the webhooks of the owner and the ones following the events of all users
+func (r *repository) SelectSubscribers(ctx context.Context, event static.WebhookEvent, ownerID primitive.ObjectID) ([]*model.Webhook, error) {
+	filter := bson.M{
+		"events": event,
+		"$or":    bson.A{bson.M{"user_id": ownerID}, bson.M{"all_users": true}},
+	}
+
+	return r.selectWebhooks(ctx, filter)
+}
+
+// selectWebhooks returns the webhooks matching the filter, the latest first
+func (r *repository) selectWebhooks(ctx context.Context, filter bson.M) ([]*model.Webhook, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	cursor, err := r.webhooks.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []*model.Webhook
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// Delete performs delete action of the webhook together with its delivery log
+func (r *repository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if _, err := r.deliveries.DeleteMany(ctx, bson.M{"webhook_id": id}); err != nil {
+		return err
+	}
+
+	_, err := r.webhooks.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+// InsertDeliveries performs insert action of the deliveries into webhook_deliveries collection,
+// the deliveries already inserted by an earlier dispatch of the event are skipped
+func (r *repository) InsertDeliveries(ctx context.Context, o []*model.WebhookDelivery) error {
+	if len(o) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	documents := make([]interface{}, 0, len(o))
+	for _, delivery := range o {
+		if delivery.ID.IsZero() {
+			delivery.ID = primitive.NewObjectID()
+		}
+		delivery.CreatedAt = &now
+		delivery.UpdatedAt = &now
+		documents = append(documents, delivery)
+	}
+
+	_, err := r.deliveries.InsertMany(ctx, documents, options.InsertMany().SetOrdered(false))
+	var writeErr mongo.BulkWriteException
+	if errors.As(err, &writeErr) && writeErr.WriteConcernError == nil {
+		for _, e := range writeErr.WriteErrors {
+			if !mongo.IsDuplicateKeyError(e) {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return err
+}
+
+// ReadDelivery finds and returns the delivery model of the webhook by ID
+func (r *repository) ReadDelivery(ctx context.Context, webhookID, id primitive.ObjectID) (*model.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var result model.WebhookDelivery
+	err := r.deliveries.FindOne(ctx, bson.M{"_id": id, "webhook_id": webhookID}).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, static.ErrWebhookDeliveryNotFound
+		}
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// SelectDeliveries returns the page of the deliveries of the webhook, the latest first, with their total number
+func (r *repository) SelectDeliveries(ctx context.Context, webhookID primitive.ObjectID, offset, limit int) ([]*model.WebhookDelivery, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"webhook_id": webhookID}
+
+	total, err := r.deliveries.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	cursor, err := r.deliveries.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []*model.WebhookDelivery
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, 0, err
+	}
+
+	return results, total, nil
+}
+
+// ClaimDueDelivery atomically moves the oldest pending delivery due at the time to delivering and returns it
+func (r *repository) ClaimDueDelivery(ctx context.Context, now time.Time) (*model.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"status": static.WebhookDeliveryPending, "next_attempt_at": bson.M{"$lte": now}}
+	update := bson.M{"$set": bson.M{"status": static.WebhookDeliveryDelivering, "updated_at": time.Now()}, "$inc": bson.M{"version": 1}}
+	findOptions := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var result model.WebhookDelivery
+	err := r.deliveries.FindOneAndUpdate(ctx, filter, update, findOptions).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, static.ErrWebhookDeliveryNotFound
+		}
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// UpdateDelivery performs update action into webhook_deliveries collection
+func (r *repository) UpdateDelivery(ctx context.Context, o *model.WebhookDelivery, updates map[string]interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	updates["updated_at"] = time.Now()
+
+	_, err := r.deliveries.UpdateOne(ctx, bson.M{"_id": o.ID}, bson.M{"$set": updates, "$inc": bson.M{"version": 1}})
+	return err
+}
+
+// RequeueStaleDeliveries moves the deliveries left delivering since before the time back to pending
+func (r *repository) RequeueStaleDeliveries(ctx context.Context, before time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"status": static.WebhookDeliveryDelivering, "updated_at": bson.M{"$lt": before}}
+	update := bson.M{"$set": bson.M{"status": static.WebhookDeliveryPending, "updated_at": time.Now()}, "$inc": bson.M{"version": 1}}
+
+	_, err := r.deliveries.UpdateMany(ctx, filter, update)
+	return err
+}
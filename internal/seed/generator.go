@@ -0,0 +1,320 @@
+package seed
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/gosimple/slug"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/internal/model"
+	"golang-project/static"
+)
+
+// Config represents the amounts of generated documents and the seed of the random source
+type Config struct {
+	Users             int
+	Tags              int
+	PostsPerUser      int
+	CommentsPerPost   int
+	FollowsPerUser    int
+	FavouritesPerUser int
+	RandomSeed        int64
+	// Anchor is the most recent time a generated document can be created at
+	Anchor time.Time
+}
+
+// Dataset represents the generated documents of every seeded collection
+type Dataset struct {
+	Users      []*model.User
+	Tags       []*model.Tag
+	Posts      []*model.Post
+	PostTags   []*model.PostTag
+	Comments   []*model.Comment
+	Follows    []*model.FollowUser
+	Favourites []*model.FavoritePost
+}
+
+// generator represents the deterministic document generator
+type generator struct {
+	config Config
+	rng    *rand.Rand
+	slugs  map[string]int
+}
+
+// Generate returns the documents generated from the config, the same config always generates the same dataset,
+// every user is given the already hashed password
+func Generate(config Config, hashedPassword string) *Dataset {
+	g := &generator{
+		config: config,
+		rng:    rand.New(rand.NewSource(config.RandomSeed)),
+		slugs:  make(map[string]int),
+	}
+
+	data := &Dataset{}
+	data.Users = g.users(hashedPassword)
+	data.Tags = g.tags()
+	data.Posts, data.PostTags = g.posts(data.Users, data.Tags)
+	data.Comments = g.comments(data.Users, data.Posts)
+	data.Follows = g.follows(data.Users)
+	data.Favourites = g.favourites(data.Users, data.Posts)
+	count(data)
+
+	return data
+}
+
+// count fills the denormalised counters of the users and posts from the generated documents
+func count(data *Dataset) {
+	users := make(map[primitive.ObjectID]*model.User, len(data.Users))
+	for _, user := range data.Users {
+		users[user.ID] = user
+	}
+
+	posts := make(map[primitive.ObjectID]*model.Post, len(data.Posts))
+	for _, post := range data.Posts {
+		posts[post.ID] = post
+		if post.IsPublished {
+			users[post.UserID].PostCount++
+		}
+	}
+
+	for _, follow := range data.Follows {
+		users[follow.UserID].FollowingCount++
+		users[follow.FollowUserID].FollowerCount++
+	}
+
+	for _, favourite := range data.Favourites {
+		posts[favourite.PostID].FavouriteCount++
+	}
+
+	for _, comment := range data.Comments {
+		posts[comment.PostID].CommentCount++
+	}
+}
+
+// users generates the user accounts, the first one is granted the admin role
+func (g *generator) users(hashedPassword string) []*model.User {
+	users := make([]*model.User, 0, g.config.Users)
+	pseudonyms := make(map[string]int)
+
+	for i := 0; i < g.config.Users; i++ {
+		firstName := pick(g.rng, firstNames)
+		lastName := pick(g.rng, lastNames)
+
+		pseudonym := strings.ToLower(firstName + "." + lastName)
+		pseudonyms[pseudonym]++
+		if n := pseudonyms[pseudonym]; n > 1 {
+			pseudonym = fmt.Sprintf("%s%d", pseudonym, n)
+		}
+
+		role := static.RoleUser
+		if i == 0 {
+			role = static.RoleAdmin
+		}
+
+		createdAt := g.timeBetween(g.config.Anchor.AddDate(-1, 0, 0), g.config.Anchor.AddDate(0, -1, 0))
+		users = append(users, &model.User{
+			BaseModel:  g.baseModel(createdAt),
+			FirstName:  firstName,
+			LastName:   lastName,
+			Email:      pseudonym + "@example.com",
+			Password:   hashedPassword,
+			Pseudonym:  pseudonym,
+			Biography:  g.sentence(),
+			IsVerified: g.rng.Intn(4) > 0,
+			Role:       role,
+		})
+	}
+
+	return users
+}
+
+// tags generates the tags from the topic list
+func (g *generator) tags() []*model.Tag {
+	count := min(g.config.Tags, len(topics))
+	tags := make([]*model.Tag, 0, count)
+
+	for _, i := range g.rng.Perm(len(topics))[:count] {
+		createdAt := g.timeBetween(g.config.Anchor.AddDate(-1, 0, 0), g.config.Anchor.AddDate(0, -1, 0))
+		tags = append(tags, &model.Tag{BaseModel: g.baseModel(createdAt), Name: topics[i]})
+	}
+
+	return tags
+}
+
+// posts generates the posts of every user labelled with up to three tags
+func (g *generator) posts(users []*model.User, tags []*model.Tag) ([]*model.Post, []*model.PostTag) {
+	var posts []*model.Post
+	var postTags []*model.PostTag
+
+	for _, user := range users {
+		for i := 0; i < g.config.PostsPerUser; i++ {
+			var tagIDs []primitive.ObjectID
+			if len(tags) > 0 {
+				for _, j := range g.rng.Perm(len(tags))[:1+g.rng.Intn(min(3, len(tags)))] {
+					tagIDs = append(tagIDs, tags[j].ID)
+				}
+			}
+
+			title := g.title()
+			createdAt := g.timeBetween(*user.CreatedAt, g.config.Anchor)
+			post := &model.Post{
+				BaseModel:   g.baseModel(createdAt),
+				Title:       title,
+				Body:        g.paragraphs(2 + g.rng.Intn(3)),
+				Slug:        g.slug(title),
+				IsPublished: g.rng.Intn(5) > 0,
+				UserID:      user.ID,
+				TagIDs:      tagIDs,
+			}
+			posts = append(posts, post)
+
+			for _, tagID := range tagIDs {
+				postTags = append(postTags, &model.PostTag{TagID: tagID, PostID: post.ID})
+			}
+		}
+	}
+
+	return posts, postTags
+}
+
+// comments generates the comment threads of every published post, a comment may reply to an earlier one
+func (g *generator) comments(users []*model.User, posts []*model.Post) []*model.Comment {
+	var comments []*model.Comment
+	if len(users) == 0 {
+		return comments
+	}
+
+	for _, post := range posts {
+		if !post.IsPublished {
+			continue
+		}
+
+		var thread []*model.Comment
+		createdAt := *post.CreatedAt
+		for i := 0; i < g.config.CommentsPerPost; i++ {
+			createdAt = g.timeBetween(createdAt, createdAt.Add(48*time.Hour))
+			comment := &model.Comment{
+				BaseModel: g.baseModel(createdAt),
+				Content:   g.sentence(),
+				PostID:    post.ID,
+				UserID:    pick(g.rng, users).ID,
+			}
+
+			if len(thread) > 0 && g.rng.Intn(5) < 2 {
+				parentID := pick(g.rng, thread).ID
+				comment.ParentCommentID = &parentID
+			}
+
+			thread = append(thread, comment)
+		}
+
+		comments = append(comments, thread...)
+	}
+
+	return comments
+}
+
+// follows generates the bloggers followed by every user, users never follow themselves
+func (g *generator) follows(users []*model.User) []*model.FollowUser {
+	var follows []*model.FollowUser
+
+	for i, user := range users {
+		count := min(g.config.FollowsPerUser, len(users)-1)
+		picked := 0
+		for _, j := range g.rng.Perm(len(users)) {
+			if picked == count {
+				break
+			}
+			if j == i {
+				continue
+			}
+
+			follows = append(follows, &model.FollowUser{UserID: user.ID, FollowUserID: users[j].ID})
+			picked++
+		}
+	}
+
+	return follows
+}
+
+// favourites generates the published posts favourited by every user
+func (g *generator) favourites(users []*model.User, posts []*model.Post) []*model.FavoritePost {
+	var published []*model.Post
+	for _, post := range posts {
+		if post.IsPublished {
+			published = append(published, post)
+		}
+	}
+
+	var favourites []*model.FavoritePost
+	for _, user := range users {
+		count := min(g.config.FavouritesPerUser, len(published))
+		for _, i := range g.rng.Perm(len(published))[:count] {
+			favourites = append(favourites, &model.FavoritePost{PostID: published[i].ID, UserID: user.ID})
+		}
+	}
+
+	return favourites
+}
+
+// baseModel returns the base model created at the time with an ObjectID drawn from the random source
+func (g *generator) baseModel(createdAt time.Time) model.BaseModel {
+	var id primitive.ObjectID
+	timestamp := uint32(createdAt.Unix())
+	id[0], id[1], id[2], id[3] = byte(timestamp>>24), byte(timestamp>>16), byte(timestamp>>8), byte(timestamp)
+	g.rng.Read(id[4:])
+
+	return model.BaseModel{ID: id, CreatedAt: &createdAt, UpdatedAt: &createdAt}
+}
+
+// timeBetween returns a random time in the range, truncated to the second
+func (g *generator) timeBetween(from, to time.Time) time.Time {
+	if !to.After(from) {
+		return from
+	}
+
+	return from.Add(time.Duration(g.rng.Int63n(int64(to.Sub(from))))).Truncate(time.Second).UTC()
+}
+
+// title returns a random post title
+func (g *generator) title() string {
+	return fmt.Sprintf("%s %s %s", pick(g.rng, titleOpenings), pick(g.rng, topics), pick(g.rng, titleEndings))
+}
+
+// slug returns the unique slug of the title
+func (g *generator) slug(title string) string {
+	base := slug.Make(title)
+	g.slugs[base]++
+	if n := g.slugs[base]; n > 1 {
+		return fmt.Sprintf("%s-%d", base, n)
+	}
+
+	return base
+}
+
+// sentence returns a random sentence
+func (g *generator) sentence() string {
+	return pick(g.rng, sentences)
+}
+
+// paragraphs returns the number of random paragraphs separated by blank lines
+func (g *generator) paragraphs(count int) string {
+	paragraphs := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		var sentencesOf []string
+		for j := 0; j < 3+g.rng.Intn(3); j++ {
+			sentencesOf = append(sentencesOf, g.sentence())
+		}
+		paragraphs = append(paragraphs, strings.Join(sentencesOf, " "))
+	}
+
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// pick returns a random element of the non empty slice
+func pick[T any](rng *rand.Rand, values []T) T {
+	return values[rng.Intn(len(values))]
+}
@@ -0,0 +1,55 @@
+package seed
+
+// Word lists the generated documents are composed from
+var (
+	firstNames = []string{
+		"Ava", "Liam", "Noah", "Emma", "Olivia", "Lucas", "Mia", "Ethan", "Sofia", "Mateo",
+		"Chloe", "Hugo", "Zoe", "Leo", "Nora", "Elias", "Maya", "Oscar", "Lena", "Felix",
+		"Aria", "Jonas", "Ines", "Theo", "Clara", "Amir", "Yuki", "Priya", "Kwame", "Linh",
+	}
+
+	lastNames = []string{
+		"Smith", "Nguyen", "Garcia", "Muller", "Rossi", "Kim", "Silva", "Novak", "Dubois", "Khan",
+		"Tanaka", "Larsen", "Okafor", "Moreau", "Ivanova", "Lopez", "Schmidt", "Haddad", "Costa", "Berg",
+	}
+
+	topics = []string{
+		"golang", "mongodb", "kubernetes", "testing", "observability", "security", "design",
+		"career", "open-source", "databases", "performance", "devops", "frontend", "architecture",
+		"productivity", "cloud", "networking", "concurrency", "apis", "writing",
+	}
+
+	titleOpenings = []string{
+		"A practical guide to", "Lessons learned from", "Why I changed my mind about", "Getting started with",
+		"Five mistakes in", "The hidden cost of", "What nobody tells you about", "A year of",
+		"Rethinking", "Notes on",
+	}
+
+	titleEndings = []string{
+		"in production", "for small teams", "at scale", "the hard way", "from scratch",
+		"in 2026", "without the hype", "on a budget", "for beginners", "revisited",
+	}
+
+	sentences = []string{
+		"We started with the simplest thing that could possibly work.",
+		"The first version was slow, but it taught us where the real bottlenecks were.",
+		"Measuring before optimizing saved us weeks of guesswork.",
+		"Most of the complexity came from handling the edge cases nobody had written down.",
+		"A small refactor made the rest of the work surprisingly easy.",
+		"The documentation was accurate, just not for the version we were running.",
+		"Pairing on the tricky parts turned out to be faster than reviewing them later.",
+		"We kept the old code path behind a flag until the new one proved itself.",
+		"Every incident review ended with a smaller, clearer runbook.",
+		"It is tempting to add abstractions early, but they rarely fit the second use case.",
+		"Our tests caught the regression before any user did.",
+		"The fix was a single line once we understood the problem.",
+		"Naming things well made the code review conversations much shorter.",
+		"We wrote the migration to be safe to run twice.",
+		"The dashboard told us what was happening, the traces told us why.",
+		"Great write-up, this matches what we saw on our team as well.",
+		"Thanks for sharing, I would love a follow-up on the testing strategy.",
+		"Did you consider the trade-offs of doing this asynchronously?",
+		"This saved me an afternoon of debugging, much appreciated.",
+		"I disagree slightly on the last point, but the rest is spot on.",
+	}
+)
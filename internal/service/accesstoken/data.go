@@ -0,0 +1,63 @@
+package accesstoken
+
+import (
+	"strings"
+	"time"
+
+	ct "golang-project/internal/contract"
+	"golang-project/internal/model"
+	"golang-project/static"
+)
+
+// prepareAccessTokenResponse transforms the data and returns the Access Token Response without the token
+func prepareAccessTokenResponse(o *model.AccessToken) *ct.AccessTokenResponse {
+	data := &ct.AccessTokenResponse{
+		ID:         o.ID,
+		Name:       o.Name,
+		Scopes:     o.Scopes,
+		Prefix:     o.Prefix,
+		LastUsedIP: o.LastUsedIP,
+	}
+
+	if o.ExpiresAt != nil {
+		data.ExpiresAt = o.ExpiresAt.Format(time.RFC3339)
+	}
+
+	if o.LastUsedAt != nil {
+		data.LastUsedAt = o.LastUsedAt.Format(time.RFC3339)
+	}
+
+	if o.RevokedAt != nil {
+		data.RevokedAt = o.RevokedAt.Format(time.RFC3339)
+	}
+
+	if o.CreatedAt != nil {
+		data.CreatedAt = o.CreatedAt.Format(time.RFC3339)
+	}
+
+	return data
+}
+
+// uniqueScopes returns the scopes without duplicates in their order
+func uniqueScopes(scopes []static.AccessTokenScope) []static.AccessTokenScope {
+	seen := make(map[static.AccessTokenScope]bool, len(scopes))
+	result := make([]static.AccessTokenScope, 0, len(scopes))
+	for _, scope := range scopes {
+		if !seen[scope] {
+			seen[scope] = true
+			result = append(result, scope)
+		}
+	}
+
+	return result
+}
+
+// joinScopes returns the scopes separated by commas for the audit log
+func joinScopes(scopes []static.AccessTokenScope) string {
+	values := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		values = append(values, string(scope))
+	}
+
+	return strings.Join(values, ",")
+}
@@ -0,0 +1,204 @@
+package accesstoken
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/internal/audit"
+	ct "golang-project/internal/contract"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	svc "golang-project/internal/service"
+	"golang-project/internal/tracing"
+	"golang-project/static"
+)
+
+// service represents the implementation of service.AccessToken
+type service struct {
+	userRepo  repo.User
+	tokenRepo repo.AccessToken
+	auditor   audit.Recorder
+}
+
+// NewService returns a new implementation of service.AccessToken
+func NewService(userRepo repo.User, tokenRepo repo.AccessToken, auditor audit.Recorder) svc.AccessToken {
+	return &service{
+		userRepo:  userRepo,
+		tokenRepo: tokenRepo,
+		auditor:   auditor,
+	}
+}
+
+// Create issues a personal access token of the user and returns the token once, only its hash is stored
+func (s *service) Create(ctx context.Context, userID primitive.ObjectID, r *ct.CreateAccessTokenRequest) (*ct.AccessTokenResponse, error) {
+	ctx, span := tracing.Start(ctx, "accesstoken.Create")
+	defer span.End()
+
+	now := time.Now()
+
+	active, err := s.tokenRepo.CountActive(ctx, userID, now)
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+	if active >= int64(static.AccessToken.MaxActivePerUser) {
+		return nil, static.ErrAccessTokenLimit
+	}
+
+	raw, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := static.AccessToken.DefaultTTL
+	if r.ExpiresInDays > 0 {
+		ttl = time.Duration(r.ExpiresInDays) * 24 * time.Hour
+	}
+	expiresAt := now.Add(ttl)
+
+	token := &model.AccessToken{
+		UserID:    userID,
+		Name:      r.Name,
+		Scopes:    uniqueScopes(r.Scopes),
+		TokenHash: hashToken(raw),
+		Prefix:    raw[:static.AccessToken.DisplayLength],
+		ExpiresAt: &expiresAt,
+	}
+	if err = s.tokenRepo.Insert(ctx, token); err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	s.auditor.Record(ctx, &audit.Entry{
+		ActorID:    userID,
+		Action:     static.AuditAccessTokenCreated,
+		TargetType: static.AuditTargetToken,
+		TargetID:   token.ID,
+		Metadata:   map[string]string{"name": token.Name, "scopes": joinScopes(token.Scopes)},
+	})
+
+	response := prepareAccessTokenResponse(token)
+	response.Token = raw
+
+	return response, nil
+}
+
+// List returns the personal access tokens of the user, the latest first
+func (s *service) List(ctx context.Context, userID primitive.ObjectID) (*ct.ListAccessTokenResponse, error) {
+	ctx, span := tracing.Start(ctx, "accesstoken.List")
+	defer span.End()
+
+	tokens, err := s.tokenRepo.SelectByUser(ctx, userID)
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	responses := make([]*ct.AccessTokenResponse, 0, len(tokens))
+	for _, token := range tokens {
+		responses = append(responses, prepareAccessTokenResponse(token))
+	}
+
+	return &ct.ListAccessTokenResponse{AccessTokens: responses}, nil
+}
+
+// Revoke revokes the personal access token of the user, revoking a revoked token has no effect
+func (s *service) Revoke(ctx context.Context, userID primitive.ObjectID, r *ct.AccessTokenRequest) error {
+	ctx, span := tracing.Start(ctx, "accesstoken.Revoke")
+	defer span.End()
+
+	token, err := s.tokenRepo.Read(ctx, r.ID)
+	if err != nil {
+		return err
+	}
+
+	if token.UserID != userID {
+		return static.ErrAccessTokenNotFound
+	}
+
+	if token.RevokedAt != nil {
+		return nil
+	}
+
+	if err = s.tokenRepo.Revoke(ctx, token, time.Now()); err != nil {
+		return static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	s.auditor.Record(ctx, &audit.Entry{
+		ActorID:    userID,
+		Action:     static.AuditAccessTokenRevoked,
+		TargetType: static.AuditTargetToken,
+		TargetID:   token.ID,
+		Metadata:   map[string]string{"name": token.Name},
+	})
+
+	return nil
+}
+
+// Authenticate returns the user of the token when it is neither revoked nor expired and the user is active,
+// the last use is only written once per precision interval to keep the hot path cheap
+func (s *service) Authenticate(ctx context.Context, raw, ip string) (*ct.ContextUser, error) {
+	ctx, span := tracing.Start(ctx, "accesstoken.Authenticate")
+	defer span.End()
+
+	if !strings.HasPrefix(raw, static.AccessToken.Prefix) {
+		return nil, static.ErrAccessTokenInvalid
+	}
+
+	token, err := s.tokenRepo.ReadByHash(ctx, hashToken(raw))
+	if err != nil {
+		if errors.Is(err, static.ErrAccessTokenNotFound) {
+			return nil, static.ErrAccessTokenInvalid
+		}
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	now := time.Now()
+	if token.RevokedAt != nil || token.ExpiresAt == nil || !now.Before(*token.ExpiresAt) {
+		return nil, static.ErrAccessTokenInvalid
+	}
+
+	user, err := s.userRepo.Read(ctx, token.UserID)
+	if err != nil {
+		if errors.Is(err, static.ErrUserNotFound) {
+			return nil, static.ErrAccessTokenInvalid
+		}
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	if user.DisabledAt != nil {
+		return nil, static.ErrUserDisabled
+	}
+	if user.SessionsRevokedAt != nil && (token.CreatedAt == nil || token.CreatedAt.Before(*user.SessionsRevokedAt)) {
+		return nil, static.ErrAccessTokenInvalid
+	}
+
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= static.AccessToken.LastUsedPrecision || token.LastUsedIP != ip {
+		if err = s.tokenRepo.TouchLastUsed(ctx, token, now, ip); err != nil {
+			log.Println("access token last use error:", err)
+		}
+	}
+
+	return &ct.ContextUser{ID: user.ID, Email: user.Email, Scopes: token.Scopes}, nil
+}
+
+// generateToken returns a new random personal access token
+func generateToken() (string, error) {
+	b := make([]byte, static.AccessToken.SecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return static.AccessToken.Prefix + hex.EncodeToString(b), nil
+}
+
+// hashToken returns the hex SHA-256 of the token, the token is random enough that a salt adds nothing
+func hashToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,56 @@
+package accesstoken
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	ct "golang-project/internal/contract"
+	"golang-project/internal/model"
+	"golang-project/internal/service/servicetest"
+	"golang-project/static"
+)
+
+func TestAuthenticateRefusesInactiveTokensAndUsers(t *testing.T) {
+	ctx := context.Background()
+	past, future := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+
+	for name, tc := range map[string]struct {
+		user  *model.User
+		token func(*model.AccessToken)
+		want  error
+	}{
+		"active":           {&model.User{}, func(*model.AccessToken) {}, nil},
+		"expired":          {&model.User{}, func(o *model.AccessToken) { o.ExpiresAt = &past }, static.ErrAccessTokenInvalid},
+		"revoked":          {&model.User{}, func(o *model.AccessToken) { o.RevokedAt = &past }, static.ErrAccessTokenInvalid},
+		"disabled user":    {&model.User{DisabledAt: &past}, func(*model.AccessToken) {}, static.ErrUserDisabled},
+		"revoked sessions": {&model.User{SessionsRevokedAt: &future}, func(*model.AccessToken) {}, static.ErrAccessTokenInvalid},
+	} {
+		userID := primitive.NewObjectID()
+		tokens := &servicetest.AccessTokens{}
+		s := NewService(servicetest.Users{Users: map[primitive.ObjectID]*model.User{userID: tc.user}}, tokens, &servicetest.Recorder{})
+
+		created, err := s.Create(ctx, userID, &ct.CreateAccessTokenRequest{Name: "ci", Scopes: []static.AccessTokenScope{static.AccessTokenRead}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		tc.token(tokens.Tokens[created.ID])
+
+		user, err := s.Authenticate(ctx, created.Token, "192.0.2.1")
+		if !errors.Is(err, tc.want) {
+			t.Errorf("%s: err = %v, want %v", name, err, tc.want)
+			continue
+		}
+		if tc.want == nil && (user.ID != userID || len(user.Scopes) != 1 || user.Scopes[0] != static.AccessTokenRead) {
+			t.Errorf("%s: user = %+v, want the user of the token with its scopes", name, user)
+		}
+	}
+
+	s := NewService(servicetest.Users{}, &servicetest.AccessTokens{}, &servicetest.Recorder{})
+	if _, err := s.Authenticate(ctx, static.AccessToken.Prefix+"unknown", "192.0.2.1"); !errors.Is(err, static.ErrAccessTokenInvalid) {
+		t.Errorf("unknown: err = %v, want %v", err, static.ErrAccessTokenInvalid)
+	}
+}
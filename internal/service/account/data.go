@@ -0,0 +1,32 @@
+package account
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+
+	ct "golang-project/internal/contract"
+	"golang-project/internal/model"
+	"golang-project/static"
+)
+
+// gracePeriod returns how long a scheduled deletion can be cancelled before the account is deleted
+func gracePeriod() time.Duration {
+	if grace := viper.GetDuration(static.EnvAccountDeletionGrace); grace > 0 {
+		return grace
+	}
+
+	return static.AccountDeletion.GracePeriod
+}
+
+// prepareDeletionResponse transforms the data and returns the Account Deletion Response
+func prepareDeletionResponse(o *model.User) *ct.AccountDeletionResponse {
+	if o.DeletionScheduledAt == nil {
+		return &ct.AccountDeletionResponse{}
+	}
+
+	return &ct.AccountDeletionResponse{
+		Scheduled:    true,
+		ScheduledFor: o.DeletionScheduledAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,279 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/database"
+	"golang-project/internal/audit"
+	ct "golang-project/internal/contract"
+	"golang-project/internal/event"
+	"golang-project/internal/model"
+	"golang-project/internal/notification"
+	repo "golang-project/internal/repository"
+	svc "golang-project/internal/service"
+	"golang-project/internal/tracing"
+	"golang-project/static"
+)
+
+// service represents the implementation of service.Account
+type service struct {
+	userRepo     repo.User
+	accountRepo  repo.Account
+	exportSvc    svc.Export
+	twoFactorSvc svc.TwoFactor
+	unitOfWork   database.UnitOfWork
+	publisher    event.Publisher
+	notifier     notification.Notifier
+	auditor      audit.Recorder
+}
+
+// NewService returns a new implementation of service.Account
+func NewService(userRepo repo.User, accountRepo repo.Account, exportSvc svc.Export, twoFactorSvc svc.TwoFactor, unitOfWork database.UnitOfWork,
+	publisher event.Publisher, notifier notification.Notifier, auditor audit.Recorder) svc.Account {
+	return &service{
+		userRepo:     userRepo,
+		accountRepo:  accountRepo,
+		exportSvc:    exportSvc,
+		twoFactorSvc: twoFactorSvc,
+		unitOfWork:   unitOfWork,
+		publisher:    publisher,
+		notifier:     notifier,
+		auditor:      auditor,
+	}
+}
+
+// ScheduleDeletion verifies the confirmation of the user and schedules the deletion after the grace period
+func (s *service) ScheduleDeletion(ctx context.Context, userID primitive.ObjectID, r *ct.ScheduleDeletionRequest) (*ct.AccountDeletionResponse, error) {
+	ctx, span := tracing.Start(ctx, "account.ScheduleDeletion")
+	defer span.End()
+
+	if err := s.twoFactorSvc.Reauthenticate(ctx, userID, &r.Reauthentication); err != nil {
+		return nil, err
+	}
+
+	// A code used for the confirmation changed the version of the user
+	user, err := s.userRepo.Read(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.DeletionScheduledAt != nil {
+		return nil, static.ErrDeletionAlreadyScheduled
+	}
+
+	scheduledAt := time.Now().Add(gracePeriod())
+	user, err = s.update(ctx, user, map[string]interface{}{"deletion_scheduled_at": scheduledAt})
+	if err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, static.AuditAccountDeletionSchedule, user.ID, user.ID)
+
+	s.notify(ctx, user, "Your account is scheduled for deletion",
+		"Your account and its data will be deleted on "+scheduledAt.Format(time.RFC1123)+", sign in and cancel the deletion to keep it.")
+
+	return prepareDeletionResponse(user), nil
+}
+
+// GetDeletion returns the scheduled deletion of the user
+func (s *service) GetDeletion(ctx context.Context, userID primitive.ObjectID) (*ct.AccountDeletionResponse, error) {
+	ctx, span := tracing.Start(ctx, "account.GetDeletion")
+	defer span.End()
+
+	user, err := s.userRepo.Read(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return prepareDeletionResponse(user), nil
+}
+
+// CancelDeletion cancels the scheduled deletion of the user during the grace period
+func (s *service) CancelDeletion(ctx context.Context, userID primitive.ObjectID) (*ct.AccountDeletionResponse, error) {
+	ctx, span := tracing.Start(ctx, "account.CancelDeletion")
+	defer span.End()
+
+	user, err := s.userRepo.Read(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.DeletionScheduledAt == nil {
+		return nil, static.ErrDeletionNotScheduled
+	}
+
+	user, err = s.update(ctx, user, map[string]interface{}{"deletion_scheduled_at": nil})
+	if err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, static.AuditAccountDeletionCancel, user.ID, user.ID)
+
+	s.notify(ctx, user, "Your account deletion is cancelled", "Your account will not be deleted.")
+
+	return prepareDeletionResponse(user), nil
+}
+
+// Work deletes the accounts whose grace period is over until ctx is cancelled
+func (s *service) Work(ctx context.Context) {
+	ticker := time.NewTicker(static.AccountDeletion.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.deleteDueAccounts(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// deleteDueAccounts runs the deletion cascade of every account due for deletion
+func (s *service) deleteDueAccounts(ctx context.Context) {
+	users, err := s.accountRepo.SelectDueDeletions(ctx, time.Now())
+	if err != nil {
+		log.Println("account deletion select error:", err)
+		return
+	}
+
+	for _, user := range users {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err = s.delete(ctx, user); err != nil {
+			log.Println("account deletion error:", user.ID.Hex(), err)
+			continue
+		}
+
+		s.record(ctx, static.AuditAccountDeleted, primitive.NilObjectID, user.ID)
+		s.notify(ctx, user, "Your account is deleted", "Your account and its data have been deleted.")
+	}
+}
+
+// delete removes or anonymises the data of the user in every collection and deletes the user last,
+// every step can run again so a failed deletion is completed by the next run
+func (s *service) delete(ctx context.Context, user *model.User) error {
+	ctx, span := tracing.Start(ctx, "account.Delete")
+	defer span.End()
+
+	steps := []func(context.Context, primitive.ObjectID) error{
+		s.exportSvc.Purge,
+		func(ctx context.Context, id primitive.ObjectID) error {
+			postIDs, err := s.accountRepo.DeletePosts(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			return s.publishPostChanges(ctx, postIDs...)
+		},
+		func(ctx context.Context, id primitive.ObjectID) error {
+			return s.accountRepo.AnonymiseComments(ctx, id, static.AccountDeletion.AnonymisedComment)
+		},
+		func(ctx context.Context, id primitive.ObjectID) error {
+			userIDs, err := s.accountRepo.DeleteFollows(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			return s.publishProfileChanges(ctx, userIDs...)
+		},
+		func(ctx context.Context, id primitive.ObjectID) error {
+			postIDs, err := s.accountRepo.DeleteFavourites(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			return s.publishPostChanges(ctx, postIDs...)
+		},
+		s.accountRepo.DeleteRelationships,
+		s.accountRepo.DeleteReactions,
+		s.accountRepo.DeleteWebhooks,
+		s.accountRepo.DeleteAccessTokens,
+		s.accountRepo.DeleteReports,
+		s.accountRepo.DeleteSignInChallenges,
+		s.accountRepo.DeleteIdempotencyKeys,
+		s.accountRepo.DeleteUser,
+		func(ctx context.Context, id primitive.ObjectID) error {
+			return s.publishProfileChanges(ctx, id)
+		},
+	}
+
+	for _, step := range steps {
+		if err := step(ctx, user.ID); err != nil {
+			span.RecordError(err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// update updates the user together with the profile change event
+func (s *service) update(ctx context.Context, user *model.User, updates map[string]interface{}) (*model.User, error) {
+	var updated *model.User
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if updated, err = s.userRepo.Update(ctx, user, updates); err != nil {
+			return err
+		}
+
+		return s.publishProfileChanges(ctx, user.ID)
+	})
+	if errors.Is(err, static.ErrVersionConflict) {
+		return nil, static.ErrConcurrentUpdate
+	}
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	return updated, nil
+}
+
+// publishProfileChanges publishes the profile change of the users so their cached profiles are dropped
+func (s *service) publishProfileChanges(ctx context.Context, userIDs ...primitive.ObjectID) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	changes := make([]event.Event, 0, len(userIDs))
+	for _, id := range userIDs {
+		changes = append(changes, event.ProfileChanged{UserID: id})
+	}
+
+	return s.publisher.Publish(ctx, changes...)
+}
+
+// publishPostChanges publishes the change of the posts so their cached details are dropped
+func (s *service) publishPostChanges(ctx context.Context, postIDs ...primitive.ObjectID) error {
+	if len(postIDs) == 0 {
+		return nil
+	}
+
+	changes := make([]event.Event, 0, len(postIDs))
+	for _, id := range postIDs {
+		changes = append(changes, event.PostChanged{PostID: id})
+	}
+
+	return s.publisher.Publish(ctx, changes...)
+}
+
+// record writes the action on the account of the user to the audit log, the deletion itself has no actor
+func (s *service) record(ctx context.Context, action static.AuditAction, actorID, userID primitive.ObjectID) {
+	s.auditor.Record(ctx, &audit.Entry{ActorID: actorID, Action: action, TargetType: static.AuditTargetUser, TargetID: userID})
+}
+
+// notify delivers the account notification to the user, failures are logged as they must not fail the request
+func (s *service) notify(ctx context.Context, user *model.User, subject, body string) {
+	err := s.notifier.Notify(ctx, &notification.Message{UserID: user.ID, Email: user.Email, Subject: subject, Body: body})
+	if err != nil {
+		log.Println("account notification error:", user.ID.Hex(), err)
+	}
+}
@@ -0,0 +1,269 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	ct "golang-project/internal/contract"
+	"golang-project/internal/event"
+	"golang-project/internal/model"
+	"golang-project/internal/notification"
+	repo "golang-project/internal/repository"
+	svc "golang-project/internal/service"
+	"golang-project/internal/service/servicetest"
+	"golang-project/static"
+)
+
+// users keeps a single user and applies the deletion schedule updates
+type users struct {
+	repo.User
+	user *model.User
+}
+
+func (r *users) Read(context.Context, primitive.ObjectID) (*model.User, error) {
+	read := *r.user
+	return &read, nil
+}
+
+func (r *users) Update(_ context.Context, _ *model.User, updates map[string]interface{}) (*model.User, error) {
+	for field, value := range updates {
+		if field != "deletion_scheduled_at" {
+			return nil, errors.New("unexpected update of " + field)
+		}
+
+		if scheduledAt, ok := value.(time.Time); ok {
+			r.user.DeletionScheduledAt = &scheduledAt
+		} else {
+			r.user.DeletionScheduledAt = nil
+		}
+	}
+
+	updated := *r.user
+	return &updated, nil
+}
+
+// accounts keeps the steps of the cascade in the order they run, a step in fail returns an error
+type accounts struct {
+	repo.Account
+	due          []*model.User
+	posts        []primitive.ObjectID
+	favourites   []primitive.ObjectID
+	followees    []primitive.ObjectID
+	fail         map[string]primitive.ObjectID
+	steps        []string
+	deletedUsers []primitive.ObjectID
+}
+
+func (r *accounts) step(name string, id primitive.ObjectID) error {
+	if failID, ok := r.fail[name]; ok && failID == id {
+		return errors.New(name + " failed")
+	}
+
+	r.steps = append(r.steps, name)
+	return nil
+}
+
+func (r *accounts) SelectDueDeletions(context.Context, time.Time) ([]*model.User, error) {
+	return r.due, nil
+}
+
+func (r *accounts) DeletePosts(_ context.Context, id primitive.ObjectID) ([]primitive.ObjectID, error) {
+	return r.posts, r.step("posts", id)
+}
+
+func (r *accounts) AnonymiseComments(_ context.Context, id primitive.ObjectID, content string) error {
+	if content != static.AccountDeletion.AnonymisedComment {
+		return errors.New("unexpected comment content " + content)
+	}
+
+	return r.step("comments", id)
+}
+
+func (r *accounts) DeleteFollows(_ context.Context, id primitive.ObjectID) ([]primitive.ObjectID, error) {
+	return r.followees, r.step("follows", id)
+}
+
+func (r *accounts) DeleteFavourites(_ context.Context, id primitive.ObjectID) ([]primitive.ObjectID, error) {
+	return r.favourites, r.step("favourites", id)
+}
+
+func (r *accounts) DeleteRelationships(_ context.Context, id primitive.ObjectID) error {
+	return r.step("relationships", id)
+}
+
+func (r *accounts) DeleteReactions(_ context.Context, id primitive.ObjectID) error {
+	return r.step("reactions", id)
+}
+
+func (r *accounts) DeleteWebhooks(_ context.Context, id primitive.ObjectID) error {
+	return r.step("webhooks", id)
+}
+
+func (r *accounts) DeleteAccessTokens(_ context.Context, id primitive.ObjectID) error {
+	return r.step("access_tokens", id)
+}
+
+func (r *accounts) DeleteReports(_ context.Context, id primitive.ObjectID) error {
+	return r.step("reports", id)
+}
+
+func (r *accounts) DeleteSignInChallenges(_ context.Context, id primitive.ObjectID) error {
+	return r.step("sign_in_challenges", id)
+}
+
+func (r *accounts) DeleteIdempotencyKeys(_ context.Context, id primitive.ObjectID) error {
+	return r.step("idempotency_keys", id)
+}
+
+func (r *accounts) DeleteUser(_ context.Context, id primitive.ObjectID) error {
+	if err := r.step("user", id); err != nil {
+		return err
+	}
+
+	r.deletedUsers = append(r.deletedUsers, id)
+	return nil
+}
+
+// exports keeps the users whose exports are purged
+type exports struct {
+	svc.Export
+	purged []primitive.ObjectID
+}
+
+func (s *exports) Purge(_ context.Context, id primitive.ObjectID) error {
+	s.purged = append(s.purged, id)
+	return nil
+}
+
+// twoFactor answers every reauthentication with err
+type twoFactor struct {
+	svc.TwoFactor
+	err error
+}
+
+func (s twoFactor) Reauthenticate(context.Context, primitive.ObjectID, *ct.Reauthentication) error {
+	return s.err
+}
+
+// notifier keeps the notifications
+type notifier struct {
+	messages []*notification.Message
+}
+
+func (n *notifier) Notify(_ context.Context, message *notification.Message) error {
+	n.messages = append(n.messages, message)
+	return nil
+}
+
+func TestScheduleDeletionUntilCancelled(t *testing.T) {
+	viper.Set(static.EnvAccountDeletionGrace, "1h")
+	t.Cleanup(func() { viper.Set(static.EnvAccountDeletionGrace, nil) })
+
+	ctx := context.Background()
+	u := &users{user: &model.User{BaseModel: model.BaseModel{ID: primitive.NewObjectID()}, Email: "jane@example.com"}}
+	publisher, recorder, n := &servicetest.Publisher{}, &servicetest.Recorder{}, &notifier{}
+	s := NewService(u, &accounts{}, &exports{}, twoFactor{err: static.ErrReauthenticationRequired}, servicetest.UnitOfWork{}, publisher, n, recorder)
+
+	// A failed confirmation does not schedule the deletion
+	if _, err := s.ScheduleDeletion(ctx, u.user.ID, &ct.ScheduleDeletionRequest{}); !errors.Is(err, static.ErrReauthenticationRequired) {
+		t.Fatalf("ScheduleDeletion() without confirmation: err = %v, want %v", err, static.ErrReauthenticationRequired)
+	}
+	if u.user.DeletionScheduledAt != nil {
+		t.Fatal("deletion scheduled without confirmation")
+	}
+
+	s = NewService(u, &accounts{}, &exports{}, twoFactor{}, servicetest.UnitOfWork{}, publisher, n, recorder)
+	before := time.Now()
+	response, err := s.ScheduleDeletion(ctx, u.user.ID, &ct.ScheduleDeletionRequest{})
+	if err != nil {
+		t.Fatalf("ScheduleDeletion() error = %v", err)
+	}
+	if !response.Scheduled || u.user.DeletionScheduledAt == nil {
+		t.Fatalf("response = %+v, want the deletion scheduled", response)
+	}
+	if grace := u.user.DeletionScheduledAt.Sub(before); grace < time.Hour || grace > time.Hour+time.Minute {
+		t.Errorf("grace period = %v, want the configured hour", grace)
+	}
+	if _, err = s.ScheduleDeletion(ctx, u.user.ID, &ct.ScheduleDeletionRequest{}); !errors.Is(err, static.ErrDeletionAlreadyScheduled) {
+		t.Errorf("ScheduleDeletion() twice: err = %v, want %v", err, static.ErrDeletionAlreadyScheduled)
+	}
+
+	response, err = s.CancelDeletion(ctx, u.user.ID)
+	if err != nil {
+		t.Fatalf("CancelDeletion() error = %v", err)
+	}
+	if response.Scheduled || u.user.DeletionScheduledAt != nil {
+		t.Fatalf("response = %+v, want the deletion cancelled", response)
+	}
+	if _, err = s.CancelDeletion(ctx, u.user.ID); !errors.Is(err, static.ErrDeletionNotScheduled) {
+		t.Errorf("CancelDeletion() twice: err = %v, want %v", err, static.ErrDeletionNotScheduled)
+	}
+
+	var actions []static.AuditAction
+	for _, entry := range recorder.Entries {
+		actions = append(actions, entry.Action)
+	}
+	if want := []static.AuditAction{static.AuditAccountDeletionSchedule, static.AuditAccountDeletionCancel}; !slices.Equal(actions, want) {
+		t.Errorf("audit actions = %v, want %v", actions, want)
+	}
+	if len(n.messages) != 2 || len(publisher.Events) != 2 {
+		t.Errorf("notifications = %d, events = %d, want two of each", len(n.messages), len(publisher.Events))
+	}
+}
+
+func TestDeleteDueAccountsRunsTheCascade(t *testing.T) {
+	ctx := context.Background()
+	failed := &model.User{BaseModel: model.BaseModel{ID: primitive.NewObjectID()}, Email: "john@example.com"}
+	deleted := &model.User{BaseModel: model.BaseModel{ID: primitive.NewObjectID()}, Email: "jane@example.com"}
+	postID, favouriteID, followeeID := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	a := &accounts{
+		due:        []*model.User{failed, deleted},
+		posts:      []primitive.ObjectID{postID},
+		favourites: []primitive.ObjectID{favouriteID},
+		followees:  []primitive.ObjectID{followeeID},
+		fail:       map[string]primitive.ObjectID{"webhooks": failed.ID},
+	}
+	e, publisher, recorder, n := &exports{}, &servicetest.Publisher{}, &servicetest.Recorder{}, &notifier{}
+	s := NewService(&users{}, a, e, twoFactor{}, servicetest.UnitOfWork{}, publisher, n, recorder).(*service)
+
+	s.deleteDueAccounts(ctx)
+
+	// The failed deletion stops before the user so the next run completes it, the next account is still deleted
+	want := []string{"posts", "comments", "follows", "favourites", "relationships", "reactions",
+		"posts", "comments", "follows", "favourites", "relationships", "reactions", "webhooks", "access_tokens",
+		"reports", "sign_in_challenges", "idempotency_keys", "user"}
+	if !slices.Equal(a.steps, want) {
+		t.Errorf("steps = %v, want %v", a.steps, want)
+	}
+	if !slices.Equal(a.deletedUsers, []primitive.ObjectID{deleted.ID}) {
+		t.Errorf("deleted users = %v, want only %s", a.deletedUsers, deleted.ID.Hex())
+	}
+	if !slices.Equal(e.purged, []primitive.ObjectID{failed.ID, deleted.ID}) {
+		t.Errorf("purged exports = %v, want both users", e.purged)
+	}
+
+	// The cached posts and profiles touched by the deletion are dropped
+	var events []event.Event
+	for _, userID := range []primitive.ObjectID{failed.ID, deleted.ID} {
+		events = append(events, event.PostChanged{PostID: postID}, event.ProfileChanged{UserID: followeeID}, event.PostChanged{PostID: favouriteID})
+		if userID == deleted.ID {
+			events = append(events, event.ProfileChanged{UserID: deleted.ID})
+		}
+	}
+	if !slices.Equal(publisher.Events, events) {
+		t.Errorf("events = %v, want %v", publisher.Events, events)
+	}
+
+	if len(recorder.Entries) != 1 || recorder.Entries[0].Action != static.AuditAccountDeleted || recorder.Entries[0].TargetID != deleted.ID {
+		t.Errorf("audit entries = %v, want only the deletion of %s", recorder.Entries, deleted.ID.Hex())
+	}
+	if len(n.messages) != 1 || n.messages[0].Email != deleted.Email {
+		t.Errorf("notifications = %v, want only %s", n.messages, deleted.Email)
+	}
+}
@@ -0,0 +1,61 @@
+package admin
+
+import (
+	"time"
+
+	ct "golang-project/internal/contract"
+	m "golang-project/internal/model"
+	"golang-project/static"
+)
+
+// prepareUserResponse transforms the data and returns the Admin User Response
+func prepareUserResponse(o *m.User, dryRun bool) *ct.AdminUserResponse {
+	data := &ct.AdminUserResponse{
+		ID:        o.ID,
+		Email:     o.Email,
+		Pseudonym: o.Pseudonym,
+		Role:      o.Role,
+		Disabled:  o.DisabledAt != nil,
+		DryRun:    dryRun,
+	}
+
+	// Accounts created before roles were introduced hold the user role
+	if data.Role == "" {
+		data.Role = static.RoleUser
+	}
+
+	if o.DisabledAt != nil {
+		data.DisabledAt = o.DisabledAt.Format(time.RFC3339)
+	}
+
+	return data
+}
+
+// preparePostResponse transforms the data and returns the Admin Post Response
+func preparePostResponse(o *m.Post, dryRun bool) *ct.AdminPostResponse {
+	return &ct.AdminPostResponse{
+		ID:          o.ID,
+		Title:       o.Title,
+		Slug:        o.Slug,
+		IsPublished: o.IsPublished,
+		DryRun:      dryRun,
+	}
+}
+
+// prepareTagResponse transforms the data and returns the Tag Response
+func prepareTagResponse(o *m.Tag) *ct.TagResponse {
+	data := &ct.TagResponse{
+		ID:   o.ID,
+		Name: o.Name,
+	}
+
+	if o.CreatedAt != nil {
+		data.CreatedAt = o.CreatedAt.Format(time.RFC3339)
+	}
+
+	if o.UpdatedAt != nil {
+		data.UpdatedAt = o.UpdatedAt.Format(time.RFC3339)
+	}
+
+	return data
+}
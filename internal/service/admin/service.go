@@ -0,0 +1,399 @@
+package admin
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/database"
+	"golang-project/internal/audit"
+	ct "golang-project/internal/contract"
+	"golang-project/internal/event"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	svc "golang-project/internal/service"
+	"golang-project/internal/tracing"
+	"golang-project/static"
+	"golang-project/util/hashing"
+)
+
+// generatedPasswordBytes is the entropy of the passwords generated by ResetPassword
+const generatedPasswordBytes = 12
+
+// service represents the implementation of service.Admin
+type service struct {
+	authService svc.Authentication
+	userRepo    repo.User
+	adminRepo   repo.Admin
+	hash        hashing.Algorithm
+	unitOfWork  database.UnitOfWork
+	publisher   event.Publisher
+	auditor     audit.Recorder
+}
+
+// NewService returns a new implementation of service.Admin
+func NewService(authService svc.Authentication, userRepo repo.User, adminRepo repo.Admin, hash hashing.Algorithm, unitOfWork database.UnitOfWork,
+	publisher event.Publisher, auditor audit.Recorder) svc.Admin {
+	return &service{
+		authService: authService,
+		userRepo:    userRepo,
+		adminRepo:   adminRepo,
+		hash:        hash,
+		unitOfWork:  unitOfWork,
+		publisher:   publisher,
+		auditor:     auditor,
+	}
+}
+
+// CreateUser registers a new user account through the sign up flow and grants it the requested role
+func (s *service) CreateUser(ctx context.Context, r *ct.AdminCreateUserRequest) (*ct.AdminUserResponse, error) {
+	ctx, span := tracing.Start(ctx, "admin.CreateUser")
+	defer span.End()
+
+	if r.DryRun {
+		_, err := s.userRepo.ReadByEmail(ctx, r.Email)
+		if err == nil {
+			return nil, static.ErrEmailAlreadyExists
+		}
+		if !errors.Is(err, static.ErrUserNotFound) {
+			return nil, static.ErrCheckEmailFailed.Wrap(err)
+		}
+
+		// The pseudonym is only generated when the user is created
+		user := &model.User{Email: r.Email, FirstName: r.FirstName, LastName: r.LastName, Role: r.Role}
+		return prepareUserResponse(user, true), nil
+	}
+
+	signUp, err := s.authService.SignUp(ctx, &ct.SignUpRequest{
+		Email:     r.Email,
+		Password:  r.Password,
+		FirstName: r.FirstName,
+		LastName:  r.LastName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The sign up leaves the password hash out of its response, the stored user carries the version
+	user, err := s.userRepo.Read(ctx, signUp.User.ID)
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+	if r.Role != user.Role {
+		user, err = s.updateUser(ctx, user, map[string]interface{}{"role": r.Role})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s.record(ctx, static.AuditUserCreated, user.ID, map[string]string{"role": string(user.Role)})
+
+	return prepareUserResponse(user, false), nil
+}
+
+// PromoteUser grants the moderator or the admin role to the user
+func (s *service) PromoteUser(ctx context.Context, r *ct.AdminPromoteUserRequest) (*ct.AdminUserResponse, error) {
+	ctx, span := tracing.Start(ctx, "admin.PromoteUser")
+	defer span.End()
+
+	user, err := s.readUser(ctx, r.Identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Role == r.Role {
+		return nil, static.ErrUserAlreadyHasRole
+	}
+
+	if r.DryRun {
+		user.Role = r.Role
+		return prepareUserResponse(user, true), nil
+	}
+
+	previousRole := user.Role
+	user, err = s.updateUser(ctx, user, map[string]interface{}{"role": r.Role})
+	if err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, static.AuditRoleChanged, user.ID, map[string]string{"from": string(previousRole), "to": string(r.Role)})
+
+	return prepareUserResponse(user, false), nil
+}
+
+// DisableUser disables the user account so it can no longer sign in
+func (s *service) DisableUser(ctx context.Context, r *ct.AdminUserRequest) (*ct.AdminUserResponse, error) {
+	ctx, span := tracing.Start(ctx, "admin.DisableUser")
+	defer span.End()
+
+	user, err := s.readUser(ctx, r.Identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.DisabledAt != nil {
+		return nil, static.ErrUserAlreadyDisabled
+	}
+
+	now := time.Now()
+	if r.DryRun {
+		user.DisabledAt = &now
+		return prepareUserResponse(user, true), nil
+	}
+
+	user, err = s.updateUser(ctx, user, map[string]interface{}{"disabled_at": now})
+	if err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, static.AuditUserDisabled, user.ID, nil)
+
+	return prepareUserResponse(user, false), nil
+}
+
+// ResetPassword replaces the password of the user with the requested or a generated one
+func (s *service) ResetPassword(ctx context.Context, r *ct.AdminResetPasswordRequest) (*ct.AdminResetPasswordResponse, error) {
+	ctx, span := tracing.Start(ctx, "admin.ResetPassword")
+	defer span.End()
+
+	user, err := s.readUser(ctx, r.Identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.DryRun {
+		return &ct.AdminResetPasswordResponse{User: prepareUserResponse(user, true), DryRun: true}, nil
+	}
+
+	password, generated := r.Password, false
+	if password == "" {
+		password, err = generatePassword()
+		if err != nil {
+			return nil, static.ErrPasswordHashingFailed.Wrap(err)
+		}
+		generated = true
+	}
+
+	hashedPassword, err := s.hash.Generate([]byte(password))
+	if err != nil {
+		return nil, static.ErrPasswordHashingFailed.Wrap(err)
+	}
+
+	user, err = s.updateUser(ctx, user, map[string]interface{}{"password": string(hashedPassword)})
+	if err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, static.AuditPasswordReset, user.ID, map[string]string{"generated": strconv.FormatBool(generated)})
+
+	response := &ct.AdminResetPasswordResponse{User: prepareUserResponse(user, false)}
+	if generated {
+		response.Password = password
+	}
+
+	return response, nil
+}
+
+// UnpublishPost takes the published post down without deleting it
+func (s *service) UnpublishPost(ctx context.Context, r *ct.AdminPostRequest) (*ct.AdminPostResponse, error) {
+	ctx, span := tracing.Start(ctx, "admin.UnpublishPost")
+	defer span.End()
+
+	post, err := s.readPost(ctx, r.Identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if !post.IsPublished {
+		return nil, static.ErrPostNotPublished
+	}
+
+	post.IsPublished = false
+	if r.DryRun {
+		return preparePostResponse(post, true), nil
+	}
+
+	err = s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := s.adminRepo.UnpublishPost(ctx, post.ID); err != nil {
+			return err
+		}
+
+		return s.publisher.Publish(ctx, event.PostChanged{PostID: post.ID}, event.ProfileChanged{UserID: post.UserID})
+	})
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	s.auditor.Record(ctx, &audit.Entry{Action: static.AuditPostUnpublished, TargetType: static.AuditTargetPost, TargetID: post.ID})
+
+	return preparePostResponse(post, false), nil
+}
+
+// MergeTags moves the posts of the source tag onto the target tag and removes the source tag
+func (s *service) MergeTags(ctx context.Context, r *ct.AdminMergeTagRequest) (*ct.AdminMergeTagResponse, error) {
+	ctx, span := tracing.Start(ctx, "admin.MergeTags")
+	defer span.End()
+
+	source, err := s.readTag(ctx, r.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := s.readTag(ctx, r.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	if source.ID == target.ID {
+		return nil, static.ErrMergeSameTag
+	}
+
+	response := &ct.AdminMergeTagResponse{
+		Source: prepareTagResponse(source),
+		Target: prepareTagResponse(target),
+		DryRun: r.DryRun,
+	}
+
+	if r.DryRun {
+		response.PostsUpdated, err = s.adminRepo.CountTagPosts(ctx, source.ID)
+		if err != nil {
+			return nil, static.ErrDatabaseOperation.Wrap(err)
+		}
+		return response, nil
+	}
+
+	// The relabelled posts and the tag list drop their cached copies, the posts showed the source tag
+	err = s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		postIDs, err := s.adminRepo.MergeTag(ctx, source.ID, target.ID)
+		if err != nil {
+			return err
+		}
+		response.PostsUpdated = int64(len(postIDs))
+
+		changes := make([]event.Event, 0, len(postIDs)+1)
+		changes = append(changes, event.TagChanged{TagID: source.ID})
+		for _, id := range postIDs {
+			changes = append(changes, event.PostChanged{PostID: id})
+		}
+
+		return s.publisher.Publish(ctx, changes...)
+	})
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	s.auditor.Record(ctx, &audit.Entry{
+		Action:     static.AuditTagsMerged,
+		TargetType: static.AuditTargetTag,
+		TargetID:   target.ID,
+		Metadata:   map[string]string{"source_id": source.ID.Hex(), "source": source.Name, "target": target.Name},
+	})
+
+	return response, nil
+}
+
+// RecomputeCounters recounts the denormalised counters of the users and posts from the source collections
+// and corrects the ones that drifted
+func (s *service) RecomputeCounters(ctx context.Context, r *ct.AdminRecomputeCountersRequest) (*ct.AdminRecomputeCountersResponse, error) {
+	ctx, span := tracing.Start(ctx, "admin.RecomputeCounters")
+	defer span.End()
+
+	var err error
+	response := &ct.AdminRecomputeCountersResponse{DryRun: r.DryRun}
+
+	userIDs, err := s.adminRepo.RecomputeUserCounters(ctx, r.DryRun)
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+	response.UsersUpdated = int64(len(userIDs))
+
+	postIDs, err := s.adminRepo.RecomputePostCounters(ctx, r.DryRun)
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+	response.PostsUpdated = int64(len(postIDs))
+
+	if !r.DryRun {
+		changes := make([]event.Event, 0, len(userIDs)+len(postIDs))
+		for _, id := range userIDs {
+			changes = append(changes, event.ProfileChanged{UserID: id})
+		}
+		for _, id := range postIDs {
+			changes = append(changes, event.PostChanged{PostID: id})
+		}
+
+		if err = s.publisher.Publish(ctx, changes...); err != nil {
+			return nil, static.ErrDatabaseOperation.Wrap(err)
+		}
+	}
+
+	return response, nil
+}
+
+// updateUser updates the user together with the profile change event, so the server drops its cached profile
+func (s *service) updateUser(ctx context.Context, user *model.User, updates map[string]interface{}) (*model.User, error) {
+	var updated *model.User
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if updated, err = s.userRepo.Update(ctx, user, updates); err != nil {
+			return err
+		}
+
+		return s.publisher.Publish(ctx, event.ProfileChanged{UserID: user.ID})
+	})
+	if errors.Is(err, static.ErrVersionConflict) {
+		return nil, static.ErrConcurrentUpdate
+	}
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	return updated, nil
+}
+
+// record writes the admin action on the user to the audit log, the admin commands have no actor
+func (s *service) record(ctx context.Context, action static.AuditAction, userID primitive.ObjectID, metadata map[string]string) {
+	s.auditor.Record(ctx, &audit.Entry{Action: action, TargetType: static.AuditTargetUser, TargetID: userID, Metadata: metadata})
+}
+
+// readUser finds the user by ID or by email
+func (s *service) readUser(ctx context.Context, identifier string) (*model.User, error) {
+	if id, err := primitive.ObjectIDFromHex(identifier); err == nil {
+		return s.userRepo.Read(ctx, id)
+	}
+
+	return s.userRepo.ReadByEmail(ctx, identifier)
+}
+
+// readPost finds the post by ID or by slug
+func (s *service) readPost(ctx context.Context, identifier string) (*model.Post, error) {
+	if id, err := primitive.ObjectIDFromHex(identifier); err == nil {
+		return s.adminRepo.ReadPost(ctx, id)
+	}
+
+	return s.adminRepo.ReadPostBySlug(ctx, identifier)
+}
+
+// readTag finds the tag by ID or by name
+func (s *service) readTag(ctx context.Context, identifier string) (*model.Tag, error) {
+	if id, err := primitive.ObjectIDFromHex(identifier); err == nil {
+		return s.adminRepo.ReadTag(ctx, id)
+	}
+
+	return s.adminRepo.ReadTagByName(ctx, identifier)
+}
+
+// generatePassword returns a random URL safe password
+func generatePassword() (string, error) {
+	buf := make([]byte, generatedPasswordBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
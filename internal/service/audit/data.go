@@ -0,0 +1,30 @@
+package audit
+
+import (
+	"time"
+
+	ct "golang-project/internal/contract"
+	"golang-project/internal/model"
+)
+
+// prepareAuditLogResponse transforms the data and returns the Audit Log Response
+func prepareAuditLogResponse(o *model.AuditLog) *ct.AuditLogResponse {
+	data := &ct.AuditLogResponse{
+		ID:            o.ID,
+		ActorID:       o.ActorID,
+		Action:        o.Action,
+		TargetType:    o.TargetType,
+		TargetID:      o.TargetID,
+		Source:        o.Source,
+		IP:            o.IP,
+		UserAgent:     o.UserAgent,
+		CorrelationID: o.CorrelationID,
+		Metadata:      o.Metadata,
+	}
+
+	if o.CreatedAt != nil {
+		data.CreatedAt = o.CreatedAt.Format(time.RFC3339)
+	}
+
+	return data
+}
@@ -0,0 +1,133 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	ct "golang-project/internal/contract"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	svc "golang-project/internal/service"
+	"golang-project/internal/tracing"
+	"golang-project/static"
+	"golang-project/util/pagination"
+)
+
+// securityActions are the actions on the account of a user shown in their security activity
+var securityActions = []static.AuditAction{
+	static.AuditSignUp,
+	static.AuditSignIn,
+	static.AuditSignInFailed,
+	static.AuditIdentityLinked,
+	static.AuditPasswordChanged,
+	static.AuditRoleChanged,
+	static.AuditUserDisabled,
+	static.AuditPasswordReset,
+	static.AuditAccountDeletionSchedule,
+	static.AuditAccountDeletionCancel,
+	static.AuditWebhookCreated,
+	static.AuditWebhookDeleted,
+	static.AuditAccessTokenCreated,
+	static.AuditAccessTokenRevoked,
+	static.AuditTwoFactorEnabled,
+	static.AuditTwoFactorDisabled,
+	static.AuditRecoveryCodesRenewed,
+}
+
+// service represents the implementation of service.Audit
+type service struct {
+	userRepo  repo.User
+	auditRepo repo.Audit
+}
+
+// NewService returns a new implementation of service.Audit
+func NewService(userRepo repo.User, auditRepo repo.Audit) svc.Audit {
+	return &service{
+		userRepo:  userRepo,
+		auditRepo: auditRepo,
+	}
+}
+
+// List searches the audit log for admins only
+func (s *service) List(ctx context.Context, userID primitive.ObjectID, r *ct.ListAuditLogRequest) (*ct.ListAuditLogResponse, error) {
+	ctx, span := tracing.Start(ctx, "audit.List")
+	defer span.End()
+
+	if err := s.requireAdmin(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	filter := &model.AuditLogFilter{}
+	if !r.ActorID.IsZero() {
+		filter.ActorID = &r.ActorID
+	}
+	if !r.TargetID.IsZero() {
+		filter.TargetID = &r.TargetID
+	}
+	if r.Action != "" {
+		filter.Actions = []static.AuditAction{r.Action}
+	}
+	if r.From != "" {
+		from, _ := time.Parse(time.RFC3339, r.From)
+		filter.From = &from
+	}
+	if r.To != "" {
+		to, _ := time.Parse(time.RFC3339, r.To)
+		filter.To = &to
+	}
+
+	return s.list(ctx, filter, r.Page, r.PageSize)
+}
+
+// ListSecurityActivity returns the sign-ins and the account changes of the user, done by the user or to the user
+func (s *service) ListSecurityActivity(ctx context.Context, userID primitive.ObjectID, r *ct.ListSecurityActivityRequest) (*ct.ListAuditLogResponse, error) {
+	ctx, span := tracing.Start(ctx, "audit.ListSecurityActivity")
+	defer span.End()
+
+	return s.list(ctx, &model.AuditLogFilter{UserID: &userID, Actions: securityActions}, r.Page, r.PageSize)
+}
+
+// list returns the page of the entries matching the filter
+func (s *service) list(ctx context.Context, filter *model.AuditLogFilter, page, pageSize int) (*ct.ListAuditLogResponse, error) {
+	if page == 0 {
+		page = static.Pagination.DefaultPage
+	}
+	if pageSize == 0 {
+		pageSize = static.Pagination.DefaultPageSize
+	}
+
+	entries, total, err := s.auditRepo.Select(ctx, filter, pagination.CalculateOffset(page, pageSize), pageSize)
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	responses := make([]*ct.AuditLogResponse, 0, len(entries))
+	for _, entry := range entries {
+		responses = append(responses, prepareAuditLogResponse(entry))
+	}
+
+	return &ct.ListAuditLogResponse{
+		Entries: responses,
+		Paging: ct.Paging{
+			Page:     page,
+			PageSize: pageSize,
+			Total:    int(total),
+		},
+	}, nil
+}
+
+// requireAdmin checks that the user has the admin role
+func (s *service) requireAdmin(ctx context.Context, userID primitive.ObjectID) error {
+	user, err := s.userRepo.Read(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.Role != static.RoleAdmin {
+		return static.ErrUserPermission
+	}
+
+	return nil
+}
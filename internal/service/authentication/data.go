@@ -1,6 +1,8 @@
 package authentication
 
 import (
+	"time"
+
 	"github.com/spf13/viper"
 
 	ct "golang-project/internal/contract"
@@ -18,8 +20,21 @@ func prepareSignInResponse(o *m.User, token string) *ct.SignInResponse {
 	}
 }
 
+// prepareSignUpResponse transforms the registered user and returns the Sign Up Response
 func prepareSignUpResponse(o *m.User) *ct.SignUpResponse {
-	return &ct.SignUpResponse{
-		User: o,
+	user := &ct.SignUpUser{
+		ID:         o.ID,
+		Email:      o.Email,
+		FirstName:  o.FirstName,
+		LastName:   o.LastName,
+		Pseudonym:  o.Pseudonym,
+		IsVerified: o.IsVerified,
+		Role:       o.Role,
+	}
+
+	if o.CreatedAt != nil {
+		user.CreatedAt = o.CreatedAt.Format(time.RFC3339)
 	}
+
+	return &ct.SignUpResponse{User: user}
 }
@@ -0,0 +1,279 @@
+package authentication
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"golang-project/internal/audit"
+	ct "golang-project/internal/contract"
+	"golang-project/internal/event"
+	"golang-project/internal/metrics"
+	"golang-project/internal/model"
+	"golang-project/internal/oidc"
+	"golang-project/internal/tracing"
+	"golang-project/static"
+)
+
+// oidcEntropy is the entropy in bytes of the state, the nonce and the PKCE verifier
+const oidcEntropy = 32
+
+// The generated pseudonyms keep 23 characters of the email for the 7 of the suffix, within the 30 allowed
+const (
+	pseudonymBaseLength = 23
+	pseudonymAttempts   = 5
+)
+
+// pseudonymInvalidChars matches the characters of an email not allowed in a pseudonym
+var pseudonymInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9_.\-]+`)
+
+// AuthorizeOIDC keeps the state, nonce and PKCE verifier of the new sign-in and returns the provider URL
+func (s *service) AuthorizeOIDC(ctx context.Context) (*ct.OIDCAuthorizationResponse, error) {
+	ctx, span := tracing.Start(ctx, "authentication.AuthorizeOIDC")
+	defer span.End()
+
+	state, nonce, verifier, err := generateOIDCSecrets()
+	if err != nil {
+		return nil, err
+	}
+
+	authorizationURL, err := s.provider.AuthorizationURL(ctx, state, nonce, oidc.CodeChallenge(verifier))
+	if errors.Is(err, oidc.ErrDisabled) {
+		return nil, static.ErrOIDCDisabled
+	}
+	if err != nil {
+		return nil, static.ErrOIDCAuthorizationFailed.Wrap(err)
+	}
+
+	expiresAt := time.Now().Add(static.OIDC.StateTTL)
+	err = s.stateRepo.Insert(ctx, &model.OIDCState{State: state, Nonce: nonce, CodeVerifier: verifier, ExpiresAt: &expiresAt})
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	return &ct.OIDCAuthorizationResponse{AuthorizationURL: authorizationURL, StateCookie: signOIDCState(state)}, nil
+}
+
+// SignInOIDC redeems the authorization code and signs in the user linked to the provider account.
+// An unlinked account is linked to the user with the same verified email, or to a new user
+func (s *service) SignInOIDC(ctx context.Context, r *ct.OIDCCallbackRequest) (*ct.SignInResponse, error) {
+	ctx, span := tracing.Start(ctx, "authentication.SignInOIDC")
+	defer span.End()
+
+	// The state must come from the browser that started the sign-in, otherwise an attacker could
+	// complete the sign-in of their own account in the browser of the victim
+	if r.StateCookie == "" || !hmac.Equal([]byte(r.StateCookie), []byte(signOIDCState(r.State))) {
+		return nil, static.ErrOIDCStateInvalid
+	}
+
+	// The state is consumed first so a failed sign-in cannot be replayed
+	state, err := s.stateRepo.Consume(ctx, r.State)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Error != "" {
+		metrics.SignInsTotal.WithLabelValues(metrics.OutcomeFailure).Inc()
+		s.auditor.Record(ctx, &audit.Entry{Action: static.AuditSignInFailed, Metadata: map[string]string{"method": "oidc", "reason": r.Error}})
+		return nil, static.ErrOIDCAuthorizationFailed.WithField("error", r.Error)
+	}
+
+	claims, err := s.provider.Exchange(ctx, r.Code, state.CodeVerifier, state.Nonce)
+	if errors.Is(err, oidc.ErrDisabled) {
+		return nil, static.ErrOIDCDisabled
+	}
+	if err != nil {
+		log.Println("oidc exchange error:", err)
+		metrics.SignInsTotal.WithLabelValues(metrics.OutcomeFailure).Inc()
+		s.auditor.Record(ctx, &audit.Entry{Action: static.AuditSignInFailed, Metadata: map[string]string{"method": "oidc", "reason": "exchange_failed"}})
+		return nil, static.ErrOIDCAuthorizationFailed.Wrap(err)
+	}
+
+	user, err := s.readOIDCUser(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.DisabledAt != nil {
+		metrics.SignInsTotal.WithLabelValues(metrics.OutcomeFailure).Inc()
+		s.recordOIDCSignIn(ctx, static.AuditSignInFailed, user, claims, "account_disabled")
+		return nil, static.ErrUserDisabled
+	}
+
+	if user.TwoFactor != nil && user.TwoFactor.EnabledAt != nil {
+		return s.challengeTwoFactor(ctx, user, "oidc")
+	}
+
+	token, err := s.generateToken(user)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.SignInsTotal.WithLabelValues(metrics.OutcomeSuccess).Inc()
+	s.recordOIDCSignIn(ctx, static.AuditSignIn, user, claims, "")
+
+	return prepareSignInResponse(user, token), nil
+}
+
+// readOIDCUser returns the user linked to the provider account, linking or creating it by the verified email
+func (s *service) readOIDCUser(ctx context.Context, claims *oidc.Claims) (*model.User, error) {
+	user, err := s.userRepo.ReadByIdentity(ctx, claims.Issuer, claims.Subject)
+	if err == nil {
+		return user, nil
+	}
+	if !errors.Is(err, static.ErrUserNotFound) {
+		return nil, err
+	}
+
+	// Linking by email is only safe when the provider vouches for the address
+	if claims.Email == "" || !claims.EmailVerified {
+		metrics.SignInsTotal.WithLabelValues(metrics.OutcomeFailure).Inc()
+		s.auditor.Record(ctx, &audit.Entry{Action: static.AuditSignInFailed, Metadata: map[string]string{"method": "oidc", "email": claims.Email, "reason": "email_not_verified"}})
+		return nil, static.ErrOIDCEmailNotVerified
+	}
+
+	email := normalizeEmail(claims.Email)
+	now := time.Now()
+	identity := &model.ExternalIdentity{Issuer: claims.Issuer, Subject: claims.Subject, Email: email, LinkedAt: &now}
+
+	user, err = s.userRepo.ReadByEmail(ctx, email)
+	if errors.Is(err, static.ErrUserNotFound) {
+		pseudonym, err := s.generatePseudonym(ctx, email)
+		if err != nil {
+			return nil, err
+		}
+
+		firstName, lastName := oidcNames(claims)
+		return s.createUser(ctx, &model.User{
+			Email:      email,
+			FirstName:  firstName,
+			LastName:   lastName,
+			Pseudonym:  pseudonym,
+			IsVerified: true,
+			Role:       static.RoleUser,
+			Identities: []*model.ExternalIdentity{identity},
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	updates := map[string]interface{}{"identities": append(user.Identities, identity)}
+	if !user.IsVerified {
+		// Nobody proved owning the email of an unverified account, it may have been signed up by someone
+		// else before its owner arrived. The owner takes it over: the password and the two-factor
+		// authentication set by the other person are removed and their sessions and access tokens end
+		updates["is_verified"] = true
+		updates["password"] = ""
+		updates["two_factor"] = nil
+		updates["sessions_revoked_at"] = now
+	}
+
+	err = s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		linked, err := s.userRepo.Update(ctx, user, updates)
+		if err != nil {
+			return err
+		}
+		user = linked
+
+		return s.publisher.Publish(ctx, event.ProfileChanged{UserID: user.ID})
+	})
+	if errors.Is(err, static.ErrVersionConflict) {
+		return nil, static.ErrConcurrentUpdate
+	}
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	s.recordOIDCSignIn(ctx, static.AuditIdentityLinked, user, claims, "")
+
+	return user, nil
+}
+
+// recordOIDCSignIn writes the OpenID Connect sign-in attempt on the account of the user to the audit log
+func (s *service) recordOIDCSignIn(ctx context.Context, action static.AuditAction, user *model.User, claims *oidc.Claims, reason string) {
+	entry := &audit.Entry{
+		ActorID:    user.ID,
+		Action:     action,
+		TargetType: static.AuditTargetUser,
+		TargetID:   user.ID,
+		Metadata:   map[string]string{"method": "oidc", "issuer": claims.Issuer, "subject": claims.Subject},
+	}
+	if reason != "" {
+		entry.Metadata["reason"] = reason
+	}
+
+	s.auditor.Record(ctx, entry)
+}
+
+// generatePseudonym returns an unused pseudonym for the new user from the local part of the email
+// and a random suffix, the email itself is not a valid pseudonym and must not be made public
+func (s *service) generatePseudonym(ctx context.Context, email string) (string, error) {
+	local, _, _ := strings.Cut(email, "@")
+	base := strings.TrimLeft(pseudonymInvalidChars.ReplaceAllString(local, ""), "_.-")
+	if len(base) > pseudonymBaseLength {
+		base = base[:pseudonymBaseLength]
+	}
+	if base == "" {
+		base = "user"
+	}
+
+	for attempt := 0; attempt < pseudonymAttempts; attempt++ {
+		suffix := make([]byte, 3)
+		if _, err := rand.Read(suffix); err != nil {
+			return "", err
+		}
+
+		pseudonym := base + "-" + hex.EncodeToString(suffix)
+		_, err := s.userRepo.ReadByPseudonym(ctx, pseudonym)
+		if errors.Is(err, static.ErrUserNotFound) {
+			return pseudonym, nil
+		}
+		if err != nil {
+			return "", static.ErrDatabaseOperation.Wrap(err)
+		}
+	}
+
+	return "", static.ErrDatabaseOperation.Wrap(errors.New("no unused pseudonym found"))
+}
+
+// signOIDCState returns the signature of the state kept in the state cookie of the browser
+func signOIDCState(state string) string {
+	mac := hmac.New(sha256.New, []byte(viper.GetString(static.EnvAuthSecret)))
+	mac.Write([]byte("oidc-state:" + state))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// generateOIDCSecrets returns a new state, nonce and PKCE verifier
+func generateOIDCSecrets() (state, nonce, verifier string, err error) {
+	if state, err = oidc.RandomString(oidcEntropy); err != nil {
+		return
+	}
+	if nonce, err = oidc.RandomString(oidcEntropy); err != nil {
+		return
+	}
+	verifier, err = oidc.RandomString(oidcEntropy)
+	return
+}
+
+// oidcNames returns the first and last names of the new user from the provider claims, falling back on the email
+func oidcNames(claims *oidc.Claims) (string, string) {
+	firstName, lastName := claims.GivenName, claims.FamilyName
+	if firstName == "" && claims.Name != "" {
+		firstName, lastName, _ = strings.Cut(claims.Name, " ")
+	}
+	if firstName == "" {
+		firstName, _, _ = strings.Cut(claims.Email, "@")
+	}
+
+	return firstName, lastName
+}
@@ -0,0 +1,407 @@
+package authentication
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/spf13/viper"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	ct "golang-project/internal/contract"
+	"golang-project/internal/model"
+	"golang-project/internal/oidc"
+	repo "golang-project/internal/repository"
+	"golang-project/internal/service/servicetest"
+	"golang-project/static"
+	"golang-project/util/hashing"
+)
+
+const testClientID = "blog"
+
+// mockProvider is a local OpenID Connect provider publishing its discovery document and signing key,
+// it redeems the codes granted by the test for the claims of the signed-in provider account
+type mockProvider struct {
+	*httptest.Server
+	key    *rsa.PrivateKey
+	mu     sync.Mutex
+	grants map[string]*grant
+}
+
+// grant represents an authorization code with the PKCE challenge and the nonce of the authorization request
+type grant struct {
+	challenge string
+	nonce     string
+	claims    jwt.MapClaims
+}
+
+func newMockProvider(t *testing.T) *mockProvider {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := &mockProvider{key: key, grants: map[string]*grant{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 p.URL,
+			"authorization_endpoint": p.URL + "/authorize",
+			"token_endpoint":         p.URL + "/token",
+			"jwks_uri":               p.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "test",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", p.token)
+	p.Server = httptest.NewServer(mux)
+	t.Cleanup(p.Close)
+
+	return p
+}
+
+// token redeems the code when the verifier matches the challenge of the authorization request
+func (p *mockProvider) token(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	g, ok := p.grants[r.FormValue("code")]
+	delete(p.grants, r.FormValue("code"))
+	p.mu.Unlock()
+
+	if !ok || r.FormValue("client_id") != testClientID || oidc.CodeChallenge(r.FormValue("code_verifier")) != g.challenge {
+		http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+		return
+	}
+
+	claims := jwt.MapClaims{"iss": p.URL, "aud": testClientID, "nonce": g.nonce, "exp": time.Now().Add(time.Minute).Unix()}
+	for name, value := range g.claims {
+		claims[name] = value
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "test"
+	signed, err := token.SignedString(p.key)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]string{"id_token": signed})
+}
+
+// authorize grants a code for the authorization URL as the provider does once the account signed in
+func (p *mockProvider) authorize(t *testing.T, authorizationURL string, claims jwt.MapClaims) string {
+	t.Helper()
+
+	u, err := url.Parse(authorizationURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := u.Query()
+	if query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		t.Fatalf("authorization URL without a S256 challenge: %s", authorizationURL)
+	}
+
+	code := primitive.NewObjectID().Hex()
+	p.mu.Lock()
+	p.grants[code] = &grant{challenge: query.Get("code_challenge"), nonce: query.Get("nonce"), claims: claims}
+	p.mu.Unlock()
+
+	return code
+}
+
+// oidcStates keeps the started sign-ins in memory
+type oidcStates struct {
+	repo.OIDCState
+	states map[string]*model.OIDCState
+}
+
+func (r *oidcStates) Insert(_ context.Context, o *model.OIDCState) error {
+	r.states[o.State] = o
+	return nil
+}
+
+func (r *oidcStates) Consume(_ context.Context, state string) (*model.OIDCState, error) {
+	o, ok := r.states[state]
+	if !ok {
+		return nil, static.ErrOIDCStateInvalid
+	}
+	delete(r.states, state)
+
+	return o, nil
+}
+
+// users keeps the users in memory
+type users struct {
+	repo.User
+	users []*model.User
+}
+
+func (r *users) Read(_ context.Context, id primitive.ObjectID) (*model.User, error) {
+	for _, user := range r.users {
+		if user.ID == id {
+			return user, nil
+		}
+	}
+
+	return nil, static.ErrUserNotFound
+}
+
+func (r *users) ReadByEmail(_ context.Context, email string) (*model.User, error) {
+	for _, user := range r.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+
+	return nil, static.ErrUserNotFound
+}
+
+func (r *users) ReadByPseudonym(_ context.Context, pseudonym string) (*model.User, error) {
+	for _, user := range r.users {
+		if user.Pseudonym == pseudonym {
+			return user, nil
+		}
+	}
+
+	return nil, static.ErrUserNotFound
+}
+
+func (r *users) ReadByIdentity(_ context.Context, issuer, subject string) (*model.User, error) {
+	for _, user := range r.users {
+		for _, identity := range user.Identities {
+			if identity.Issuer == issuer && identity.Subject == subject {
+				return user, nil
+			}
+		}
+	}
+
+	return nil, static.ErrUserNotFound
+}
+
+func (r *users) Insert(_ context.Context, o *model.User) (*model.User, error) {
+	o.ID = primitive.NewObjectID()
+	r.users = append(r.users, o)
+
+	return o, nil
+}
+
+func (r *users) Update(_ context.Context, o *model.User, updates map[string]interface{}) (*model.User, error) {
+	user, err := r.Read(context.Background(), o.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for field, value := range updates {
+		switch field {
+		case "identities":
+			user.Identities = value.([]*model.ExternalIdentity)
+		case "is_verified":
+			user.IsVerified = value.(bool)
+		case "password":
+			user.Password = value.(string)
+		case "two_factor":
+			user.TwoFactor, _ = value.(*model.TwoFactor)
+		case "sessions_revoked_at":
+			revokedAt := value.(time.Time)
+			user.SessionsRevokedAt = &revokedAt
+		default:
+			return nil, errors.New("unexpected update of " + field)
+		}
+	}
+	user.Version++
+
+	return user, nil
+}
+
+// newOIDCTestService returns the service signing in through the mock provider
+func newOIDCTestService(t *testing.T, provider *mockProvider, userRepo *users) (*service, *oidcStates) {
+	t.Helper()
+
+	viper.Set(static.EnvAuthSecret, "test-secret")
+	t.Cleanup(func() { viper.Set(static.EnvAuthSecret, nil) })
+
+	states := &oidcStates{states: map[string]*model.OIDCState{}}
+	client := oidc.NewProvider(oidc.Config{Issuer: provider.URL, ClientID: testClientID, RedirectURL: "https://blog.test/callback", Scopes: []string{"openid", "email"}})
+
+	return &service{
+		userRepo:   userRepo,
+		hash:       hashing.NewBcrypt(),
+		unitOfWork: servicetest.UnitOfWork{},
+		publisher:  &servicetest.Publisher{},
+		auditor:    &servicetest.Recorder{},
+		provider:   client,
+		stateRepo:  states,
+	}, states
+}
+
+// signIn starts the sign-in and completes it with the claims of the provider account
+func signIn(t *testing.T, s *service, provider *mockProvider, claims jwt.MapClaims) (*ct.SignInResponse, error) {
+	t.Helper()
+	ctx := context.Background()
+
+	authorization, err := s.AuthorizeOIDC(ctx)
+	if err != nil {
+		t.Fatalf("AuthorizeOIDC() error = %v", err)
+	}
+
+	u, _ := url.Parse(authorization.AuthorizationURL)
+	code := provider.authorize(t, authorization.AuthorizationURL, claims)
+
+	return s.SignInOIDC(ctx, &ct.OIDCCallbackRequest{State: u.Query().Get("state"), Code: code, StateCookie: authorization.StateCookie})
+}
+
+func TestSignInOIDCChecksTheStateThePKCEVerifierAndTheNonce(t *testing.T) {
+	provider := newMockProvider(t)
+	s, states := newOIDCTestService(t, provider, &users{})
+	ctx := context.Background()
+	claims := jwt.MapClaims{"sub": "42", "email": "jane@example.com", "email_verified": true}
+
+	for name, tc := range map[string]struct {
+		tamper func(state *model.OIDCState, request *ct.OIDCCallbackRequest)
+		want   error
+	}{
+		"state of another browser": {func(_ *model.OIDCState, r *ct.OIDCCallbackRequest) { r.StateCookie = signOIDCState("other") }, static.ErrOIDCStateInvalid},
+		"unknown state": {func(_ *model.OIDCState, r *ct.OIDCCallbackRequest) {
+			r.State, r.StateCookie = "other", signOIDCState("other")
+		}, static.ErrOIDCStateInvalid},
+		"wrong PKCE verifier": {func(o *model.OIDCState, _ *ct.OIDCCallbackRequest) { o.CodeVerifier = "other" }, static.ErrOIDCAuthorizationFailed},
+		"replayed nonce":      {func(o *model.OIDCState, _ *ct.OIDCCallbackRequest) { o.Nonce = "other" }, static.ErrOIDCAuthorizationFailed},
+	} {
+		authorization, err := s.AuthorizeOIDC(ctx)
+		if err != nil {
+			t.Fatalf("AuthorizeOIDC() error = %v", err)
+		}
+		u, _ := url.Parse(authorization.AuthorizationURL)
+		state := u.Query().Get("state")
+
+		request := &ct.OIDCCallbackRequest{State: state, Code: provider.authorize(t, authorization.AuthorizationURL, claims), StateCookie: authorization.StateCookie}
+		tc.tamper(states.states[state], request)
+
+		if _, err = s.SignInOIDC(ctx, request); !errors.Is(err, tc.want) {
+			t.Errorf("%s: err = %v, want %v", name, err, tc.want)
+		}
+	}
+
+	// The state is used up by the callback, a replay of the same callback is refused
+	authorization, _ := s.AuthorizeOIDC(ctx)
+	u, _ := url.Parse(authorization.AuthorizationURL)
+	request := &ct.OIDCCallbackRequest{State: u.Query().Get("state"), Code: provider.authorize(t, authorization.AuthorizationURL, claims), StateCookie: authorization.StateCookie}
+	if _, err := s.SignInOIDC(ctx, request); err != nil {
+		t.Fatalf("sign-in: %v", err)
+	}
+	if _, err := s.SignInOIDC(ctx, request); !errors.Is(err, static.ErrOIDCStateInvalid) {
+		t.Errorf("replayed callback: err = %v, want %v", err, static.ErrOIDCStateInvalid)
+	}
+}
+
+func TestSignInOIDCRefusesUnverifiedEmails(t *testing.T) {
+	provider := newMockProvider(t)
+	userRepo := &users{users: []*model.User{{BaseModel: model.BaseModel{ID: primitive.NewObjectID()}, Email: "jane@example.com", IsVerified: true}}}
+	s, _ := newOIDCTestService(t, provider, userRepo)
+
+	_, err := signIn(t, s, provider, jwt.MapClaims{"sub": "42", "email": "jane@example.com", "email_verified": false})
+	if !errors.Is(err, static.ErrOIDCEmailNotVerified) {
+		t.Fatalf("err = %v, want %v", err, static.ErrOIDCEmailNotVerified)
+	}
+	if len(userRepo.users[0].Identities) != 0 {
+		t.Errorf("identities = %d, want the unverified account left unlinked", len(userRepo.users[0].Identities))
+	}
+}
+
+func TestSignInOIDCLinksVerifiedUsers(t *testing.T) {
+	provider := newMockProvider(t)
+	user := &model.User{BaseModel: model.BaseModel{ID: primitive.NewObjectID()}, Email: "jane@example.com", Password: "hashed", IsVerified: true}
+	userRepo := &users{users: []*model.User{user}}
+	s, _ := newOIDCTestService(t, provider, userRepo)
+
+	response, err := signIn(t, s, provider, jwt.MapClaims{"sub": "42", "email": "Jane@Example.com", "email_verified": true})
+	if err != nil {
+		t.Fatalf("SignInOIDC() error = %v", err)
+	}
+	if response.UserID != user.ID || response.Token == "" {
+		t.Fatalf("signed in %s, want %s with a token", response.UserID.Hex(), user.ID.Hex())
+	}
+	if len(user.Identities) != 1 || user.Identities[0].Subject != "42" {
+		t.Fatalf("identities = %v, want the provider account", user.Identities)
+	}
+	if user.Password != "hashed" || user.SessionsRevokedAt != nil {
+		t.Errorf("the password and sessions of the verified owner were changed")
+	}
+
+	// The linked account signs in by its subject even once the email changes at the provider
+	response, err = signIn(t, s, provider, jwt.MapClaims{"sub": "42", "email": "jane@new.example.com", "email_verified": true})
+	if err != nil || response.UserID != user.ID {
+		t.Errorf("sign-in of the linked account: user = %v, err = %v", response, err)
+	}
+}
+
+func TestSignInOIDCTakesOverUnverifiedUsers(t *testing.T) {
+	provider := newMockProvider(t)
+	secret := "JBSWY3DPEHPK3PXP"
+	squatter := &model.User{
+		BaseModel: model.BaseModel{ID: primitive.NewObjectID()},
+		Email:     "jane@example.com",
+		Password:  "hashed by someone else",
+		TwoFactor: &model.TwoFactor{Secret: secret},
+	}
+	s, _ := newOIDCTestService(t, provider, &users{users: []*model.User{squatter}})
+
+	response, err := signIn(t, s, provider, jwt.MapClaims{"sub": "42", "email": "jane@example.com", "email_verified": true})
+	if err != nil {
+		t.Fatalf("SignInOIDC() error = %v", err)
+	}
+	if response.UserID != squatter.ID {
+		t.Fatalf("signed in %s, want %s", response.UserID.Hex(), squatter.ID.Hex())
+	}
+	if !squatter.IsVerified || squatter.Password != "" || squatter.TwoFactor != nil {
+		t.Errorf("verified = %t, password = %q, two-factor = %v, want a verified account without the credentials of the sign-up", squatter.IsVerified, squatter.Password, squatter.TwoFactor)
+	}
+	if squatter.SessionsRevokedAt == nil {
+		t.Error("the sessions of the sign-up were not revoked")
+	}
+}
+
+func TestSignInOIDCCreatesNewUsers(t *testing.T) {
+	provider := newMockProvider(t)
+	userRepo := &users{}
+	s, _ := newOIDCTestService(t, provider, userRepo)
+
+	response, err := signIn(t, s, provider, jwt.MapClaims{"sub": "42", "email": "Jane.Doe@Example.com", "email_verified": "true", "name": "Jane Doe"})
+	if err != nil {
+		t.Fatalf("SignInOIDC() error = %v", err)
+	}
+	if len(userRepo.users) != 1 || response.UserID != userRepo.users[0].ID {
+		t.Fatalf("users = %d, want the new user signed in", len(userRepo.users))
+	}
+
+	user := userRepo.users[0]
+	if user.Email != "jane.doe@example.com" || !user.IsVerified || user.Password != "" {
+		t.Errorf("email = %q, verified = %t, want a verified lowercase email without password", user.Email, user.IsVerified)
+	}
+	if user.FirstName != "Jane" || user.LastName != "Doe" {
+		t.Errorf("names = %q %q, want Jane Doe", user.FirstName, user.LastName)
+	}
+	if len(user.Pseudonym) != len("jane.doe-")+6 || user.Pseudonym[:9] != "jane.doe-" {
+		t.Errorf("pseudonym = %q, want the local part of the email with a suffix", user.Pseudonym)
+	}
+}
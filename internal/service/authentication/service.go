@@ -1,44 +1,88 @@
 package authentication
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt"
 	"github.com/google/uuid"
 	"github.com/spf13/viper"
 
+	"golang-project/database"
+	"golang-project/internal/audit"
 	ct "golang-project/internal/contract"
+	"golang-project/internal/event"
+	"golang-project/internal/metrics"
 	"golang-project/internal/model"
+	"golang-project/internal/oidc"
 	repo "golang-project/internal/repository"
 	svc "golang-project/internal/service"
+	"golang-project/internal/tracing"
 	"golang-project/static"
 	"golang-project/util/hashing"
 )
 
 // service represents the implementation of service.Authentication
 type service struct {
-	userRepo repo.User
-	hash     hashing.Algorithm
+	userRepo      repo.User
+	hash          hashing.Algorithm
+	unitOfWork    database.UnitOfWork
+	publisher     event.Publisher
+	auditor       audit.Recorder
+	provider      oidc.Provider
+	stateRepo     repo.OIDCState
+	challengeRepo repo.SignInChallenge
+	twoFactorSvc  svc.TwoFactor
 }
 
 // NewService returns a new implementation of service.Authentication
-func NewService(userRepo repo.User, hash hashing.Algorithm) svc.Authentication {
+func NewService(userRepo repo.User, hash hashing.Algorithm, unitOfWork database.UnitOfWork, publisher event.Publisher, auditor audit.Recorder,
+	provider oidc.Provider, stateRepo repo.OIDCState, challengeRepo repo.SignInChallenge, twoFactorSvc svc.TwoFactor) svc.Authentication {
 	return &service{
-		userRepo: userRepo,
-		hash:     hash,
+		userRepo:      userRepo,
+		hash:          hash,
+		unitOfWork:    unitOfWork,
+		publisher:     publisher,
+		auditor:       auditor,
+		provider:      provider,
+		stateRepo:     stateRepo,
+		challengeRepo: challengeRepo,
+		twoFactorSvc:  twoFactorSvc,
 	}
 }
 
 // SignIn executes the user authentication logic
-func (s *service) SignIn(r *ct.SignInRequest) (*ct.SignInResponse, error) {
-	user, err := s.userRepo.ReadByEmail(r.Email)
+func (s *service) SignIn(ctx context.Context, r *ct.SignInRequest) (*ct.SignInResponse, error) {
+	ctx, span := tracing.Start(ctx, "authentication.SignIn")
+	defer span.End()
+
+	user, err := s.userRepo.ReadByEmail(ctx, r.Email)
+	if errors.Is(err, static.ErrUserNotFound) {
+		metrics.SignInsTotal.WithLabelValues(metrics.OutcomeFailure).Inc()
+		s.auditor.Record(ctx, &audit.Entry{Action: static.AuditSignInFailed, Metadata: map[string]string{"method": "password", "email": r.Email, "reason": "unknown_email"}})
+		return nil, static.ErrInvalidCredentials
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	err = s.hash.Compare([]byte(user.Password), []byte(r.Password))
 	if err != nil {
-		return nil, err
+		metrics.SignInsTotal.WithLabelValues(metrics.OutcomeFailure).Inc()
+		s.recordSignIn(ctx, static.AuditSignInFailed, user, "invalid_password")
+		return nil, static.ErrInvalidCredentials
+	}
+
+	if user.DisabledAt != nil {
+		metrics.SignInsTotal.WithLabelValues(metrics.OutcomeFailure).Inc()
+		s.recordSignIn(ctx, static.AuditSignInFailed, user, "account_disabled")
+		return nil, static.ErrUserDisabled
+	}
+
+	if user.TwoFactor != nil && user.TwoFactor.EnabledAt != nil {
+		return s.challengeTwoFactor(ctx, user, "password")
 	}
 
 	token, err := s.generateToken(user)
@@ -46,9 +90,23 @@ func (s *service) SignIn(r *ct.SignInRequest) (*ct.SignInResponse, error) {
 		return nil, err
 	}
 
+	metrics.SignInsTotal.WithLabelValues(metrics.OutcomeSuccess).Inc()
+	s.recordSignIn(ctx, static.AuditSignIn, user, "")
+
 	return prepareSignInResponse(user, token), nil
 }
 
+// recordSignIn writes the password sign-in attempt on the account of the user to the audit log
+func (s *service) recordSignIn(ctx context.Context, action static.AuditAction, user *model.User, reason string) {
+	entry := &audit.Entry{ActorID: user.ID, Action: action, TargetType: static.AuditTargetUser, TargetID: user.ID}
+	entry.Metadata = map[string]string{"method": "password"}
+	if reason != "" {
+		entry.Metadata["reason"] = reason
+	}
+
+	s.auditor.Record(ctx, entry)
+}
+
 // generateToken returns the JWT token based on the information from model.User
 func (s *service) generateToken(user *model.User) (string, error) {
 	secret := []byte(viper.GetString(static.EnvAuthSecret))
@@ -70,18 +128,21 @@ func (s *service) generateToken(user *model.User) (string, error) {
 }
 
 // SignUp handles the user registration process
-func (s *service) SignUp(r *ct.SignUpRequest) (*ct.SignUpResponse, error) {
-	// Check if email already exists
-	existingUser, err := s.userRepo.ReadByEmail(r.Email)
-	if err != nil {
-		// For MongoDB, we'll handle "not found" differently
-		// This will be updated when we rewrite the repository
-		return nil, static.ErrCheckEmailFailed
-	}
+func (s *service) SignUp(ctx context.Context, r *ct.SignUpRequest) (*ct.SignUpResponse, error) {
+	ctx, span := tracing.Start(ctx, "authentication.SignUp")
+	defer span.End()
 
-	if existingUser != nil {
+	// Emails are stored lowercase, the lookups ignore their case
+	email := normalizeEmail(r.Email)
+
+	// Check if email already exists
+	_, err := s.userRepo.ReadByEmail(ctx, email)
+	if err == nil {
 		return nil, static.ErrEmailAlreadyExists
 	}
+	if !errors.Is(err, static.ErrUserNotFound) {
+		return nil, static.ErrCheckEmailFailed.Wrap(err)
+	}
 
 	// Hash the password
 	hashedPassword, err := s.hash.Generate([]byte(r.Password))
@@ -89,23 +150,56 @@ func (s *service) SignUp(r *ct.SignUpRequest) (*ct.SignUpResponse, error) {
 		return nil, static.ErrPasswordHashingFailed
 	}
 
+	// The email must not be made public, the pseudonym is generated from its local part
+	pseudonym, err := s.generatePseudonym(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create new user
 	user := &model.User{
-		Email:      r.Email,
+		Email:      email,
 		Password:   string(hashedPassword),
 		FirstName:  r.FirstName,
 		LastName:   r.LastName,
-		Pseudonym:  r.Email,
+		Pseudonym:  pseudonym,
 		IsVerified: false,
+		Role:       static.RoleUser,
+	}
+
+	user, err = s.createUser(ctx, user)
+	if err != nil {
+		return nil, err
 	}
 
-	// Save to database
-	user, err = s.userRepo.Insert(user)
+	return prepareSignUpResponse(user), nil
+}
+
+// createUser saves the new user together with the sign-up event
+func (s *service) createUser(ctx context.Context, user *model.User) (*model.User, error) {
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = s.userRepo.Insert(ctx, user)
+		if err != nil {
+			return err
+		}
+
+		return s.publisher.Publish(ctx, event.UserSignedUp{UserID: user.ID, Email: user.Email})
+	})
+	if errors.Is(err, static.ErrEmailAlreadyExists) {
+		return nil, err
+	}
 	if err != nil {
-		return nil, static.ErrSaveUserFailed
+		return nil, static.ErrSaveUserFailed.Wrap(err)
 	}
 
-	return &ct.SignUpResponse{
-		User: user,
-	}, nil
+	metrics.SignUpsTotal.Inc()
+	s.auditor.Record(ctx, &audit.Entry{ActorID: user.ID, Action: static.AuditSignUp, TargetType: static.AuditTargetUser, TargetID: user.ID})
+
+	return user, nil
+}
+
+// normalizeEmail returns the email as it is stored, trimmed and lowercase
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
 }
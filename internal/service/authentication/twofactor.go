@@ -0,0 +1,154 @@
+package authentication
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"time"
+
+	"golang-project/internal/audit"
+	ct "golang-project/internal/contract"
+	"golang-project/internal/metrics"
+	"golang-project/internal/model"
+	"golang-project/internal/oidc"
+	"golang-project/internal/tracing"
+	"golang-project/static"
+)
+
+// challengeEntropy is the entropy in bytes of the two-factor challenge token
+const challengeEntropy = 32
+
+// challengeTwoFactor keeps the pending sign-in of the user whose first factor is verified
+// and returns the challenge token the code is sent with instead of the JWT. No challenge is
+// handed out while the codes of the user are locked after too many invalid codes.
+func (s *service) challengeTwoFactor(ctx context.Context, user *model.User, method string) (*ct.SignInResponse, error) {
+	if user.TwoFactor.LockedUntil != nil && time.Now().Before(*user.TwoFactor.LockedUntil) {
+		metrics.SignInsTotal.WithLabelValues(metrics.OutcomeFailure).Inc()
+		s.recordTwoFactorSignIn(ctx, static.AuditSignInFailed, user, method, "", "two_factor_locked")
+		return nil, static.ErrTwoFactorLocked
+	}
+
+	token, err := oidc.RandomString(challengeEntropy)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(static.TwoFactor.ChallengeTTL)
+	err = s.challengeRepo.Insert(ctx, &model.SignInChallenge{
+		UserID:    user.ID,
+		TokenHash: hashChallengeToken(token),
+		Method:    method,
+		ExpiresAt: &expiresAt,
+	})
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	return &ct.SignInResponse{
+		TwoFactorRequired:  true,
+		ChallengeToken:     token,
+		ChallengeExpiresAt: expiresAt.Format(time.RFC3339),
+	}, nil
+}
+
+// SignInTwoFactor checks the code of the pending sign-in and returns the JWT, every challenge allows
+// a few attempts before the user has to sign in again and too many invalid codes across the challenges
+// lock the codes of the user
+func (s *service) SignInTwoFactor(ctx context.Context, r *ct.TwoFactorSignInRequest) (*ct.SignInResponse, error) {
+	ctx, span := tracing.Start(ctx, "authentication.SignInTwoFactor")
+	defer span.End()
+
+	challenge, err := s.challengeRepo.Attempt(ctx, hashChallengeToken(r.ChallengeToken), static.TwoFactor.ChallengeAttempts)
+	if errors.Is(err, static.ErrTwoFactorChallengeInvalid) {
+		// The user of an unknown, expired or exhausted challenge is not known
+		metrics.SignInsTotal.WithLabelValues(metrics.OutcomeFailure).Inc()
+		s.auditor.Record(ctx, &audit.Entry{
+			Action:     static.AuditSignInFailed,
+			TargetType: static.AuditTargetUser,
+			Metadata:   map[string]string{"reason": "two_factor_challenge_invalid"},
+		})
+		return nil, err
+	}
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	user, err := s.userRepo.Read(ctx, challenge.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.DisabledAt != nil {
+		metrics.SignInsTotal.WithLabelValues(metrics.OutcomeFailure).Inc()
+		s.recordTwoFactorSignIn(ctx, static.AuditSignInFailed, user, challenge.Method, "", "account_disabled")
+		return nil, static.ErrUserDisabled
+	}
+
+	recoveryCode, err := s.twoFactorSvc.Verify(ctx, user.ID, r.Code)
+	if errors.Is(err, static.ErrTwoFactorNotEnabled) {
+		// Two-factor authentication was disabled since the first step, the challenge is stale
+		return nil, static.ErrTwoFactorChallengeInvalid
+	}
+	if errors.Is(err, static.ErrTwoFactorCodeInvalid) {
+		metrics.SignInsTotal.WithLabelValues(metrics.OutcomeFailure).Inc()
+		s.recordTwoFactorSignIn(ctx, static.AuditSignInFailed, user, challenge.Method, "", "invalid_two_factor_code")
+		return nil, err
+	}
+	if errors.Is(err, static.ErrTwoFactorLocked) {
+		// The challenge is dropped, the user signs in again once the lock is over
+		metrics.SignInsTotal.WithLabelValues(metrics.OutcomeFailure).Inc()
+		s.recordTwoFactorSignIn(ctx, static.AuditSignInFailed, user, challenge.Method, "", "two_factor_locked")
+		if err = s.challengeRepo.Delete(ctx, challenge.ID); err != nil {
+			log.Println("sign-in challenge delete error:", err)
+		}
+		return nil, static.ErrTwoFactorLocked
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.challengeRepo.Delete(ctx, challenge.ID); err != nil {
+		log.Println("sign-in challenge delete error:", err)
+	}
+
+	token, err := s.generateToken(user)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.SignInsTotal.WithLabelValues(metrics.OutcomeSuccess).Inc()
+	s.recordTwoFactorSignIn(ctx, static.AuditSignIn, user, challenge.Method, factorName(recoveryCode), "")
+
+	return prepareSignInResponse(user, token), nil
+}
+
+// recordTwoFactorSignIn writes the second step of the sign-in of the user to the audit log
+func (s *service) recordTwoFactorSignIn(ctx context.Context, action static.AuditAction, user *model.User, method, factor, reason string) {
+	entry := &audit.Entry{ActorID: user.ID, Action: action, TargetType: static.AuditTargetUser, TargetID: user.ID}
+	entry.Metadata = map[string]string{"method": method}
+	if factor != "" {
+		entry.Metadata["two_factor"] = factor
+	}
+	if reason != "" {
+		entry.Metadata["reason"] = reason
+	}
+
+	s.auditor.Record(ctx, entry)
+}
+
+// factorName returns the kind of the two-factor code for the audit log
+func factorName(recoveryCode bool) string {
+	if recoveryCode {
+		return "recovery_code"
+	}
+
+	return "authenticator"
+}
+
+// hashChallengeToken returns the hex SHA-256 of the challenge token it is stored by
+func hashChallengeToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,90 @@
+package comment
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	ct "golang-project/internal/contract"
+	"golang-project/internal/model"
+)
+
+// prepareCommentResponses transforms the top-level comments and nests their replies, the comments keep their order
+func prepareCommentResponses(comments []*model.Comment, users map[primitive.ObjectID]*model.User) []*ct.CommentResponse {
+	responses := make([]*ct.CommentResponse, 0, len(comments))
+	parents := make(map[primitive.ObjectID]*ct.CommentResponse, len(comments))
+
+	for _, comment := range comments {
+		if comment.ParentCommentID == nil {
+			data := prepareCommentResponse(comment, users)
+			parents[comment.ID] = data
+			responses = append(responses, data)
+			continue
+		}
+
+		if parent, ok := parents[*comment.ParentCommentID]; ok {
+			parent.ChildComments = append(parent.ChildComments, prepareChildCommentResponse(comment, users))
+		}
+	}
+
+	return responses
+}
+
+// prepareCommentResponse transforms model.Comment to contract.CommentResponse
+func prepareCommentResponse(o *model.Comment, users map[primitive.ObjectID]*model.User) *ct.CommentResponse {
+	data := &ct.CommentResponse{
+		ID:              o.ID,
+		Content:         o.Content,
+		User:            prepareAuthorResponse(users[o.UserID]),
+		ParentCommentID: o.ParentCommentID,
+	}
+
+	if o.CreatedAt != nil {
+		data.CreatedAt = o.CreatedAt.Format(time.RFC3339)
+	}
+
+	if o.UpdatedAt != nil {
+		data.UpdatedAt = o.UpdatedAt.Format(time.RFC3339)
+	}
+
+	return data
+}
+
+// prepareChildCommentResponse transforms a reply to contract.ChildCommentResponse
+func prepareChildCommentResponse(o *model.Comment, users map[primitive.ObjectID]*model.User) *ct.ChildCommentResponse {
+	data := &ct.ChildCommentResponse{
+		ID:              o.ID,
+		Content:         o.Content,
+		ParentCommentID: o.ParentCommentID,
+		User:            prepareAuthorResponse(users[o.UserID]),
+	}
+
+	if o.CreatedAt != nil {
+		data.CreatedAt = o.CreatedAt.Format(time.RFC3339)
+	}
+
+	if o.UpdatedAt != nil {
+		data.UpdatedAt = o.UpdatedAt.Format(time.RFC3339)
+	}
+
+	return data
+}
+
+// prepareAuthorResponse transforms the author of a comment into the public Profile Response, the email is left out
+func prepareAuthorResponse(o *model.User) *ct.ProfileResponse {
+	if o == nil {
+		return nil
+	}
+
+	return &ct.ProfileResponse{
+		ID:             o.ID,
+		FirstName:      o.FirstName,
+		LastName:       o.LastName,
+		Pseudonym:      o.Pseudonym,
+		ProfileImage:   o.ProfileImage,
+		Biography:      o.Biography,
+		FollowerCount:  o.FollowerCount,
+		FollowingCount: o.FollowingCount,
+		PostCount:      o.PostCount,
+	}
+}
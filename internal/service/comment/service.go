@@ -0,0 +1,266 @@
+package comment
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/database"
+	ct "golang-project/internal/contract"
+	"golang-project/internal/event"
+	"golang-project/internal/metrics"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	svc "golang-project/internal/service"
+	"golang-project/internal/tracing"
+	"golang-project/static"
+)
+
+// service represents the implementation of service.Comment
+type service struct {
+	commentRepo     repo.Comment
+	postRepo        repo.Post
+	userRepo        repo.User
+	relationshipSvc svc.Relationship
+	reactionSvc     svc.Reaction
+	unitOfWork      database.UnitOfWork
+	publisher       event.Publisher
+}
+
+// NewService returns a new implementation of service.Comment
+func NewService(commentRepo repo.Comment, postRepo repo.Post, userRepo repo.User, relationshipSvc svc.Relationship,
+	reactionSvc svc.Reaction, unitOfWork database.UnitOfWork, publisher event.Publisher) svc.Comment {
+	return &service{
+		commentRepo:     commentRepo,
+		postRepo:        postRepo,
+		userRepo:        userRepo,
+		relationshipSvc: relationshipSvc,
+		reactionSvc:     reactionSvc,
+		unitOfWork:      unitOfWork,
+		publisher:       publisher,
+	}
+}
+
+// List returns the page of top-level comments of the published post with their replies and reactions, the oldest first
+func (s *service) List(ctx context.Context, viewerID *primitive.ObjectID, req *ct.ListCommentRequest) (*ct.ListCommentResponse, error) {
+	ctx, span := tracing.Start(ctx, "comment.List")
+	defer span.End()
+
+	if req.Page == 0 {
+		req.Page = static.Pagination.DefaultPage
+	}
+	if req.PageSize == 0 {
+		req.PageSize = static.Pagination.DefaultPageSize
+	}
+
+	if _, err := s.readPublishedPost(ctx, req.PostID); err != nil {
+		return nil, err
+	}
+
+	comments, total, err := s.commentRepo.Select(ctx, req)
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	users, err := s.selectAuthors(ctx, comments)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := prepareCommentResponses(comments, users)
+	if err = s.reactionSvc.AttachToComments(ctx, viewerID, responses); err != nil {
+		return nil, err
+	}
+
+	return &ct.ListCommentResponse{
+		Comments: responses,
+		Paging:   ct.Paging{Page: req.Page, PageSize: req.PageSize, Total: int(total)},
+	}, nil
+}
+
+// Create comments the published post, a reply to a reply joins the thread of its top-level comment.
+// The comment is refused when the user and the author of the post or of the replied comment blocked each other
+func (s *service) Create(ctx context.Context, req *ct.CreateCommentRequest, userID primitive.ObjectID) (*ct.CommentResponse, error) {
+	ctx, span := tracing.Start(ctx, "comment.Create")
+	defer span.End()
+
+	post, err := s.readPublishedPost(ctx, req.PostID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.relationshipSvc.CheckInteraction(ctx, userID, post.UserID); err != nil {
+		return nil, err
+	}
+
+	comment := &model.Comment{Content: req.Content, PostID: post.ID, UserID: userID}
+
+	if req.ParentCommentID != nil {
+		parent, err := s.read(ctx, *req.ParentCommentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent.PostID != post.ID || parent.HiddenAt != nil {
+			return nil, static.ErrCommentNotFound
+		}
+
+		if err = s.relationshipSvc.CheckInteraction(ctx, userID, parent.UserID); err != nil {
+			return nil, err
+		}
+
+		comment.ParentCommentID = &parent.ID
+		if parent.ParentCommentID != nil {
+			comment.ParentCommentID = parent.ParentCommentID
+		}
+	}
+
+	err = s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.commentRepo.Insert(ctx, comment); err != nil {
+			return err
+		}
+
+		return s.publisher.Publish(ctx,
+			event.CommentCreated{CommentID: comment.ID, PostID: post.ID, UserID: userID, PostUserID: post.UserID},
+			event.PostChanged{PostID: post.ID},
+		)
+	})
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	metrics.CommentsCreatedTotal.Inc()
+
+	return s.prepareResponse(ctx, comment, userID)
+}
+
+// Update updates the content of the comment of the user
+func (s *service) Update(ctx context.Context, req *ct.UpdateCommentRequest, userID primitive.ObjectID) (*ct.CommentResponse, error) {
+	ctx, span := tracing.Start(ctx, "comment.Update")
+	defer span.End()
+
+	comment, err := s.readOwn(ctx, req.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.commentRepo.UpdateCommentByID(ctx, comment.ID, map[string]interface{}{"content": req.Content})
+	if errors.Is(err, static.ErrCommentNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	if comment, err = s.read(ctx, comment.ID); err != nil {
+		return nil, err
+	}
+
+	return s.prepareResponse(ctx, comment, userID)
+}
+
+// Delete deletes the comment of the user with its replies
+func (s *service) Delete(ctx context.Context, id, userID primitive.ObjectID) error {
+	ctx, span := tracing.Start(ctx, "comment.Delete")
+	defer span.End()
+
+	comment, err := s.readOwn(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	err = s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.commentRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		return s.publisher.Publish(ctx, event.PostChanged{PostID: comment.PostID})
+	})
+	if errors.Is(err, static.ErrCommentNotFound) {
+		return err
+	}
+	if err != nil {
+		return static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	return nil
+}
+
+// readPublishedPost reads the post and checks it is published
+func (s *service) readPublishedPost(ctx context.Context, id primitive.ObjectID) (*model.Post, error) {
+	post, err := s.postRepo.Read(ctx, id)
+	if errors.Is(err, static.ErrPostNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, static.ErrFetchPostDetail.Wrap(err)
+	}
+
+	if !post.IsPublished || post.HiddenAt != nil {
+		return nil, static.ErrPostNotFound
+	}
+
+	return post, nil
+}
+
+// readOwn reads the comment and checks the user is its author
+func (s *service) readOwn(ctx context.Context, id, userID primitive.ObjectID) (*model.Comment, error) {
+	comment, err := s.read(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.UserID != userID {
+		return nil, static.ErrUserPermission
+	}
+
+	return comment, nil
+}
+
+// read reads the comment
+func (s *service) read(ctx context.Context, id primitive.ObjectID) (*model.Comment, error) {
+	comment, err := s.commentRepo.Read(ctx, id)
+	if errors.Is(err, static.ErrCommentNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	return comment, nil
+}
+
+// prepareResponse loads the author and the reactions of the comment and returns its Comment Response
+func (s *service) prepareResponse(ctx context.Context, comment *model.Comment, viewerID primitive.ObjectID) (*ct.CommentResponse, error) {
+	users, err := s.selectAuthors(ctx, []*model.Comment{comment})
+	if err != nil {
+		return nil, err
+	}
+
+	response := prepareCommentResponse(comment, users)
+	if err = s.reactionSvc.AttachToComments(ctx, &viewerID, []*ct.CommentResponse{response}); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// selectAuthors loads the authors of the comments
+func (s *service) selectAuthors(ctx context.Context, comments []*model.Comment) (map[primitive.ObjectID]*model.User, error) {
+	ids := make([]primitive.ObjectID, 0, len(comments))
+	for _, comment := range comments {
+		ids = append(ids, comment.UserID)
+	}
+
+	users, err := s.userRepo.Select(ctx, ids)
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	usersByID := make(map[primitive.ObjectID]*model.User, len(users))
+	for _, user := range users {
+		usersByID[user.ID] = user
+	}
+
+	return usersByID, nil
+}
@@ -0,0 +1,81 @@
+package comment
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	ct "golang-project/internal/contract"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	svc "golang-project/internal/service"
+	"golang-project/internal/service/servicetest"
+	"golang-project/static"
+)
+
+// commentRepo stores the inserted comment and lists it
+type commentRepo struct {
+	repo.Comment
+	comment *model.Comment
+}
+
+func (r *commentRepo) Select(context.Context, *ct.ListCommentRequest) ([]*model.Comment, int64, error) {
+	return []*model.Comment{r.comment}, 1, nil
+}
+
+func (r *commentRepo) Insert(_ context.Context, comment *model.Comment) (*model.Comment, error) {
+	comment.ID = primitive.NewObjectID()
+	r.comment = comment
+	return comment, nil
+}
+
+// likedReactions lets the given user like every comment
+type likedReactions struct {
+	svc.Reaction
+	userID primitive.ObjectID
+}
+
+func (r likedReactions) AttachToComments(_ context.Context, viewerID *primitive.ObjectID, comments []*ct.CommentResponse) error {
+	for _, comment := range comments {
+		comment.Reactions = ct.ReactionCounts{static.ReactionLike: 1}
+		if viewerID != nil && *viewerID == r.userID {
+			comment.MyReaction = static.ReactionLike
+		}
+	}
+
+	return nil
+}
+
+func TestCommentsCarryTheReactionOfTheViewer(t *testing.T) {
+	ctx := context.Background()
+	userID := primitive.NewObjectID()
+	s := NewService(&commentRepo{}, servicetest.Posts{AuthorID: primitive.NewObjectID()}, servicetest.Users{}, servicetest.Relationships{},
+		likedReactions{userID: userID}, servicetest.UnitOfWork{}, &servicetest.Publisher{})
+
+	created, err := s.Create(ctx, &ct.CreateCommentRequest{Content: "First", PostID: primitive.NewObjectID()}, userID)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if created.MyReaction != static.ReactionLike || created.Reactions[static.ReactionLike] != 1 {
+		t.Errorf("created comment reactions = %v, mine = %q", created.Reactions, created.MyReaction)
+	}
+
+	otherID := primitive.NewObjectID()
+	for name, tc := range map[string]struct {
+		viewerID *primitive.ObjectID
+		want     static.ReactionType
+	}{
+		"author":    {&userID, static.ReactionLike},
+		"other":     {&otherID, ""},
+		"anonymous": {nil, ""},
+	} {
+		list, err := s.List(ctx, tc.viewerID, &ct.ListCommentRequest{PostID: primitive.NewObjectID()})
+		if err != nil {
+			t.Fatalf("List(%s) error = %v", name, err)
+		}
+		if got := list.Comments[0].MyReaction; got != tc.want {
+			t.Errorf("reaction seen by the %s = %q, want %q", name, got, tc.want)
+		}
+	}
+}
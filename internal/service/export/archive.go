@@ -0,0 +1,125 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	ct "golang-project/internal/contract"
+	"golang-project/internal/model"
+)
+
+// archive represents the personal data written into the export ZIP
+type archive struct {
+	user       *model.User
+	posts      []*model.Post
+	comments   []*model.Comment
+	following  []*model.User
+	favourites []*model.Post
+	tags       map[string]*model.Tag
+}
+
+// mediaManifest lists the media referenced by the user, media are stored by URL and not uploaded to the server
+type mediaManifest struct {
+	ProfileImage string `json:"profile_image,omitempty"`
+}
+
+// writeArchive writes the personal data as a ZIP archive:
+// profile.json, posts/<slug>.md and posts/<slug>.json, comments.json, following.json, favourites.json
+// and media/manifest.json
+func writeArchive(w io.Writer, a *archive) error {
+	zw := zip.NewWriter(w)
+
+	if err := writeJSON(zw, "profile.json", prepareProfileResponse(a.user, true)); err != nil {
+		return err
+	}
+
+	for _, post := range a.posts {
+		name := post.Slug
+		if name == "" {
+			name = post.ID.Hex()
+		}
+
+		response := preparePostResponse(post, a.tags)
+		if err := writeJSON(zw, "posts/"+name+".json", response); err != nil {
+			return err
+		}
+		if err := writeFile(zw, "posts/"+name+".md", []byte(toMarkdown(response))); err != nil {
+			return err
+		}
+	}
+
+	comments := make([]*ct.CommentResponse, 0, len(a.comments))
+	for _, comment := range a.comments {
+		comments = append(comments, prepareCommentResponse(comment))
+	}
+	if err := writeJSON(zw, "comments.json", comments); err != nil {
+		return err
+	}
+
+	following := make([]*ct.ProfileResponse, 0, len(a.following))
+	for _, user := range a.following {
+		following = append(following, prepareProfileResponse(user, false))
+	}
+	if err := writeJSON(zw, "following.json", following); err != nil {
+		return err
+	}
+
+	favourites := make([]*ct.PostResponse, 0, len(a.favourites))
+	for _, post := range a.favourites {
+		favourites = append(favourites, &ct.PostResponse{ID: post.ID, Title: post.Title, Slug: post.Slug, IsPublished: post.IsPublished})
+	}
+	if err := writeJSON(zw, "favourites.json", favourites); err != nil {
+		return err
+	}
+
+	if err := writeJSON(zw, "media/manifest.json", mediaManifest{ProfileImage: a.user.ProfileImage}); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+// writeJSON writes the value as an indented JSON file of the archive
+func writeJSON(zw *zip.Writer, name string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFile(zw, name, data)
+}
+
+// writeFile writes the content as a file of the archive
+func writeFile(zw *zip.Writer, name string, content []byte) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(content)
+	return err
+}
+
+// toMarkdown renders the post as Markdown with a front matter header
+func toMarkdown(post *ct.PostResponse) string {
+	tags := make([]string, 0, len(post.Tags))
+	for _, tag := range post.Tags {
+		tags = append(tags, strconv.Quote(tag.Name))
+	}
+
+	var builder strings.Builder
+	builder.WriteString("---\n")
+	fmt.Fprintf(&builder, "title: %s\n", strconv.Quote(post.Title))
+	fmt.Fprintf(&builder, "slug: %s\n", strconv.Quote(post.Slug))
+	fmt.Fprintf(&builder, "published: %t\n", post.IsPublished)
+	fmt.Fprintf(&builder, "created_at: %s\n", post.CreatedAt)
+	fmt.Fprintf(&builder, "tags: [%s]\n", strings.Join(tags, ", "))
+	builder.WriteString("---\n\n")
+	fmt.Fprintf(&builder, "# %s\n\n%s\n", post.Title, post.Body)
+
+	return builder.String()
+}
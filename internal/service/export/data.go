@@ -0,0 +1,147 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	ct "golang-project/internal/contract"
+	"golang-project/internal/model"
+	"golang-project/static"
+)
+
+// storageDir returns the directory the export archives are written to
+func storageDir() string {
+	if dir := viper.GetString(static.EnvExportStorageDir); dir != "" {
+		return dir
+	}
+
+	return static.Export.StorageDir
+}
+
+// downloadTTL returns how long the export archive can be downloaded once ready
+func downloadTTL() time.Duration {
+	if ttl := viper.GetDuration(static.EnvExportTTL); ttl > 0 {
+		return ttl
+	}
+
+	return static.Export.TTL
+}
+
+// downloadURL returns the public URL the export archive is downloaded from
+func downloadURL(o *model.Export) string {
+	base := viper.GetString(static.EnvServerBaseURL)
+	if base == "" {
+		base = fmt.Sprintf("http://%s", viper.GetString(static.EnvServerAddress))
+	}
+
+	return fmt.Sprintf("%s/%s/exports/%s/download", strings.TrimSuffix(base, "/"), static.APIVersionV1, o.ID.Hex())
+}
+
+// prepareExportResponse transforms the data and returns the Export Response
+func prepareExportResponse(o *model.Export) *ct.ExportResponse {
+	data := &ct.ExportResponse{
+		ID:     o.ID,
+		Status: o.Status,
+		Size:   o.Size,
+	}
+
+	if o.Status == static.ExportReady {
+		data.DownloadURL = downloadURL(o)
+	}
+
+	if o.CreatedAt != nil {
+		data.CreatedAt = o.CreatedAt.Format(time.RFC3339)
+	}
+
+	if o.CompletedAt != nil {
+		data.CompletedAt = o.CompletedAt.Format(time.RFC3339)
+	}
+
+	if o.ExpiresAt != nil {
+		data.ExpiresAt = o.ExpiresAt.Format(time.RFC3339)
+	}
+
+	return data
+}
+
+// prepareProfileResponse transforms the data and returns the Profile Response,
+// the email is only kept for the owner of the export
+func prepareProfileResponse(o *model.User, withEmail bool) *ct.ProfileResponse {
+	data := &ct.ProfileResponse{
+		ID:             o.ID,
+		FirstName:      o.FirstName,
+		LastName:       o.LastName,
+		Pseudonym:      o.Pseudonym,
+		ProfileImage:   o.ProfileImage,
+		Biography:      o.Biography,
+		FollowerCount:  o.FollowerCount,
+		FollowingCount: o.FollowingCount,
+		PostCount:      o.PostCount,
+	}
+
+	if withEmail {
+		data.Email = o.Email
+	}
+
+	if o.CreatedAt != nil {
+		data.CreatedAt = o.CreatedAt.Format(time.RFC3339)
+	}
+
+	if o.UpdatedAt != nil {
+		data.UpdatedAt = o.UpdatedAt.Format(time.RFC3339)
+	}
+
+	return data
+}
+
+// preparePostResponse transforms the data and returns the Post Response with its tags
+func preparePostResponse(o *model.Post, tags map[string]*model.Tag) *ct.PostResponse {
+	data := &ct.PostResponse{
+		ID:             o.ID,
+		Title:          o.Title,
+		Body:           o.Body,
+		Slug:           o.Slug,
+		IsPublished:    o.IsPublished,
+		FavouriteCount: o.FavouriteCount,
+		CommentCount:   o.CommentCount,
+	}
+
+	for _, id := range o.TagIDs {
+		if tag, ok := tags[id.Hex()]; ok {
+			data.Tags = append(data.Tags, &ct.TagResponse{ID: tag.ID, Name: tag.Name})
+		}
+	}
+
+	if o.CreatedAt != nil {
+		data.CreatedAt = o.CreatedAt.Format(time.RFC3339)
+	}
+
+	if o.UpdatedAt != nil {
+		data.UpdatedAt = o.UpdatedAt.Format(time.RFC3339)
+	}
+
+	return data
+}
+
+// prepareCommentResponse transforms the data and returns the Comment Response referencing its post
+func prepareCommentResponse(o *model.Comment) *ct.CommentResponse {
+	data := &ct.CommentResponse{
+		ID:              o.ID,
+		Content:         o.Content,
+		Post:            &ct.PostResponse{ID: o.PostID},
+		ParentCommentID: o.ParentCommentID,
+	}
+
+	if o.CreatedAt != nil {
+		data.CreatedAt = o.CreatedAt.Format(time.RFC3339)
+	}
+
+	if o.UpdatedAt != nil {
+		data.UpdatedAt = o.UpdatedAt.Format(time.RFC3339)
+	}
+
+	return data
+}
@@ -0,0 +1,331 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	ct "golang-project/internal/contract"
+	"golang-project/internal/model"
+	"golang-project/internal/notification"
+	repo "golang-project/internal/repository"
+	svc "golang-project/internal/service"
+	"golang-project/internal/tracing"
+	"golang-project/static"
+)
+
+// service represents the implementation of service.Export
+type service struct {
+	exportRepo repo.Export
+	notifier   notification.Notifier
+	wake       chan struct{}
+}
+
+// NewService returns a new implementation of service.Export
+func NewService(exportRepo repo.Export, notifier notification.Notifier) svc.Export {
+	return &service{
+		exportRepo: exportRepo,
+		notifier:   notifier,
+		wake:       make(chan struct{}, 1),
+	}
+}
+
+// Request queues a new personal data export of the user,
+// the export already in progress is returned instead of queueing another one
+func (s *service) Request(ctx context.Context, userID primitive.ObjectID) (*ct.ExportResponse, error) {
+	ctx, span := tracing.Start(ctx, "export.Request")
+	defer span.End()
+
+	active, err := s.exportRepo.ReadActiveByUser(ctx, userID)
+	if err == nil {
+		return prepareExportResponse(active), nil
+	}
+	if !errors.Is(err, static.ErrExportNotFound) {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	export, err := s.exportRepo.Insert(ctx, &model.Export{UserID: userID, Status: static.ExportPending})
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	// Wake the worker up, a pending wake up already covers this export
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+
+	return prepareExportResponse(export), nil
+}
+
+// Get returns the export of the user
+func (s *service) Get(ctx context.Context, userID primitive.ObjectID, r *ct.ExportRequest) (*ct.ExportResponse, error) {
+	ctx, span := tracing.Start(ctx, "export.Get")
+	defer span.End()
+
+	export, err := s.readOwnExport(ctx, userID, r.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return prepareExportResponse(export), nil
+}
+
+// Download returns the archive of the ready export of the user
+func (s *service) Download(ctx context.Context, userID primitive.ObjectID, r *ct.ExportRequest) (*ct.ExportFileResponse, error) {
+	ctx, span := tracing.Start(ctx, "export.Download")
+	defer span.End()
+
+	export, err := s.readOwnExport(ctx, userID, r.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch export.Status {
+	case static.ExportReady:
+		if export.ExpiresAt != nil && !time.Now().Before(*export.ExpiresAt) {
+			return nil, static.ErrExportExpired
+		}
+	case static.ExportExpired:
+		return nil, static.ErrExportExpired
+	case static.ExportFailed:
+		return nil, static.ErrExportFailed
+	default:
+		return nil, static.ErrExportNotReady
+	}
+
+	return &ct.ExportFileResponse{
+		Path:     filepath.Join(storageDir(), export.FileName),
+		FileName: "personal-data-" + export.CreatedAt.Format("20060102") + ".zip",
+	}, nil
+}
+
+// Purge removes every export of the user together with its archive
+func (s *service) Purge(ctx context.Context, userID primitive.ObjectID) error {
+	ctx, span := tracing.Start(ctx, "export.Purge")
+	defer span.End()
+
+	exports, err := s.exportRepo.SelectByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, export := range exports {
+		if export.FileName == "" {
+			continue
+		}
+
+		err = os.Remove(filepath.Join(storageDir(), export.FileName))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	return s.exportRepo.DeleteByUser(ctx, userID)
+}
+
+// Work builds the pending exports and expires the outdated downloads until ctx is cancelled
+func (s *service) Work(ctx context.Context) {
+	ticker := time.NewTicker(static.Export.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.processPending(ctx)
+		s.expireDownloads(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+// readOwnExport reads the export and hides the exports of the other users
+func (s *service) readOwnExport(ctx context.Context, userID, exportID primitive.ObjectID) (*model.Export, error) {
+	export, err := s.exportRepo.Read(ctx, exportID)
+	if err != nil {
+		return nil, err
+	}
+
+	if export.UserID != userID {
+		return nil, static.ErrExportNotFound
+	}
+
+	return export, nil
+}
+
+// processPending builds the pending exports one at a time, exports left processing by a stopped worker are retried
+func (s *service) processPending(ctx context.Context) {
+	err := s.exportRepo.RequeueStale(ctx, time.Now().Add(-static.Export.StaleAfter))
+	if err != nil {
+		log.Println("export requeue error:", err)
+	}
+
+	for ctx.Err() == nil {
+		export, err := s.exportRepo.ClaimPending(ctx)
+		if errors.Is(err, static.ErrExportNotFound) {
+			return
+		}
+		if err != nil {
+			log.Println("export claim error:", err)
+			return
+		}
+
+		s.build(ctx, export)
+	}
+}
+
+// build writes the archive of the export, marks it ready and notifies the user
+func (s *service) build(ctx context.Context, export *model.Export) {
+	ctx, span := tracing.Start(ctx, "export.Build")
+	defer span.End()
+
+	user, size, err := s.writeArchive(ctx, export)
+	if err != nil {
+		log.Println("export build error:", export.ID.Hex(), err)
+		span.RecordError(err)
+
+		err = s.exportRepo.Update(ctx, export, map[string]interface{}{"status": static.ExportFailed, "error": err.Error()})
+		if err != nil {
+			log.Println("export update error:", export.ID.Hex(), err)
+		}
+		return
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(downloadTTL())
+	export.Status, export.FileName, export.Size = static.ExportReady, export.ID.Hex()+".zip", size
+	export.CompletedAt, export.ExpiresAt = &now, &expiresAt
+
+	err = s.exportRepo.Update(ctx, export, map[string]interface{}{
+		"status":       export.Status,
+		"file_name":    export.FileName,
+		"size":         export.Size,
+		"completed_at": now,
+		"expires_at":   expiresAt,
+	})
+	if err != nil {
+		log.Println("export update error:", export.ID.Hex(), err)
+		return
+	}
+
+	err = s.notifier.Notify(ctx, &notification.Message{
+		UserID:  user.ID,
+		Email:   user.Email,
+		Subject: "Your personal data export is ready",
+		Body:    "Your archive can be downloaded until " + expiresAt.Format(time.RFC1123) + ".",
+		Link:    downloadURL(export),
+	})
+	if err != nil {
+		log.Println("export notification error:", export.ID.Hex(), err)
+	}
+}
+
+// writeArchive collects the personal data of the export owner and writes it to the storage,
+// it returns the owner and the archive size
+func (s *service) writeArchive(ctx context.Context, export *model.Export) (*model.User, int64, error) {
+	data, err := s.collect(ctx, export.UserID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	dir := storageDir()
+	if err = os.MkdirAll(dir, 0o750); err != nil {
+		return nil, 0, err
+	}
+
+	// Write to a temporary file so a partially written archive is never served
+	path := filepath.Join(dir, export.ID.Hex()+".zip")
+	f, err := os.CreateTemp(dir, export.ID.Hex()+"-*.tmp")
+	if err != nil {
+		return nil, 0, err
+	}
+	defer os.Remove(f.Name())
+
+	if err = writeArchive(f, data); err != nil {
+		f.Close()
+		return nil, 0, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, 0, err
+	}
+
+	if err = f.Close(); err != nil {
+		return nil, 0, err
+	}
+
+	if err = os.Rename(f.Name(), path); err != nil {
+		return nil, 0, err
+	}
+
+	return data.user, info.Size(), nil
+}
+
+// collect reads the personal data of the user
+func (s *service) collect(ctx context.Context, userID primitive.ObjectID) (*archive, error) {
+	var err error
+	data := &archive{tags: make(map[string]*model.Tag)}
+
+	if data.user, err = s.exportRepo.ReadUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	if data.posts, err = s.exportRepo.SelectUserPosts(ctx, userID); err != nil {
+		return nil, err
+	}
+	if data.comments, err = s.exportRepo.SelectUserComments(ctx, userID); err != nil {
+		return nil, err
+	}
+	if data.following, err = s.exportRepo.SelectFollowing(ctx, userID); err != nil {
+		return nil, err
+	}
+	if data.favourites, err = s.exportRepo.SelectFavouritePosts(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	var tagIDs []primitive.ObjectID
+	for _, post := range data.posts {
+		tagIDs = append(tagIDs, post.TagIDs...)
+	}
+
+	tags, err := s.exportRepo.SelectTags(ctx, tagIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range tags {
+		data.tags[tag.ID.Hex()] = tag
+	}
+
+	return data, nil
+}
+
+// expireDownloads removes the archives of the exports whose download expired
+func (s *service) expireDownloads(ctx context.Context) {
+	exports, err := s.exportRepo.SelectExpired(ctx, time.Now())
+	if err != nil {
+		log.Println("export expiry error:", err)
+		return
+	}
+
+	for _, export := range exports {
+		err = os.Remove(filepath.Join(storageDir(), export.FileName))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Println("export remove error:", export.ID.Hex(), err)
+			continue
+		}
+
+		err = s.exportRepo.Update(ctx, export, map[string]interface{}{"status": static.ExportExpired})
+		if err != nil {
+			log.Println("export update error:", export.ID.Hex(), err)
+		}
+	}
+}
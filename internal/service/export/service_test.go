@@ -0,0 +1,200 @@
+package export
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	ct "golang-project/internal/contract"
+	"golang-project/internal/model"
+	"golang-project/internal/notification"
+	repo "golang-project/internal/repository"
+	"golang-project/static"
+)
+
+// exports keeps a single export of the user with the personal data of the user
+type exports struct {
+	repo.Export
+	export *model.Export
+	user   *model.User
+	posts  []*model.Post
+	tags   []*model.Tag
+}
+
+func (r *exports) Read(_ context.Context, id primitive.ObjectID) (*model.Export, error) {
+	if r.export.ID != id {
+		return nil, static.ErrExportNotFound
+	}
+
+	read := *r.export
+	return &read, nil
+}
+
+func (r *exports) Update(_ context.Context, o *model.Export, updates map[string]interface{}) error {
+	for field, value := range updates {
+		switch field {
+		case "status":
+			r.export.Status = value.(static.ExportStatus)
+		case "file_name":
+			r.export.FileName = value.(string)
+		case "size":
+			r.export.Size = value.(int64)
+		case "completed_at":
+			completedAt := value.(time.Time)
+			r.export.CompletedAt = &completedAt
+		case "expires_at":
+			expiresAt := value.(time.Time)
+			r.export.ExpiresAt = &expiresAt
+		default:
+			return errors.New("unexpected update of " + field)
+		}
+	}
+
+	return nil
+}
+
+func (r *exports) SelectExpired(_ context.Context, now time.Time) ([]*model.Export, error) {
+	if r.export.Status != static.ExportReady || now.Before(*r.export.ExpiresAt) {
+		return nil, nil
+	}
+
+	expired := *r.export
+	return []*model.Export{&expired}, nil
+}
+
+func (r *exports) ReadUser(context.Context, primitive.ObjectID) (*model.User, error) {
+	return r.user, nil
+}
+
+func (r *exports) SelectUserPosts(context.Context, primitive.ObjectID) ([]*model.Post, error) {
+	return r.posts, nil
+}
+
+func (r *exports) SelectUserComments(context.Context, primitive.ObjectID) ([]*model.Comment, error) {
+	return nil, nil
+}
+
+func (r *exports) SelectFollowing(context.Context, primitive.ObjectID) ([]*model.User, error) {
+	return nil, nil
+}
+
+func (r *exports) SelectFavouritePosts(context.Context, primitive.ObjectID) ([]*model.Post, error) {
+	return nil, nil
+}
+
+func (r *exports) SelectTags(context.Context, []primitive.ObjectID) ([]*model.Tag, error) {
+	return r.tags, nil
+}
+
+// notifier keeps the notifications
+type notifier struct {
+	messages []*notification.Message
+}
+
+func (n *notifier) Notify(_ context.Context, message *notification.Message) error {
+	n.messages = append(n.messages, message)
+	return nil
+}
+
+func TestBuildWritesTheArchiveUntilItExpires(t *testing.T) {
+	dir := t.TempDir()
+	viper.Set(static.EnvExportStorageDir, dir)
+	t.Cleanup(func() { viper.Set(static.EnvExportStorageDir, nil) })
+
+	ctx := context.Background()
+	now := time.Now()
+	tag := &model.Tag{BaseModel: model.BaseModel{ID: primitive.NewObjectID()}, Name: "go"}
+	user := &model.User{BaseModel: model.BaseModel{ID: primitive.NewObjectID(), CreatedAt: &now}, Email: "jane@example.com", Pseudonym: "jane-3f9a1c"}
+	r := &exports{
+		export: &model.Export{BaseModel: model.BaseModel{ID: primitive.NewObjectID(), CreatedAt: &now}, UserID: user.ID, Status: static.ExportProcessing},
+		user:   user,
+		posts:  []*model.Post{{BaseModel: model.BaseModel{ID: primitive.NewObjectID(), CreatedAt: &now}, Title: "Generics", Slug: "generics", TagIDs: []primitive.ObjectID{tag.ID}}},
+		tags:   []*model.Tag{tag},
+	}
+	n := &notifier{}
+	s := NewService(r, n).(*service)
+
+	export := *r.export
+	s.build(ctx, &export)
+
+	if r.export.Status != static.ExportReady || len(n.messages) != 1 {
+		t.Fatalf("status = %s, notifications = %d, want ready with one notification", r.export.Status, len(n.messages))
+	}
+	if ttl := r.export.ExpiresAt.Sub(*r.export.CompletedAt); ttl != static.Export.TTL {
+		t.Errorf("download TTL = %v, want %v", ttl, static.Export.TTL)
+	}
+
+	// Only the archive is left in the storage, the temporary file is renamed
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != r.export.FileName {
+		t.Fatalf("storage = %v, want only %s", entries, r.export.FileName)
+	}
+
+	download, err := s.Download(ctx, user.ID, &ct.ExportRequest{ID: r.export.ID})
+	if err != nil {
+		t.Fatalf("Download() error = %v", err)
+	}
+	if download.Path != filepath.Join(dir, r.export.FileName) {
+		t.Errorf("path = %s, want %s", download.Path, filepath.Join(dir, r.export.FileName))
+	}
+
+	zr, err := zip.OpenReader(download.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer zr.Close()
+
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	want := []string{"profile.json", "posts/generics.json", "posts/generics.md", "comments.json", "following.json", "favourites.json", "media/manifest.json"}
+	if !slices.Equal(names, want) {
+		t.Errorf("archive files = %v, want %v", names, want)
+	}
+
+	profile, err := zr.Open("profile.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var response ct.ProfileResponse
+	if err = json.NewDecoder(profile).Decode(&response); err != nil {
+		t.Fatal(err)
+	}
+	profile.Close()
+	if response.Email != user.Email {
+		t.Errorf("exported email = %q, want %q", response.Email, user.Email)
+	}
+
+	// Once expired the download is refused and the archive removed
+	past := time.Now().Add(-time.Minute)
+	r.export.ExpiresAt = &past
+	if _, err = s.Download(ctx, user.ID, &ct.ExportRequest{ID: r.export.ID}); !errors.Is(err, static.ErrExportExpired) {
+		t.Errorf("Download() after the expiry: err = %v, want %v", err, static.ErrExportExpired)
+	}
+
+	s.expireDownloads(ctx)
+	if r.export.Status != static.ExportExpired {
+		t.Errorf("status after the expiry = %s, want %s", r.export.Status, static.ExportExpired)
+	}
+	if _, err = os.Stat(download.Path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("archive after the expiry: stat err = %v, want it removed", err)
+	}
+
+	// Another user cannot read the export
+	if _, err = s.Download(ctx, primitive.NewObjectID(), &ct.ExportRequest{ID: r.export.ID}); !errors.Is(err, static.ErrExportNotFound) {
+		t.Errorf("Download() by another user: err = %v, want %v", err, static.ErrExportNotFound)
+	}
+}
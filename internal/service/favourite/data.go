@@ -0,0 +1,106 @@
+package favourite
+
+import (
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	ct "golang-project/internal/contract"
+	"golang-project/internal/model"
+)
+
+// preparePostResponses transforms the posts with their authors and tags and returns the Post Responses
+func preparePostResponses(posts []*model.Post, users []*model.User, tags []*model.Tag) []*ct.PostResponse {
+	usersByID := make(map[primitive.ObjectID]*model.User, len(users))
+	for _, user := range users {
+		usersByID[user.ID] = user
+	}
+
+	tagsByID := make(map[primitive.ObjectID]*model.Tag, len(tags))
+	for _, tag := range tags {
+		tagsByID[tag.ID] = tag
+	}
+
+	responses := make([]*ct.PostResponse, 0, len(posts))
+	for _, post := range posts {
+		data := &ct.PostResponse{
+			ID:             post.ID,
+			Title:          post.Title,
+			Body:           post.Body,
+			Slug:           post.Slug,
+			IsPublished:    post.IsPublished,
+			User:           prepareAuthorResponse(usersByID[post.UserID]),
+			FavouriteCount: post.FavouriteCount,
+			CommentCount:   post.CommentCount,
+			Version:        post.Version,
+		}
+
+		for _, tagID := range post.TagIDs {
+			if tag, ok := tagsByID[tagID]; ok {
+				data.Tags = append(data.Tags, prepareTagResponse(tag))
+			}
+		}
+
+		if post.CreatedAt != nil {
+			data.CreatedAt = post.CreatedAt.Format(time.RFC3339)
+		}
+
+		if post.UpdatedAt != nil {
+			data.UpdatedAt = post.UpdatedAt.Format(time.RFC3339)
+		}
+
+		responses = append(responses, data)
+	}
+
+	return responses
+}
+
+// prepareAuthorResponse transforms the author of a post into the public Profile Response, the email is left out
+func prepareAuthorResponse(o *model.User) *ct.ProfileResponse {
+	if o == nil {
+		return nil
+	}
+
+	return &ct.ProfileResponse{
+		ID:             o.ID,
+		FirstName:      o.FirstName,
+		LastName:       o.LastName,
+		Pseudonym:      o.Pseudonym,
+		ProfileImage:   o.ProfileImage,
+		Biography:      o.Biography,
+		FollowerCount:  o.FollowerCount,
+		FollowingCount: o.FollowingCount,
+		PostCount:      o.PostCount,
+	}
+}
+
+// prepareTagResponse transforms model.Tag to contract.TagResponse
+func prepareTagResponse(o *model.Tag) *ct.TagResponse {
+	data := &ct.TagResponse{
+		ID:   o.ID,
+		Name: o.Name,
+	}
+
+	if o.CreatedAt != nil {
+		data.CreatedAt = o.CreatedAt.Format(time.RFC3339)
+	}
+
+	if o.UpdatedAt != nil {
+		data.UpdatedAt = o.UpdatedAt.Format(time.RFC3339)
+	}
+
+	return data
+}
+
+// uniqueIDs returns the IDs without duplicates in their first order, never nil
+func uniqueIDs(ids []primitive.ObjectID) []primitive.ObjectID {
+	result := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if !slices.Contains(result, id) {
+			result = append(result, id)
+		}
+	}
+
+	return result
+}
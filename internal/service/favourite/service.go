@@ -0,0 +1,215 @@
+package favourite
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/database"
+	ct "golang-project/internal/contract"
+	"golang-project/internal/event"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	svc "golang-project/internal/service"
+	"golang-project/internal/tracing"
+	"golang-project/static"
+)
+
+// service represents the implementation of service.Favourite
+type service struct {
+	favouriteRepo   repo.Favourite
+	userRepo        repo.User
+	postRepo        repo.Post
+	tagRepo         repo.Tag
+	relationshipSvc svc.Relationship
+	unitOfWork      database.UnitOfWork
+	publisher       event.Publisher
+}
+
+// NewService returns a new implementation of service.Favourite
+func NewService(favouriteRepo repo.Favourite, userRepo repo.User, postRepo repo.Post, tagRepo repo.Tag, relationshipSvc svc.Relationship,
+	unitOfWork database.UnitOfWork, publisher event.Publisher) svc.Favourite {
+	return &service{
+		favouriteRepo:   favouriteRepo,
+		userRepo:        userRepo,
+		postRepo:        postRepo,
+		tagRepo:         tagRepo,
+		relationshipSvc: relationshipSvc,
+		unitOfWork:      unitOfWork,
+		publisher:       publisher,
+	}
+}
+
+// UpdateFollowStatus follows or unfollows the blogger, following is refused when the users blocked each other
+func (s *service) UpdateFollowStatus(ctx context.Context, userID primitive.ObjectID, req *ct.BloggerFollowRequest) (*ct.BloggerFollowStatusResponse, error) {
+	ctx, span := tracing.Start(ctx, "favourite.UpdateFollowStatus")
+	defer span.End()
+
+	if req.UserID == userID {
+		return nil, static.ErrSelfFollow
+	}
+
+	blogger, err := s.userRepo.Read(ctx, req.UserID)
+	if errors.Is(err, static.ErrUserNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, static.ErrFollowStatusUpdate.Wrap(err)
+	}
+
+	var isFollowing bool
+	switch req.Action {
+	case static.Follow:
+		if err = s.relationshipSvc.CheckInteraction(ctx, userID, blogger.ID); err != nil {
+			return nil, err
+		}
+
+		err = s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+			inserted, err := s.favouriteRepo.Follow(ctx, &model.FollowUser{UserID: userID, FollowUserID: blogger.ID})
+			if err != nil || !inserted {
+				return err
+			}
+
+			return s.publisher.Publish(ctx,
+				event.Followed{UserID: userID, FollowedUserID: blogger.ID},
+				event.ProfileChanged{UserID: userID},
+				event.ProfileChanged{UserID: blogger.ID},
+			)
+		})
+		isFollowing = true
+	case static.Unfollow:
+		err = s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+			deleted, err := s.favouriteRepo.Unfollow(ctx, userID, blogger.ID)
+			if err != nil || !deleted {
+				return err
+			}
+
+			return s.publisher.Publish(ctx, event.ProfileChanged{UserID: userID}, event.ProfileChanged{UserID: blogger.ID})
+		})
+	default:
+		return nil, static.ErrUnsupportedFollowAction
+	}
+	if err != nil {
+		return nil, static.ErrFollowStatusUpdate.Wrap(err)
+	}
+
+	return &ct.BloggerFollowStatusResponse{UserID: blogger.ID, IsFollowing: isFollowing}, nil
+}
+
+// ListFollowingUsers returns the bloggers followed by the user
+func (s *service) ListFollowingUsers(ctx context.Context, userID primitive.ObjectID) (*ct.ListProfileResponse, error) {
+	ctx, span := tracing.Start(ctx, "favourite.ListFollowingUsers")
+	defer span.End()
+
+	users, err := s.favouriteRepo.SelectFollowing(ctx, userID)
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	data := &ct.ListProfileResponse{Bloggers: make([]*ct.ProfileResponse, 0, len(users))}
+	for _, user := range users {
+		data.Bloggers = append(data.Bloggers, prepareAuthorResponse(user))
+	}
+
+	return data, nil
+}
+
+// ListUserPosts returns the published posts of the bloggers followed by the user, the latest first
+func (s *service) ListUserPosts(ctx context.Context, userID primitive.ObjectID) (*ct.ListPostResponse, error) {
+	ctx, span := tracing.Start(ctx, "favourite.ListUserPosts")
+	defer span.End()
+
+	posts, err := s.favouriteRepo.SelectFollowingUsersPosts(ctx, userID)
+	if err != nil {
+		return nil, static.ErrGetFollowedBloggerPosts.Wrap(err)
+	}
+
+	return s.preparePosts(ctx, posts)
+}
+
+// UpdateFavouriteStatus adds the published post to or removes it from the favourites of the user,
+// adding is refused when the user and the author of the post blocked each other
+func (s *service) UpdateFavouriteStatus(ctx context.Context, userID primitive.ObjectID, req *ct.PostFavouriteRequest) (*ct.PostFavouriteStatusResponse, error) {
+	ctx, span := tracing.Start(ctx, "favourite.UpdateFavouriteStatus")
+	defer span.End()
+
+	post, err := s.postRepo.Read(ctx, req.PostID)
+	if errors.Is(err, static.ErrPostNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, static.ErrFavouriteStatusUpdate.Wrap(err)
+	}
+	if !post.IsPublished || post.HiddenAt != nil {
+		return nil, static.ErrPostNotFound
+	}
+
+	var isFavourite bool
+	switch req.Action {
+	case static.Favourite:
+		if err = s.relationshipSvc.CheckInteraction(ctx, userID, post.UserID); err != nil {
+			return nil, err
+		}
+
+		err = s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+			inserted, err := s.favouriteRepo.Favourite(ctx, &model.FavoritePost{UserID: userID, PostID: post.ID})
+			if err != nil || !inserted {
+				return err
+			}
+
+			return s.publisher.Publish(ctx, event.PostChanged{PostID: post.ID})
+		})
+		isFavourite = true
+	case static.Unfavourite:
+		err = s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+			deleted, err := s.favouriteRepo.Unfavourite(ctx, userID, post.ID)
+			if err != nil || !deleted {
+				return err
+			}
+
+			return s.publisher.Publish(ctx, event.PostChanged{PostID: post.ID})
+		})
+	default:
+		return nil, static.ErrUnsupportedFavouriteAction
+	}
+	if err != nil {
+		return nil, static.ErrFavouriteStatusUpdate.Wrap(err)
+	}
+
+	return &ct.PostFavouriteStatusResponse{PostID: post.ID, IsFavourite: isFavourite}, nil
+}
+
+// ListFavouritePosts returns the published posts favourited by the user, the latest first
+func (s *service) ListFavouritePosts(ctx context.Context, userID primitive.ObjectID) (*ct.ListPostResponse, error) {
+	ctx, span := tracing.Start(ctx, "favourite.ListFavouritePosts")
+	defer span.End()
+
+	posts, err := s.favouriteRepo.SelectFavouritePosts(ctx, userID)
+	if err != nil {
+		return nil, static.ErrGetFavouritePosts.Wrap(err)
+	}
+
+	return s.preparePosts(ctx, posts)
+}
+
+// preparePosts loads the authors and the tags of the posts and returns the List Post Response
+func (s *service) preparePosts(ctx context.Context, posts []*model.Post) (*ct.ListPostResponse, error) {
+	var userIDs, tagIDs []primitive.ObjectID
+	for _, post := range posts {
+		userIDs = append(userIDs, post.UserID)
+		tagIDs = append(tagIDs, post.TagIDs...)
+	}
+
+	users, err := s.userRepo.Select(ctx, uniqueIDs(userIDs))
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	tags, err := s.tagRepo.Select(ctx, uniqueIDs(tagIDs))
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	return &ct.ListPostResponse{Posts: preparePostResponses(posts, users, tags)}, nil
+}
@@ -0,0 +1,56 @@
+package favourite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	ct "golang-project/internal/contract"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	"golang-project/internal/service/servicetest"
+	"golang-project/static"
+)
+
+// favourites names the embedded repository, a field named Favourite would hide the method
+type favourites = repo.Favourite
+
+// favouriteRepo counts the follows and favourites written
+type favouriteRepo struct {
+	favourites
+	writes int
+}
+
+func (r *favouriteRepo) Follow(context.Context, *model.FollowUser) (bool, error) {
+	r.writes++
+	return true, nil
+}
+
+func (r *favouriteRepo) Favourite(context.Context, *model.FavoritePost) (bool, error) {
+	r.writes++
+	return true, nil
+}
+
+func TestBlockedUsersCannotFollowOrFavourite(t *testing.T) {
+	ctx := context.Background()
+	favourites := &favouriteRepo{}
+	s := NewService(favourites, servicetest.Users{}, servicetest.Posts{AuthorID: primitive.NewObjectID()}, nil,
+		servicetest.Relationships{Err: static.ErrUserBlocked}, servicetest.UnitOfWork{}, nil)
+	userID := primitive.NewObjectID()
+
+	_, err := s.UpdateFollowStatus(ctx, userID, &ct.BloggerFollowRequest{Action: static.Follow, UserID: primitive.NewObjectID()})
+	if !errors.Is(err, static.ErrUserBlocked) {
+		t.Errorf("follow: err = %v, want %v", err, static.ErrUserBlocked)
+	}
+
+	_, err = s.UpdateFavouriteStatus(ctx, userID, &ct.PostFavouriteRequest{Action: static.Favourite, PostID: primitive.NewObjectID()})
+	if !errors.Is(err, static.ErrUserBlocked) {
+		t.Errorf("favourite: err = %v, want %v", err, static.ErrUserBlocked)
+	}
+
+	if favourites.writes != 0 {
+		t.Errorf("writes = %d, want 0", favourites.writes)
+	}
+}
@@ -0,0 +1,105 @@
+package feed
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/internal/model"
+	"golang-project/static"
+	"golang-project/util/syndication"
+)
+
+// summaryLength is the maximum number of characters of a post body used as the item summary
+const summaryLength = 280
+
+// baseURL returns the public URL that the feed links are built from
+func baseURL() string {
+	base := viper.GetString(static.EnvServerBaseURL)
+	if base == "" {
+		base = fmt.Sprintf("http://%s", viper.GetString(static.EnvServerAddress))
+	}
+
+	return strings.TrimSuffix(base, "/")
+}
+
+// postURL returns the public slug URL of the post
+func postURL(slug string) string {
+	return fmt.Sprintf("%s/posts/%s", baseURL(), url.PathEscape(slug))
+}
+
+// bloggerURL returns the public URL of the blogger page
+func bloggerURL(pseudonym string) string {
+	return fmt.Sprintf("%s/bloggers/%s", baseURL(), url.PathEscape(pseudonym))
+}
+
+// tagURL returns the public URL of the tag page
+func tagURL(name string) string {
+	return fmt.Sprintf("%s/tags/%s", baseURL(), url.PathEscape(name))
+}
+
+// prepareFeedItems transforms the posts into syndication items
+func prepareFeedItems(posts []*model.Post, users map[primitive.ObjectID]*model.User, tags map[primitive.ObjectID]*model.Tag) []*syndication.Item {
+	items := make([]*syndication.Item, 0, len(posts))
+	for _, post := range posts {
+		link := postURL(post.Slug)
+		item := &syndication.Item{
+			ID:      link,
+			Title:   post.Title,
+			Link:    link,
+			Summary: summarise(post.Body),
+			Content: post.Body,
+		}
+
+		if user, ok := users[post.UserID]; ok {
+			item.Author = user.Pseudonym
+		}
+
+		for _, tagID := range post.TagIDs {
+			if tag, ok := tags[tagID]; ok {
+				item.Categories = append(item.Categories, tag.Name)
+			}
+		}
+
+		if post.CreatedAt != nil {
+			item.Published = *post.CreatedAt
+			item.Updated = *post.CreatedAt
+		}
+
+		if post.UpdatedAt != nil {
+			item.Updated = *post.UpdatedAt
+		}
+
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// lastModified returns the latest modification time among the posts, truncated to seconds
+func lastModified(posts []*model.Post) time.Time {
+	var latest time.Time
+	for _, post := range posts {
+		for _, t := range []*time.Time{post.CreatedAt, post.UpdatedAt} {
+			if t != nil && t.After(latest) {
+				latest = *t
+			}
+		}
+	}
+
+	return latest.UTC().Truncate(time.Second)
+}
+
+// summarise shortens the post body into a plain summary
+func summarise(body string) string {
+	runes := []rune(strings.TrimSpace(body))
+	if len(runes) <= summaryLength {
+		return string(runes)
+	}
+
+	return strings.TrimSpace(string(runes[:summaryLength])) + "…"
+}
@@ -0,0 +1,185 @@
+package feed
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	ct "golang-project/internal/contract"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	svc "golang-project/internal/service"
+	"golang-project/internal/tracing"
+	"golang-project/static"
+	"golang-project/util/syndication"
+)
+
+// service represents the implementation of service.Feed
+type service struct {
+	feedRepo        repo.Feed
+	relationshipSvc svc.Relationship
+}
+
+// NewService returns a new implementation of service.Feed
+func NewService(feedRepo repo.Feed, relationshipSvc svc.Relationship) svc.Feed {
+	return &service{
+		feedRepo:        feedRepo,
+		relationshipSvc: relationshipSvc,
+	}
+}
+
+// Site executes the site-wide feed rendering logic
+func (s *service) Site(ctx context.Context, req *ct.FeedRequest) (*ct.FeedResponse, error) {
+	ctx, span := tracing.Start(ctx, "feed.Site")
+	defer span.End()
+
+	channel := &syndication.Feed{
+		Title:       static.Feed.Title,
+		Description: fmt.Sprintf("Latest posts on %s", static.Feed.Title),
+		Link:        baseURL(),
+	}
+
+	return s.render(ctx, req, channel, nil, nil)
+}
+
+// Blogger executes the feed rendering logic for posts of a single blogger
+func (s *service) Blogger(ctx context.Context, req *ct.FeedRequest) (*ct.FeedResponse, error) {
+	ctx, span := tracing.Start(ctx, "feed.Blogger")
+	defer span.End()
+
+	user, err := s.feedRepo.ReadUserByPseudonym(ctx, req.Pseudonym)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ViewerID != nil {
+		if err = s.relationshipSvc.CheckInteraction(ctx, *req.ViewerID, user.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	channel := &syndication.Feed{
+		Title:       fmt.Sprintf("%s - %s", user.Pseudonym, static.Feed.Title),
+		Description: fmt.Sprintf("Latest posts by %s", user.Pseudonym),
+		Link:        bloggerURL(user.Pseudonym),
+	}
+
+	return s.render(ctx, req, channel, &user.ID, nil)
+}
+
+// Tag executes the feed rendering logic for posts of a single tag
+func (s *service) Tag(ctx context.Context, req *ct.FeedRequest) (*ct.FeedResponse, error) {
+	ctx, span := tracing.Start(ctx, "feed.Tag")
+	defer span.End()
+
+	tag, err := s.feedRepo.ReadTagByName(ctx, req.Tag)
+	if err != nil {
+		return nil, err
+	}
+
+	channel := &syndication.Feed{
+		Title:       fmt.Sprintf("#%s - %s", tag.Name, static.Feed.Title),
+		Description: fmt.Sprintf("Latest posts tagged %s", tag.Name),
+		Link:        tagURL(tag.Name),
+	}
+
+	return s.render(ctx, req, channel, nil, &tag.ID)
+}
+
+// render loads the published posts into the channel and serialises it in the requested format,
+// the posts of the users blocked or muted by the viewer are left out
+func (s *service) render(ctx context.Context, req *ct.FeedRequest, channel *syndication.Feed, userID, tagID *primitive.ObjectID) (*ct.FeedResponse, error) {
+	renderer, contentType, err := selectRenderer(req.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	var hiddenUserIDs []primitive.ObjectID
+	if req.ViewerID != nil {
+		if hiddenUserIDs, err = s.relationshipSvc.HiddenUserIDs(ctx, *req.ViewerID); err != nil {
+			return nil, err
+		}
+	}
+
+	posts, err := s.feedRepo.SelectPublishedPosts(ctx, userID, tagID, hiddenUserIDs, static.Feed.ItemLimit)
+	if err != nil {
+		return nil, static.ErrDatabaseOperation
+	}
+
+	users, tags, err := s.loadRelations(ctx, posts)
+	if err != nil {
+		return nil, static.ErrDatabaseOperation
+	}
+
+	channel.ID = req.SelfLink
+	channel.SelfLink = req.SelfLink
+	channel.Updated = lastModified(posts)
+	channel.Items = prepareFeedItems(posts, users, tags)
+
+	body, err := renderer(channel)
+	if err != nil {
+		return nil, static.ErrRenderFeed
+	}
+
+	checksum := sha256.Sum256(body)
+
+	return &ct.FeedResponse{
+		ContentType:  contentType,
+		Body:         body,
+		ETag:         fmt.Sprintf(`"%s"`, hex.EncodeToString(checksum[:16])),
+		LastModified: channel.Updated,
+	}, nil
+}
+
+// loadRelations returns the authors and tags of the posts indexed by their ID
+func (s *service) loadRelations(ctx context.Context, posts []*model.Post) (map[primitive.ObjectID]*model.User, map[primitive.ObjectID]*model.Tag, error) {
+	userIDs := make([]primitive.ObjectID, 0, len(posts))
+	tagIDs := make([]primitive.ObjectID, 0)
+	for _, post := range posts {
+		userIDs = append(userIDs, post.UserID)
+		tagIDs = append(tagIDs, post.TagIDs...)
+	}
+
+	users := make(map[primitive.ObjectID]*model.User, len(userIDs))
+	tags := make(map[primitive.ObjectID]*model.Tag, len(tagIDs))
+	if len(posts) == 0 {
+		return users, tags, nil
+	}
+
+	userList, err := s.feedRepo.SelectUsers(ctx, userIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, user := range userList {
+		users[user.ID] = user
+	}
+
+	if len(tagIDs) == 0 {
+		return users, tags, nil
+	}
+
+	tagList, err := s.feedRepo.SelectTags(ctx, tagIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, tag := range tagList {
+		tags[tag.ID] = tag
+	}
+
+	return users, tags, nil
+}
+
+// selectRenderer returns the syndication renderer and content type of the feed format
+func selectRenderer(format static.FeedFormat) (syndication.Renderer, string, error) {
+	switch format {
+	case static.FeedRSS:
+		return syndication.RenderRSS, syndication.ContentTypeRSS, nil
+	case static.FeedAtom:
+		return syndication.RenderAtom, syndication.ContentTypeAtom, nil
+	default:
+		return nil, "", static.ErrUnsupportedFeedFormat
+	}
+}
@@ -0,0 +1,113 @@
+package moderation
+
+import (
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	ct "golang-project/internal/contract"
+	"golang-project/internal/model"
+	"golang-project/internal/notification"
+	"golang-project/static"
+)
+
+// previewLength is the number of characters of the reported content shown in the moderation queue
+const previewLength = 200
+
+// target represents the reported post or comment
+type target struct {
+	authorID primitive.ObjectID
+	postID   primitive.ObjectID
+	preview  string
+	hidden   bool
+}
+
+// actionMessage returns the notification sent to the author for the moderation action
+func actionMessage(o *model.ModerationAction) *notification.Message {
+	var subject, body string
+
+	switch o.Action {
+	case static.ModerationHide:
+		subject = fmt.Sprintf("Your %s has been hidden", o.TargetType)
+		body = fmt.Sprintf("Your %s was hidden by a moderator after being reported.", o.TargetType)
+	case static.ModerationWarn:
+		subject = "You have received a moderation warning"
+		body = fmt.Sprintf("A moderator reviewed the reports on your %s, repeated violations may suspend your account.", o.TargetType)
+	case static.ModerationSuspend:
+		subject = "Your account has been suspended"
+		body = fmt.Sprintf("Your account was suspended by a moderator after the reports on your %s.", o.TargetType)
+	default:
+		return nil
+	}
+
+	if o.Note != "" {
+		body += "\n\n" + o.Note
+	}
+
+	return &notification.Message{Subject: subject, Body: body}
+}
+
+// prepareReportResponse transforms the data and returns the Report Response
+func prepareReportResponse(o *model.Report) *ct.ReportResponse {
+	data := &ct.ReportResponse{
+		ID:         o.ID,
+		TargetType: o.TargetType,
+		TargetID:   o.TargetID,
+		Reason:     o.Reason,
+		Status:     o.Status,
+	}
+
+	if o.CreatedAt != nil {
+		data.CreatedAt = o.CreatedAt.Format(time.RFC3339)
+	}
+
+	return data
+}
+
+// prepareQueueItemResponse transforms the data and returns the Report Queue Item Response,
+// the target is nil when the reported content no longer exists
+func prepareQueueItemResponse(o *model.ReportGroup, t *target) *ct.ReportQueueItemResponse {
+	data := &ct.ReportQueueItemResponse{
+		TargetType:      o.TargetType,
+		TargetID:        o.TargetID,
+		AuthorID:        o.AuthorID,
+		ReportCount:     o.Count,
+		Reasons:         make(map[static.ReportReason]int),
+		FirstReportedAt: o.FirstReportedAt.Format(time.RFC3339),
+		LastReportedAt:  o.LastReportedAt.Format(time.RFC3339),
+	}
+
+	for _, reason := range o.Reasons {
+		data.Reasons[reason]++
+	}
+
+	if t != nil {
+		data.IsHidden = t.hidden
+		data.Preview = t.preview
+		if runes := []rune(t.preview); len(runes) > previewLength {
+			data.Preview = string(runes[:previewLength]) + "…"
+		}
+	}
+
+	return data
+}
+
+// prepareActionResponse transforms the data and returns the Moderation Action Response
+func prepareActionResponse(o *model.ModerationAction) *ct.ModerationActionResponse {
+	data := &ct.ModerationActionResponse{
+		ID:              o.ID,
+		TargetType:      o.TargetType,
+		TargetID:        o.TargetID,
+		AuthorID:        o.AuthorID,
+		Action:          o.Action,
+		Note:            o.Note,
+		ReportsResolved: int64(o.ReportCount),
+	}
+
+	if o.CreatedAt != nil {
+		data.CreatedAt = o.CreatedAt.Format(time.RFC3339)
+	}
+
+	return data
+}
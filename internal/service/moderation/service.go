@@ -0,0 +1,280 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/database"
+	"golang-project/internal/audit"
+	ct "golang-project/internal/contract"
+	"golang-project/internal/event"
+	"golang-project/internal/model"
+	"golang-project/internal/notification"
+	repo "golang-project/internal/repository"
+	svc "golang-project/internal/service"
+	"golang-project/internal/tracing"
+	"golang-project/static"
+	"golang-project/util/pagination"
+)
+
+// service represents the implementation of service.Moderation
+type service struct {
+	userRepo       repo.User
+	moderationRepo repo.Moderation
+	notifier       notification.Notifier
+	auditor        audit.Recorder
+	unitOfWork     database.UnitOfWork
+	publisher      event.Publisher
+}
+
+// NewService returns a new implementation of service.Moderation
+func NewService(userRepo repo.User, moderationRepo repo.Moderation, notifier notification.Notifier, auditor audit.Recorder,
+	unitOfWork database.UnitOfWork, publisher event.Publisher) svc.Moderation {
+	return &service{
+		userRepo:       userRepo,
+		moderationRepo: moderationRepo,
+		notifier:       notifier,
+		auditor:        auditor,
+		unitOfWork:     unitOfWork,
+		publisher:      publisher,
+	}
+}
+
+// Report files the report of the user on a post or a comment,
+// a user has at most one open report per content and cannot report their own content
+func (s *service) Report(ctx context.Context, reporterID primitive.ObjectID, r *ct.CreateReportRequest) (*ct.ReportResponse, error) {
+	ctx, span := tracing.Start(ctx, "moderation.Report")
+	defer span.End()
+
+	target, err := s.readTarget(ctx, r.TargetType, r.TargetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if target.authorID == reporterID {
+		return nil, static.ErrSelfReport
+	}
+
+	reported, err := s.moderationRepo.HasOpenReport(ctx, reporterID, r.TargetType, r.TargetID)
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+	if reported {
+		return nil, static.ErrAlreadyReported
+	}
+
+	report, err := s.moderationRepo.InsertReport(ctx, &model.Report{
+		ReporterID: reporterID,
+		TargetType: r.TargetType,
+		TargetID:   r.TargetID,
+		AuthorID:   target.authorID,
+		Reason:     r.Reason,
+		Details:    r.Details,
+		Status:     static.ReportOpen,
+	})
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	return prepareReportResponse(report), nil
+}
+
+// ListQueue returns the open reports aggregated per content, the most reported content first
+func (s *service) ListQueue(ctx context.Context, moderatorID primitive.ObjectID, r *ct.ListReportQueueRequest) (*ct.ReportQueueResponse, error) {
+	ctx, span := tracing.Start(ctx, "moderation.ListQueue")
+	defer span.End()
+
+	if err := s.requireModerator(ctx, moderatorID); err != nil {
+		return nil, err
+	}
+
+	if r.Page == 0 {
+		r.Page = static.Pagination.DefaultPage
+	}
+	if r.PageSize == 0 {
+		r.PageSize = static.Pagination.DefaultPageSize
+	}
+
+	groups, total, err := s.moderationRepo.SelectReportGroups(ctx, r.TargetType, pagination.CalculateOffset(r.Page, r.PageSize), r.PageSize)
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	items := make([]*ct.ReportQueueItemResponse, 0, len(groups))
+	for _, group := range groups {
+		// The content may have been deleted since it was reported, it stays in the queue to be dismissed
+		target, err := s.readTarget(ctx, group.TargetType, group.TargetID)
+		if err != nil && !errors.Is(err, static.ErrReportTargetNotFound) {
+			return nil, static.ErrDatabaseOperation.Wrap(err)
+		}
+
+		items = append(items, prepareQueueItemResponse(group, target))
+	}
+
+	return &ct.ReportQueueResponse{
+		Items: items,
+		Paging: ct.Paging{
+			Page:     r.Page,
+			PageSize: r.PageSize,
+			Total:    int(total),
+		},
+	}, nil
+}
+
+// TakeAction applies the moderator decision on the reported content, records it and resolves the open
+// reports of the content in one transaction. The content of the moderator, of other moderators and of admins
+// cannot be moderated.
+func (s *service) TakeAction(ctx context.Context, moderatorID primitive.ObjectID, r *ct.ModerationActionRequest) (*ct.ModerationActionResponse, error) {
+	ctx, span := tracing.Start(ctx, "moderation.TakeAction")
+	defer span.End()
+
+	if err := s.requireModerator(ctx, moderatorID); err != nil {
+		return nil, err
+	}
+
+	target, err := s.readTarget(ctx, r.TargetType, r.TargetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.checkAuthor(ctx, moderatorID, target.authorID); err != nil {
+		return nil, err
+	}
+
+	action := &model.ModerationAction{
+		ModeratorID: moderatorID,
+		TargetType:  r.TargetType,
+		TargetID:    r.TargetID,
+		AuthorID:    target.authorID,
+		Action:      r.Action,
+		Note:        r.Note,
+	}
+	action.ID = primitive.NewObjectID()
+
+	err = s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		// Reports are resolved first so a dismissal without open reports records nothing
+		resolved, err := s.moderationRepo.ResolveReports(ctx, r.TargetType, r.TargetID, action.ID)
+		if err != nil {
+			return err
+		}
+		if resolved == 0 && r.Action == static.ModerationDismiss {
+			return static.ErrNoOpenReports
+		}
+		action.ReportCount = int(resolved)
+
+		switch r.Action {
+		case static.ModerationHide:
+			if err = s.moderationRepo.HideContent(ctx, r.TargetType, r.TargetID); err != nil {
+				return err
+			}
+
+			// The post, or the post of the hidden comment, changes for the readers
+			if err = s.publisher.Publish(ctx, event.PostChanged{PostID: target.postID}); err != nil {
+				return err
+			}
+		case static.ModerationSuspend:
+			if err = s.moderationRepo.SuspendUser(ctx, target.authorID); err != nil {
+				return err
+			}
+
+			if err = s.publisher.Publish(ctx, event.ProfileChanged{UserID: target.authorID}); err != nil {
+				return err
+			}
+		}
+
+		action, err = s.moderationRepo.InsertAction(ctx, action)
+		return err
+	})
+	if errors.Is(err, static.ErrNoOpenReports) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	s.auditor.Record(ctx, &audit.Entry{
+		ActorID:    moderatorID,
+		Action:     static.AuditModerationAction,
+		TargetType: static.AuditTargetType(r.TargetType),
+		TargetID:   r.TargetID,
+		Metadata:   map[string]string{"action": string(r.Action), "author_id": target.authorID.Hex()},
+	})
+
+	s.notifyAuthor(ctx, action)
+
+	return prepareActionResponse(action), nil
+}
+
+// requireModerator checks that the user has the moderator or the admin role
+func (s *service) requireModerator(ctx context.Context, userID primitive.ObjectID) error {
+	user, err := s.userRepo.Read(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.Role != static.RoleModerator && user.Role != static.RoleAdmin {
+		return static.ErrModeratorRequired
+	}
+
+	return nil
+}
+
+// checkAuthor refuses the moderation of the content of the moderator, of other moderators and of admins
+func (s *service) checkAuthor(ctx context.Context, moderatorID, authorID primitive.ObjectID) error {
+	if authorID == moderatorID {
+		return static.ErrSelfModeration
+	}
+
+	author, err := s.userRepo.Read(ctx, authorID)
+	if err != nil {
+		return err
+	}
+
+	if author.Role == static.RoleModerator || author.Role == static.RoleAdmin {
+		return static.ErrPrivilegedTarget
+	}
+
+	return nil
+}
+
+// readTarget reads the reported post or comment
+func (s *service) readTarget(ctx context.Context, targetType static.ReportTargetType, targetID primitive.ObjectID) (*target, error) {
+	if targetType == static.ReportTargetComment {
+		comment, err := s.moderationRepo.ReadComment(ctx, targetID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &target{authorID: comment.UserID, postID: comment.PostID, preview: comment.Content, hidden: comment.HiddenAt != nil}, nil
+	}
+
+	post, err := s.moderationRepo.ReadPost(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &target{authorID: post.UserID, postID: post.ID, preview: post.Title, hidden: post.HiddenAt != nil}, nil
+}
+
+// notifyAuthor tells the author about the action taken on their content, dismissals are not notified
+// and failures are logged as they must not fail the request
+func (s *service) notifyAuthor(ctx context.Context, action *model.ModerationAction) {
+	message := actionMessage(action)
+	if message == nil {
+		return
+	}
+
+	author, err := s.userRepo.Read(ctx, action.AuthorID)
+	if err != nil {
+		log.Println("moderation notification error:", action.ID.Hex(), err)
+		return
+	}
+
+	message.UserID, message.Email = author.ID, author.Email
+	if err = s.notifier.Notify(ctx, message); err != nil {
+		log.Println("moderation notification error:", action.ID.Hex(), err)
+	}
+}
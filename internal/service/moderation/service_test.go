@@ -0,0 +1,135 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/database"
+	"golang-project/database/databasetest"
+	ct "golang-project/internal/contract"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	moderationRepo "golang-project/internal/repository/moderation"
+	postRepo "golang-project/internal/repository/post"
+	userRepo "golang-project/internal/repository/user"
+	"golang-project/internal/service/servicetest"
+	"golang-project/static"
+)
+
+// moderations reads the posts of the author and records the writes of the actions
+type moderations struct {
+	repo.Moderation
+	authorID primitive.ObjectID
+	writes   []string
+}
+
+func (r *moderations) ReadPost(_ context.Context, id primitive.ObjectID) (*model.Post, error) {
+	return &model.Post{BaseModel: model.BaseModel{ID: id}, UserID: r.authorID}, nil
+}
+
+func (r *moderations) ResolveReports(context.Context, static.ReportTargetType, primitive.ObjectID, primitive.ObjectID) (int64, error) {
+	r.writes = append(r.writes, "resolve")
+	return 1, nil
+}
+
+func (r *moderations) SuspendUser(context.Context, primitive.ObjectID) error {
+	r.writes = append(r.writes, "suspend")
+	return nil
+}
+
+func TestTakeActionRefusesPrivilegedTargets(t *testing.T) {
+	moderatorID, otherModeratorID, adminID := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	users := servicetest.Users{Users: map[primitive.ObjectID]*model.User{
+		moderatorID:      {Role: static.RoleModerator},
+		otherModeratorID: {Role: static.RoleModerator},
+		adminID:          {Role: static.RoleAdmin},
+	}}
+
+	for authorID, want := range map[primitive.ObjectID]error{
+		moderatorID:      static.ErrSelfModeration,
+		otherModeratorID: static.ErrPrivilegedTarget,
+		adminID:          static.ErrPrivilegedTarget,
+	} {
+		r := &moderations{authorID: authorID}
+		s := NewService(users, r, nil, nil, servicetest.UnitOfWork{}, &servicetest.Publisher{})
+
+		_, err := s.TakeAction(context.Background(), moderatorID, &ct.ModerationActionRequest{
+			TargetType: static.ReportTargetPost,
+			TargetID:   primitive.NewObjectID(),
+			Action:     static.ModerationSuspend,
+		})
+		if !errors.Is(err, want) {
+			t.Errorf("err = %v, want %v", err, want)
+		}
+		if len(r.writes) != 0 {
+			t.Errorf("writes = %v, want none", r.writes)
+		}
+	}
+}
+
+// unrecordedActions fails to record the actions after the other writes of the action
+type unrecordedActions struct {
+	repo.Moderation
+}
+
+func (unrecordedActions) InsertAction(context.Context, *model.ModerationAction) (*model.ModerationAction, error) {
+	return nil, errors.New("insert failed")
+}
+
+func TestTakeActionRollsBackWhenTheActionIsNotRecorded(t *testing.T) {
+	db := databasetest.Connect(t)
+	ctx := context.Background()
+	users := userRepo.NewRepository(db)
+	moderationRepository := moderationRepo.NewRepository(db)
+
+	moderator, err := users.Insert(ctx, &model.User{Pseudonym: "moderator", Email: "moderator@example.com", Role: static.RoleModerator})
+	if err != nil {
+		t.Fatal(err)
+	}
+	author, err := users.Insert(ctx, &model.User{Pseudonym: "author", Email: "author@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	post, err := postRepo.NewRepository(db).Insert(ctx, &model.Post{Title: "Generics", IsPublished: true, UserID: author.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reporterID := primitive.NewObjectID()
+	_, err = moderationRepository.InsertReport(ctx, &model.Report{
+		ReporterID: reporterID, TargetType: static.ReportTargetPost, TargetID: post.ID, AuthorID: author.ID,
+		Reason: static.ReportSpam, Status: static.ReportOpen,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewService(users, unrecordedActions{moderationRepository}, nil, nil, database.NewUnitOfWork(db), &servicetest.Publisher{})
+	_, err = s.TakeAction(ctx, moderator.ID, &ct.ModerationActionRequest{
+		TargetType: static.ReportTargetPost,
+		TargetID:   post.ID,
+		Action:     static.ModerationSuspend,
+	})
+	if err == nil {
+		t.Fatal("err = nil, want the insert error")
+	}
+
+	// The reports stay open and the author stays active when the action cannot be recorded
+	open, err := moderationRepository.HasOpenReport(ctx, reporterID, static.ReportTargetPost, post.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !open {
+		t.Error("the report was resolved")
+	}
+
+	read, err := users.Read(ctx, author.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.DisabledAt != nil {
+		t.Error("the author was suspended")
+	}
+}
@@ -0,0 +1,118 @@
+package post
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	ct "golang-project/internal/contract"
+	"golang-project/internal/model"
+)
+
+// preparePostResponses transforms the posts with their authors and tags and returns the Post Responses
+func preparePostResponses(posts []*model.Post, users []*model.User, tags []*model.Tag) []*ct.PostResponse {
+	usersByID := make(map[primitive.ObjectID]*model.User, len(users))
+	for _, user := range users {
+		usersByID[user.ID] = user
+	}
+
+	tagsByID := make(map[primitive.ObjectID]*model.Tag, len(tags))
+	for _, tag := range tags {
+		tagsByID[tag.ID] = tag
+	}
+
+	responses := make([]*ct.PostResponse, 0, len(posts))
+	for _, post := range posts {
+		data := &ct.PostResponse{
+			ID:             post.ID,
+			Title:          post.Title,
+			Body:           post.Body,
+			Slug:           post.Slug,
+			IsPublished:    post.IsPublished,
+			User:           prepareAuthorResponse(usersByID[post.UserID]),
+			FavouriteCount: post.FavouriteCount,
+			CommentCount:   post.CommentCount,
+			Version:        post.Version,
+		}
+
+		for _, tagID := range post.TagIDs {
+			if tag, ok := tagsByID[tagID]; ok {
+				data.Tags = append(data.Tags, prepareTagResponse(tag))
+			}
+		}
+
+		if post.CreatedAt != nil {
+			data.CreatedAt = post.CreatedAt.Format(time.RFC3339)
+		}
+
+		if post.UpdatedAt != nil {
+			data.UpdatedAt = post.UpdatedAt.Format(time.RFC3339)
+		}
+
+		responses = append(responses, data)
+	}
+
+	return responses
+}
+
+// prepareAuthorResponse transforms the author of a post into the public Profile Response, the email is left out
+func prepareAuthorResponse(o *model.User) *ct.ProfileResponse {
+	if o == nil {
+		return nil
+	}
+
+	return &ct.ProfileResponse{
+		ID:             o.ID,
+		FirstName:      o.FirstName,
+		LastName:       o.LastName,
+		Pseudonym:      o.Pseudonym,
+		ProfileImage:   o.ProfileImage,
+		Biography:      o.Biography,
+		FollowerCount:  o.FollowerCount,
+		FollowingCount: o.FollowingCount,
+		PostCount:      o.PostCount,
+	}
+}
+
+// prepareTagResponse transforms model.Tag to contract.TagResponse
+func prepareTagResponse(o *model.Tag) *ct.TagResponse {
+	data := &ct.TagResponse{
+		ID:   o.ID,
+		Name: o.Name,
+	}
+
+	if o.CreatedAt != nil {
+		data.CreatedAt = o.CreatedAt.Format(time.RFC3339)
+	}
+
+	if o.UpdatedAt != nil {
+		data.UpdatedAt = o.UpdatedAt.Format(time.RFC3339)
+	}
+
+	return data
+}
+
+// prepareUpdatePost updates fields of a Post model and prepares the update map, empty title and body are kept
+// and the tags are only replaced when the request sends them
+func prepareUpdatePost(o *model.Post, req *ct.UpdatePostRequest) map[string]interface{} {
+	if req.Title != "" {
+		o.Title = req.Title
+	}
+	if req.Body != "" {
+		o.Body = req.Body
+	}
+	o.IsPublished = req.IsPublished
+
+	updates := map[string]interface{}{
+		"title":        o.Title,
+		"body":         o.Body,
+		"is_published": o.IsPublished,
+	}
+
+	if req.Tags != nil {
+		o.TagIDs = uniqueIDs(req.Tags)
+		updates["tag_ids"] = o.TagIDs
+	}
+
+	return updates
+}
@@ -0,0 +1,331 @@
+package post
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/gosimple/slug"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/database"
+	ct "golang-project/internal/contract"
+	"golang-project/internal/event"
+	"golang-project/internal/metrics"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	svc "golang-project/internal/service"
+	"golang-project/internal/tracing"
+	"golang-project/static"
+)
+
+// service represents the implementation of service.Post
+type service struct {
+	postRepo    repo.Post
+	tagRepo     repo.Tag
+	reactionSvc svc.Reaction
+	unitOfWork  database.UnitOfWork
+	publisher   event.Publisher
+}
+
+// NewService returns a new implementation of service.Post
+func NewService(postRepo repo.Post, tagRepo repo.Tag, reactionSvc svc.Reaction, unitOfWork database.UnitOfWork, publisher event.Publisher) svc.Post {
+	return &service{
+		postRepo:    postRepo,
+		tagRepo:     tagRepo,
+		reactionSvc: reactionSvc,
+		unitOfWork:  unitOfWork,
+		publisher:   publisher,
+	}
+}
+
+// GetByID returns the published post with its author, tags and reactions
+func (s *service) GetByID(ctx context.Context, viewerID *primitive.ObjectID, id primitive.ObjectID) (*ct.PostResponse, error) {
+	ctx, span := tracing.Start(ctx, "post.GetByID")
+	defer span.End()
+
+	post, err := s.postRepo.Read(ctx, id)
+	if errors.Is(err, static.ErrPostNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, static.ErrFetchPostDetail.Wrap(err)
+	}
+
+	// Drafts are only visible to their author through the profile and hidden posts to nobody
+	if !post.IsPublished || post.HiddenAt != nil {
+		return nil, static.ErrPostNotFound
+	}
+
+	responses, err := s.prepareResponses(ctx, []*model.Post{post})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.reactionSvc.AttachToPosts(ctx, viewerID, responses); err != nil {
+		return nil, err
+	}
+
+	return responses[0], nil
+}
+
+// List returns the published posts matching the filters with their reactions, the latest first
+func (s *service) List(ctx context.Context, viewerID *primitive.ObjectID, req *ct.ListPostRequest) (*ct.ListPostResponse, error) {
+	ctx, span := tracing.Start(ctx, "post.List")
+	defer span.End()
+
+	if req.Page == 0 {
+		req.Page = static.Pagination.DefaultPage
+	}
+	if req.PageSize == 0 {
+		req.PageSize = static.Pagination.DefaultPageSize
+	}
+
+	posts, err := s.postRepo.Select(ctx, req)
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	responses, err := s.prepareResponses(ctx, posts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.reactionSvc.AttachToPosts(ctx, viewerID, responses); err != nil {
+		return nil, err
+	}
+
+	return &ct.ListPostResponse{Posts: responses}, nil
+}
+
+// Create creates the post of the user with a unique slug, the post and its tag links are written together
+// with the publication event of a published post
+func (s *service) Create(ctx context.Context, req *ct.CreatePostRequest, userID primitive.ObjectID) (*ct.PostResponse, error) {
+	ctx, span := tracing.Start(ctx, "post.Create")
+	defer span.End()
+
+	postSlug, err := s.uniqueSlug(ctx, req.Title)
+	if err != nil {
+		return nil, static.ErrInsertPost.Wrap(err)
+	}
+
+	post := &model.Post{
+		Title:       req.Title,
+		Body:        req.Body,
+		Slug:        postSlug,
+		IsPublished: req.IsPublished,
+		UserID:      userID,
+		TagIDs:      uniqueIDs(req.Tags),
+	}
+
+	err = s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.postRepo.Insert(ctx, post); err != nil {
+			return err
+		}
+		if err := s.postRepo.AddPostTags(ctx, post.ID, post.TagIDs); err != nil {
+			return err
+		}
+
+		return s.publishStateChange(ctx, false, post)
+	})
+	if errors.Is(err, static.ErrTagNotFoundOrDeleted) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, static.ErrInsertPost.Wrap(err)
+	}
+
+	metrics.PostsCreatedTotal.Inc()
+
+	responses, err := s.prepareResponses(ctx, []*model.Post{post})
+	if err != nil {
+		return nil, err
+	}
+
+	return responses[0], nil
+}
+
+// Update updates the post of the user, new tags replace the tag links in the same transaction
+// and publishing a draft raises the publication event
+func (s *service) Update(ctx context.Context, userID primitive.ObjectID, req *ct.UpdatePostRequest) (*ct.PostResponse, error) {
+	ctx, span := tracing.Start(ctx, "post.Update")
+	defer span.End()
+
+	post, err := s.readOwn(ctx, req.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.IfMatch != nil && *req.IfMatch != post.Version {
+		return nil, static.ErrVersionConflict
+	}
+
+	wasPublished := post.IsPublished
+	updates := prepareUpdatePost(post, req)
+
+	err = s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := s.postRepo.UpdatePost(ctx, post, updates); err != nil {
+			return err
+		}
+		if req.Tags != nil {
+			if err := s.postRepo.UpdatePostTag(ctx, post, post.TagIDs); err != nil {
+				return err
+			}
+		}
+
+		return s.publishStateChange(ctx, wasPublished, post)
+	})
+	if errors.Is(err, static.ErrVersionConflict) {
+		return nil, versionConflict(req.IfMatch)
+	}
+	if errors.Is(err, static.ErrTagNotFoundOrDeleted) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	return s.getOwn(ctx, req.ID, userID)
+}
+
+// Delete deletes the post of the user, deleting a published post changes the post counter of the author
+func (s *service) Delete(ctx context.Context, id, userID primitive.ObjectID) error {
+	ctx, span := tracing.Start(ctx, "post.Delete")
+	defer span.End()
+
+	post, err := s.readOwn(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	err = s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := s.postRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		if !post.IsPublished {
+			return nil
+		}
+
+		return s.publisher.Publish(ctx, event.ProfileChanged{UserID: post.UserID})
+	})
+	if errors.Is(err, static.ErrPostNotFound) {
+		return err
+	}
+	if err != nil {
+		return static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	return nil
+}
+
+// publishStateChange publishes the change of the post counter of the author when the post is published or unpublished,
+// with the publication event when it turns published. It must run in the UnitOfWork of the change
+func (s *service) publishStateChange(ctx context.Context, wasPublished bool, post *model.Post) error {
+	if wasPublished == post.IsPublished {
+		return nil
+	}
+
+	events := []event.Event{event.ProfileChanged{UserID: post.UserID}}
+	if post.IsPublished {
+		events = append(events, event.PostPublished{PostID: post.ID, UserID: post.UserID, Title: post.Title})
+	}
+
+	return s.publisher.Publish(ctx, events...)
+}
+
+// versionConflict returns the error of an update that lost to a concurrent one, the precondition
+// failed when the client sent the version and the update conflicted otherwise
+func versionConflict(ifMatch *int64) error {
+	if ifMatch != nil {
+		return static.ErrVersionConflict
+	}
+
+	return static.ErrConcurrentUpdate
+}
+
+// getOwn returns the post of the user whether it is published or not
+func (s *service) getOwn(ctx context.Context, id, userID primitive.ObjectID) (*ct.PostResponse, error) {
+	post, err := s.readOwn(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses, err := s.prepareResponses(ctx, []*model.Post{post})
+	if err != nil {
+		return nil, err
+	}
+
+	return responses[0], nil
+}
+
+// readOwn reads the post and checks the user is its author
+func (s *service) readOwn(ctx context.Context, id, userID primitive.ObjectID) (*model.Post, error) {
+	post, err := s.postRepo.Read(ctx, id)
+	if errors.Is(err, static.ErrPostNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, static.ErrFetchPostDetail.Wrap(err)
+	}
+
+	if post.UserID != userID {
+		return nil, static.ErrPostOwner
+	}
+
+	return post, nil
+}
+
+// uniqueSlug returns the slug of the title, numbered when the slug is already taken
+func (s *service) uniqueSlug(ctx context.Context, title string) (string, error) {
+	base := slug.Make(title)
+	if base == "" {
+		base = "post"
+	}
+
+	taken, err := s.postRepo.FindSlugsLike(ctx, base)
+	if err != nil {
+		return "", err
+	}
+
+	candidate := base
+	for n := 2; slices.Contains(taken, candidate); n++ {
+		candidate = fmt.Sprintf("%s-%d", base, n)
+	}
+
+	return candidate, nil
+}
+
+// prepareResponses loads the authors and the tags of the posts and returns their Post Responses
+func (s *service) prepareResponses(ctx context.Context, posts []*model.Post) ([]*ct.PostResponse, error) {
+	var userIDs, tagIDs []primitive.ObjectID
+	for _, post := range posts {
+		userIDs = append(userIDs, post.UserID)
+		tagIDs = append(tagIDs, post.TagIDs...)
+	}
+
+	users, err := s.tagRepo.SelectUser(ctx, uniqueIDs(userIDs))
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	tags, err := s.tagRepo.Select(ctx, uniqueIDs(tagIDs))
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	return preparePostResponses(posts, users, tags), nil
+}
+
+// uniqueIDs returns the IDs without duplicates in their first order, never nil
+func uniqueIDs(ids []primitive.ObjectID) []primitive.ObjectID {
+	result := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if !slices.Contains(result, id) {
+			result = append(result, id)
+		}
+	}
+
+	return result
+}
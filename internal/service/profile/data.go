@@ -10,13 +10,17 @@ import (
 // prepareSignInResponse transforms the data and returns the Profile Response
 func prepareProfileResponse(o *model.User) *ct.ProfileResponse {
 	data := &ct.ProfileResponse{
-		ID:           o.ID,
-		FirstName:    o.FirstName,
-		LastName:     o.LastName,
-		Email:        o.Email,
-		Pseudonym:    o.Pseudonym,
-		ProfileImage: o.ProfileImage,
-		Biography:    o.Biography,
+		ID:             o.ID,
+		FirstName:      o.FirstName,
+		LastName:       o.LastName,
+		Email:          o.Email,
+		Pseudonym:      o.Pseudonym,
+		ProfileImage:   o.ProfileImage,
+		Biography:      o.Biography,
+		FollowerCount:  o.FollowerCount,
+		FollowingCount: o.FollowingCount,
+		PostCount:      o.PostCount,
+		Version:        o.Version,
 	}
 
 	if o.CreatedAt != nil {
@@ -83,11 +87,14 @@ func preparePostResponse(post *model.Post) *ct.PostResponse {
 	}
 
 	data := &ct.PostResponse{
-		ID:          post.ID,
-		Title:       post.Title,
-		Body:        post.Body,
-		Slug:        post.Slug,
-		IsPublished: post.IsPublished,
+		ID:             post.ID,
+		Title:          post.Title,
+		Body:           post.Body,
+		Slug:           post.Slug,
+		IsPublished:    post.IsPublished,
+		FavouriteCount: post.FavouriteCount,
+		CommentCount:   post.CommentCount,
+		Version:        post.Version,
 	}
 
 	if post.CreatedAt != nil {
@@ -1,36 +1,49 @@
 package profile
 
 import (
+	"context"
+	"errors"
 	"strconv"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
+	"golang-project/internal/audit"
 	ct "golang-project/internal/contract"
 	repo "golang-project/internal/repository"
 	svc "golang-project/internal/service"
+	"golang-project/internal/tracing"
 	"golang-project/static"
 	"golang-project/util/hashing"
 )
 
 // service represents the implementation of service.Profile
 type service struct {
-	userRepo repo.User
-	postRepo repo.Post
-	tagRepo  repo.Tag
+	userRepo        repo.User
+	postRepo        repo.Post
+	tagRepo         repo.Tag
+	reactionSvc     svc.Reaction
+	relationshipSvc svc.Relationship
+	auditor         audit.Recorder
 }
 
 // NewService returns a new implementation of service.Profile
-func NewService(userRepo repo.User, postRepo repo.Post, tagRepo repo.Tag) svc.Profile {
+func NewService(userRepo repo.User, postRepo repo.Post, tagRepo repo.Tag, reactionSvc svc.Reaction, relationshipSvc svc.Relationship, auditor audit.Recorder) svc.Profile {
 	return &service{
-		userRepo: userRepo,
-		postRepo: postRepo,
-		tagRepo:  tagRepo,
+		userRepo:        userRepo,
+		postRepo:        postRepo,
+		tagRepo:         tagRepo,
+		reactionSvc:     reactionSvc,
+		relationshipSvc: relationshipSvc,
+		auditor:         auditor,
 	}
 }
 
 // GetByID executes the profile detail retrieval logic
-func (s *service) GetByID(id primitive.ObjectID) (*ct.ProfileResponse, error) {
-	user, err := s.userRepo.Read(id)
+func (s *service) GetByID(ctx context.Context, id primitive.ObjectID) (*ct.ProfileResponse, error) {
+	ctx, span := tracing.Start(ctx, "profile.GetByID")
+	defer span.End()
+
+	user, err := s.userRepo.Read(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -38,18 +51,54 @@ func (s *service) GetByID(id primitive.ObjectID) (*ct.ProfileResponse, error) {
 	return prepareProfileResponse(user), nil
 }
 
+// View returns the public profile of the user, the email and the version are left out.
+// The viewer is nil for anonymous requests, the users who blocked each other cannot view each other
+func (s *service) View(ctx context.Context, viewerID *primitive.ObjectID, id primitive.ObjectID) (*ct.ProfileResponse, error) {
+	ctx, span := tracing.Start(ctx, "profile.View")
+	defer span.End()
+
+	if viewerID != nil && *viewerID != id {
+		if err := s.relationshipSvc.CheckInteraction(ctx, *viewerID, id); err != nil {
+			return nil, err
+		}
+	}
+
+	user, err := s.userRepo.Read(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.DisabledAt != nil {
+		return nil, static.ErrUserNotFound
+	}
+
+	data := prepareProfileResponse(user)
+	data.Email, data.Version = "", 0
+
+	return data, nil
+}
+
 // Update executes the profile update logic
-func (s *service) Update(id primitive.ObjectID, req *ct.UpdateProfileRequest) (*ct.ProfileResponse, error) {
-	user, err := s.userRepo.Read(id)
+func (s *service) Update(ctx context.Context, id primitive.ObjectID, req *ct.UpdateProfileRequest) (*ct.ProfileResponse, error) {
+	ctx, span := tracing.Start(ctx, "profile.Update")
+	defer span.End()
+
+	user, err := s.userRepo.Read(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
+	if req.IfMatch != nil && *req.IfMatch != user.Version {
+		return nil, static.ErrVersionConflict
+	}
+
 	// Prepare updates user fields
 	updates := prepareUpdateProfile(user, req)
 
 	// Save updated user
-	updatedUser, err := s.userRepo.Update(user, updates)
+	updatedUser, err := s.userRepo.Update(ctx, user, updates)
+	if errors.Is(err, static.ErrVersionConflict) && req.IfMatch == nil {
+		return nil, static.ErrConcurrentUpdate
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -58,8 +107,11 @@ func (s *service) Update(id primitive.ObjectID, req *ct.UpdateProfileRequest) (*
 }
 
 // ChangePassword executes the password change logic
-func (s *service) ChangePassword(id primitive.ObjectID, req *ct.ChangePasswordRequest) (*ct.ChangePasswordResponse, error) {
-	user, err := s.userRepo.Read(id)
+func (s *service) ChangePassword(ctx context.Context, id primitive.ObjectID, req *ct.ChangePasswordRequest) (*ct.ChangePasswordResponse, error) {
+	ctx, span := tracing.Start(ctx, "profile.ChangePassword")
+	defer span.End()
+
+	user, err := s.userRepo.Read(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -68,12 +120,12 @@ func (s *service) ChangePassword(id primitive.ObjectID, req *ct.ChangePasswordRe
 	hash := hashing.NewBcrypt()
 	err = hash.Compare([]byte(user.Password), []byte(req.CurrentPassword))
 	if err != nil {
-		return nil, static.ErrInvalidPassword
+		return nil, static.ErrInvalidPassword.WithField("current_password", "does not match the current password")
 	}
 
 	// Verify new password matches confirm password
 	if req.NewPassword != req.ConfirmNewPassword {
-		return nil, static.ErrComfirmPassword
+		return nil, static.ErrComfirmPassword.WithField("confirm_new_password", "must match the new password")
 	}
 
 	// Hash new password
@@ -88,19 +140,32 @@ func (s *service) ChangePassword(id primitive.ObjectID, req *ct.ChangePasswordRe
 	}
 
 	// Save updated password
-	_, err = s.userRepo.Update(user, updates)
+	_, err = s.userRepo.Update(ctx, user, updates)
+	if errors.Is(err, static.ErrVersionConflict) {
+		return nil, static.ErrConcurrentUpdate
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	s.auditor.Record(ctx, &audit.Entry{
+		ActorID:    user.ID,
+		Action:     static.AuditPasswordChanged,
+		TargetType: static.AuditTargetUser,
+		TargetID:   user.ID,
+	})
+
 	return &ct.ChangePasswordResponse{
 		Message: "Password changed successfully",
 	}, nil
 }
 
 // GetPost executes the User get their own post detail retrieval logic
-func (s *service) GetPost(postID, ctxUserID primitive.ObjectID) (*ct.PostResponse, error) {
-	post, err := s.postRepo.Read(postID)
+func (s *service) GetPost(ctx context.Context, postID, ctxUserID primitive.ObjectID) (*ct.PostResponse, error) {
+	ctx, span := tracing.Start(ctx, "profile.GetPost")
+	defer span.End()
+
+	post, err := s.postRepo.Read(ctx, postID)
 	if err != nil {
 		return nil, err
 	}
@@ -110,11 +175,24 @@ func (s *service) GetPost(postID, ctxUserID primitive.ObjectID) (*ct.PostRespons
 		return nil, static.ErrPostOwner
 	}
 
-	return preparePostResponse(post), nil
+	// Hidden posts are left out like in the post list
+	if post.HiddenAt != nil {
+		return nil, static.ErrPostNotFound
+	}
+
+	response := preparePostResponse(post)
+	if err = s.reactionSvc.AttachToPosts(ctx, &ctxUserID, []*ct.PostResponse{response}); err != nil {
+		return nil, err
+	}
+
+	return response, nil
 }
 
 // ListBloggerPosts executes the User get their own posts retrieval logic
-func (s *service) ListBloggerPosts(id primitive.ObjectID, isPublishedParam string) (*ct.ListPostResponse, error) {
+func (s *service) ListBloggerPosts(ctx context.Context, id primitive.ObjectID, isPublishedParam string) (*ct.ListPostResponse, error) {
+	ctx, span := tracing.Start(ctx, "profile.ListBloggerPosts")
+	defer span.End()
+
 	var isPublishedFilter *bool
 	if isPublishedParam != "" {
 		if b, err := strconv.ParseBool(isPublishedParam); err == nil {
@@ -124,7 +202,7 @@ func (s *service) ListBloggerPosts(id primitive.ObjectID, isPublishedParam strin
 		}
 	}
 
-	posts, err := s.userRepo.ReadOwnPosts(id, isPublishedFilter)
+	posts, err := s.userRepo.ReadOwnPosts(ctx, id, isPublishedFilter)
 	if err != nil {
 		return nil, static.ErrListBloggerPosts
 	}
@@ -134,6 +212,10 @@ func (s *service) ListBloggerPosts(id primitive.ObjectID, isPublishedParam strin
 		responses = append(responses, preparePostResponse(post))
 	}
 
+	if err = s.reactionSvc.AttachToPosts(ctx, &id, responses); err != nil {
+		return nil, err
+	}
+
 	return &ct.ListPostResponse{
 		Posts: responses,
 	}, nil
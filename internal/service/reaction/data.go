@@ -0,0 +1,25 @@
+package reaction
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	ct "golang-project/internal/contract"
+	"golang-project/static"
+)
+
+// prepareSummaryResponse transforms the data and returns the Reaction Summary Response
+func prepareSummaryResponse(targetType static.ReactionTargetType, targetID primitive.ObjectID, counts ct.ReactionCounts, mine static.ReactionType) *ct.ReactionSummaryResponse {
+	data := &ct.ReactionSummaryResponse{
+		TargetType: targetType,
+		TargetID:   targetID,
+		Reactions:  make(ct.ReactionCounts, len(counts)),
+		MyReaction: mine,
+	}
+
+	for reaction, count := range counts {
+		data.Reactions[reaction] = count
+		data.Total += count
+	}
+
+	return data
+}
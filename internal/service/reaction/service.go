@@ -0,0 +1,223 @@
+package reaction
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/database"
+	ct "golang-project/internal/contract"
+	"golang-project/internal/event"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	svc "golang-project/internal/service"
+	"golang-project/internal/tracing"
+	"golang-project/static"
+)
+
+// service represents the implementation of service.Reaction
+type service struct {
+	reactionRepo    repo.Reaction
+	relationshipSvc svc.Relationship
+	unitOfWork      database.UnitOfWork
+	publisher       event.Publisher
+}
+
+// NewService returns a new implementation of service.Reaction
+func NewService(reactionRepo repo.Reaction, relationshipSvc svc.Relationship, unitOfWork database.UnitOfWork, publisher event.Publisher) svc.Reaction {
+	return &service{
+		reactionRepo:    reactionRepo,
+		relationshipSvc: relationshipSvc,
+		unitOfWork:      unitOfWork,
+		publisher:       publisher,
+	}
+}
+
+// React sets the reaction of the user on the post or comment, one reaction per user and content,
+// a new reaction type replaces the previous one
+func (s *service) React(ctx context.Context, userID primitive.ObjectID, r *ct.SetReactionRequest) (*ct.ReactionSummaryResponse, error) {
+	ctx, span := tracing.Start(ctx, "reaction.React")
+	defer span.End()
+
+	authorID, err := s.readAuthor(ctx, r.TargetType, r.TargetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.relationshipSvc.CheckInteraction(ctx, userID, authorID); err != nil {
+		return nil, err
+	}
+
+	err = s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		_, err := s.reactionRepo.Upsert(ctx, &model.Reaction{UserID: userID, TargetType: r.TargetType, TargetID: r.TargetID, Type: r.Type})
+		if err != nil {
+			return err
+		}
+
+		return s.publishChange(ctx, r.TargetType, r.TargetID)
+	})
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	return s.summarise(ctx, &userID, r.TargetType, r.TargetID)
+}
+
+// Unreact removes the reaction of the user on the post or comment
+func (s *service) Unreact(ctx context.Context, userID primitive.ObjectID, r *ct.ReactionTargetRequest) (*ct.ReactionSummaryResponse, error) {
+	ctx, span := tracing.Start(ctx, "reaction.Unreact")
+	defer span.End()
+
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		deleted, err := s.reactionRepo.Delete(ctx, userID, r.TargetType, r.TargetID)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return static.ErrReactionNotFound
+		}
+
+		return s.publishChange(ctx, r.TargetType, r.TargetID)
+	})
+	if errors.Is(err, static.ErrReactionNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	return s.summarise(ctx, &userID, r.TargetType, r.TargetID)
+}
+
+// Summary returns the reaction counts of the post or comment and the reaction of the viewer when not nil
+func (s *service) Summary(ctx context.Context, viewerID *primitive.ObjectID, r *ct.ReactionTargetRequest) (*ct.ReactionSummaryResponse, error) {
+	ctx, span := tracing.Start(ctx, "reaction.Summary")
+	defer span.End()
+
+	if _, err := s.readAuthor(ctx, r.TargetType, r.TargetID); err != nil {
+		return nil, err
+	}
+
+	return s.summarise(ctx, viewerID, r.TargetType, r.TargetID)
+}
+
+// AttachToPosts fills the reaction counts of the posts and the reaction of the viewer when not nil
+func (s *service) AttachToPosts(ctx context.Context, viewerID *primitive.ObjectID, posts []*ct.PostResponse) error {
+	ctx, span := tracing.Start(ctx, "reaction.AttachToPosts")
+	defer span.End()
+
+	ids := make([]primitive.ObjectID, 0, len(posts))
+	for _, post := range posts {
+		ids = append(ids, post.ID)
+	}
+
+	counts, mine, err := s.load(ctx, viewerID, static.ReactionTargetPost, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, post := range posts {
+		post.Reactions, post.MyReaction = counts[post.ID], mine[post.ID]
+	}
+
+	return nil
+}
+
+// AttachToComments fills the reaction counts of the comments and their child comments
+// and the reaction of the viewer when not nil
+func (s *service) AttachToComments(ctx context.Context, viewerID *primitive.ObjectID, comments []*ct.CommentResponse) error {
+	ctx, span := tracing.Start(ctx, "reaction.AttachToComments")
+	defer span.End()
+
+	ids := make([]primitive.ObjectID, 0, len(comments))
+	for _, comment := range comments {
+		ids = append(ids, comment.ID)
+		for _, child := range comment.ChildComments {
+			ids = append(ids, child.ID)
+		}
+	}
+
+	counts, mine, err := s.load(ctx, viewerID, static.ReactionTargetComment, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, comment := range comments {
+		comment.Reactions, comment.MyReaction = counts[comment.ID], mine[comment.ID]
+		for _, child := range comment.ChildComments {
+			child.Reactions, child.MyReaction = counts[child.ID], mine[child.ID]
+		}
+	}
+
+	return nil
+}
+
+// readAuthor reads the visible post or comment and returns its author
+func (s *service) readAuthor(ctx context.Context, targetType static.ReactionTargetType, targetID primitive.ObjectID) (primitive.ObjectID, error) {
+	if targetType == static.ReactionTargetComment {
+		comment, err := s.reactionRepo.ReadComment(ctx, targetID)
+		if err != nil {
+			return primitive.NilObjectID, err
+		}
+
+		return comment.UserID, nil
+	}
+
+	post, err := s.reactionRepo.ReadPost(ctx, targetID)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+
+	return post.UserID, nil
+}
+
+// summarise returns the Reaction Summary Response of a single content
+func (s *service) summarise(ctx context.Context, viewerID *primitive.ObjectID, targetType static.ReactionTargetType, targetID primitive.ObjectID) (*ct.ReactionSummaryResponse, error) {
+	counts, mine, err := s.load(ctx, viewerID, targetType, []primitive.ObjectID{targetID})
+	if err != nil {
+		return nil, err
+	}
+
+	return prepareSummaryResponse(targetType, targetID, counts[targetID], mine[targetID]), nil
+}
+
+// load returns the reaction counts of the contents and the reactions of the viewer indexed by content ID
+func (s *service) load(ctx context.Context, viewerID *primitive.ObjectID, targetType static.ReactionTargetType, ids []primitive.ObjectID) (map[primitive.ObjectID]ct.ReactionCounts, map[primitive.ObjectID]static.ReactionType, error) {
+	counts := make(map[primitive.ObjectID]ct.ReactionCounts, len(ids))
+	mine := make(map[primitive.ObjectID]static.ReactionType)
+
+	rows, err := s.reactionRepo.SelectCounts(ctx, targetType, ids)
+	if err != nil {
+		return nil, nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+	for _, row := range rows {
+		if counts[row.TargetID] == nil {
+			counts[row.TargetID] = make(ct.ReactionCounts)
+		}
+		counts[row.TargetID][row.Type] = row.Count
+	}
+
+	if viewerID == nil {
+		return counts, mine, nil
+	}
+
+	reactions, err := s.reactionRepo.SelectUserReactions(ctx, *viewerID, targetType, ids)
+	if err != nil {
+		return nil, nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+	for _, reaction := range reactions {
+		mine[reaction.TargetID] = reaction.Type
+	}
+
+	return counts, mine, nil
+}
+
+// publishChange publishes the change of the reaction counts of a post, the reactions of comments are not part of the post details
+func (s *service) publishChange(ctx context.Context, targetType static.ReactionTargetType, targetID primitive.ObjectID) error {
+	if targetType != static.ReactionTargetPost {
+		return nil
+	}
+
+	return s.publisher.Publish(ctx, event.PostChanged{PostID: targetID})
+}
@@ -0,0 +1,40 @@
+package relationship
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	ct "golang-project/internal/contract"
+	"golang-project/internal/model"
+)
+
+// relation represents a block or a mute pointing at another user
+type relation struct {
+	userID primitive.ObjectID
+	base   *model.BaseModel
+}
+
+// prepareRelationshipResponse transforms the data and returns the Relationship Response,
+// the email of the other user is not disclosed
+func prepareRelationshipResponse(o *model.User, base *model.BaseModel) *ct.RelationshipResponse {
+	data := &ct.RelationshipResponse{
+		User: &ct.ProfileResponse{
+			ID:             o.ID,
+			FirstName:      o.FirstName,
+			LastName:       o.LastName,
+			Pseudonym:      o.Pseudonym,
+			ProfileImage:   o.ProfileImage,
+			Biography:      o.Biography,
+			FollowerCount:  o.FollowerCount,
+			FollowingCount: o.FollowingCount,
+			PostCount:      o.PostCount,
+		},
+	}
+
+	if base.CreatedAt != nil {
+		data.CreatedAt = base.CreatedAt.Format(time.RFC3339)
+	}
+
+	return data
+}
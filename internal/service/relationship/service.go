@@ -0,0 +1,232 @@
+package relationship
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/database"
+	ct "golang-project/internal/contract"
+	"golang-project/internal/event"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	svc "golang-project/internal/service"
+	"golang-project/internal/tracing"
+	"golang-project/static"
+)
+
+// service represents the implementation of service.Relationship
+type service struct {
+	userRepo         repo.User
+	relationshipRepo repo.Relationship
+	unitOfWork       database.UnitOfWork
+	publisher        event.Publisher
+}
+
+// NewService returns a new implementation of service.Relationship
+func NewService(userRepo repo.User, relationshipRepo repo.Relationship, unitOfWork database.UnitOfWork, publisher event.Publisher) svc.Relationship {
+	return &service{
+		userRepo:         userRepo,
+		relationshipRepo: relationshipRepo,
+		unitOfWork:       unitOfWork,
+		publisher:        publisher,
+	}
+}
+
+// Block blocks the user and removes the follows between the two users
+func (s *service) Block(ctx context.Context, userID primitive.ObjectID, r *ct.RelationshipRequest) (*ct.RelationshipResponse, error) {
+	ctx, span := tracing.Start(ctx, "relationship.Block")
+	defer span.End()
+
+	target, err := s.readTarget(ctx, userID, r.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	block := &model.BlockUser{UserID: userID, BlockedUserID: target.ID}
+	err = s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		inserted, err := s.relationshipRepo.InsertBlock(ctx, block)
+		if err != nil {
+			return static.ErrDatabaseOperation.Wrap(err)
+		}
+		if !inserted {
+			return static.ErrAlreadyBlocked
+		}
+
+		if err = s.relationshipRepo.DeleteFollows(ctx, userID, target.ID); err != nil {
+			return static.ErrDatabaseOperation.Wrap(err)
+		}
+
+		// The follow counters of the two users may have changed
+		if err = s.publisher.Publish(ctx, event.ProfileChanged{UserID: userID}, event.ProfileChanged{UserID: target.ID}); err != nil {
+			return static.ErrDatabaseOperation.Wrap(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return prepareRelationshipResponse(target, &block.BaseModel), nil
+}
+
+// Unblock removes the block of the user
+func (s *service) Unblock(ctx context.Context, userID primitive.ObjectID, r *ct.RelationshipRequest) error {
+	ctx, span := tracing.Start(ctx, "relationship.Unblock")
+	defer span.End()
+
+	deleted, err := s.relationshipRepo.DeleteBlock(ctx, userID, r.UserID)
+	if err != nil {
+		return static.ErrDatabaseOperation.Wrap(err)
+	}
+	if !deleted {
+		return static.ErrNotBlocked
+	}
+
+	return nil
+}
+
+// ListBlocked returns the users blocked by the user, the latest first
+func (s *service) ListBlocked(ctx context.Context, userID primitive.ObjectID) (*ct.ListRelationshipResponse, error) {
+	ctx, span := tracing.Start(ctx, "relationship.ListBlocked")
+	defer span.End()
+
+	blocks, err := s.relationshipRepo.SelectBlocks(ctx, userID)
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	relations := make([]relation, 0, len(blocks))
+	for _, block := range blocks {
+		relations = append(relations, relation{userID: block.BlockedUserID, base: &block.BaseModel})
+	}
+
+	return s.prepareList(ctx, relations)
+}
+
+// Mute hides the content of the user from the feeds and notifications of the user
+func (s *service) Mute(ctx context.Context, userID primitive.ObjectID, r *ct.RelationshipRequest) (*ct.RelationshipResponse, error) {
+	ctx, span := tracing.Start(ctx, "relationship.Mute")
+	defer span.End()
+
+	target, err := s.readTarget(ctx, userID, r.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	mute := &model.MuteUser{UserID: userID, MutedUserID: target.ID}
+	inserted, err := s.relationshipRepo.InsertMute(ctx, mute)
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+	if !inserted {
+		return nil, static.ErrAlreadyMuted
+	}
+
+	return prepareRelationshipResponse(target, &mute.BaseModel), nil
+}
+
+// Unmute removes the mute of the user
+func (s *service) Unmute(ctx context.Context, userID primitive.ObjectID, r *ct.RelationshipRequest) error {
+	ctx, span := tracing.Start(ctx, "relationship.Unmute")
+	defer span.End()
+
+	deleted, err := s.relationshipRepo.DeleteMute(ctx, userID, r.UserID)
+	if err != nil {
+		return static.ErrDatabaseOperation.Wrap(err)
+	}
+	if !deleted {
+		return static.ErrNotMuted
+	}
+
+	return nil
+}
+
+// ListMuted returns the users muted by the user, the latest first
+func (s *service) ListMuted(ctx context.Context, userID primitive.ObjectID) (*ct.ListRelationshipResponse, error) {
+	ctx, span := tracing.Start(ctx, "relationship.ListMuted")
+	defer span.End()
+
+	mutes, err := s.relationshipRepo.SelectMutes(ctx, userID)
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	relations := make([]relation, 0, len(mutes))
+	for _, mute := range mutes {
+		relations = append(relations, relation{userID: mute.MutedUserID, base: &mute.BaseModel})
+	}
+
+	return s.prepareList(ctx, relations)
+}
+
+// CheckInteraction refuses the interaction when one of the users blocked the other
+func (s *service) CheckInteraction(ctx context.Context, userID, otherUserID primitive.ObjectID) error {
+	ctx, span := tracing.Start(ctx, "relationship.CheckInteraction")
+	defer span.End()
+
+	if userID == otherUserID {
+		return nil
+	}
+
+	blocked, err := s.relationshipRepo.IsBlocked(ctx, userID, otherUserID)
+	if err != nil {
+		return static.ErrDatabaseOperation.Wrap(err)
+	}
+	if blocked {
+		return static.ErrUserBlocked
+	}
+
+	return nil
+}
+
+// HiddenUserIDs returns the users blocked by or blocking the user and the users muted by the user
+func (s *service) HiddenUserIDs(ctx context.Context, userID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	ctx, span := tracing.Start(ctx, "relationship.HiddenUserIDs")
+	defer span.End()
+
+	ids, err := s.relationshipRepo.SelectHiddenUserIDs(ctx, userID)
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	return ids, nil
+}
+
+// readTarget reads the user to block or mute, users cannot block or mute themselves
+func (s *service) readTarget(ctx context.Context, userID, targetID primitive.ObjectID) (*model.User, error) {
+	if userID == targetID {
+		return nil, static.ErrSelfRelationship
+	}
+
+	return s.userRepo.Read(ctx, targetID)
+}
+
+// prepareList loads the users of the relations and returns the List Relationship Response,
+// the relations of deleted users are left out
+func (s *service) prepareList(ctx context.Context, relations []relation) (*ct.ListRelationshipResponse, error) {
+	ids := make([]primitive.ObjectID, 0, len(relations))
+	for _, rel := range relations {
+		ids = append(ids, rel.userID)
+	}
+
+	users, err := s.relationshipRepo.SelectUsers(ctx, ids)
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	byID := make(map[primitive.ObjectID]*model.User, len(users))
+	for _, user := range users {
+		byID[user.ID] = user
+	}
+
+	data := &ct.ListRelationshipResponse{Users: make([]*ct.RelationshipResponse, 0, len(relations))}
+	for _, rel := range relations {
+		if user, ok := byID[rel.userID]; ok {
+			data.Users = append(data.Users, prepareRelationshipResponse(user, rel.base))
+		}
+	}
+
+	return data, nil
+}
@@ -1,55 +1,181 @@
 package service
 
 import (
+	"context"
+
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	ct "golang-project/internal/contract"
+	"golang-project/static"
 )
 
 // Authentication represents the service logic of Authentication
 type Authentication interface {
-	SignIn(*ct.SignInRequest) (*ct.SignInResponse, error)
-	SignUp(*ct.SignUpRequest) (*ct.SignUpResponse, error)
+	SignIn(context.Context, *ct.SignInRequest) (*ct.SignInResponse, error)
+	SignUp(context.Context, *ct.SignUpRequest) (*ct.SignUpResponse, error)
+	// AuthorizeOIDC starts the OpenID Connect sign-in and returns the provider URL to redirect the user to
+	AuthorizeOIDC(context.Context) (*ct.OIDCAuthorizationResponse, error)
+	// SignInOIDC ends the OpenID Connect sign-in and returns the same token as SignIn
+	SignInOIDC(context.Context, *ct.OIDCCallbackRequest) (*ct.SignInResponse, error)
+	// SignInTwoFactor ends the sign-in of a user with two-factor authentication and returns the same token as SignIn
+	SignInTwoFactor(context.Context, *ct.TwoFactorSignInRequest) (*ct.SignInResponse, error)
 }
 
 // Profile represents the service logic of Profile
 type Profile interface {
-	GetByID(primitive.ObjectID) (*ct.ProfileResponse, error)
-	GetPost(primitive.ObjectID, primitive.ObjectID) (*ct.PostResponse, error)
-	Update(primitive.ObjectID, *ct.UpdateProfileRequest) (*ct.ProfileResponse, error)
-	ChangePassword(primitive.ObjectID, *ct.ChangePasswordRequest) (*ct.ChangePasswordResponse, error)
-	ListBloggerPosts(id primitive.ObjectID, isPublishedFilter string) (*ct.ListPostResponse, error)
+	GetByID(context.Context, primitive.ObjectID) (*ct.ProfileResponse, error)
+	// View returns the public profile of the user, refused when the viewer and the user blocked each other
+	View(ctx context.Context, viewerID *primitive.ObjectID, id primitive.ObjectID) (*ct.ProfileResponse, error)
+	GetPost(context.Context, primitive.ObjectID, primitive.ObjectID) (*ct.PostResponse, error)
+	Update(context.Context, primitive.ObjectID, *ct.UpdateProfileRequest) (*ct.ProfileResponse, error)
+	ChangePassword(context.Context, primitive.ObjectID, *ct.ChangePasswordRequest) (*ct.ChangePasswordResponse, error)
+	ListBloggerPosts(ctx context.Context, id primitive.ObjectID, isPublishedFilter string) (*ct.ListPostResponse, error)
 }
 
 type Tag interface {
-	Create(string) (*ct.TagResponse, error)
-	Delete(primitive.ObjectID) error
-	List() (*ct.ListTagResponse, error)
-	ListPosts(primitive.ObjectID) (*ct.ListPostResponse, error)
+	Create(context.Context, string) (*ct.TagResponse, error)
+	Delete(context.Context, primitive.ObjectID) error
+	List(context.Context) (*ct.ListTagResponse, error)
+	ListPosts(context.Context, primitive.ObjectID) (*ct.ListPostResponse, error)
 }
 
 type Comment interface {
-	List(*ct.ListCommentRequest) (*ct.ListCommentResponse, error)
-	Create(*ct.CreateCommentRequest, primitive.ObjectID) (*ct.CommentResponse, error)
-	Update(*ct.UpdateCommentRequest, primitive.ObjectID) (*ct.CommentResponse, error)
-	Delete(primitive.ObjectID, primitive.ObjectID) error
+	// List returns the comments of the post with the reactions, the reaction of the viewer is filled when not nil
+	List(ctx context.Context, viewerID *primitive.ObjectID, req *ct.ListCommentRequest) (*ct.ListCommentResponse, error)
+	Create(context.Context, *ct.CreateCommentRequest, primitive.ObjectID) (*ct.CommentResponse, error)
+	Update(context.Context, *ct.UpdateCommentRequest, primitive.ObjectID) (*ct.CommentResponse, error)
+	Delete(context.Context, primitive.ObjectID, primitive.ObjectID) error
 }
 
 type Post interface {
-	GetByID(primitive.ObjectID) (*ct.PostResponse, error)
-	List(*ct.ListPostRequest) (*ct.ListPostResponse, error)
-	Create(*ct.CreatePostRequest, primitive.ObjectID) (*ct.PostResponse, error)
-	Update(primitive.ObjectID, *ct.UpdatePostRequest) (*ct.PostResponse, error)
-	Delete(primitive.ObjectID, primitive.ObjectID) error
+	// GetByID returns the published post with the reactions, the reaction of the viewer is filled when not nil
+	GetByID(ctx context.Context, viewerID *primitive.ObjectID, id primitive.ObjectID) (*ct.PostResponse, error)
+	// List returns the published posts with the reactions, the reaction of the viewer is filled when not nil
+	List(ctx context.Context, viewerID *primitive.ObjectID, req *ct.ListPostRequest) (*ct.ListPostResponse, error)
+	Create(context.Context, *ct.CreatePostRequest, primitive.ObjectID) (*ct.PostResponse, error)
+	Update(context.Context, primitive.ObjectID, *ct.UpdatePostRequest) (*ct.PostResponse, error)
+	Delete(context.Context, primitive.ObjectID, primitive.ObjectID) error
 }
 
 // Favourite represents the service logic of Favourite features
 type Favourite interface {
 	// User following operations
-	UpdateFollowStatus(userID primitive.ObjectID, req *ct.BloggerFollowRequest) (*ct.BloggerFollowStatusResponse, error)
-	ListFollowingUsers(userID primitive.ObjectID) (*ct.ListProfileResponse, error)
-	ListUserPosts(userID primitive.ObjectID) (*ct.ListPostResponse, error)
+	UpdateFollowStatus(ctx context.Context, userID primitive.ObjectID, req *ct.BloggerFollowRequest) (*ct.BloggerFollowStatusResponse, error)
+	ListFollowingUsers(ctx context.Context, userID primitive.ObjectID) (*ct.ListProfileResponse, error)
+	ListUserPosts(ctx context.Context, userID primitive.ObjectID) (*ct.ListPostResponse, error)
 	// Post favorite operations
-	UpdateFavouriteStatus(userID primitive.ObjectID, req *ct.PostFavouriteRequest) (*ct.PostFavouriteStatusResponse, error)
-	ListFavouritePosts(userID primitive.ObjectID) (*ct.ListPostResponse, error)
+	UpdateFavouriteStatus(ctx context.Context, userID primitive.ObjectID, req *ct.PostFavouriteRequest) (*ct.PostFavouriteStatusResponse, error)
+	ListFavouritePosts(ctx context.Context, userID primitive.ObjectID) (*ct.ListPostResponse, error)
+}
+
+// Feed represents the service logic of syndication feeds
+type Feed interface {
+	Site(context.Context, *ct.FeedRequest) (*ct.FeedResponse, error)
+	Blogger(context.Context, *ct.FeedRequest) (*ct.FeedResponse, error)
+	Tag(context.Context, *ct.FeedRequest) (*ct.FeedResponse, error)
+}
+
+// Admin represents the service logic of the operator commands
+type Admin interface {
+	CreateUser(context.Context, *ct.AdminCreateUserRequest) (*ct.AdminUserResponse, error)
+	PromoteUser(context.Context, *ct.AdminPromoteUserRequest) (*ct.AdminUserResponse, error)
+	DisableUser(context.Context, *ct.AdminUserRequest) (*ct.AdminUserResponse, error)
+	ResetPassword(context.Context, *ct.AdminResetPasswordRequest) (*ct.AdminResetPasswordResponse, error)
+	UnpublishPost(context.Context, *ct.AdminPostRequest) (*ct.AdminPostResponse, error)
+	MergeTags(context.Context, *ct.AdminMergeTagRequest) (*ct.AdminMergeTagResponse, error)
+	RecomputeCounters(context.Context, *ct.AdminRecomputeCountersRequest) (*ct.AdminRecomputeCountersResponse, error)
+}
+
+// Export represents the service logic of personal data exports
+type Export interface {
+	Request(context.Context, primitive.ObjectID) (*ct.ExportResponse, error)
+	Get(context.Context, primitive.ObjectID, *ct.ExportRequest) (*ct.ExportResponse, error)
+	Download(context.Context, primitive.ObjectID, *ct.ExportRequest) (*ct.ExportFileResponse, error)
+	Purge(context.Context, primitive.ObjectID) error
+	Work(context.Context)
+}
+
+// Account represents the service logic of the account lifecycle
+type Account interface {
+	ScheduleDeletion(context.Context, primitive.ObjectID, *ct.ScheduleDeletionRequest) (*ct.AccountDeletionResponse, error)
+	GetDeletion(context.Context, primitive.ObjectID) (*ct.AccountDeletionResponse, error)
+	CancelDeletion(context.Context, primitive.ObjectID) (*ct.AccountDeletionResponse, error)
+	Work(context.Context)
+}
+
+// Moderation represents the service logic of content reports and the moderation queue
+type Moderation interface {
+	Report(context.Context, primitive.ObjectID, *ct.CreateReportRequest) (*ct.ReportResponse, error)
+	ListQueue(context.Context, primitive.ObjectID, *ct.ListReportQueueRequest) (*ct.ReportQueueResponse, error)
+	TakeAction(context.Context, primitive.ObjectID, *ct.ModerationActionRequest) (*ct.ModerationActionResponse, error)
+}
+
+// Relationship represents the service logic of blocked and muted users
+type Relationship interface {
+	Block(context.Context, primitive.ObjectID, *ct.RelationshipRequest) (*ct.RelationshipResponse, error)
+	Unblock(context.Context, primitive.ObjectID, *ct.RelationshipRequest) error
+	ListBlocked(context.Context, primitive.ObjectID) (*ct.ListRelationshipResponse, error)
+	Mute(context.Context, primitive.ObjectID, *ct.RelationshipRequest) (*ct.RelationshipResponse, error)
+	Unmute(context.Context, primitive.ObjectID, *ct.RelationshipRequest) error
+	ListMuted(context.Context, primitive.ObjectID) (*ct.ListRelationshipResponse, error)
+	// CheckInteraction returns static.ErrUserBlocked when one of the users blocked the other,
+	// follows, comments, favourites and profile views between them are refused
+	CheckInteraction(ctx context.Context, userID, otherUserID primitive.ObjectID) error
+	// HiddenUserIDs returns the users whose content is left out of the feeds and notifications of the user
+	HiddenUserIDs(context.Context, primitive.ObjectID) ([]primitive.ObjectID, error)
+}
+
+// Reaction represents the service logic of reactions on posts and comments
+type Reaction interface {
+	React(context.Context, primitive.ObjectID, *ct.SetReactionRequest) (*ct.ReactionSummaryResponse, error)
+	Unreact(context.Context, primitive.ObjectID, *ct.ReactionTargetRequest) (*ct.ReactionSummaryResponse, error)
+	Summary(context.Context, *primitive.ObjectID, *ct.ReactionTargetRequest) (*ct.ReactionSummaryResponse, error)
+	// AttachToPosts fills the reaction counts of the posts and the reaction of the viewer when not nil
+	AttachToPosts(ctx context.Context, viewerID *primitive.ObjectID, posts []*ct.PostResponse) error
+	// AttachToComments fills the reaction counts of the comments and the reaction of the viewer when not nil
+	AttachToComments(ctx context.Context, viewerID *primitive.ObjectID, comments []*ct.CommentResponse) error
+}
+
+// Webhook represents the service logic of outbound webhooks
+type Webhook interface {
+	Create(context.Context, primitive.ObjectID, *ct.CreateWebhookRequest) (*ct.WebhookResponse, error)
+	List(context.Context, primitive.ObjectID) (*ct.ListWebhookResponse, error)
+	Delete(context.Context, primitive.ObjectID, *ct.WebhookRequest) error
+	ListDeliveries(context.Context, primitive.ObjectID, *ct.ListWebhookDeliveryRequest) (*ct.ListWebhookDeliveryResponse, error)
+	Redeliver(context.Context, primitive.ObjectID, *ct.RedeliverWebhookRequest) (*ct.WebhookDeliveryResponse, error)
+	// Dispatch queues a delivery of the event to the webhooks of the owner, the user the event is about,
+	// and to the webhooks subscribed to the events of all users, eventID is the ID of the outbox event
+	Dispatch(ctx context.Context, eventID primitive.ObjectID, event static.WebhookEvent, ownerID primitive.ObjectID, data interface{}) error
+	Work(context.Context)
+}
+
+// Audit represents the service logic of the audit log queries
+type Audit interface {
+	List(context.Context, primitive.ObjectID, *ct.ListAuditLogRequest) (*ct.ListAuditLogResponse, error)
+	ListSecurityActivity(context.Context, primitive.ObjectID, *ct.ListSecurityActivityRequest) (*ct.ListAuditLogResponse, error)
+}
+
+// AccessToken represents the service logic of personal access tokens
+type AccessToken interface {
+	Create(context.Context, primitive.ObjectID, *ct.CreateAccessTokenRequest) (*ct.AccessTokenResponse, error)
+	List(context.Context, primitive.ObjectID) (*ct.ListAccessTokenResponse, error)
+	Revoke(context.Context, primitive.ObjectID, *ct.AccessTokenRequest) error
+	// Authenticate returns the user of the active token and records its use
+	Authenticate(ctx context.Context, token, ip string) (*ct.ContextUser, error)
+}
+
+// TwoFactor represents the service logic of the TOTP two-factor authentication
+type TwoFactor interface {
+	Status(context.Context, primitive.ObjectID) (*ct.TwoFactorStatusResponse, error)
+	Enrol(context.Context, primitive.ObjectID, *ct.EnrolTwoFactorRequest) (*ct.TwoFactorEnrolmentResponse, error)
+	Confirm(context.Context, primitive.ObjectID, *ct.TwoFactorCodeRequest) (*ct.RecoveryCodesResponse, error)
+	RenewRecoveryCodes(context.Context, primitive.ObjectID, *ct.TwoFactorCodeRequest) (*ct.RecoveryCodesResponse, error)
+	Disable(context.Context, primitive.ObjectID, *ct.DisableTwoFactorRequest) error
+	// Reauthenticate checks the confirmation of a sensitive action by the user, a code it contains is used up
+	Reauthenticate(ctx context.Context, userID primitive.ObjectID, r *ct.Reauthentication) error
+	// Verify checks the authenticator or recovery code of the user and uses it up,
+	// recoveryCode reports which kind of code it was
+	Verify(ctx context.Context, userID primitive.ObjectID, code string) (recoveryCode bool, err error)
+	// EncryptSecrets encrypts the TOTP secrets stored in plain and returns how many were encrypted
+	EncryptSecrets(context.Context) (int, error)
 }
@@ -0,0 +1,160 @@
+// Package servicetest provides the in-memory dependencies shared by the unit tests of the services
+package servicetest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/internal/audit"
+	"golang-project/internal/event"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	svc "golang-project/internal/service"
+	"golang-project/static"
+)
+
+// UnitOfWork runs the work without a transaction, the tests of the rollbacks run against databasetest
+type UnitOfWork struct{}
+
+func (UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// Publisher keeps the published events
+type Publisher struct {
+	mu     sync.Mutex
+	Events []event.Event
+}
+
+func (p *Publisher) Publish(_ context.Context, events ...event.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.Events = append(p.Events, events...)
+	return nil
+}
+
+// Recorder keeps the audit entries
+type Recorder struct {
+	mu      sync.Mutex
+	Entries []*audit.Entry
+}
+
+func (r *Recorder) Record(_ context.Context, entry *audit.Entry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.Entries = append(r.Entries, entry)
+}
+
+// Users reads the users by ID, an ID not in Users reads an active user without role
+type Users struct {
+	repo.User
+	Users map[primitive.ObjectID]*model.User
+}
+
+func (r Users) Read(_ context.Context, id primitive.ObjectID) (*model.User, error) {
+	if user, ok := r.Users[id]; ok {
+		read := *user
+		read.ID = id
+		return &read, nil
+	}
+
+	return &model.User{BaseModel: model.BaseModel{ID: id}}, nil
+}
+
+func (r Users) Select(_ context.Context, ids []primitive.ObjectID) ([]*model.User, error) {
+	var users []*model.User
+	for _, id := range ids {
+		if user, ok := r.Users[id]; ok {
+			selected := *user
+			selected.ID = id
+			users = append(users, &selected)
+		}
+	}
+
+	return users, nil
+}
+
+// Posts reads every ID as a published post of the author
+type Posts struct {
+	repo.Post
+	AuthorID primitive.ObjectID
+}
+
+func (r Posts) Read(_ context.Context, id primitive.ObjectID) (*model.Post, error) {
+	return &model.Post{BaseModel: model.BaseModel{ID: id}, IsPublished: true, UserID: r.AuthorID}, nil
+}
+
+// Relationships answers every interaction check with Err, nil allows every interaction
+type Relationships struct {
+	svc.Relationship
+	Err error
+}
+
+func (r Relationships) CheckInteraction(context.Context, primitive.ObjectID, primitive.ObjectID) error {
+	return r.Err
+}
+
+// AccessTokens keeps the personal access tokens by ID
+type AccessTokens struct {
+	repo.AccessToken
+	mu     sync.Mutex
+	Tokens map[primitive.ObjectID]*model.AccessToken
+}
+
+func (r *AccessTokens) Insert(_ context.Context, o *model.AccessToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	o.ID, o.CreatedAt = primitive.NewObjectID(), &now
+	if r.Tokens == nil {
+		r.Tokens = map[primitive.ObjectID]*model.AccessToken{}
+	}
+	r.Tokens[o.ID] = o
+
+	return nil
+}
+
+func (r *AccessTokens) CountActive(_ context.Context, userID primitive.ObjectID, now time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var count int64
+	for _, token := range r.Tokens {
+		if token.UserID == userID && token.RevokedAt == nil && now.Before(*token.ExpiresAt) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (r *AccessTokens) ReadByHash(_ context.Context, hash string) (*model.AccessToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, token := range r.Tokens {
+		if token.TokenHash == hash {
+			read := *token
+			return &read, nil
+		}
+	}
+
+	return nil, static.ErrAccessTokenNotFound
+}
+
+func (r *AccessTokens) TouchLastUsed(_ context.Context, o *model.AccessToken, at time.Time, ip string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if token, ok := r.Tokens[o.ID]; ok {
+		token.LastUsedAt, token.LastUsedIP = &at, ip
+	}
+
+	return nil
+}
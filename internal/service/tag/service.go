@@ -0,0 +1,129 @@
+package tag
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/database"
+	ct "golang-project/internal/contract"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	svc "golang-project/internal/service"
+	"golang-project/internal/tracing"
+	"golang-project/static"
+)
+
+// service represents the implementation of service.Tag
+type service struct {
+	tagRepo    repo.Tag
+	unitOfWork database.UnitOfWork
+}
+
+// NewService returns a new implementation of service.Tag
+func NewService(tagRepo repo.Tag, unitOfWork database.UnitOfWork) svc.Tag {
+	return &service{
+		tagRepo:    tagRepo,
+		unitOfWork: unitOfWork,
+	}
+}
+
+// Create creates the tag, tag names are unique
+func (s *service) Create(ctx context.Context, name string) (*ct.TagResponse, error) {
+	ctx, span := tracing.Start(ctx, "tag.Create")
+	defer span.End()
+
+	tag := &model.Tag{Name: strings.TrimSpace(name)}
+	err := s.tagRepo.Insert(ctx, tag)
+	if errors.Is(err, static.ErrTagExists) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	return prepareTagResponse(tag), nil
+}
+
+// Delete deletes the tag unless a post is labelled with it, the check and the delete run in one transaction
+// so a post labelled in between makes the transaction conflict and retry
+func (s *service) Delete(ctx context.Context, id primitive.ObjectID) error {
+	ctx, span := tracing.Start(ctx, "tag.Delete")
+	defer span.End()
+
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		hasPosts, err := s.tagRepo.HasPosts(ctx, id)
+		if err != nil {
+			return err
+		}
+		if hasPosts {
+			return static.ErrHasPosts
+		}
+
+		return s.tagRepo.Delete(ctx, id)
+	})
+	if errors.Is(err, static.ErrHasPosts) || errors.Is(err, static.ErrTagNotFound) {
+		return err
+	}
+	if err != nil {
+		return static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	return nil
+}
+
+// List returns every tag ordered by name
+func (s *service) List(ctx context.Context) (*ct.ListTagResponse, error) {
+	ctx, span := tracing.Start(ctx, "tag.List")
+	defer span.End()
+
+	tags, err := s.tagRepo.Select(ctx, nil)
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	data := &ct.ListTagResponse{Tags: make([]*ct.TagResponse, 0, len(tags))}
+	for _, tag := range tags {
+		data.Tags = append(data.Tags, prepareTagResponse(tag))
+	}
+
+	return data, nil
+}
+
+// ListPosts returns the published posts labelled with the tag, the latest first
+func (s *service) ListPosts(ctx context.Context, id primitive.ObjectID) (*ct.ListPostResponse, error) {
+	ctx, span := tracing.Start(ctx, "tag.ListPosts")
+	defer span.End()
+
+	if _, err := s.tagRepo.Read(ctx, id); err != nil {
+		if errors.Is(err, static.ErrTagNotFound) {
+			return nil, err
+		}
+		return nil, static.ErrReadTagID.Wrap(err)
+	}
+
+	posts, err := s.tagRepo.SelectPost(ctx, id)
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	var userIDs, tagIDs []primitive.ObjectID
+	for _, post := range posts {
+		userIDs = append(userIDs, post.UserID)
+		tagIDs = append(tagIDs, post.TagIDs...)
+	}
+
+	users, err := s.tagRepo.SelectUser(ctx, uniqueIDs(userIDs))
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	tags, err := s.tagRepo.Select(ctx, uniqueIDs(tagIDs))
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	return &ct.ListPostResponse{Posts: preparePostResponses(posts, users, tags)}, nil
+}
@@ -0,0 +1,112 @@
+package tag
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/database"
+	"golang-project/database/databasetest"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	postRepo "golang-project/internal/repository/post"
+	tagRepo "golang-project/internal/repository/tag"
+	"golang-project/static"
+)
+
+// pausingTagRepo pauses the first delete transaction between the check for posts and the delete
+type pausingTagRepo struct {
+	repo.Tag
+	once     sync.Once
+	checked  chan struct{}
+	labelled chan struct{}
+}
+
+func (r *pausingTagRepo) HasPosts(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	hasPosts, err := r.Tag.HasPosts(ctx, id)
+	r.once.Do(func() {
+		close(r.checked)
+		<-r.labelled
+	})
+
+	return hasPosts, err
+}
+
+func TestDeleteRefusesTagLabelledDuringTheCheck(t *testing.T) {
+	db := databasetest.Connect(t)
+	ctx := context.Background()
+	unitOfWork := database.NewUnitOfWork(db)
+	posts := postRepo.NewRepository(db)
+	tags := &pausingTagRepo{Tag: tagRepo.NewRepository(db), checked: make(chan struct{}), labelled: make(chan struct{})}
+
+	tag := &model.Tag{Name: "go"}
+	if err := tags.Insert(ctx, tag); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &service{tagRepo: tags, unitOfWork: unitOfWork}
+	deleted := make(chan error, 1)
+	go func() { deleted <- s.Delete(ctx, tag.ID) }()
+
+	// A post is labelled with the tag after the delete found no posts and before it deletes the tag
+	<-tags.checked
+	err := unitOfWork.Do(ctx, func(ctx context.Context) error {
+		post, err := posts.Insert(ctx, &model.Post{Title: "Generics", TagIDs: []primitive.ObjectID{tag.ID}})
+		if err != nil {
+			return err
+		}
+
+		return posts.AddPostTags(ctx, post.ID, post.TagIDs)
+	})
+	close(tags.labelled)
+	if err != nil {
+		t.Fatalf("labelling: %v", err)
+	}
+
+	if err = <-deleted; !errors.Is(err, static.ErrHasPosts) {
+		t.Fatalf("delete: err = %v, want %v", err, static.ErrHasPosts)
+	}
+	if _, err = tags.Read(ctx, tag.ID); err != nil {
+		t.Fatalf("tag in use was deleted: %v", err)
+	}
+}
+
+func TestLabellingRefusesDeletedTag(t *testing.T) {
+	db := databasetest.Connect(t)
+	ctx := context.Background()
+	unitOfWork := database.NewUnitOfWork(db)
+	posts := postRepo.NewRepository(db)
+	tags := tagRepo.NewRepository(db)
+
+	tag := &model.Tag{Name: "go"}
+	if err := tags.Insert(ctx, tag); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &service{tagRepo: tags, unitOfWork: unitOfWork}
+	if err := s.Delete(ctx, tag.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	var postID primitive.ObjectID
+	err := unitOfWork.Do(ctx, func(ctx context.Context) error {
+		post, err := posts.Insert(ctx, &model.Post{Title: "Generics", TagIDs: []primitive.ObjectID{tag.ID}})
+		if err != nil {
+			return err
+		}
+		postID = post.ID
+
+		return posts.AddPostTags(ctx, post.ID, post.TagIDs)
+	})
+	if !errors.Is(err, static.ErrTagNotFoundOrDeleted) {
+		t.Fatalf("err = %v, want %v", err, static.ErrTagNotFoundOrDeleted)
+	}
+
+	// The post is rolled back with its tag links
+	if _, err = posts.Read(ctx, postID); !errors.Is(err, static.ErrPostNotFound) {
+		t.Fatalf("post without its tags was kept: %v", err)
+	}
+}
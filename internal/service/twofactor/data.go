@@ -0,0 +1,121 @@
+package twofactor
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	ct "golang-project/internal/contract"
+	"golang-project/internal/model"
+	"golang-project/static"
+	"golang-project/util/encryption"
+)
+
+// recoveryEncoding is the lower case unpadded base32 the recovery codes are written in
+var recoveryEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// prepareStatusResponse transforms the data and returns the Two Factor Status Response
+func prepareStatusResponse(o *model.TwoFactor) *ct.TwoFactorStatusResponse {
+	data := &ct.TwoFactorStatusResponse{}
+	if !isEnabled(o) {
+		return data
+	}
+
+	data.Enabled = true
+	data.EnabledAt = o.EnabledAt.Format(time.RFC3339)
+	data.RecoveryCodesRemaining = len(o.RecoveryCodes)
+
+	return data
+}
+
+// isEnabled reports whether the two-factor authentication is enrolled and confirmed
+func isEnabled(o *model.TwoFactor) bool {
+	return o != nil && o.EnabledAt != nil
+}
+
+// isLocked reports whether the codes are locked after too many invalid codes
+func isLocked(o *model.TwoFactor) bool {
+	return o != nil && o.LockedUntil != nil && time.Now().Before(*o.LockedUntil)
+}
+
+// isAuthenticatorCode reports whether the code has the digits of an authenticator app code
+func isAuthenticatorCode(code string) bool {
+	if len(code) != static.TwoFactor.Digits {
+		return false
+	}
+
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// generateRecoveryCode returns a new random recovery code split in two halves by a dash for readability
+func generateRecoveryCode() (string, error) {
+	b := make([]byte, static.TwoFactor.RecoveryCodeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	code := recoveryEncoding.EncodeToString(b)[:static.TwoFactor.RecoveryCodeLength]
+	half := static.TwoFactor.RecoveryCodeLength / 2
+
+	return code[:half] + "-" + code[half:], nil
+}
+
+// normalizeRecoveryCode returns the recovery code without dashes and spaces in lower case, the form it is hashed in
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+// secretCipher returns the cipher of the TOTP secrets with the key of the server
+func secretCipher() (encryption.Cipher, error) {
+	c, err := encryption.NewAESGCM(viper.GetString(static.EnvTwoFactorEncryptionKey))
+	if err != nil {
+		return nil, static.ErrTwoFactorSecretSealing.Wrap(err)
+	}
+
+	return c, nil
+}
+
+// sealSecret returns the TOTP secret encrypted with the key of the server, the form it is stored in
+func sealSecret(secret string) (string, error) {
+	c, err := secretCipher()
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := c.Encrypt(secret)
+	if err != nil {
+		return "", static.ErrTwoFactorSecretSealing.Wrap(err)
+	}
+
+	return sealed, nil
+}
+
+// openSecret returns the plain TOTP secret of the two-factor authentication, a secret stored before
+// the encryption is plain until the server encrypts it on start
+func openSecret(o *model.TwoFactor) (string, error) {
+	if !encryption.IsEncrypted(o.Secret) {
+		return o.Secret, nil
+	}
+
+	c, err := secretCipher()
+	if err != nil {
+		return "", err
+	}
+
+	secret, err := c.Decrypt(o.Secret)
+	if err != nil {
+		return "", static.ErrTwoFactorSecretSealing.Wrap(err)
+	}
+
+	return secret, nil
+}
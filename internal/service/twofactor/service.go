@@ -0,0 +1,434 @@
+package twofactor
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/database"
+	"golang-project/internal/audit"
+	ct "golang-project/internal/contract"
+	"golang-project/internal/event"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	svc "golang-project/internal/service"
+	"golang-project/internal/totp"
+	"golang-project/internal/tracing"
+	"golang-project/static"
+	"golang-project/util/hashing"
+)
+
+// service represents the implementation of service.TwoFactor
+type service struct {
+	userRepo   repo.User
+	hash       hashing.Algorithm
+	unitOfWork database.UnitOfWork
+	publisher  event.Publisher
+	auditor    audit.Recorder
+}
+
+// NewService returns a new implementation of service.TwoFactor
+func NewService(userRepo repo.User, hash hashing.Algorithm, unitOfWork database.UnitOfWork, publisher event.Publisher, auditor audit.Recorder) svc.TwoFactor {
+	return &service{
+		userRepo:   userRepo,
+		hash:       hash,
+		unitOfWork: unitOfWork,
+		publisher:  publisher,
+		auditor:    auditor,
+	}
+}
+
+// Status returns whether the two-factor authentication of the user is enabled and the recovery codes left
+func (s *service) Status(ctx context.Context, userID primitive.ObjectID) (*ct.TwoFactorStatusResponse, error) {
+	ctx, span := tracing.Start(ctx, "twofactor.Status")
+	defer span.End()
+
+	user, err := s.userRepo.Read(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return prepareStatusResponse(user.TwoFactor), nil
+}
+
+// Enrol verifies the confirmation of the user and generates a new TOTP secret, stored encrypted. The two-factor
+// authentication is only enabled once a code of the secret is confirmed so starting over replaces the unconfirmed secret
+func (s *service) Enrol(ctx context.Context, userID primitive.ObjectID, r *ct.EnrolTwoFactorRequest) (*ct.TwoFactorEnrolmentResponse, error) {
+	ctx, span := tracing.Start(ctx, "twofactor.Enrol")
+	defer span.End()
+
+	if err := s.Reauthenticate(ctx, userID, &r.Reauthentication); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.Read(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if isEnabled(user.TwoFactor) {
+		return nil, static.ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := sealSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.update(ctx, user, map[string]interface{}{"two_factor": &model.TwoFactor{Secret: sealed}}); err != nil {
+		return nil, err
+	}
+
+	return &ct.TwoFactorEnrolmentResponse{
+		Secret:     secret,
+		OTPAuthURI: totp.URI(static.TwoFactor.Issuer, user.Email, secret),
+	}, nil
+}
+
+// Confirm enables the two-factor authentication with the first code of the enrolled secret and returns the recovery codes
+func (s *service) Confirm(ctx context.Context, userID primitive.ObjectID, r *ct.TwoFactorCodeRequest) (*ct.RecoveryCodesResponse, error) {
+	ctx, span := tracing.Start(ctx, "twofactor.Confirm")
+	defer span.End()
+
+	user, err := s.userRepo.Read(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if isEnabled(user.TwoFactor) {
+		return nil, static.ErrTwoFactorAlreadyEnabled
+	}
+	if user.TwoFactor == nil {
+		return nil, static.ErrTwoFactorNotEnrolled
+	}
+
+	secret, err := openSecret(user.TwoFactor)
+	if err != nil {
+		return nil, err
+	}
+
+	step, ok := totp.Validate(secret, r.Code, time.Now())
+	if !ok {
+		return nil, static.ErrTwoFactorCodeInvalid.WithField("code", "is invalid")
+	}
+
+	codes, hashes, err := s.generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.update(ctx, user, map[string]interface{}{
+		"two_factor.enabled_at":     time.Now(),
+		"two_factor.last_used_step": step,
+		"two_factor.recovery_codes": hashes,
+	}); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, static.AuditTwoFactorEnabled, user.ID)
+
+	return &ct.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// RenewRecoveryCodes replaces the recovery codes of the user after checking a code of the authenticator app
+func (s *service) RenewRecoveryCodes(ctx context.Context, userID primitive.ObjectID, r *ct.TwoFactorCodeRequest) (*ct.RecoveryCodesResponse, error) {
+	ctx, span := tracing.Start(ctx, "twofactor.RenewRecoveryCodes")
+	defer span.End()
+
+	user, err := s.userRepo.Read(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isEnabled(user.TwoFactor) {
+		return nil, static.ErrTwoFactorNotEnabled
+	}
+	if isLocked(user.TwoFactor) {
+		return nil, static.ErrTwoFactorLocked
+	}
+
+	err = s.useAuthenticatorCode(ctx, user, r.Code)
+	if errors.Is(err, static.ErrTwoFactorCodeInvalid) {
+		return nil, s.fail(ctx, user)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Using the code changed the version of the user
+	if user, err = s.userRepo.Read(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := s.generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.update(ctx, user, map[string]interface{}{"two_factor.recovery_codes": hashes}); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, static.AuditRecoveryCodesRenewed, user.ID)
+
+	return &ct.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// Disable verifies the confirmation of the user and removes the two-factor authentication with its recovery codes
+func (s *service) Disable(ctx context.Context, userID primitive.ObjectID, r *ct.DisableTwoFactorRequest) error {
+	ctx, span := tracing.Start(ctx, "twofactor.Disable")
+	defer span.End()
+
+	if err := s.Reauthenticate(ctx, userID, &r.Reauthentication); err != nil {
+		return err
+	}
+
+	// A code used for the confirmation changed the version of the user
+	user, err := s.userRepo.Read(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !isEnabled(user.TwoFactor) {
+		return static.ErrTwoFactorNotEnabled
+	}
+
+	if err = s.update(ctx, user, map[string]interface{}{"two_factor": nil}); err != nil {
+		return err
+	}
+
+	s.record(ctx, static.AuditTwoFactorDisabled, user.ID)
+
+	return nil
+}
+
+// EncryptSecrets encrypts the TOTP secrets stored in plain before the secrets were encrypted and returns how
+// many were encrypted, a secret replaced by an enrolment in the meantime is left alone
+func (s *service) EncryptSecrets(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "twofactor.EncryptSecrets")
+	defer span.End()
+
+	users, err := s.userRepo.SelectPlainTwoFactorSecrets(ctx)
+	if err != nil {
+		return 0, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	encrypted := 0
+	for _, user := range users {
+		sealed, err := sealSecret(user.TwoFactor.Secret)
+		if err != nil {
+			return encrypted, err
+		}
+
+		replaced, err := s.userRepo.ReplaceTwoFactorSecret(ctx, user.ID, user.TwoFactor.Secret, sealed)
+		if err != nil {
+			return encrypted, static.ErrDatabaseOperation.Wrap(err)
+		}
+		if replaced {
+			encrypted++
+		}
+	}
+
+	return encrypted, nil
+}
+
+// Reauthenticate checks the password of the user. Users without a password, who sign in with an OpenID Connect
+// provider, confirm with a code of the authenticator app or a recovery code, or else with a recent sign-in
+func (s *service) Reauthenticate(ctx context.Context, userID primitive.ObjectID, r *ct.Reauthentication) error {
+	ctx, span := tracing.Start(ctx, "twofactor.Reauthenticate")
+	defer span.End()
+
+	user, err := s.userRepo.Read(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.Password != "" {
+		if err = s.hash.Compare([]byte(user.Password), []byte(r.Password)); err != nil {
+			return static.ErrInvalidPassword.WithField("password", "is incorrect")
+		}
+
+		return nil
+	}
+
+	if r.Code != "" {
+		if !isEnabled(user.TwoFactor) {
+			return static.ErrTwoFactorNotEnabled
+		}
+
+		_, err = s.Verify(ctx, userID, r.Code)
+		return err
+	}
+
+	if time.Since(r.SignedInAt) > static.TwoFactor.RecentSignIn {
+		return static.ErrReauthenticationRequired
+	}
+
+	return nil
+}
+
+// Verify checks the code of the authenticator app, or else a recovery code, and uses it up so it cannot be replayed.
+// Too many invalid codes in a row lock the codes of the user for a while.
+func (s *service) Verify(ctx context.Context, userID primitive.ObjectID, code string) (bool, error) {
+	ctx, span := tracing.Start(ctx, "twofactor.Verify")
+	defer span.End()
+
+	user, err := s.userRepo.Read(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	if !isEnabled(user.TwoFactor) {
+		return false, static.ErrTwoFactorNotEnabled
+	}
+	if isLocked(user.TwoFactor) {
+		return false, static.ErrTwoFactorLocked
+	}
+
+	recoveryCode, err := s.verify(ctx, user, code)
+	if errors.Is(err, static.ErrTwoFactorCodeInvalid) {
+		return recoveryCode, s.fail(ctx, user)
+	}
+
+	return recoveryCode, err
+}
+
+// verify checks and uses up the code of the user, it returns whether the code is a recovery code
+func (s *service) verify(ctx context.Context, user *model.User, code string) (bool, error) {
+	if isAuthenticatorCode(code) {
+		return false, s.useAuthenticatorCode(ctx, user, code)
+	}
+
+	normalized := normalizeRecoveryCode(code)
+	for _, hashed := range user.TwoFactor.RecoveryCodes {
+		if s.hash.Compare([]byte(hashed), []byte(normalized)) != nil {
+			continue
+		}
+
+		// The code is only removed while it is left, a concurrent use of the same code fails
+		removed, err := s.use(ctx, user.ID, func(ctx context.Context) (bool, error) {
+			return s.userRepo.UseRecoveryCode(ctx, user.ID, hashed)
+		})
+		if err != nil {
+			return true, static.ErrDatabaseOperation.Wrap(err)
+		}
+		if !removed {
+			return true, static.ErrTwoFactorCodeInvalid
+		}
+
+		return true, nil
+	}
+
+	return false, static.ErrTwoFactorCodeInvalid
+}
+
+// fail counts the invalid code of the user, the code that locks the codes is refused with
+// static.ErrTwoFactorLocked and the lock is written to the audit log
+func (s *service) fail(ctx context.Context, user *model.User) error {
+	locked, err := s.userRepo.FailTwoFactorCode(ctx, user.ID, static.TwoFactor.MaxFailedCodes, time.Now().Add(static.TwoFactor.Lockout))
+	if err != nil {
+		return static.ErrDatabaseOperation.Wrap(err)
+	}
+	if locked {
+		s.record(ctx, static.AuditTwoFactorLocked, user.ID)
+		return static.ErrTwoFactorLocked
+	}
+
+	return static.ErrTwoFactorCodeInvalid
+}
+
+// useAuthenticatorCode checks the code of the authenticator app and records its time step,
+// a code of the same or an earlier step is refused so every code is only accepted once
+func (s *service) useAuthenticatorCode(ctx context.Context, user *model.User, code string) error {
+	secret, err := openSecret(user.TwoFactor)
+	if err != nil {
+		return err
+	}
+
+	step, ok := totp.Validate(secret, code, time.Now())
+	if !ok || step <= user.TwoFactor.LastUsedStep {
+		return static.ErrTwoFactorCodeInvalid
+	}
+
+	// The step is only recorded over an earlier one, a concurrent use of the same code fails
+	recorded, err := s.use(ctx, user.ID, func(ctx context.Context) (bool, error) {
+		return s.userRepo.UseTwoFactorStep(ctx, user.ID, step)
+	})
+	if err != nil {
+		return static.ErrDatabaseOperation.Wrap(err)
+	}
+	if !recorded {
+		return static.ErrTwoFactorCodeInvalid
+	}
+
+	return nil
+}
+
+// update updates the two-factor authentication of the user together with the profile change event
+func (s *service) update(ctx context.Context, user *model.User, updates map[string]interface{}) error {
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.userRepo.Update(ctx, user, updates); err != nil {
+			return err
+		}
+
+		return s.publisher.Publish(ctx, event.ProfileChanged{UserID: user.ID})
+	})
+	if errors.Is(err, static.ErrVersionConflict) {
+		return static.ErrConcurrentUpdate
+	}
+	if err != nil {
+		return static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	return nil
+}
+
+// use runs the conditional update using up a code together with the profile change event, it returns
+// whether the code was used up
+func (s *service) use(ctx context.Context, userID primitive.ObjectID, fn func(context.Context) (bool, error)) (bool, error) {
+	var used bool
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if used, err = fn(ctx); err != nil || !used {
+			return err
+		}
+
+		return s.publisher.Publish(ctx, event.ProfileChanged{UserID: userID})
+	})
+
+	return used, err
+}
+
+// generateRecoveryCodes returns new recovery codes with their hashes
+func (s *service) generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, static.TwoFactor.RecoveryCodes)
+	hashes := make([]string, 0, static.TwoFactor.RecoveryCodes)
+	for i := 0; i < static.TwoFactor.RecoveryCodes; i++ {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		hashed, err := s.hash.Generate([]byte(normalizeRecoveryCode(code)))
+		if err != nil {
+			return nil, nil, static.ErrPasswordHashingFailed.Wrap(err)
+		}
+
+		codes = append(codes, code)
+		hashes = append(hashes, string(hashed))
+	}
+
+	return codes, hashes, nil
+}
+
+// record writes the two-factor authentication change of the user to the audit log
+func (s *service) record(ctx context.Context, action static.AuditAction, userID primitive.ObjectID) {
+	s.auditor.Record(ctx, &audit.Entry{ActorID: userID, Action: action, TargetType: static.AuditTargetUser, TargetID: userID})
+}
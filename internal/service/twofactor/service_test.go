@@ -0,0 +1,417 @@
+package twofactor
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	ct "golang-project/internal/contract"
+	"golang-project/internal/event"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	"golang-project/internal/service/servicetest"
+	"golang-project/internal/totp"
+	"golang-project/static"
+	"golang-project/util/encryption"
+	"golang-project/util/hashing"
+)
+
+// userRepo keeps a single user in memory with the conditional updates of the user repository,
+// a stale user is read instead when set, as by a request racing with another
+type userRepo struct {
+	repo.User
+	user  *model.User
+	stale *model.User
+}
+
+func (r *userRepo) Read(_ context.Context, id primitive.ObjectID) (*model.User, error) {
+	if r.user.ID != id {
+		return nil, static.ErrUserNotFound
+	}
+	if r.stale != nil {
+		return copyUser(r.stale), nil
+	}
+
+	return copyUser(r.user), nil
+}
+
+func (r *userRepo) UseTwoFactorStep(_ context.Context, id primitive.ObjectID, step int64) (bool, error) {
+	if r.user.ID != id || r.user.TwoFactor.LastUsedStep >= step {
+		return false, nil
+	}
+
+	r.user.TwoFactor.LastUsedStep = step
+	r.user.TwoFactor.FailedCodes = 0
+	r.user.Version++
+
+	return true, nil
+}
+
+func (r *userRepo) UseRecoveryCode(_ context.Context, id primitive.ObjectID, hashed string) (bool, error) {
+	i := slices.Index(r.user.TwoFactor.RecoveryCodes, hashed)
+	if r.user.ID != id || i < 0 {
+		return false, nil
+	}
+
+	r.user.TwoFactor.RecoveryCodes = slices.Delete(r.user.TwoFactor.RecoveryCodes, i, i+1)
+	r.user.TwoFactor.FailedCodes = 0
+	r.user.Version++
+
+	return true, nil
+}
+
+func (r *userRepo) FailTwoFactorCode(_ context.Context, id primitive.ObjectID, maxFailed int, lockedUntil time.Time) (bool, error) {
+	if r.user.ID != id || r.user.TwoFactor == nil {
+		return false, nil
+	}
+
+	r.user.TwoFactor.FailedCodes++
+	if r.user.TwoFactor.FailedCodes < maxFailed {
+		return false, nil
+	}
+
+	r.user.TwoFactor.FailedCodes = 0
+	r.user.TwoFactor.LockedUntil = &lockedUntil
+
+	return true, nil
+}
+
+func (r *userRepo) SelectPlainTwoFactorSecrets(context.Context) ([]*model.User, error) {
+	if r.user.TwoFactor == nil || encryption.IsEncrypted(r.user.TwoFactor.Secret) {
+		return nil, nil
+	}
+
+	return []*model.User{copyUser(r.user)}, nil
+}
+
+func (r *userRepo) ReplaceTwoFactorSecret(_ context.Context, id primitive.ObjectID, secret, replacement string) (bool, error) {
+	if r.user.ID != id || r.user.TwoFactor == nil || r.user.TwoFactor.Secret != secret {
+		return false, nil
+	}
+
+	r.user.TwoFactor.Secret = replacement
+	return true, nil
+}
+
+func (r *userRepo) Update(_ context.Context, o *model.User, updates map[string]interface{}) (*model.User, error) {
+	if o.ID != r.user.ID || o.Version != r.user.Version {
+		return nil, static.ErrVersionConflict
+	}
+
+	for field, value := range updates {
+		switch field {
+		case "two_factor.enabled_at":
+			enabledAt := value.(time.Time)
+			r.user.TwoFactor.EnabledAt = &enabledAt
+		case "two_factor.last_used_step":
+			r.user.TwoFactor.LastUsedStep = value.(int64)
+		case "two_factor.recovery_codes":
+			r.user.TwoFactor.RecoveryCodes = value.([]string)
+		case "two_factor":
+			r.user.TwoFactor, _ = value.(*model.TwoFactor)
+		default:
+			return nil, errors.New("unexpected update of " + field)
+		}
+	}
+	r.user.Version++
+
+	return copyUser(r.user), nil
+}
+
+// copyUser returns a copy of the user so the service cannot change the stored user in place
+func copyUser(o *model.User) *model.User {
+	user := *o
+	if o.TwoFactor != nil {
+		twoFactor := *o.TwoFactor
+		twoFactor.RecoveryCodes = append([]string{}, o.TwoFactor.RecoveryCodes...)
+		user.TwoFactor = &twoFactor
+	}
+
+	return &user
+}
+
+// newTestService returns the service with a user enrolled with the secret and the recovery code
+func newTestService(t *testing.T, secret, recoveryCode string) (*service, *userRepo) {
+	t.Helper()
+
+	hash := hashing.NewBcrypt()
+	hashed, err := hash.Generate([]byte(normalizeRecoveryCode(recoveryCode)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	enabledAt := time.Now()
+	users := &userRepo{user: &model.User{
+		BaseModel: model.BaseModel{ID: primitive.NewObjectID()},
+		TwoFactor: &model.TwoFactor{
+			Secret:        secret,
+			EnabledAt:     &enabledAt,
+			RecoveryCodes: []string{string(hashed)},
+		},
+	}}
+
+	return &service{userRepo: users, hash: hash, unitOfWork: servicetest.UnitOfWork{}, publisher: &servicetest.Publisher{},
+		auditor: &servicetest.Recorder{}}, users
+}
+
+func TestVerifyRefusesReusedAuthenticatorCode(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, users := newTestService(t, secret, "abcde-fghij")
+	ctx := context.Background()
+
+	current := totp.Step(time.Now())
+	code, err := totp.Code(secret, current)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = s.Verify(ctx, users.user.ID, code); err != nil {
+		t.Fatalf("first use: %v", err)
+	}
+	if users.user.TwoFactor.LastUsedStep < current {
+		t.Fatalf("last used step = %d, want at least %d", users.user.TwoFactor.LastUsedStep, current)
+	}
+
+	if _, err = s.Verify(ctx, users.user.ID, code); !errors.Is(err, static.ErrTwoFactorCodeInvalid) {
+		t.Fatalf("reused code: err = %v, want %v", err, static.ErrTwoFactorCodeInvalid)
+	}
+
+	// A code of an earlier step within the skew is refused once a later step was used
+	previous, err := totp.Code(secret, users.user.TwoFactor.LastUsedStep-1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.Verify(ctx, users.user.ID, previous); !errors.Is(err, static.ErrTwoFactorCodeInvalid) {
+		t.Fatalf("earlier code: err = %v, want %v", err, static.ErrTwoFactorCodeInvalid)
+	}
+}
+
+func TestVerifyUsesRecoveryCodeOnce(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, users := newTestService(t, secret, "abcde-fghij")
+	ctx := context.Background()
+
+	recoveryCode, err := s.Verify(ctx, users.user.ID, "ABCDE FGHIJ")
+	if err != nil {
+		t.Fatalf("first use: %v", err)
+	}
+	if !recoveryCode {
+		t.Fatal("code was not verified as a recovery code")
+	}
+	if len(users.user.TwoFactor.RecoveryCodes) != 0 {
+		t.Fatalf("recovery codes left = %d, want 0", len(users.user.TwoFactor.RecoveryCodes))
+	}
+
+	if _, err = s.Verify(ctx, users.user.ID, "abcde-fghij"); !errors.Is(err, static.ErrTwoFactorCodeInvalid) {
+		t.Fatalf("reused recovery code: err = %v, want %v", err, static.ErrTwoFactorCodeInvalid)
+	}
+
+	// Only the use that changed the version of the user drops the cached profile
+	events := s.publisher.(*servicetest.Publisher).Events
+	if len(events) != 1 || events[0] != (event.ProfileChanged{UserID: users.user.ID}) {
+		t.Errorf("published events = %v, want one profile change of the user", events)
+	}
+}
+
+func TestVerifyLocksTheCodesAfterTooManyInvalidCodes(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, users := newTestService(t, secret, "abcde-fghij")
+	ctx := context.Background()
+
+	current := totp.Step(time.Now())
+	invalid, err := totp.Code(secret, current-100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A valid code starts the count over
+	for i := 1; i < static.TwoFactor.MaxFailedCodes; i++ {
+		if _, err = s.Verify(ctx, users.user.ID, invalid); !errors.Is(err, static.ErrTwoFactorCodeInvalid) {
+			t.Fatalf("invalid code %d: err = %v, want %v", i, err, static.ErrTwoFactorCodeInvalid)
+		}
+	}
+	code, err := totp.Code(secret, current)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.Verify(ctx, users.user.ID, code); err != nil {
+		t.Fatalf("valid code: %v", err)
+	}
+
+	for i := 1; i < static.TwoFactor.MaxFailedCodes; i++ {
+		if _, err = s.Verify(ctx, users.user.ID, invalid); !errors.Is(err, static.ErrTwoFactorCodeInvalid) {
+			t.Fatalf("invalid code %d after the valid one: err = %v, want %v", i, err, static.ErrTwoFactorCodeInvalid)
+		}
+	}
+	if _, err = s.Verify(ctx, users.user.ID, invalid); !errors.Is(err, static.ErrTwoFactorLocked) {
+		t.Fatalf("invalid code reaching the maximum: err = %v, want %v", err, static.ErrTwoFactorLocked)
+	}
+
+	// The recovery code is refused too while the codes are locked
+	if _, err = s.Verify(ctx, users.user.ID, "abcde-fghij"); !errors.Is(err, static.ErrTwoFactorLocked) {
+		t.Fatalf("recovery code while locked: err = %v, want %v", err, static.ErrTwoFactorLocked)
+	}
+	if len(users.user.TwoFactor.RecoveryCodes) != 1 {
+		t.Errorf("recovery codes left = %d, want 1", len(users.user.TwoFactor.RecoveryCodes))
+	}
+}
+
+func TestVerifyRefusesCodesUsedConcurrently(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, users := newTestService(t, secret, "abcde-fghij")
+	ctx := context.Background()
+
+	code, err := totp.Code(secret, totp.Step(time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The second request read the user before the first one used the codes
+	snapshot := copyUser(users.user)
+	for _, c := range []string{code, "abcde-fghij"} {
+		users.stale = nil
+		if _, err = s.Verify(ctx, users.user.ID, c); err != nil {
+			t.Fatalf("first use of %s: %v", c, err)
+		}
+
+		users.stale = snapshot
+		if _, err = s.Verify(ctx, users.user.ID, c); !errors.Is(err, static.ErrTwoFactorCodeInvalid) {
+			t.Errorf("concurrent use of %s: err = %v, want %v", c, err, static.ErrTwoFactorCodeInvalid)
+		}
+	}
+}
+
+func TestDisableConfirmsUsersWithoutPassword(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	for name, tc := range map[string]struct {
+		password string
+		confirm  ct.Reauthentication
+		want     error
+	}{
+		"old sign-in":           {"", ct.Reauthentication{SignedInAt: time.Now().Add(-time.Hour)}, static.ErrReauthenticationRequired},
+		"recent sign-in":        {"", ct.Reauthentication{SignedInAt: time.Now()}, nil},
+		"recovery code":         {"", ct.Reauthentication{Code: "abcde-fghij", SignedInAt: time.Now().Add(-time.Hour)}, nil},
+		"wrong recovery code":   {"", ct.Reauthentication{Code: "fghij-abcde"}, static.ErrTwoFactorCodeInvalid},
+		"password user by code": {"hashed", ct.Reauthentication{Code: "abcde-fghij", SignedInAt: time.Now()}, static.ErrInvalidPassword},
+	} {
+		// The users of an OpenID Connect provider have no password
+		s, users := newTestService(t, secret, "abcde-fghij")
+		users.user.Password = tc.password
+
+		err = s.Disable(ctx, users.user.ID, &ct.DisableTwoFactorRequest{Reauthentication: tc.confirm})
+		if !errors.Is(err, tc.want) {
+			t.Errorf("%s: err = %v, want %v", name, err, tc.want)
+			continue
+		}
+		if disabled := users.user.TwoFactor == nil; disabled != (tc.want == nil) {
+			t.Errorf("%s: two-factor disabled = %v, want %v", name, disabled, tc.want == nil)
+		}
+	}
+}
+
+// setEncryptionKey sets a random key of the TOTP secrets for the test
+func setEncryptionKey(t *testing.T) {
+	t.Helper()
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatal(err)
+	}
+	viper.Set(static.EnvTwoFactorEncryptionKey, base64.StdEncoding.EncodeToString(key))
+	t.Cleanup(func() { viper.Set(static.EnvTwoFactorEncryptionKey, nil) })
+}
+
+func TestEnrolConfirmsTheUserAndEncryptsTheSecret(t *testing.T) {
+	setEncryptionKey(t)
+	ctx := context.Background()
+
+	s, users := newTestService(t, "", "abcde-fghij")
+	users.user.TwoFactor = nil
+
+	_, err := s.Enrol(ctx, users.user.ID, &ct.EnrolTwoFactorRequest{Reauthentication: ct.Reauthentication{SignedInAt: time.Now().Add(-time.Hour)}})
+	if !errors.Is(err, static.ErrReauthenticationRequired) {
+		t.Fatalf("enrolment signed in long ago: err = %v, want %v", err, static.ErrReauthenticationRequired)
+	}
+	if users.user.TwoFactor != nil {
+		t.Fatal("a secret was enrolled without the confirmation of the user")
+	}
+
+	response, err := s.Enrol(ctx, users.user.ID, &ct.EnrolTwoFactorRequest{Reauthentication: ct.Reauthentication{SignedInAt: time.Now()}})
+	if err != nil {
+		t.Fatalf("Enrol() error = %v", err)
+	}
+	if stored := users.user.TwoFactor.Secret; !encryption.IsEncrypted(stored) || stored == response.Secret {
+		t.Fatalf("stored secret = %q, want the secret encrypted", stored)
+	}
+
+	code, err := totp.Code(response.Secret, totp.Step(time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.Confirm(ctx, users.user.ID, &ct.TwoFactorCodeRequest{Code: code}); err != nil {
+		t.Fatalf("Confirm() with a code of the returned secret: %v", err)
+	}
+
+	// The secret cannot be read with another key
+	setEncryptionKey(t)
+	next, err := totp.Code(response.Secret, totp.Step(time.Now())+1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.Verify(ctx, users.user.ID, next); !errors.Is(err, static.ErrTwoFactorSecretSealing) {
+		t.Errorf("code with another key: err = %v, want %v", err, static.ErrTwoFactorSecretSealing)
+	}
+}
+
+func TestEncryptSecretsEncryptsThePlainSecrets(t *testing.T) {
+	setEncryptionKey(t)
+	ctx := context.Background()
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, users := newTestService(t, secret, "abcde-fghij")
+
+	for _, want := range []int{1, 0} {
+		encrypted, err := s.EncryptSecrets(ctx)
+		if err != nil || encrypted != want {
+			t.Fatalf("EncryptSecrets() = %d, %v, want %d", encrypted, err, want)
+		}
+	}
+	if !encryption.IsEncrypted(users.user.TwoFactor.Secret) {
+		t.Fatalf("stored secret = %q, want the secret encrypted", users.user.TwoFactor.Secret)
+	}
+
+	code, err := totp.Code(secret, totp.Step(time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.Verify(ctx, users.user.ID, code); err != nil {
+		t.Errorf("code of the encrypted secret: %v", err)
+	}
+}
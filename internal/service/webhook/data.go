@@ -0,0 +1,194 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/viper"
+
+	ct "golang-project/internal/contract"
+	"golang-project/internal/model"
+	"golang-project/static"
+)
+
+// prepareWebhookResponse transforms the data and returns the Webhook Response without its secret
+func prepareWebhookResponse(o *model.Webhook) *ct.WebhookResponse {
+	data := &ct.WebhookResponse{
+		ID:       o.ID,
+		URL:      o.URL,
+		Events:   o.Events,
+		AllUsers: o.AllUsers,
+	}
+
+	if o.CreatedAt != nil {
+		data.CreatedAt = o.CreatedAt.Format(time.RFC3339)
+	}
+
+	return data
+}
+
+// prepareDeliveryResponse transforms the data and returns the Webhook Delivery Response
+func prepareDeliveryResponse(o *model.WebhookDelivery) *ct.WebhookDeliveryResponse {
+	data := &ct.WebhookDeliveryResponse{
+		ID:             o.ID,
+		Event:          o.Event,
+		Status:         o.Status,
+		Attempts:       o.Attempts,
+		LastStatusCode: o.LastStatusCode,
+		LastError:      o.LastError,
+		RedeliveryOf:   o.RedeliveryOf,
+	}
+
+	if o.NextAttemptAt != nil && o.Status == static.WebhookDeliveryPending {
+		data.NextAttemptAt = o.NextAttemptAt.Format(time.RFC3339)
+	}
+
+	if o.DeliveredAt != nil {
+		data.DeliveredAt = o.DeliveredAt.Format(time.RFC3339)
+	}
+
+	if o.CreatedAt != nil {
+		data.CreatedAt = o.CreatedAt.Format(time.RFC3339)
+	}
+
+	return data
+}
+
+// uniqueEvents returns the events without duplicates in their order
+func uniqueEvents(events []static.WebhookEvent) []static.WebhookEvent {
+	seen := make(map[static.WebhookEvent]bool, len(events))
+	result := make([]static.WebhookEvent, 0, len(events))
+	for _, event := range events {
+		if !seen[event] {
+			seen[event] = true
+			result = append(result, event)
+		}
+	}
+
+	return result
+}
+
+// truncate limits the delivery error recorded in the log
+func truncate(s string) string {
+	runes := []rune(s)
+	if len(runes) <= static.Webhook.MaxErrorLength {
+		return s
+	}
+
+	return string(runes[:static.Webhook.MaxErrorLength])
+}
+
+// errAddressNotAllowed is returned by the dialer for the addresses the deliveries must not reach
+var errAddressNotAllowed = errors.New("address not allowed")
+
+// allowInsecure returns whether plain http and private addresses are allowed, for local development only
+func allowInsecure() bool {
+	return viper.GetBool(static.EnvWebhookAllowInsecure)
+}
+
+// checkURL refuses the webhook URLs that are not https or resolve to a loopback, link-local,
+// private or unspecified address
+func checkURL(ctx context.Context, rawURL string) error {
+	if allowInsecure() {
+		return nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" {
+		return static.ErrWebhookURLNotAllowed
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return static.ErrWebhookURLNotAllowed
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		if !isPublicIP(ip) {
+			return static.ErrWebhookURLNotAllowed
+		}
+		return nil
+	}
+
+	addresses, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil || len(addresses) == 0 {
+		return static.ErrWebhookURLNotAllowed
+	}
+	for _, address := range addresses {
+		if !isPublicIP(address.IP) {
+			return static.ErrWebhookURLNotAllowed
+		}
+	}
+
+	return nil
+}
+
+// dialControl refuses the connections to the addresses that are not public, it runs after the
+// name resolution so a host cannot be rebound to an internal address after its registration
+func dialControl(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return errAddressNotAllowed
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !isPublicIP(ip) {
+		return errAddressNotAllowed
+	}
+
+	return nil
+}
+
+// nonPublicNetworks lists the special-purpose ranges the net.IP methods do not cover, the shared address space
+// of carrier-grade NAT (RFC 6598) and the IETF protocol assignments (RFC 6890)
+var nonPublicNetworks = []*net.IPNet{
+	mustParseCIDR("100.64.0.0/10"),
+	mustParseCIDR("192.0.0.0/24"),
+}
+
+// isPublicIP returns whether the address is neither loopback, link-local, private, shared, reserved for
+// protocol assignments, unspecified nor multicast
+func isPublicIP(ip net.IP) bool {
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+
+	return !ip.IsLoopback() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsPrivate() &&
+		!ip.IsUnspecified()
+}
+
+// mustParseCIDR returns the network of the CIDR notation and panics when it is invalid
+func mustParseCIDR(cidr string) *net.IPNet {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+
+	return network
+}
+
+// deliveryError returns the error recorded for a failed request, the transport errors are replaced
+// by a generic message so they do not reveal the internal network to the webhook owner
+func deliveryError(err error) string {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, errAddressNotAllowed):
+		return "endpoint address is not allowed"
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return "endpoint did not respond in time"
+	default:
+		return "endpoint could not be reached"
+	}
+}
@@ -0,0 +1,489 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/internal/audit"
+	ct "golang-project/internal/contract"
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	svc "golang-project/internal/service"
+	"golang-project/internal/tracing"
+	"golang-project/static"
+	"golang-project/util/pagination"
+)
+
+// Delivery request headers
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// service represents the implementation of service.Webhook
+type service struct {
+	userRepo    repo.User
+	webhookRepo repo.Webhook
+	auditor     audit.Recorder
+	client      *http.Client
+	wake        chan struct{}
+}
+
+// NewService returns a new implementation of service.Webhook
+func NewService(userRepo repo.User, webhookRepo repo.Webhook, auditor audit.Recorder) svc.Webhook {
+	// Deliveries connect directly, the dialer refuses the internal addresses unless allowed for development
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	if !allowInsecure() {
+		dialer := &net.Dialer{Timeout: static.Webhook.Timeout, KeepAlive: 30 * time.Second, Control: dialControl}
+		transport.DialContext = dialer.DialContext
+	}
+
+	return &service{
+		userRepo:    userRepo,
+		webhookRepo: webhookRepo,
+		auditor:     auditor,
+		client: &http.Client{
+			Timeout:   static.Webhook.Timeout,
+			Transport: transport,
+			// Redirects are not followed so an endpoint cannot bounce the deliveries to another host
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+		wake: make(chan struct{}, 1),
+	}
+}
+
+// Create registers the webhook endpoint of the user and returns its signing secret once
+func (s *service) Create(ctx context.Context, userID primitive.ObjectID, r *ct.CreateWebhookRequest) (*ct.WebhookResponse, error) {
+	ctx, span := tracing.Start(ctx, "webhook.Create")
+	defer span.End()
+
+	if r.AllUsers {
+		user, err := s.userRepo.Read(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if user.Role != static.RoleAdmin {
+			return nil, static.ErrWebhookAdminRequired
+		}
+	}
+
+	if err := checkURL(ctx, r.URL); err != nil {
+		return nil, err
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	webhook, err := s.webhookRepo.Insert(ctx, &model.Webhook{
+		UserID:   userID,
+		URL:      r.URL,
+		Events:   uniqueEvents(r.Events),
+		AllUsers: r.AllUsers,
+		Secret:   secret,
+	})
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	s.auditor.Record(ctx, &audit.Entry{
+		ActorID:    userID,
+		Action:     static.AuditWebhookCreated,
+		TargetType: static.AuditTargetWebhook,
+		TargetID:   webhook.ID,
+		Metadata:   map[string]string{"url": webhook.URL, "all_users": strconv.FormatBool(webhook.AllUsers)},
+	})
+
+	response := prepareWebhookResponse(webhook)
+	response.Secret = webhook.Secret
+
+	return response, nil
+}
+
+// List returns the webhooks of the user, the latest first
+func (s *service) List(ctx context.Context, userID primitive.ObjectID) (*ct.ListWebhookResponse, error) {
+	ctx, span := tracing.Start(ctx, "webhook.List")
+	defer span.End()
+
+	webhooks, err := s.webhookRepo.SelectByUser(ctx, userID)
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	responses := make([]*ct.WebhookResponse, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		responses = append(responses, prepareWebhookResponse(webhook))
+	}
+
+	return &ct.ListWebhookResponse{Webhooks: responses}, nil
+}
+
+// Delete removes the webhook of the user with its delivery log
+func (s *service) Delete(ctx context.Context, userID primitive.ObjectID, r *ct.WebhookRequest) error {
+	ctx, span := tracing.Start(ctx, "webhook.Delete")
+	defer span.End()
+
+	webhook, err := s.readOwnWebhook(ctx, userID, r.ID)
+	if err != nil {
+		return err
+	}
+
+	if err = s.webhookRepo.Delete(ctx, webhook.ID); err != nil {
+		return static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	s.auditor.Record(ctx, &audit.Entry{
+		ActorID:    userID,
+		Action:     static.AuditWebhookDeleted,
+		TargetType: static.AuditTargetWebhook,
+		TargetID:   webhook.ID,
+		Metadata:   map[string]string{"url": webhook.URL},
+	})
+
+	return nil
+}
+
+// ListDeliveries returns the page of the delivery log of the webhook of the user, the latest first
+func (s *service) ListDeliveries(ctx context.Context, userID primitive.ObjectID, r *ct.ListWebhookDeliveryRequest) (*ct.ListWebhookDeliveryResponse, error) {
+	ctx, span := tracing.Start(ctx, "webhook.ListDeliveries")
+	defer span.End()
+
+	webhook, err := s.readOwnWebhook(ctx, userID, r.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Page == 0 {
+		r.Page = static.Pagination.DefaultPage
+	}
+	if r.PageSize == 0 {
+		r.PageSize = static.Pagination.DefaultPageSize
+	}
+
+	deliveries, total, err := s.webhookRepo.SelectDeliveries(ctx, webhook.ID, pagination.CalculateOffset(r.Page, r.PageSize), r.PageSize)
+	if err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+
+	responses := make([]*ct.WebhookDeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		responses = append(responses, prepareDeliveryResponse(delivery))
+	}
+
+	return &ct.ListWebhookDeliveryResponse{
+		Deliveries: responses,
+		Paging: ct.Paging{
+			Page:     r.Page,
+			PageSize: r.PageSize,
+			Total:    int(total),
+		},
+	}, nil
+}
+
+// Redeliver queues a new delivery of the payload of a past delivery of the webhook of the user
+func (s *service) Redeliver(ctx context.Context, userID primitive.ObjectID, r *ct.RedeliverWebhookRequest) (*ct.WebhookDeliveryResponse, error) {
+	ctx, span := tracing.Start(ctx, "webhook.Redeliver")
+	defer span.End()
+
+	webhook, err := s.readOwnWebhook(ctx, userID, r.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := s.webhookRepo.ReadDelivery(ctx, webhook.ID, r.DeliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	delivery := &model.WebhookDelivery{
+		WebhookID:     webhook.ID,
+		UserID:        webhook.UserID,
+		Event:         original.Event,
+		Payload:       original.Payload,
+		Status:        static.WebhookDeliveryPending,
+		NextAttemptAt: &now,
+		RedeliveryOf:  &original.ID,
+	}
+
+	if err = s.webhookRepo.InsertDeliveries(ctx, []*model.WebhookDelivery{delivery}); err != nil {
+		return nil, static.ErrDatabaseOperation.Wrap(err)
+	}
+	s.wakeUp()
+
+	return prepareDeliveryResponse(delivery), nil
+}
+
+// Dispatch queues a delivery of the event to every subscribed webhook, a dispatch retried by the relay
+// queues the same deliveries so the webhooks already queued are skipped
+func (s *service) Dispatch(ctx context.Context, eventID primitive.ObjectID, event static.WebhookEvent, ownerID primitive.ObjectID, data interface{}) error {
+	ctx, span := tracing.Start(ctx, "webhook.Dispatch")
+	defer span.End()
+
+	webhooks, err := s.webhookRepo.SelectSubscribers(ctx, event, ownerID)
+	if err != nil {
+		return err
+	}
+	if webhooks, err = s.allowedSubscribers(ctx, webhooks, ownerID); err != nil || len(webhooks) == 0 {
+		return err
+	}
+
+	now := time.Now()
+	deliveries := make([]*model.WebhookDelivery, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		delivery := &model.WebhookDelivery{
+			BaseModel:     model.BaseModel{ID: deliveryID(eventID, webhook.ID)},
+			WebhookID:     webhook.ID,
+			UserID:        webhook.UserID,
+			Event:         event,
+			Status:        static.WebhookDeliveryPending,
+			NextAttemptAt: &now,
+		}
+
+		// The payload ID is the delivery ID, derived from the event, so the receivers can drop the events
+		// they already processed even when the relay dispatched the event again
+		delivery.Payload, err = json.Marshal(&ct.WebhookPayload{
+			ID:        delivery.ID,
+			Event:     event,
+			CreatedAt: now.Format(time.RFC3339),
+			Data:      data,
+		})
+		if err != nil {
+			return err
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err = s.webhookRepo.InsertDeliveries(ctx, deliveries); err != nil {
+		return err
+	}
+	s.wakeUp()
+
+	return nil
+}
+
+// allowedSubscribers drops the webhooks following the events of all users whose owner is no longer
+// an active admin, the role and the account may have changed since the webhook was registered
+func (s *service) allowedSubscribers(ctx context.Context, webhooks []*model.Webhook, ownerID primitive.ObjectID) ([]*model.Webhook, error) {
+	var userIDs []primitive.ObjectID
+	for _, webhook := range webhooks {
+		if webhook.UserID != ownerID {
+			userIDs = append(userIDs, webhook.UserID)
+		}
+	}
+	if len(userIDs) == 0 {
+		return webhooks, nil
+	}
+
+	users, err := s.userRepo.Select(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	admins := make(map[primitive.ObjectID]bool, len(users))
+	for _, user := range users {
+		admins[user.ID] = user.Role == static.RoleAdmin && user.DisabledAt == nil
+	}
+
+	allowed := webhooks[:0]
+	for _, webhook := range webhooks {
+		if webhook.UserID == ownerID || admins[webhook.UserID] {
+			allowed = append(allowed, webhook)
+		}
+	}
+
+	return allowed, nil
+}
+
+// deliveryID returns the ID of the delivery of the event to the webhook, it keeps the timestamp of the event ID
+// and takes the rest from the hash of both IDs so every dispatch of the event gives the same ID
+func deliveryID(eventID, webhookID primitive.ObjectID) primitive.ObjectID {
+	hash := sha256.Sum256(append(eventID[:], webhookID[:]...))
+
+	var id primitive.ObjectID
+	copy(id[:4], eventID[:4])
+	copy(id[4:], hash[:8])
+	return id
+}
+
+// Work sends the due deliveries until ctx is cancelled
+func (s *service) Work(ctx context.Context) {
+	ticker := time.NewTicker(static.Webhook.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.processDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+// processDue sends the due deliveries one at a time, deliveries left delivering by a stopped worker are retried
+func (s *service) processDue(ctx context.Context) {
+	err := s.webhookRepo.RequeueStaleDeliveries(ctx, time.Now().Add(-static.Webhook.StaleAfter))
+	if err != nil {
+		log.Println("webhook requeue error:", err)
+	}
+
+	for ctx.Err() == nil {
+		delivery, err := s.webhookRepo.ClaimDueDelivery(ctx, time.Now())
+		if errors.Is(err, static.ErrWebhookDeliveryNotFound) {
+			return
+		}
+		if err != nil {
+			log.Println("webhook claim error:", err)
+			return
+		}
+
+		s.deliver(ctx, delivery)
+	}
+}
+
+// deliver posts the signed payload to the webhook endpoint and records the attempt,
+// failed attempts are retried with an exponential backoff until the attempts run out
+func (s *service) deliver(ctx context.Context, delivery *model.WebhookDelivery) {
+	ctx, span := tracing.Start(ctx, "webhook.Deliver")
+	defer span.End()
+
+	webhook, err := s.webhookRepo.Read(ctx, delivery.WebhookID)
+	if err != nil {
+		// The webhook was deleted after the delivery was claimed, its deliveries are gone too
+		if !errors.Is(err, static.ErrWebhookNotFound) {
+			log.Println("webhook read error:", delivery.ID.Hex(), err)
+		}
+		return
+	}
+
+	delivery.Attempts++
+	updates := map[string]interface{}{"attempts": delivery.Attempts}
+
+	statusCode, err := s.post(ctx, webhook, delivery)
+	if statusCode != 0 {
+		updates["last_status_code"] = statusCode
+	}
+
+	now := time.Now()
+	switch {
+	case err == nil:
+		updates["status"] = static.WebhookDeliveryDelivered
+		updates["delivered_at"] = now
+		updates["last_error"] = ""
+	case delivery.Attempts >= static.Webhook.MaxAttempts:
+		span.RecordError(err)
+		updates["status"] = static.WebhookDeliveryFailed
+		updates["last_error"] = truncate(err.Error())
+	default:
+		span.RecordError(err)
+		updates["status"] = static.WebhookDeliveryPending
+		updates["next_attempt_at"] = now.Add(backoff(delivery.Attempts))
+		updates["last_error"] = truncate(err.Error())
+	}
+
+	if err = s.webhookRepo.UpdateDelivery(ctx, delivery, updates); err != nil {
+		log.Println("webhook delivery update error:", delivery.ID.Hex(), err)
+	}
+}
+
+// post sends the delivery and returns the response status code, non 2xx responses are errors
+func (s *service) post(ctx context.Context, webhook *model.Webhook, delivery *model.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "golang-project-webhook")
+	req.Header.Set(HeaderEvent, string(delivery.Event))
+	req.Header.Set(HeaderDelivery, delivery.ID.Hex())
+	req.Header.Set(HeaderSignature, "t="+timestamp+",v1="+sign(webhook.Secret, timestamp, delivery.Payload))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		log.Println("webhook delivery error:", delivery.ID.Hex(), err)
+		return 0, errors.New(deliveryError(err))
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return res.StatusCode, fmt.Errorf("endpoint responded %s", res.Status)
+	}
+
+	return res.StatusCode, nil
+}
+
+// readOwnWebhook reads the webhook and hides the webhooks of the other users
+func (s *service) readOwnWebhook(ctx context.Context, userID, webhookID primitive.ObjectID) (*model.Webhook, error) {
+	webhook, err := s.webhookRepo.Read(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if webhook.UserID != userID {
+		return nil, static.ErrWebhookNotFound
+	}
+
+	return webhook, nil
+}
+
+// wakeUp wakes the worker up, a pending wake up already covers the new deliveries
+func (s *service) wakeUp() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// sign returns the hex HMAC-SHA256 of the timestamp and the payload joined by a dot with the webhook secret
+func sign(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// backoff returns the delay before the next attempt, doubling from the initial backoff up to the maximum
+func backoff(attempts int) time.Duration {
+	delay := static.Webhook.InitialBackoff
+	for i := 1; i < attempts && delay < static.Webhook.MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, static.Webhook.MaxBackoff)
+}
+
+// generateSecret returns a new random webhook signing secret
+func generateSecret() (string, error) {
+	b := make([]byte, static.Webhook.SecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return "whsec_" + hex.EncodeToString(b), nil
+}
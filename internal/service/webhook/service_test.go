@@ -0,0 +1,218 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/internal/model"
+	repo "golang-project/internal/repository"
+	"golang-project/internal/service/servicetest"
+	"golang-project/static"
+)
+
+func TestSign(t *testing.T) {
+	// HMAC-SHA256 of "1700000000.{"event":"post.published"}" with the secret "whsec_test"
+	want := "7b75d2efbd85f56f9d78d15cce3ed1b6a66b9deace99aae9bbbef7cc25692285"
+
+	if got := sign("whsec_test", "1700000000", []byte(`{"event":"post.published"}`)); got != want {
+		t.Errorf("sign() = %s, want %s", got, want)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for _, tc := range []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: static.Webhook.InitialBackoff},
+		{attempts: 2, want: 2 * static.Webhook.InitialBackoff},
+		{attempts: 3, want: 4 * static.Webhook.InitialBackoff},
+		{attempts: 100, want: static.Webhook.MaxBackoff},
+	} {
+		if got := backoff(tc.attempts); got != tc.want {
+			t.Errorf("backoff(%d) = %s, want %s", tc.attempts, got, tc.want)
+		}
+	}
+}
+
+func TestDeliveryIDIsStablePerEventAndWebhook(t *testing.T) {
+	eventID, webhookID := primitive.NewObjectID(), primitive.NewObjectID()
+
+	id := deliveryID(eventID, webhookID)
+	if again := deliveryID(eventID, webhookID); again != id {
+		t.Errorf("deliveryID() of the same event = %s, want %s", again.Hex(), id.Hex())
+	}
+	if other := deliveryID(eventID, primitive.NewObjectID()); other == id {
+		t.Errorf("deliveryID() of another webhook = %s, want a different ID", other.Hex())
+	}
+	if other := deliveryID(primitive.NewObjectID(), webhookID); other == id {
+		t.Errorf("deliveryID() of another event = %s, want a different ID", other.Hex())
+	}
+	if !id.Timestamp().Equal(eventID.Timestamp()) {
+		t.Errorf("deliveryID() timestamp = %s, want the event's %s", id.Timestamp(), eventID.Timestamp())
+	}
+}
+
+func TestIsPublicIP(t *testing.T) {
+	for address, want := range map[string]bool{
+		"93.184.216.34":        true,
+		"2606:2800:220:1::248": true,
+		"127.0.0.1":            false,
+		"::1":                  false,
+		"10.1.2.3":             false,
+		"172.16.0.1":           false,
+		"192.168.1.1":          false,
+		"169.254.169.254":      false,
+		"100.64.0.1":           false,
+		"100.127.255.254":      false,
+		"::ffff:100.64.0.1":    false,
+		"100.128.0.1":          true,
+		"192.0.0.170":          false,
+		"192.0.1.1":            true,
+		"fe80::1":              false,
+		"fc00::1":              false,
+		"0.0.0.0":              false,
+		"::":                   false,
+		"224.0.0.1":            false,
+		"ff02::1":              false,
+	} {
+		if got := isPublicIP(net.ParseIP(address)); got != want {
+			t.Errorf("isPublicIP(%s) = %t, want %t", address, got, want)
+		}
+
+		// The dialer checks the resolved address again when each delivery connects
+		if err := dialControl("tcp", net.JoinHostPort(address, "443"), nil); (err == nil) != want {
+			t.Errorf("dialControl(%s) = %v, want allowed %t", address, err, want)
+		}
+	}
+}
+
+func TestCheckURLRefusesInternalEndpoints(t *testing.T) {
+	for _, rawURL := range []string{
+		"http://93.184.216.34/hook",
+		"https://127.0.0.1/hook",
+		"https://[::1]/hook",
+		"https://169.254.169.254/latest/meta-data",
+		"https://100.100.100.200/latest/meta-data",
+		"https://192.0.0.192/hook",
+		"https://localhost/hook",
+		"https://api.localhost./hook",
+		"https:///hook",
+	} {
+		if err := checkURL(context.Background(), rawURL); !errors.Is(err, static.ErrWebhookURLNotAllowed) {
+			t.Errorf("checkURL(%s) = %v, want %v", rawURL, err, static.ErrWebhookURLNotAllowed)
+		}
+	}
+
+	if err := checkURL(context.Background(), "https://93.184.216.34/hook"); err != nil {
+		t.Errorf("checkURL(public address) = %v, want nil", err)
+	}
+}
+
+func TestPostSignsDelivery(t *testing.T) {
+	viper.Set(static.EnvWebhookAllowInsecure, true)
+	t.Cleanup(func() { viper.Set(static.EnvWebhookAllowInsecure, false) })
+
+	payload := []byte(`{"post_id":"1"}`)
+	delivery := &model.WebhookDelivery{BaseModel: model.BaseModel{ID: primitive.NewObjectID()}, Event: static.WebhookPostPublished, Payload: payload}
+
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		var timestamp, signature string
+		for _, part := range strings.Split(r.Header.Get(HeaderSignature), ",") {
+			key, value, _ := strings.Cut(part, "=")
+			switch key {
+			case "t":
+				timestamp = value
+			case "v1":
+				signature = value
+			}
+		}
+
+		if r.Header.Get(HeaderEvent) != string(static.WebhookPostPublished) || r.Header.Get(HeaderDelivery) != delivery.ID.Hex() ||
+			signature != sign("whsec_test", timestamp, body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer endpoint.Close()
+
+	s := NewService(nil, nil, nil).(*service)
+	statusCode, err := s.post(context.Background(), &model.Webhook{URL: endpoint.URL, Secret: "whsec_test"}, delivery)
+	if err != nil || statusCode != http.StatusNoContent {
+		t.Errorf("post() = %d, %v, want %d", statusCode, err, http.StatusNoContent)
+	}
+}
+
+func TestPostRefusesLoopback(t *testing.T) {
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the delivery reached a loopback endpoint")
+	}))
+	defer endpoint.Close()
+
+	s := NewService(nil, nil, nil).(*service)
+	delivery := &model.WebhookDelivery{BaseModel: model.BaseModel{ID: primitive.NewObjectID()}, Payload: []byte(`{}`)}
+	if _, err := s.post(context.Background(), &model.Webhook{URL: endpoint.URL, Secret: "whsec_test"}, delivery); err == nil {
+		t.Error("post() = nil, want an error")
+	}
+}
+
+// subscribers returns the webhooks subscribed to every event and keeps the queued deliveries
+type subscribers struct {
+	repo.Webhook
+	webhooks   []*model.Webhook
+	deliveries []*model.WebhookDelivery
+}
+
+func (r *subscribers) SelectSubscribers(context.Context, static.WebhookEvent, primitive.ObjectID) ([]*model.Webhook, error) {
+	return r.webhooks, nil
+}
+
+func (r *subscribers) InsertDeliveries(_ context.Context, deliveries []*model.WebhookDelivery) error {
+	r.deliveries = append(r.deliveries, deliveries...)
+	return nil
+}
+
+func TestDispatchSkipsAllUsersWebhooksOfFormerOrDisabledAdmins(t *testing.T) {
+	now := time.Now()
+	owner := &model.User{BaseModel: model.BaseModel{ID: primitive.NewObjectID()}}
+	admin := &model.User{BaseModel: model.BaseModel{ID: primitive.NewObjectID()}, Role: static.RoleAdmin}
+	demoted := &model.User{BaseModel: model.BaseModel{ID: primitive.NewObjectID()}}
+	disabled := &model.User{BaseModel: model.BaseModel{ID: primitive.NewObjectID()}, Role: static.RoleAdmin, DisabledAt: &now}
+
+	webhooks := &subscribers{}
+	for _, user := range []*model.User{owner, admin, demoted, disabled} {
+		webhooks.webhooks = append(webhooks.webhooks, &model.Webhook{
+			BaseModel: model.BaseModel{ID: primitive.NewObjectID()},
+			UserID:    user.ID,
+			AllUsers:  user != owner,
+		})
+	}
+	userRepo := servicetest.Users{Users: map[primitive.ObjectID]*model.User{owner.ID: owner, admin.ID: admin, demoted.ID: demoted, disabled.ID: disabled}}
+
+	s := NewService(userRepo, webhooks, nil)
+	if err := s.Dispatch(context.Background(), primitive.NewObjectID(), static.WebhookPostPublished, owner.ID, map[string]string{}); err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+
+	var got []primitive.ObjectID
+	for _, delivery := range webhooks.deliveries {
+		got = append(got, delivery.UserID)
+	}
+	if want := []primitive.ObjectID{owner.ID, admin.ID}; !slices.Equal(got, want) {
+		t.Errorf("Dispatch() delivered to %v, want the owner and the active admin %v", got, want)
+	}
+}
@@ -0,0 +1,113 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// mongoMonitor keeps the in-flight command spans keyed by connection and request ID
+type mongoMonitor struct {
+	sync.Mutex
+	spans map[mongoSpanKey]trace.Span
+}
+
+type mongoSpanKey struct {
+	connectionID string
+	requestID    int64
+}
+
+// MongoMonitor attaches a command monitor creating a client span per MongoDB command,
+// chained after any command monitor already set on the client options
+func MongoMonitor(clientOptions *options.ClientOptions) {
+	m := &mongoMonitor{spans: map[mongoSpanKey]trace.Span{}}
+	previous := clientOptions.Monitor
+
+	clientOptions.SetMonitor(&event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			m.started(ctx, e)
+			if previous != nil && previous.Started != nil {
+				previous.Started(ctx, e)
+			}
+		},
+		Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+			m.finished(&e.CommandFinishedEvent, nil)
+			if previous != nil && previous.Succeeded != nil {
+				previous.Succeeded(ctx, e)
+			}
+		},
+		Failed: func(ctx context.Context, e *event.CommandFailedEvent) {
+			m.finished(&e.CommandFinishedEvent, fmt.Errorf("%s", e.Failure))
+			if previous != nil && previous.Failed != nil {
+				previous.Failed(ctx, e)
+			}
+		},
+	})
+}
+
+// started opens the span of the command as the child of the span in the operation context
+func (m *mongoMonitor) started(ctx context.Context, e *event.CommandStartedEvent) {
+	collection := collectionName(e)
+	spanName := e.CommandName
+	if collection != "" {
+		spanName = fmt.Sprintf("%s.%s", collection, e.CommandName)
+	}
+
+	attrs := []attribute.KeyValue{
+		semconv.DBSystemMongoDB,
+		semconv.DBOperationName(e.CommandName),
+		semconv.DBNamespace(e.DatabaseName),
+	}
+	if collection != "" {
+		attrs = append(attrs, semconv.DBCollectionName(collection))
+	}
+
+	_, span := Start(ctx, spanName, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+
+	m.Lock()
+	m.spans[mongoSpanKey{connectionID: e.ConnectionID, requestID: e.RequestID}] = span
+	m.Unlock()
+}
+
+// finished ends the span of the command, recording the failure if any
+func (m *mongoMonitor) finished(e *event.CommandFinishedEvent, err error) {
+	key := mongoSpanKey{connectionID: e.ConnectionID, requestID: e.RequestID}
+
+	m.Lock()
+	span, ok := m.spans[key]
+	delete(m.spans, key)
+	m.Unlock()
+
+	if !ok {
+		return
+	}
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// collectionName returns the collection the command targets, which is the value of its first element
+func collectionName(e *event.CommandStartedEvent) string {
+	elements, err := e.Command.Elements()
+	if err != nil || len(elements) == 0 {
+		return ""
+	}
+
+	value, ok := elements[0].Value().StringValueOK()
+	if !ok {
+		return ""
+	}
+
+	return value
+}
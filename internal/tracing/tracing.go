@@ -0,0 +1,112 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"golang-project/static"
+)
+
+var (
+	ErrUnsupportedExporter = errors.New("tracing exporter is not supported")
+)
+
+// Exporter names accepted by the TRACING_EXPORTER environment variable
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// instrumentationName identifies the tracer of the server spans
+const instrumentationName = "golang-project"
+
+// AttributeCorrelationID is the span attribute key holding the request X-Correlation-ID
+const AttributeCorrelationID = attribute.Key("http.correlation_id")
+
+// ShutdownFunc flushes the pending spans and stops the tracer provider
+type ShutdownFunc func(context.Context) error
+
+// NewProviderFromEnv installs the global tracer provider and W3C propagators configured from environment variables
+func NewProviderFromEnv(ctx context.Context) (ShutdownFunc, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	exporterName := viper.GetString(static.EnvTracingExporter)
+	if exporterName == "" || exporterName == ExporterNone {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := newExporter(ctx, exporterName)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceName := viper.GetString(static.EnvTracingServiceName)
+	if serviceName == "" {
+		serviceName = instrumentationName
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+		semconv.DeploymentEnvironment(viper.GetString(static.EnvServerEnv)),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	ratio := 1.0
+	if viper.IsSet(static.EnvTracingSampleRatio) {
+		ratio = viper.GetFloat64(static.EnvTracingSampleRatio)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// newExporter returns the span exporter by its configured name
+func newExporter(ctx context.Context, name string) (sdktrace.SpanExporter, error) {
+	switch name {
+	case ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case ExporterOTLP:
+		opts := []otlptracehttp.Option{}
+		if endpoint := viper.GetString(static.EnvTracingOTLPEndpoint); endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(endpoint))
+		}
+		if viper.GetBool(static.EnvTracingOTLPInsecure) {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedExporter, name)
+	}
+}
+
+// Tracer returns the server tracer from the global tracer provider
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Start creates a span as the child of the span in the context
+func Start(ctx context.Context, spanName string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, spanName, opts...)
+}
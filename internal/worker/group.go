@@ -0,0 +1,58 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"sync"
+)
+
+// Func represents a background job running until its context is cancelled
+type Func func(ctx context.Context)
+
+// job represents a registered background job
+type job struct {
+	name string
+	fn   Func
+}
+
+// Group represents the background jobs started alongside the server
+type Group struct {
+	mu   sync.Mutex
+	jobs []job
+	wg   sync.WaitGroup
+}
+
+// NewGroup creates and returns an empty background job group
+func NewGroup() *Group {
+	return &Group{}
+}
+
+// Register adds the background job under its name, jobs registered after Start are not started
+func (g *Group) Register(name string, fn Func) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.jobs = append(g.jobs, job{name: name, fn: fn})
+}
+
+// Start runs every registered job in its own goroutine until ctx is cancelled
+func (g *Group) Start(ctx context.Context) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, j := range g.jobs {
+		g.wg.Add(1)
+		go func(j job) {
+			defer g.wg.Done()
+
+			log.Println("background job starts:", j.name)
+			j.fn(ctx)
+			log.Println("background job stops:", j.name)
+		}(j)
+	}
+}
+
+// Wait blocks until every started job has returned
+func (g *Group) Wait() {
+	g.wg.Wait()
+}
@@ -2,6 +2,8 @@ package server
 
 import (
 	"errors"
+	"path"
+	"time"
 
 	"github.com/labstack/echo/v4"
 )
@@ -18,8 +20,27 @@ type ConfigProvider func(*echo.Echo)
 type HandlerRegistry struct {
 	// Route is the route group name of URI
 	Route string
+	// Version is the API version the route group is mounted under, empty mounts it at the root
+	Version string
 	// IsAuthenticated indicates where this route group needs authenticated access
 	IsAuthenticated bool
+	// Deprecation marks the route group as deprecated when it is set
+	Deprecation *Deprecation
 	// Register the function to register the handler for each rout
 	Register func(*echo.Group)
 }
+
+// Prefix returns the URI prefix the route group is mounted under
+func (r HandlerRegistry) Prefix() string {
+	return path.Join("/", r.Version, r.Route)
+}
+
+// Deprecation represents the deprecation and sunset schedule of a route group
+type Deprecation struct {
+	// Since is the time the route group has been deprecated
+	Since time.Time
+	// Sunset is the time the route group will stop being served, zero when it is not scheduled
+	Sunset time.Time
+	// Link is the URI of the replacement or the migration documentation
+	Link string
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Deprecation and sunset response header names
+const (
+	HeaderDeprecation = "Deprecation"
+	HeaderSunset      = "Sunset"
+	HeaderLink        = "Link"
+)
+
+// Middleware returns the middleware emitting the Deprecation (RFC 9745), Sunset (RFC 8594) and Link headers
+func (d *Deprecation) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Response().Header()
+
+			if d.Since.IsZero() {
+				header.Set(HeaderDeprecation, "true")
+			} else {
+				header.Set(HeaderDeprecation, fmt.Sprintf("@%d", d.Since.Unix()))
+			}
+
+			if !d.Sunset.IsZero() {
+				header.Set(HeaderSunset, d.Sunset.UTC().Format(http.TimeFormat))
+			}
+
+			if d.Link != "" {
+				header.Add(HeaderLink, fmt.Sprintf(`<%s>; rel="deprecation"`, d.Link))
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -42,7 +42,12 @@ func (e *engine) Startup(handlers ...HandlerRegistry) error {
 	}
 
 	for _, handler := range handlers {
-		handler.Register(e.server.Group(handler.Route))
+		group := e.server.Group(handler.Prefix())
+		if handler.Deprecation != nil {
+			group.Use(handler.Deprecation.Middleware())
+		}
+
+		handler.Register(group)
 	}
 
 	return e.server.Start(e.Address())
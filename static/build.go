@@ -0,0 +1,49 @@
+package static
+
+import (
+	"runtime"
+	"runtime/debug"
+	"time"
+)
+
+// Build information, overridden at link time via
+// -ldflags "-X golang-project/static.BuildVersion=... -X golang-project/static.BuildCommit=... -X golang-project/static.BuildTime=..."
+var (
+	BuildVersion = "dev"
+	BuildCommit  = ""
+	BuildTime    = ""
+)
+
+// StartedAt is the time the server process started
+var StartedAt = time.Now()
+
+// BuildInfo defines a struct that holds the build and runtime information of the binary.
+type BuildInfo struct {
+	Version   string
+	Commit    string
+	BuiltAt   string
+	GoVersion string
+}
+
+// GetBuildInfo returns the build information, falling back to the VCS stamp of the Go toolchain
+func GetBuildInfo() BuildInfo {
+	info := BuildInfo{
+		Version:   BuildVersion,
+		Commit:    BuildCommit,
+		BuiltAt:   BuildTime,
+		GoVersion: runtime.Version(),
+	}
+
+	if buildInfo, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range buildInfo.Settings {
+			switch {
+			case setting.Key == "vcs.revision" && info.Commit == "":
+				info.Commit = setting.Value
+			case setting.Key == "vcs.time" && info.BuiltAt == "":
+				info.BuiltAt = setting.Value
+			}
+		}
+	}
+
+	return info
+}
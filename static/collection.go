@@ -2,11 +2,25 @@ package static
 
 // Collection names for MongoDB
 const (
-	CollectionUsers     = "users"
-	CollectionPosts     = "posts"
-	CollectionComments  = "comments"
-	CollectionTags      = "tags"
-	CollectionPostTags  = "post_tags"
-	CollectionFavorites = "favorites"
-	CollectionFollows   = "follows"
+	CollectionUsers             = "users"
+	CollectionPosts             = "posts"
+	CollectionComments          = "comments"
+	CollectionTags              = "tags"
+	CollectionPostTags          = "post_tags"
+	CollectionFavorites         = "favorites"
+	CollectionFollows           = "follows"
+	CollectionExports           = "exports"
+	CollectionReports           = "reports"
+	CollectionModerationActions = "moderation_actions"
+	CollectionBlocks            = "blocks"
+	CollectionMutes             = "mutes"
+	CollectionReactions         = "reactions"
+	CollectionIdempotencyKeys   = "idempotency_keys"
+	CollectionWebhooks          = "webhooks"
+	CollectionWebhookDeliveries = "webhook_deliveries"
+	CollectionOutbox            = "outbox"
+	CollectionAuditLogs         = "audit_logs"
+	CollectionOIDCStates        = "oidc_states"
+	CollectionAccessTokens      = "access_tokens"
+	CollectionSignInChallenges  = "sign_in_challenges"
 )
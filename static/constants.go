@@ -15,3 +15,203 @@ const (
 	Favourite PostFavouriteAction = "favourite"
 	Unfavourite PostFavouriteAction = "unfavourite"
 )
+
+// FeedFormat defines the syndication formats supported by the feed API
+type FeedFormat string
+
+const (
+	FeedRSS  FeedFormat = "rss"
+	FeedAtom FeedFormat = "atom"
+)
+
+// UserRole defines the roles a user account can hold
+type UserRole string
+
+const (
+	RoleUser      UserRole = "user"
+	RoleModerator UserRole = "moderator"
+	RoleAdmin     UserRole = "admin"
+)
+
+// OutputFormat defines the output formats supported by the admin commands
+type OutputFormat string
+
+const (
+	OutputTable OutputFormat = "table"
+	OutputJSON  OutputFormat = "json"
+)
+
+// API versions the resource handlers are mounted under
+const (
+	APIVersionV1 = "v1"
+	APIVersionV2 = "v2"
+)
+
+// ExportStatus defines the lifecycle states of a personal data export
+type ExportStatus string
+
+const (
+	ExportPending    ExportStatus = "pending"
+	ExportProcessing ExportStatus = "processing"
+	ExportReady      ExportStatus = "ready"
+	ExportFailed     ExportStatus = "failed"
+	ExportExpired    ExportStatus = "expired"
+)
+
+// ReportTargetType defines the kinds of content that can be reported
+type ReportTargetType string
+
+const (
+	ReportTargetPost    ReportTargetType = "post"
+	ReportTargetComment ReportTargetType = "comment"
+)
+
+// ReportReason defines the reason categories of a content report
+type ReportReason string
+
+const (
+	ReportSpam           ReportReason = "spam"
+	ReportHarassment     ReportReason = "harassment"
+	ReportHateSpeech     ReportReason = "hate_speech"
+	ReportViolence       ReportReason = "violence"
+	ReportSexualContent  ReportReason = "sexual_content"
+	ReportMisinformation ReportReason = "misinformation"
+	ReportOther          ReportReason = "other"
+)
+
+// ReportStatus defines the lifecycle states of a content report
+type ReportStatus string
+
+const (
+	ReportOpen     ReportStatus = "open"
+	ReportResolved ReportStatus = "resolved"
+)
+
+// ModerationActionType defines the actions a moderator can take on reported content
+type ModerationActionType string
+
+const (
+	ModerationDismiss ModerationActionType = "dismiss"
+	ModerationHide    ModerationActionType = "hide"
+	ModerationWarn    ModerationActionType = "warn"
+	ModerationSuspend ModerationActionType = "suspend"
+)
+
+// ReactionType defines the reactions a user can leave on posts and comments
+type ReactionType string
+
+const (
+	ReactionLike       ReactionType = "like"
+	ReactionLove       ReactionType = "love"
+	ReactionInsightful ReactionType = "insightful"
+	ReactionFunny      ReactionType = "funny"
+	ReactionCelebrate  ReactionType = "celebrate"
+)
+
+// ReactionTargetType defines the kinds of content that can receive reactions
+type ReactionTargetType string
+
+const (
+	ReactionTargetPost    ReactionTargetType = "post"
+	ReactionTargetComment ReactionTargetType = "comment"
+)
+
+// WebhookEvent defines the events a webhook endpoint can subscribe to
+type WebhookEvent string
+
+const (
+	WebhookPostPublished  WebhookEvent = "post.published"
+	WebhookCommentCreated WebhookEvent = "comment.created"
+	WebhookUserFollowed   WebhookEvent = "user.followed"
+)
+
+// WebhookDeliveryStatus defines the lifecycle of a webhook delivery
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending    WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivering WebhookDeliveryStatus = "delivering"
+	WebhookDeliveryDelivered  WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryFailed     WebhookDeliveryStatus = "failed"
+)
+
+// EventName defines the domain events published through the event bus
+type EventName string
+
+const (
+	EventUserSignedUp   EventName = "user.signed_up"
+	EventPostPublished  EventName = "post.published"
+	EventCommentCreated EventName = "comment.created"
+	EventFollowed       EventName = "user.followed"
+	EventPostChanged    EventName = "post.changed"
+	EventProfileChanged EventName = "profile.changed"
+	EventTagChanged     EventName = "tag.changed"
+)
+
+// OutboxStatus defines the lifecycle of an event in the outbox
+type OutboxStatus string
+
+const (
+	OutboxPending     OutboxStatus = "pending"
+	OutboxDispatching OutboxStatus = "dispatching"
+	OutboxDispatched  OutboxStatus = "dispatched"
+	OutboxFailed      OutboxStatus = "failed"
+)
+
+// AuditAction defines the security-sensitive actions recorded in the audit log
+type AuditAction string
+
+const (
+	AuditSignUp                  AuditAction = "auth.sign_up"
+	AuditSignIn                  AuditAction = "auth.sign_in"
+	AuditSignInFailed            AuditAction = "auth.sign_in_failed"
+	AuditIdentityLinked          AuditAction = "auth.identity_linked"
+	AuditPasswordChanged         AuditAction = "auth.password_changed"
+	AuditUserCreated             AuditAction = "admin.user_created"
+	AuditRoleChanged             AuditAction = "admin.role_changed"
+	AuditUserDisabled            AuditAction = "admin.user_disabled"
+	AuditPasswordReset           AuditAction = "admin.password_reset"
+	AuditPostUnpublished         AuditAction = "admin.post_unpublished"
+	AuditTagsMerged              AuditAction = "admin.tags_merged"
+	AuditModerationAction        AuditAction = "moderation.action_taken"
+	AuditAccountDeletionSchedule AuditAction = "account.deletion_scheduled"
+	AuditAccountDeletionCancel   AuditAction = "account.deletion_cancelled"
+	AuditAccountDeleted          AuditAction = "account.deleted"
+	AuditWebhookCreated          AuditAction = "webhook.created"
+	AuditWebhookDeleted          AuditAction = "webhook.deleted"
+	AuditAccessTokenCreated      AuditAction = "access_token.created"
+	AuditAccessTokenRevoked      AuditAction = "access_token.revoked"
+	AuditTwoFactorEnabled        AuditAction = "two_factor.enabled"
+	AuditTwoFactorDisabled       AuditAction = "two_factor.disabled"
+	AuditRecoveryCodesRenewed    AuditAction = "two_factor.recovery_codes_renewed"
+	AuditTwoFactorLocked         AuditAction = "two_factor.locked"
+)
+
+// AuditSource defines where an audited action comes from
+type AuditSource string
+
+const (
+	AuditSourceAPI    AuditSource = "api"
+	AuditSourceCLI    AuditSource = "cli"
+	AuditSourceSystem AuditSource = "system"
+)
+
+// AuditTargetType defines the kind of resource an audited action applies to
+type AuditTargetType string
+
+const (
+	AuditTargetUser    AuditTargetType = "user"
+	AuditTargetPost    AuditTargetType = "post"
+	AuditTargetComment AuditTargetType = "comment"
+	AuditTargetTag     AuditTargetType = "tag"
+	AuditTargetWebhook AuditTargetType = "webhook"
+	AuditTargetToken   AuditTargetType = "access_token"
+)
+
+// AccessTokenScope defines what a personal access token may do, read allows the safe methods only
+type AccessTokenScope string
+
+const (
+	AccessTokenRead  AccessTokenScope = "read"
+	AccessTokenWrite AccessTokenScope = "write"
+)
@@ -1,5 +1,7 @@
 package static
 
+import "time"
+
 // PaginationDefault defines a struct that holds default pagination values.
 type PaginationDefault struct {
 	DefaultPage     int
@@ -11,3 +13,262 @@ var Pagination = PaginationDefault{
 	DefaultPage:     1,
 	DefaultPageSize: 10,
 }
+
+// FeedDefault defines a struct that holds default syndication feed values.
+type FeedDefault struct {
+	Title     string
+	ItemLimit int
+}
+
+// Feed represents the default syndication feed settings
+var Feed = FeedDefault{
+	Title:     "Social Blog",
+	ItemLimit: 20,
+}
+
+// HealthCheckDefault defines a struct that holds default health check values.
+type HealthCheckDefault struct {
+	Timeout  time.Duration
+	CacheTTL time.Duration
+}
+
+// HealthCheck represents the default health check settings
+var HealthCheck = HealthCheckDefault{
+	Timeout:  2 * time.Second,
+	CacheTTL: 5 * time.Second,
+}
+
+// APIVersionDefault defines a struct that holds default API versioning values.
+type APIVersionDefault struct {
+	LegacyDeprecatedAt time.Time
+}
+
+// APIVersioning represents the default API versioning settings
+var APIVersioning = APIVersionDefault{
+	LegacyDeprecatedAt: time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC),
+}
+
+// SeedDefault defines a struct that holds default seed command values.
+type SeedDefault struct {
+	Users             int
+	Tags              int
+	PostsPerUser      int
+	CommentsPerPost   int
+	FollowsPerUser    int
+	FavouritesPerUser int
+	RandomSeed        int64
+	Password          string
+	Anchor            time.Time
+	ResetEnvironments []string
+}
+
+// Seed represents the default seed command settings
+var Seed = SeedDefault{
+	Users:             20,
+	Tags:              12,
+	PostsPerUser:      5,
+	CommentsPerPost:   4,
+	FollowsPerUser:    5,
+	FavouritesPerUser: 8,
+	RandomSeed:        42,
+	Password:          "password123",
+	Anchor:            time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC),
+	ResetEnvironments: []string{"local", "dev", "development", "test"},
+}
+
+// ExportDefault defines a struct that holds default personal data export values.
+type ExportDefault struct {
+	StorageDir   string
+	TTL          time.Duration
+	PollInterval time.Duration
+	StaleAfter   time.Duration
+}
+
+// Export represents the default personal data export settings
+var Export = ExportDefault{
+	StorageDir:   "./storage/exports",
+	TTL:          72 * time.Hour,
+	PollInterval: 5 * time.Second,
+	StaleAfter:   30 * time.Minute,
+}
+
+// AccountDeletionDefault defines a struct that holds default account deletion values.
+type AccountDeletionDefault struct {
+	GracePeriod       time.Duration
+	PollInterval      time.Duration
+	AnonymisedComment string
+}
+
+// AccountDeletion represents the default account deletion settings
+var AccountDeletion = AccountDeletionDefault{
+	GracePeriod:       14 * 24 * time.Hour,
+	PollInterval:      time.Minute,
+	AnonymisedComment: "[deleted]",
+}
+
+// CacheDefault defines a struct that holds default read-through cache values.
+type CacheDefault struct {
+	TTL          time.Duration
+	Size         int
+	KeyPrefix    string
+	RedisTimeout time.Duration
+	RedisMaxIdle int
+}
+
+// Cache represents the default read-through cache settings
+var Cache = CacheDefault{
+	TTL:          time.Minute,
+	Size:         10000,
+	KeyPrefix:    "social_blog:",
+	RedisTimeout: time.Second,
+	RedisMaxIdle: 10,
+}
+
+// WebhookDefault defines a struct that holds default webhook delivery values.
+type WebhookDefault struct {
+	Timeout        time.Duration
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	PollInterval   time.Duration
+	StaleAfter     time.Duration
+	SecretBytes    int
+	MaxErrorLength int
+	Retention      time.Duration
+}
+
+// Webhook represents the default webhook delivery settings
+var Webhook = WebhookDefault{
+	Timeout:        10 * time.Second,
+	MaxAttempts:    8,
+	InitialBackoff: 30 * time.Second,
+	MaxBackoff:     6 * time.Hour,
+	PollInterval:   5 * time.Second,
+	StaleAfter:     5 * time.Minute,
+	SecretBytes:    32,
+	MaxErrorLength: 500,
+	Retention:      30 * 24 * time.Hour,
+}
+
+// IdempotencyDefault defines a struct that holds default idempotency key values.
+type IdempotencyDefault struct {
+	TTL          time.Duration
+	MaxKeyLength int
+	MaxBodySize  int64
+}
+
+// Idempotency represents the default idempotency key settings
+var Idempotency = IdempotencyDefault{
+	TTL:          24 * time.Hour,
+	MaxKeyLength: 255,
+	MaxBodySize:  1 << 20,
+}
+
+// TransactionDefault defines a struct that holds default transaction values.
+type TransactionDefault struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	Timeout     time.Duration
+}
+
+// Transaction represents the default unit of work transaction settings
+var Transaction = TransactionDefault{
+	MaxAttempts: 3,
+	Backoff:     50 * time.Millisecond,
+	Timeout:     30 * time.Second,
+}
+
+// OutboxDefault defines a struct that holds default event outbox relay values.
+type OutboxDefault struct {
+	PollInterval   time.Duration
+	StaleAfter     time.Duration
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	MaxErrorLength int
+	Retention      time.Duration
+}
+
+// Outbox represents the default event outbox relay settings
+var Outbox = OutboxDefault{
+	PollInterval:   2 * time.Second,
+	StaleAfter:     5 * time.Minute,
+	MaxAttempts:    10,
+	InitialBackoff: 5 * time.Second,
+	MaxBackoff:     time.Hour,
+	MaxErrorLength: 500,
+	Retention:      7 * 24 * time.Hour,
+}
+
+// OIDCDefault defines a struct that holds default OpenID Connect sign-in values.
+type OIDCDefault struct {
+	Scopes       string
+	StateTTL     time.Duration
+	Timeout      time.Duration
+	DiscoveryTTL time.Duration
+	CallbackPath string
+	StateCookie  string
+}
+
+// OIDC represents the default OpenID Connect sign-in settings
+var OIDC = OIDCDefault{
+	Scopes:       "openid email profile",
+	StateTTL:     10 * time.Minute,
+	Timeout:      10 * time.Second,
+	DiscoveryTTL: time.Hour,
+	CallbackPath: "/v1/auth/oidc/callback",
+	StateCookie:  "oidc_state",
+}
+
+// AccessTokenDefault defines a struct that holds default personal access token values.
+type AccessTokenDefault struct {
+	Prefix            string
+	SecretBytes       int
+	DisplayLength     int
+	DefaultTTL        time.Duration
+	MaxActivePerUser  int
+	LastUsedPrecision time.Duration
+}
+
+// AccessToken represents the default personal access token settings
+var AccessToken = AccessTokenDefault{
+	Prefix:            "pat_",
+	SecretBytes:       32,
+	DisplayLength:     12,
+	DefaultTTL:        90 * 24 * time.Hour,
+	MaxActivePerUser:  50,
+	LastUsedPrecision: time.Minute,
+}
+
+// TwoFactorDefault defines a struct that holds default two-factor authentication values.
+type TwoFactorDefault struct {
+	Issuer             string
+	SecretBytes        int
+	Digits             int
+	Period             time.Duration
+	Skew               int64
+	ChallengeTTL       time.Duration
+	ChallengeAttempts  int
+	RecoveryCodes      int
+	RecoveryCodeLength int
+	RecentSignIn       time.Duration
+	MaxFailedCodes     int
+	Lockout            time.Duration
+}
+
+// TwoFactor represents the default TOTP two-factor authentication settings,
+// the codes are the 6 digit SHA-1 codes every authenticator app supports
+var TwoFactor = TwoFactorDefault{
+	Issuer:             "golang-project",
+	SecretBytes:        20,
+	Digits:             6,
+	Period:             30 * time.Second,
+	Skew:               1,
+	ChallengeTTL:       5 * time.Minute,
+	ChallengeAttempts:  5,
+	RecoveryCodes:      10,
+	RecoveryCodeLength: 10,
+	RecentSignIn:       5 * time.Minute,
+	MaxFailedCodes:     10,
+	Lockout:            15 * time.Minute,
+}
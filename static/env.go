@@ -4,6 +4,32 @@ package static
 const (
 	EnvServerEnv     = "SERVER_ENV"
 	EnvServerAddress = "SERVER_ADDRESS"
+	EnvServerBaseURL = "SERVER_BASE_URL"
+
+	EnvServerTrustedProxies = "SERVER_TRUSTED_PROXIES"
+	EnvServerReplicas       = "SERVER_REPLICAS"
+)
+
+// API versioning environment variable name
+const (
+	EnvAPILegacySunset = "API_LEGACY_SUNSET"
+)
+
+// Health check environment variable name
+const (
+	EnvHealthCheckTimeout  = "HEALTH_CHECK_TIMEOUT"
+	EnvHealthCheckCacheTTL = "HEALTH_CHECK_CACHE_TTL"
+)
+
+// Personal data export environment variable name
+const (
+	EnvExportStorageDir = "EXPORT_STORAGE_DIR"
+	EnvExportTTL        = "EXPORT_TTL"
+)
+
+// Account deletion environment variable name
+const (
+	EnvAccountDeletionGrace = "ACCOUNT_DELETION_GRACE"
 )
 
 // Database environment variable name
@@ -30,3 +56,47 @@ const (
 	EnvAuthIssuer   = "AUTH_ISSUER"
 	EnvAuthSubject  = "AUTH_SUBJECT"
 )
+
+// OpenID Connect environment variable name
+const (
+	EnvOIDCIssuer       = "OIDC_ISSUER"
+	EnvOIDCClientID     = "OIDC_CLIENT_ID"
+	EnvOIDCClientSecret = "OIDC_CLIENT_SECRET"
+	EnvOIDCRedirectURL  = "OIDC_REDIRECT_URL"
+	EnvOIDCScopes       = "OIDC_SCOPES"
+)
+
+// Two-factor environment variable name
+const (
+	EnvTwoFactorEncryptionKey = "TWO_FACTOR_ENCRYPTION_KEY"
+)
+
+// Tracing environment variable name
+const (
+	EnvTracingExporter     = "TRACING_EXPORTER"
+	EnvTracingServiceName  = "TRACING_SERVICE_NAME"
+	EnvTracingSampleRatio  = "TRACING_SAMPLE_RATIO"
+	EnvTracingOTLPEndpoint = "TRACING_OTLP_ENDPOINT"
+	EnvTracingOTLPInsecure = "TRACING_OTLP_INSECURE"
+)
+
+// Idempotency environment variable name
+const (
+	EnvIdempotencyTTL         = "IDEMPOTENCY_TTL"
+	EnvIdempotencyMaxBodySize = "IDEMPOTENCY_MAX_BODY_SIZE"
+)
+
+// Webhook environment variable name
+const (
+	EnvWebhookAllowInsecure = "WEBHOOK_ALLOW_INSECURE"
+)
+
+// Cache environment variable name
+const (
+	EnvCacheDriver        = "CACHE_DRIVER"
+	EnvCacheTTL           = "CACHE_TTL"
+	EnvCacheSize          = "CACHE_SIZE"
+	EnvCacheRedisAddress  = "CACHE_REDIS_ADDRESS"
+	EnvCacheRedisPassword = "CACHE_REDIS_PASSWORD"
+	EnvCacheRedisDB       = "CACHE_REDIS_DB"
+)
@@ -1,55 +1,151 @@
 package static
 
-import "errors"
+import (
+	"net/http"
+
+	"golang-project/util/apperror"
+)
 
 var (
+	// Validation errors
+	ErrValidation = apperror.New(http.StatusUnprocessableEntity, "validation_failed", "error request validation failed", "One or more fields are invalid.")
+
+	// Authentication errors
+	ErrInvalidCredentials = apperror.New(http.StatusUnauthorized, "invalid_credentials", "error invalid email or password", "The email or password is incorrect.")
+	ErrUserDisabled       = apperror.New(http.StatusForbidden, "account_disabled", "error user account is disabled", "This account has been disabled.")
+
 	// User Permission errors
-	ErrUserPermission = errors.New("error user do not have permission")
-	ErrPostOwner      = errors.New("error user do not own the requested post")
+	ErrUserPermission = apperror.New(http.StatusForbidden, "permission_denied", "error user do not have permission", "You do not have permission to perform this action.")
+	ErrPostOwner      = apperror.New(http.StatusForbidden, "post_owner_required", "error user do not own the requested post", "You do not own the requested post.")
 
 	//Profile errors
-	ErrListBloggerPosts = errors.New("error retrieving blogger posts")
-	ErrParamInvalid     = errors.New("error invalid param")
+	ErrListBloggerPosts = apperror.New(http.StatusInternalServerError, "list_blogger_posts_failed", "error retrieving blogger posts", "The blogger posts could not be retrieved.")
+	ErrParamInvalid     = apperror.New(http.StatusBadRequest, "invalid_param", "error invalid param", "One or more parameters are invalid.")
 
 	// Tags errors
-	ErrReadTagID   = errors.New("error get tag detail")
-	ErrHasPosts    = errors.New("error delete tag because it has associated posts")
-	ErrTagNotFound = errors.New("error tag id not found")
+	ErrReadTagID   = apperror.New(http.StatusInternalServerError, "read_tag_failed", "error get tag detail", "The tag could not be retrieved.")
+	ErrHasPosts    = apperror.New(http.StatusConflict, "tag_has_posts", "error delete tag because it has associated posts", "The tag cannot be deleted because posts are still using it.")
+	ErrTagNotFound = apperror.New(http.StatusNotFound, "tag_not_found", "error tag id not found", "The tag does not exist.")
+	ErrTagExists   = apperror.New(http.StatusConflict, "tag_exists", "error tag name already exists", "A tag with this name already exists.")
 
 	// Favourite errors - User following
-	ErrUserNotFound            = errors.New("error user id not found")
-	ErrSelfFollow              = errors.New("error cannot follow yourself")
-	ErrDatabaseOperation       = errors.New("error occurred during database operation")
-	ErrFollowStatusUpdate      = errors.New("error failed to update follow status")
-	ErrUnsupportedFollowAction = errors.New("error unsupported follow action")
+	ErrUserNotFound            = apperror.New(http.StatusNotFound, "user_not_found", "error user id not found", "The user does not exist.")
+	ErrSelfFollow              = apperror.New(http.StatusBadRequest, "self_follow", "error cannot follow yourself", "You cannot follow yourself.")
+	ErrDatabaseOperation       = apperror.New(http.StatusInternalServerError, "database_error", "error occurred during database operation", "The request could not be completed, please try again later.")
+	ErrFollowStatusUpdate      = apperror.New(http.StatusInternalServerError, "follow_update_failed", "error failed to update follow status", "The follow status could not be updated.")
+	ErrUnsupportedFollowAction = apperror.New(http.StatusBadRequest, "unsupported_follow_action", "error unsupported follow action", "The follow action is not supported.")
 
 	// Favourite errors - Post favourites
-	ErrGetFavouritePosts          = errors.New("error retrieving favourite posts")
-	ErrGetFollowedBloggerPosts    = errors.New("error retrieving followed blogger posts")
-	ErrFavouriteStatusUpdate      = errors.New("error failed to update favourite status")
-	ErrUnsupportedFavouriteAction = errors.New("error unsupported favourite action")
+	ErrGetFavouritePosts          = apperror.New(http.StatusInternalServerError, "list_favourite_posts_failed", "error retrieving favourite posts", "The favourite posts could not be retrieved.")
+	ErrGetFollowedBloggerPosts    = apperror.New(http.StatusInternalServerError, "list_followed_posts_failed", "error retrieving followed blogger posts", "The posts of followed bloggers could not be retrieved.")
+	ErrFavouriteStatusUpdate      = apperror.New(http.StatusInternalServerError, "favourite_update_failed", "error failed to update favourite status", "The favourite status could not be updated.")
+	ErrUnsupportedFavouriteAction = apperror.New(http.StatusBadRequest, "unsupported_favourite_action", "error unsupported favourite action", "The favourite action is not supported.")
+
+	// Admin errors
+	ErrUserAlreadyHasRole  = apperror.New(http.StatusConflict, "user_already_has_role", "error user already holds the role", "The user already holds this role.")
+	ErrUserAlreadyDisabled = apperror.New(http.StatusConflict, "user_already_disabled", "error user account already disabled", "The account is already disabled.")
+	ErrPostNotPublished    = apperror.New(http.StatusConflict, "post_not_published", "error post is not published", "The post is not published.")
+	ErrMergeSameTag        = apperror.New(http.StatusBadRequest, "merge_same_tag", "error cannot merge a tag into itself", "The source and target tags must be different.")
+
+	// Feed errors
+	ErrUnsupportedFeedFormat = apperror.New(http.StatusNotFound, "unsupported_feed_format", "error unsupported feed format", "The feed format is not supported, use rss or atom.")
+	ErrBloggerNotFound       = apperror.New(http.StatusNotFound, "blogger_not_found", "error blogger pseudonym not found", "The blogger does not exist.")
+	ErrRenderFeed            = apperror.New(http.StatusInternalServerError, "render_feed_failed", "error rendering feed", "The feed could not be rendered.")
+
+	// Account deletion errors
+	ErrDeletionAlreadyScheduled = apperror.New(http.StatusConflict, "deletion_already_scheduled", "error account deletion already scheduled", "The account deletion is already scheduled.")
+	ErrDeletionNotScheduled     = apperror.New(http.StatusNotFound, "deletion_not_scheduled", "error account deletion not scheduled", "No account deletion is scheduled.")
+
+	// Moderation errors
+	ErrAlreadyReported      = apperror.New(http.StatusConflict, "already_reported", "error content already reported by user", "You have already reported this content.")
+	ErrSelfReport           = apperror.New(http.StatusBadRequest, "self_report", "error cannot report own content", "You cannot report your own content.")
+	ErrReportTargetNotFound = apperror.New(http.StatusNotFound, "report_target_not_found", "error reported content not found", "The reported content does not exist.")
+	ErrModeratorRequired    = apperror.New(http.StatusForbidden, "moderator_required", "error user is not a moderator", "Only moderators can perform this action.")
+	ErrNoOpenReports        = apperror.New(http.StatusConflict, "no_open_reports", "error content has no open reports", "The content has no open reports.")
+	ErrSelfModeration       = apperror.New(http.StatusForbidden, "self_moderation", "error cannot moderate own content", "You cannot moderate your own content.")
+	ErrPrivilegedTarget     = apperror.New(http.StatusForbidden, "privileged_target", "error cannot moderate content of moderators or admins", "The content of moderators and admins cannot be moderated.")
+
+	// Relationship errors
+	ErrSelfRelationship = apperror.New(http.StatusBadRequest, "self_relationship", "error cannot block or mute self", "You cannot block or mute yourself.")
+	ErrUserBlocked      = apperror.New(http.StatusForbidden, "user_blocked", "error users have blocked each other", "This action is not available between you and this user.")
+	ErrAlreadyBlocked   = apperror.New(http.StatusConflict, "already_blocked", "error user already blocked", "You have already blocked this user.")
+	ErrNotBlocked       = apperror.New(http.StatusNotFound, "not_blocked", "error user not blocked", "You have not blocked this user.")
+	ErrAlreadyMuted     = apperror.New(http.StatusConflict, "already_muted", "error user already muted", "You have already muted this user.")
+	ErrNotMuted         = apperror.New(http.StatusNotFound, "not_muted", "error user not muted", "You have not muted this user.")
+
+	// Reaction errors
+	ErrReactionTargetNotFound = apperror.New(http.StatusNotFound, "reaction_target_not_found", "error reacted content not found", "The content does not exist.")
+	ErrReactionNotFound       = apperror.New(http.StatusNotFound, "reaction_not_found", "error reaction not found", "You have not reacted to this content.")
+
+	// Export errors
+	ErrExportNotFound = apperror.New(http.StatusNotFound, "export_not_found", "error export not found", "The export does not exist.")
+	ErrExportNotReady = apperror.New(http.StatusConflict, "export_not_ready", "error export is not ready", "The export is still being prepared, please try again later.")
+	ErrExportExpired  = apperror.New(http.StatusGone, "export_expired", "error export download expired", "The export download has expired, please request a new export.")
+	ErrExportFailed   = apperror.New(http.StatusInternalServerError, "export_failed", "error building export archive", "The export could not be prepared, please request a new export.")
 
 	// SignUp errors
-	ErrEmailAlreadyExists    = errors.New("error email already exists")
-	ErrInvalidEmail          = errors.New("error invalid email format")
-	ErrPasswordHashingFailed = errors.New("error hashing password")
-	ErrSaveUserFailed        = errors.New("error saving user to database")
-	ErrInvalidName           = errors.New("error invalid name format")
-	ErrCheckEmailFailed      = errors.New("error checking email failed")
+	ErrEmailAlreadyExists    = apperror.New(http.StatusConflict, "email_already_exists", "error email already exists", "An account with this email already exists.")
+	ErrInvalidEmail          = apperror.New(http.StatusUnprocessableEntity, "invalid_email", "error invalid email format", "The email address is invalid.")
+	ErrPasswordHashingFailed = apperror.New(http.StatusInternalServerError, "password_hashing_failed", "error hashing password", "The password could not be processed.")
+	ErrSaveUserFailed        = apperror.New(http.StatusInternalServerError, "save_user_failed", "error saving user to database", "The account could not be created.")
+	ErrInvalidName           = apperror.New(http.StatusUnprocessableEntity, "invalid_name", "error invalid name format", "The name may only contain letters, spaces, hyphens and apostrophes.")
+	ErrCheckEmailFailed      = apperror.New(http.StatusInternalServerError, "check_email_failed", "error checking email failed", "The email address could not be verified.")
 
 	// Post errors
-	ErrInsertPost           = errors.New("error creating post")
-	ErrTagNotFoundOrDeleted = errors.New("error one or more tags not found or deleted")
-	ErrInsertPostTags       = errors.New("error creating post tag")
-	ErrFetchPostDetail      = errors.New("error fetching post detail")
-	ErrPostNotFound         = errors.New("error post not found")
-	ErrInvalidPostID        = errors.New("error invalid post id")
+	ErrInsertPost           = apperror.New(http.StatusInternalServerError, "create_post_failed", "error creating post", "The post could not be created.")
+	ErrTagNotFoundOrDeleted = apperror.New(http.StatusUnprocessableEntity, "tags_not_found", "error one or more tags not found or deleted", "One or more tags do not exist.")
+	ErrInsertPostTags       = apperror.New(http.StatusInternalServerError, "create_post_tags_failed", "error creating post tag", "The post tags could not be saved.")
+	ErrFetchPostDetail      = apperror.New(http.StatusInternalServerError, "fetch_post_failed", "error fetching post detail", "The post could not be retrieved.")
+	ErrPostNotFound         = apperror.New(http.StatusNotFound, "post_not_found", "error post not found", "The post does not exist.")
+	ErrInvalidPostID        = apperror.New(http.StatusBadRequest, "invalid_post_id", "error invalid post id", "The post ID is invalid.")
 
 	// Comment errors
-	ErrCommentNotFound  = errors.New("error comment not found")
-	ErrInvalidCommentID = errors.New("error invalid comment id")
+	ErrCommentNotFound  = apperror.New(http.StatusNotFound, "comment_not_found", "error comment not found", "The comment does not exist.")
+	ErrInvalidCommentID = apperror.New(http.StatusBadRequest, "invalid_comment_id", "error invalid comment id", "The comment ID is invalid.")
+
+	// Conditional request errors
+	ErrVersionConflict  = apperror.New(http.StatusPreconditionFailed, "version_conflict", "error document version does not match the if-match precondition", "The resource has changed since it was retrieved, reload it and try again.")
+	ErrConcurrentUpdate = apperror.New(http.StatusConflict, "concurrent_update", "error document changed by a concurrent update", "The resource was changed by another request at the same time, try again.")
+
+	// Webhook errors
+	ErrWebhookNotFound         = apperror.New(http.StatusNotFound, "webhook_not_found", "error webhook not found", "The webhook does not exist.")
+	ErrWebhookDeliveryNotFound = apperror.New(http.StatusNotFound, "webhook_delivery_not_found", "error webhook delivery not found", "The webhook delivery does not exist.")
+	ErrWebhookAdminRequired    = apperror.New(http.StatusForbidden, "webhook_admin_required", "error only admins can subscribe to the events of all users", "Only admins can subscribe to the events of all users.")
+	ErrWebhookURLNotAllowed    = apperror.New(http.StatusUnprocessableEntity, "webhook_url_not_allowed", "error webhook url is not https or does not resolve to a public address", "The webhook URL must use https and point to a public address.")
+
+	// OpenID Connect errors
+	ErrOIDCDisabled            = apperror.New(http.StatusNotFound, "oidc_disabled", "error openid connect sign-in is not configured", "Single sign-on is not available.")
+	ErrOIDCStateInvalid        = apperror.New(http.StatusBadRequest, "oidc_state_invalid", "error openid connect state is unknown or expired", "The sign-in session has expired, start the sign-in again.")
+	ErrOIDCAuthorizationFailed = apperror.New(http.StatusUnauthorized, "oidc_authorization_failed", "error openid connect authorization failed", "The identity provider did not authorize the sign-in.")
+	ErrOIDCEmailNotVerified    = apperror.New(http.StatusForbidden, "oidc_email_not_verified", "error openid connect email is missing or not verified", "The identity provider did not confirm a verified email address.")
+
+	// Access token errors
+	ErrAccessTokenNotFound = apperror.New(http.StatusNotFound, "access_token_not_found", "error access token not found", "The access token does not exist.")
+	ErrAccessTokenInvalid  = apperror.New(http.StatusUnauthorized, "access_token_invalid", "error access token is unknown, expired or revoked", "The access token is invalid, expired or revoked.")
+	ErrAccessTokenScope    = apperror.New(http.StatusForbidden, "insufficient_scope", "error access token lacks the scope of the request", "The access token does not have the scope required by this request.")
+	ErrAccessTokenLimit    = apperror.New(http.StatusConflict, "access_token_limit", "error user reached the access token limit", "You have reached the maximum number of active access tokens, revoke one first.")
+	ErrSessionRequired     = apperror.New(http.StatusForbidden, "session_required", "error request requires a signed-in session instead of an access token", "This request cannot be made with an access token, sign in instead.")
+
+	// Two-factor authentication errors
+	ErrTwoFactorAlreadyEnabled   = apperror.New(http.StatusConflict, "two_factor_already_enabled", "error two-factor authentication is already enabled", "Two-factor authentication is already enabled, disable it first.")
+	ErrTwoFactorNotEnrolled      = apperror.New(http.StatusConflict, "two_factor_not_enrolled", "error two-factor authentication enrolment not started", "Start the two-factor authentication enrolment first.")
+	ErrTwoFactorNotEnabled       = apperror.New(http.StatusConflict, "two_factor_not_enabled", "error two-factor authentication is not enabled", "Two-factor authentication is not enabled.")
+	ErrTwoFactorCodeInvalid      = apperror.New(http.StatusUnauthorized, "two_factor_code_invalid", "error two-factor code is invalid or already used", "The authentication code is invalid or has already been used.")
+	ErrReauthenticationRequired  = apperror.New(http.StatusUnauthorized, "reauthentication_required", "error action requires a recent sign-in or a two-factor code", "Sign in again or send a code of your authenticator app or a recovery code to confirm this action.")
+	ErrTwoFactorLocked           = apperror.New(http.StatusTooManyRequests, "two_factor_locked", "error two-factor codes are locked after too many invalid codes", "Too many invalid authentication codes, try again later.")
+	ErrTwoFactorChallengeInvalid = apperror.New(http.StatusUnauthorized, "two_factor_challenge_invalid", "error two-factor challenge is unknown, expired or exhausted", "The sign-in has expired, sign in again.")
+	ErrTwoFactorSecretSealing    = apperror.New(http.StatusInternalServerError, "two_factor_unavailable", "error encrypting or decrypting the TOTP secret", "Two-factor authentication is unavailable, please try again later.")
+
+	// Event errors
+	ErrOutboxEventNotFound = apperror.New(http.StatusNotFound, "outbox_event_not_found", "error outbox event not found", "The event does not exist.")
+
+	// Idempotency errors
+	ErrIdempotencyKeyInvalid    = apperror.New(http.StatusBadRequest, "idempotency_key_invalid", "error idempotency key is empty or too long", "The Idempotency-Key header must hold between 1 and 255 characters.")
+	ErrIdempotencyKeyReused     = apperror.New(http.StatusUnprocessableEntity, "idempotency_key_reused", "error idempotency key reused with a different request", "The Idempotency-Key was already used for a different request.")
+	ErrIdempotencyBodyTooLarge  = apperror.New(http.StatusRequestEntityTooLarge, "idempotency_body_too_large", "error request body with an idempotency key is too large", "The body of a request sent with an Idempotency-Key is too large.")
+	ErrIdempotencyKeyInProgress = apperror.New(http.StatusConflict, "idempotency_key_in_progress", "error request with the idempotency key is still in progress", "A request with the same Idempotency-Key is still being processed, retry later.")
 
 	// Change Password errors
-	ErrInvalidPassword = errors.New("invalid password")
-	ErrComfirmPassword = errors.New("comfirm new passwords do not match")
+	ErrInvalidPassword = apperror.New(http.StatusBadRequest, "invalid_password", "invalid password", "The current password is incorrect.")
+	ErrComfirmPassword = apperror.New(http.StatusUnprocessableEntity, "confirm_password_mismatch", "comfirm new passwords do not match", "The new password confirmation does not match.")
 )
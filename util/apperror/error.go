@@ -0,0 +1,94 @@
+package apperror
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Code values of the errors that are not declared as domain errors
+const (
+	CodeInternal = "internal_error"
+)
+
+// FieldError represents the error of a single request field
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error represents the typed application error carrying its HTTP semantics
+type Error struct {
+	// Code is the stable machine readable identifier of the error
+	Code string
+	// Status is the HTTP status code the error is responded with
+	Status int
+	// Message is the message safe to show to the API user
+	Message string
+	// Fields lists the request fields the error relates to
+	Fields []FieldError
+
+	text string
+	err  error
+}
+
+// New creates and returns an application error, text is the internal description returned by Error
+func New(status int, code, text, message string) *Error {
+	return &Error{Code: code, Status: status, Message: message, text: text}
+}
+
+// Error returns the internal description of the error
+func (e *Error) Error() string {
+	if e.err != nil {
+		return e.text + ": " + e.err.Error()
+	}
+
+	return e.text
+}
+
+// Unwrap returns the underlying cause of the error
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// Is reports whether the target is an application error with the same code
+func (e *Error) Is(target error) bool {
+	var appErr *Error
+	if !errors.As(target, &appErr) {
+		return false
+	}
+
+	return e.Code == appErr.Code
+}
+
+// Wrap returns a copy of the error caused by err
+func (e *Error) Wrap(err error) *Error {
+	clone := *e
+	clone.err = err
+
+	return &clone
+}
+
+// WithField returns a copy of the error related to the request field
+func (e *Error) WithField(field, message string) *Error {
+	clone := *e
+	clone.Fields = append(append([]FieldError{}, e.Fields...), FieldError{Field: field, Message: message})
+
+	return &clone
+}
+
+// From returns the application error in the chain of err,
+// errors that are not declared as application errors are reported as internal errors
+func From(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	return &Error{
+		Code:    CodeInternal,
+		Status:  http.StatusInternalServerError,
+		Message: http.StatusText(http.StatusInternalServerError),
+		text:    "unexpected error",
+		err:     err,
+	}
+}
@@ -0,0 +1,75 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+)
+
+// Prefix marks the values sealed by AESGCM, it tells them apart from the values stored before the encryption
+const Prefix = "v1:"
+
+// AESGCM is an implementation of encryption.Cipher with AES-256 in Galois/Counter Mode
+type AESGCM struct {
+	aead cipher.AEAD
+}
+
+// NewAESGCM creates and returns an AESGCM implementation of the Cipher from the base64 encoded 32 bytes key
+func NewAESGCM(encodedKey string) (Cipher, error) {
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil || len(key) != 32 {
+		return nil, ErrKeyInvalid
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AESGCM{aead: aead}, nil
+}
+
+// Encrypt returns the plain value sealed with a random nonce, the nonce is kept in front of the ciphertext
+func (a *AESGCM) Encrypt(plainValue string) (string, error) {
+	nonce := make([]byte, a.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := a.aead.Seal(nonce, nonce, []byte(plainValue), nil)
+
+	return Prefix + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt returns the plain value of the value sealed by Encrypt
+// returns ErrCiphertextInvalid error if the value was changed or sealed with another key
+func (a *AESGCM) Decrypt(encryptedValue string) (string, error) {
+	if !IsEncrypted(encryptedValue) {
+		return "", ErrCiphertextInvalid
+	}
+
+	sealed, err := base64.RawStdEncoding.DecodeString(strings.TrimPrefix(encryptedValue, Prefix))
+	if err != nil || len(sealed) < a.aead.NonceSize() {
+		return "", ErrCiphertextInvalid
+	}
+
+	nonce, ciphertext := sealed[:a.aead.NonceSize()], sealed[a.aead.NonceSize():]
+	plainValue, err := a.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", ErrCiphertextInvalid
+	}
+
+	return string(plainValue), nil
+}
+
+// IsEncrypted reports whether the value was sealed by AESGCM
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, Prefix)
+}
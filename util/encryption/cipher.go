@@ -0,0 +1,14 @@
+package encryption
+
+import "errors"
+
+var (
+	ErrKeyInvalid        = errors.New("encryption key must be 32 bytes encoded in base64")
+	ErrCiphertextInvalid = errors.New("encrypted value is malformed or sealed with another key")
+)
+
+// Cipher represents the symmetrical encryption of the secrets stored at rest.
+type Cipher interface {
+	Encrypt(plainValue string) (string, error)
+	Decrypt(encryptedValue string) (string, error)
+}
@@ -0,0 +1,112 @@
+package syndication
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// ContentTypeAtom is the media type of an Atom 1.0 document
+const ContentTypeAtom = "application/atom+xml; charset=utf-8"
+
+type atom struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Updated  string      `xml:"updated"`
+	Links    []atomLink  `xml:"link"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Updated    string         `xml:"updated"`
+	Published  string         `xml:"published,omitempty"`
+	Links      []atomLink     `xml:"link"`
+	Author     *atomPerson    `xml:"author,omitempty"`
+	Categories []atomCategory `xml:"category,omitempty"`
+	Summary    *atomText      `xml:"summary,omitempty"`
+	Content    *atomText      `xml:"content,omitempty"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomText struct {
+	Type  string `xml:"type,attr,omitempty"`
+	Value string `xml:",chardata"`
+}
+
+// RenderAtom serialises the Feed into an Atom 1.0 document
+func RenderAtom(f *Feed) ([]byte, error) {
+	if f.ID == "" {
+		return nil, ErrMissingFeedID
+	}
+
+	document := atom{
+		ID:       f.ID,
+		Title:    f.Title,
+		Subtitle: f.Description,
+		Updated:  formatAtomTime(f.Updated),
+		Links:    []atomLink{{Href: f.Link, Rel: "alternate"}},
+		Entries:  make([]atomEntry, 0, len(f.Items)),
+	}
+
+	if f.SelfLink != "" {
+		document.Links = append(document.Links, atomLink{Href: f.SelfLink, Rel: "self", Type: ContentTypeAtom})
+	}
+
+	for _, item := range f.Items {
+		entry := atomEntry{
+			ID:      item.ID,
+			Title:   item.Title,
+			Updated: formatAtomTime(item.Updated),
+			Links:   []atomLink{{Href: item.Link, Rel: "alternate"}},
+		}
+
+		if !item.Published.IsZero() {
+			entry.Published = formatAtomTime(item.Published)
+		}
+
+		if item.Author != "" {
+			entry.Author = &atomPerson{Name: item.Author}
+		}
+
+		for _, category := range item.Categories {
+			entry.Categories = append(entry.Categories, atomCategory{Term: category})
+		}
+
+		if item.Summary != "" {
+			entry.Summary = &atomText{Type: "text", Value: item.Summary}
+		}
+
+		if item.Content != "" {
+			entry.Content = &atomText{Type: "text", Value: item.Content}
+		}
+
+		document.Entries = append(document.Entries, entry)
+	}
+
+	return marshal(document)
+}
+
+// formatAtomTime formats the time as RFC 3339, falling back to the epoch when it is unset
+func formatAtomTime(t time.Time) string {
+	if t.IsZero() {
+		t = time.Unix(0, 0)
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,37 @@
+package syndication
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrMissingFeedID = errors.New("feed id is missing")
+)
+
+// Feed represents the format independent syndication channel
+type Feed struct {
+	ID          string
+	Title       string
+	Description string
+	Link        string
+	SelfLink    string
+	Updated     time.Time
+	Items       []*Item
+}
+
+// Item represents a single entry of the syndication channel
+type Item struct {
+	ID         string
+	Title      string
+	Link       string
+	Summary    string
+	Content    string
+	Author     string
+	Categories []string
+	Published  time.Time
+	Updated    time.Time
+}
+
+// Renderer represents the function that serialises a Feed into a specific format
+type Renderer func(*Feed) ([]byte, error)
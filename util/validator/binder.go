@@ -0,0 +1,24 @@
+package validator
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// Binder is an implementation of echo.Binder validating the request right after binding it
+type Binder struct {
+	echo.DefaultBinder
+}
+
+// NewBinder creates and returns the validating Binder
+func NewBinder() echo.Binder {
+	return &Binder{}
+}
+
+// Bind binds the path, query, header and body data into i and validates it with the registered echo.Validator
+func (b *Binder) Bind(i interface{}, c echo.Context) error {
+	if err := b.DefaultBinder.Bind(i, c); err != nil {
+		return err
+	}
+
+	return c.Validate(i)
+}
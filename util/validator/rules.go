@@ -0,0 +1,122 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	playground "github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	nameRegexp      = regexp.MustCompile(`^[\p{L}][\p{L}\s\-']*$`)
+	pseudonymRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.\-]{2,29}$`)
+	slugRegexp      = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+)
+
+// rule represents a custom validation rule with its field error message
+type rule struct {
+	fn      playground.Func
+	message string
+}
+
+// rules lists the custom validation rules by their struct tag
+var rules = map[string]rule{
+	"personname": {fn: isPersonName, message: "must start with a letter and contain only letters, spaces, hyphens and apostrophes"},
+	"pseudonym":  {fn: isPseudonym, message: "must be 3 to 30 letters, digits, dots, underscores or hyphens starting with a letter or digit"},
+	"objectid":   {fn: isObjectID, message: "must be a valid ObjectID"},
+	"slug":       {fn: isSlug, message: "must contain only lowercase letters, digits and single hyphens"},
+	"notblank":   {fn: isNotBlank, message: "must not be blank"},
+}
+
+// isPersonName validates the first and last name of a user
+func isPersonName(fl playground.FieldLevel) bool {
+	return nameRegexp.MatchString(strings.TrimSpace(fl.Field().String()))
+}
+
+// isPseudonym validates the public pseudonym of a blogger
+func isPseudonym(fl playground.FieldLevel) bool {
+	return pseudonymRegexp.MatchString(fl.Field().String())
+}
+
+// isSlug validates the URL slug of a post
+func isSlug(fl playground.FieldLevel) bool {
+	return slugRegexp.MatchString(fl.Field().String())
+}
+
+// isNotBlank validates the string contains other characters than whitespaces
+func isNotBlank(fl playground.FieldLevel) bool {
+	return strings.TrimSpace(fl.Field().String()) != ""
+}
+
+// isObjectID validates the hexadecimal string, primitive.ObjectID or slice of primitive.ObjectID is non zero
+func isObjectID(fl playground.FieldLevel) bool {
+	field := fl.Field()
+
+	switch value := field.Interface().(type) {
+	case primitive.ObjectID:
+		return !value.IsZero()
+	case string:
+		return primitive.IsValidObjectID(value)
+	case []primitive.ObjectID:
+		for _, id := range value {
+			if id.IsZero() {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// fieldMessage returns the human readable message of the failing validation
+func fieldMessage(fieldErr playground.FieldError) string {
+	if r, ok := rules[fieldErr.Tag()]; ok {
+		return r.message
+	}
+
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "min":
+		if fieldErr.Kind() == reflect.String {
+			return fmt.Sprintf("must be at least %s characters long", fieldErr.Param())
+		}
+		return fmt.Sprintf("must be at least %s", fieldErr.Param())
+	case "max":
+		if fieldErr.Kind() == reflect.String {
+			return fmt.Sprintf("must be at most %s characters long", fieldErr.Param())
+		}
+		return fmt.Sprintf("must be at most %s", fieldErr.Param())
+	case "eqfield":
+		return fmt.Sprintf("must match %s", toSnakeCase(fieldErr.Param()))
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s", strings.ReplaceAll(fieldErr.Param(), " ", ", "))
+	case "url":
+		return "must be a valid URL"
+	case "numeric":
+		return "must contain digits only"
+	case "datetime":
+		return fmt.Sprintf("must be a date time formatted as %s", fieldErr.Param())
+	default:
+		return fmt.Sprintf("failed on the %s rule", fieldErr.Tag())
+	}
+}
+
+// toSnakeCase converts the Go field name referenced by a cross-field rule into its request name
+func toSnakeCase(name string) string {
+	var builder strings.Builder
+	for i, r := range name {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			builder.WriteByte('_')
+		}
+		builder.WriteRune(r)
+	}
+
+	return strings.ToLower(builder.String())
+}
@@ -0,0 +1,76 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	playground "github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+
+	"golang-project/static"
+)
+
+// Validator is an implementation of echo.Validator backed by struct tags
+type Validator struct {
+	validate *playground.Validate
+}
+
+// New creates and returns the struct-tag Validator with the custom rules registered
+func New() echo.Validator {
+	validate := playground.New(playground.WithRequiredStructEnabled())
+	validate.RegisterTagNameFunc(jsonFieldName)
+
+	for tag, r := range rules {
+		if err := validate.RegisterValidation(tag, r.fn); err != nil {
+			panic("failed to register validation rule " + tag + ": " + err.Error())
+		}
+	}
+
+	return &Validator{validate: validate}
+}
+
+// Validate validates the struct and returns static.ErrValidation listing each failing field
+func (v *Validator) Validate(i interface{}) error {
+	err := v.validate.Struct(i)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrs playground.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return err
+	}
+
+	appErr := static.ErrValidation
+	for _, fieldErr := range validationErrs {
+		appErr = appErr.WithField(fieldPath(fieldErr), fieldMessage(fieldErr))
+	}
+
+	return appErr
+}
+
+// jsonFieldName returns the name of the struct field as it appears in the request
+func jsonFieldName(field reflect.StructField) string {
+	for _, key := range []string{"json", "query", "param", "form"} {
+		name := strings.SplitN(field.Tag.Get(key), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+
+	return field.Name
+}
+
+// fieldPath returns the field namespace without the root struct name
+func fieldPath(fieldErr playground.FieldError) string {
+	namespace := fieldErr.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+
+	return namespace
+}
@@ -0,0 +1,103 @@
+package validator
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"golang-project/static"
+	"golang-project/util/apperror"
+)
+
+func TestCustomRules(t *testing.T) {
+	v := New()
+
+	for _, tc := range []struct {
+		tag   string
+		value interface{}
+		valid bool
+	}{
+		{"pseudonym", "jane.doe-3f9a1c", true},
+		{"pseudonym", "jd", false},
+		{"pseudonym", "_jane", false},
+		{"pseudonym", "jane@example.com", false},
+		{"pseudonym", strings.Repeat("a", 31), false},
+		{"objectid", primitive.NewObjectID().Hex(), true},
+		{"objectid", "not-an-id", false},
+		{"objectid", primitive.NewObjectID(), true},
+		{"objectid", primitive.NilObjectID, false},
+		{"objectid", []primitive.ObjectID{primitive.NewObjectID()}, true},
+		{"objectid", []primitive.ObjectID{primitive.NewObjectID(), primitive.NilObjectID}, false},
+		{"slug", "generics-in-go-2", true},
+		{"slug", "Generics", false},
+		{"slug", "generics--in-go", false},
+		{"slug", "-generics", false},
+		{"personname", "Anne-Marie O'Neil", true},
+		{"personname", "4nne", false},
+		{"notblank", " x ", true},
+		{"notblank", " \t", false},
+	} {
+		err := v.(*Validator).validate.Var(tc.value, tc.tag)
+		if valid := err == nil; valid != tc.valid {
+			t.Errorf("%s %v: valid = %v, want %v", tc.tag, tc.value, valid, tc.valid)
+		}
+	}
+}
+
+// signUpRequest nests a struct to check the path of its fields
+type signUpRequest struct {
+	Email     string `json:"email" validate:"required,email"`
+	Pseudonym string `json:"pseudonym" validate:"required,pseudonym"`
+	Password  string `json:"password" validate:"required,min=8"`
+	Confirm   string `json:"password_confirmation" validate:"eqfield=Password"`
+	Profile   struct {
+		Slug string `json:"slug" validate:"omitempty,slug"`
+	} `json:"profile"`
+}
+
+func TestBindListsTheFailingFields(t *testing.T) {
+	e := echo.New()
+	e.Validator = New()
+	e.Binder = NewBinder()
+
+	body := `{"email":"jane","pseudonym":"jane@example.com","password":"short","password_confirmation":"other","profile":{"slug":"Not A Slug"}}`
+	req := httptest.NewRequest(http.MethodPost, "/v1/auth/sign-up", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	err := c.Bind(new(signUpRequest))
+	if !errors.Is(err, static.ErrValidation) {
+		t.Fatalf("err = %v, want %v", err, static.ErrValidation)
+	}
+
+	appErr := apperror.From(err)
+	if appErr.Status != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want %d", appErr.Status, http.StatusUnprocessableEntity)
+	}
+
+	var fields []string
+	for _, fieldErr := range appErr.Fields {
+		fields = append(fields, fieldErr.Field+" "+fieldErr.Message)
+	}
+	want := []string{
+		"email must be a valid email address",
+		"pseudonym " + rules["pseudonym"].message,
+		"password must be at least 8 characters long",
+		"password_confirmation must match password",
+		"profile.slug " + rules["slug"].message,
+	}
+	if !slices.Equal(fields, want) {
+		t.Errorf("fields = %q, want %q", fields, want)
+	}
+
+	// The error declared in static is left unchanged by the fields of a request
+	if len(static.ErrValidation.Fields) != 0 {
+		t.Errorf("static.ErrValidation.Fields = %v, want none", static.ErrValidation.Fields)
+	}
+}